@@ -1,11 +1,16 @@
 package api
 
 import (
+	"encoding/json"
+	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/containous/mux"
 	"github.com/containous/traefik/log"
 	"github.com/containous/traefik/middlewares"
+	"github.com/containous/traefik/middlewares/drain"
+	"github.com/containous/traefik/middlewares/toggles"
 	"github.com/containous/traefik/safe"
 	"github.com/containous/traefik/types"
 	"github.com/containous/traefik/version"
@@ -21,9 +26,13 @@ type Handler struct {
 	Debug                 bool   `export:"true"`
 	CurrentConfigurations *safe.Safe
 	Statistics            *types.Statistics          `description:"Enable more detailed statistics" export:"true"`
+	RuntimeToggles        bool                       `description:"Enable the routes changing the state of Traefik at runtime" export:"true"`
 	Stats                 *thoas_stats.Stats         `json:"-"`
 	StatsRecorder         *middlewares.StatsRecorder `json:"-"`
 	DashboardAssets       *assetfs.AssetFS           `json:"-"`
+	MaintenanceToggles    *toggles.Store             `json:"-"`
+	FaultInjectionToggles *toggles.Store             `json:"-"`
+	Drain                 *drain.State               `json:"-"`
 }
 
 var (
@@ -50,6 +59,38 @@ func (p Handler) AddRoutes(router *mux.Router) {
 	router.Methods(http.MethodGet).Path("/api/providers/{provider}/frontends/{frontend}/routes").HandlerFunc(p.getRoutesHandler)
 	router.Methods(http.MethodGet).Path("/api/providers/{provider}/frontends/{frontend}/routes/{route}").HandlerFunc(p.getRouteHandler)
 
+	if p.MaintenanceToggles != nil {
+		p.addToggleRoutes(router, "maintenance", frontendToggle{
+			name:    "Maintenance",
+			toggles: p.MaintenanceToggles,
+			configured: func(frontend *types.Frontend) bool {
+				return frontend.Maintenance != nil && frontend.Maintenance.Enabled
+			},
+		})
+	}
+
+	if p.FaultInjectionToggles != nil {
+		p.addToggleRoutes(router, "faultinjection", frontendToggle{
+			name:    "Fault injection",
+			toggles: p.FaultInjectionToggles,
+			configured: func(frontend *types.Frontend) bool {
+				return frontend.FaultInjection != nil && frontend.FaultInjection.Enabled
+			},
+			// the faults are the ones of the frontend configuration
+			available: func(frontend *types.Frontend) bool {
+				return frontend.FaultInjection != nil
+			},
+		})
+	}
+
+	if p.Drain != nil {
+		router.Methods(http.MethodGet).Path("/api/drain").HandlerFunc(p.getDrainHandler)
+		if p.RuntimeToggles {
+			router.Methods(http.MethodPut).Path("/api/drain").HandlerFunc(p.putDrainHandler)
+			router.Methods(http.MethodDelete).Path("/api/drain").HandlerFunc(p.deleteDrainHandler)
+		}
+	}
+
 	// health route
 	router.Methods(http.MethodGet).Path("/health").HandlerFunc(p.getHealthHandler)
 
@@ -233,6 +274,124 @@ func (p Handler) getRouteHandler(response http.ResponseWriter, request *http.Req
 	http.NotFound(response, request)
 }
 
+// frontendToggle describes a middleware of the frontends which can be enabled or disabled at runtime.
+// The middleware can only be toggled on the frontends for which it is available, on all of them when available is nil.
+type frontendToggle struct {
+	name       string
+	toggles    *toggles.Store
+	configured func(frontend *types.Frontend) bool
+	available  func(frontend *types.Frontend) bool
+}
+
+// toggleResponse holds the state of a middleware of a frontend.
+type toggleResponse struct {
+	Enabled    bool `json:"enabled"`
+	Configured bool `json:"configured"`
+	Overridden bool `json:"overridden"`
+}
+
+// toggleRequest holds the state to set at runtime on a middleware of a frontend.
+type toggleRequest struct {
+	Enabled *bool `json:"enabled"`
+}
+
+func (p Handler) addToggleRoutes(router *mux.Router, path string, toggle frontendToggle) {
+	route := "/api/providers/{provider}/frontends/{frontend}/" + path
+
+	router.Methods(http.MethodGet).Path(route).HandlerFunc(p.getToggleHandler(toggle))
+	router.Methods(http.MethodPut).Path(route).HandlerFunc(p.putToggleHandler(toggle))
+	router.Methods(http.MethodDelete).Path(route).HandlerFunc(p.deleteToggleHandler(toggle))
+}
+
+func (p Handler) getToggleHandler(toggle frontendToggle) http.HandlerFunc {
+	return func(response http.ResponseWriter, request *http.Request) {
+		providerID, frontendID, frontend := p.getToggleFrontend(response, request, toggle)
+		if frontend == nil {
+			return
+		}
+
+		renderToggle(response, toggle, providerID, frontendID, frontend)
+	}
+}
+
+func (p Handler) putToggleHandler(toggle frontendToggle) http.HandlerFunc {
+	return func(response http.ResponseWriter, request *http.Request) {
+		providerID, frontendID, frontend := p.getToggleFrontend(response, request, toggle)
+		if frontend == nil {
+			return
+		}
+
+		toggleReq := &toggleRequest{}
+		if err := json.NewDecoder(request.Body).Decode(toggleReq); err != nil || toggleReq.Enabled == nil {
+			http.Error(response, `invalid request, expected {"enabled": true|false}`, http.StatusBadRequest)
+			return
+		}
+
+		toggle.toggles.Set(providerID, frontendID, *toggleReq.Enabled)
+		log.Infof("%s of the frontend %s from %s set to %t", toggle.name, frontendID, providerID, *toggleReq.Enabled)
+
+		renderToggle(response, toggle, providerID, frontendID, frontend)
+	}
+}
+
+func (p Handler) deleteToggleHandler(toggle frontendToggle) http.HandlerFunc {
+	return func(response http.ResponseWriter, request *http.Request) {
+		providerID, frontendID, frontend := p.getToggleFrontend(response, request, toggle)
+		if frontend == nil {
+			return
+		}
+
+		toggle.toggles.Reset(providerID, frontendID)
+		log.Infof("%s of the frontend %s from %s reset to its configuration", toggle.name, frontendID, providerID)
+
+		renderToggle(response, toggle, providerID, frontendID, frontend)
+	}
+}
+
+// getToggleFrontend returns the frontend of the toggle request, or answers with a 404 and returns a nil frontend.
+func (p Handler) getToggleFrontend(response http.ResponseWriter, request *http.Request, toggle frontendToggle) (string, string, *types.Frontend) {
+	providerID, frontendID, frontend := p.getFrontendFromVars(mux.Vars(request))
+	if frontend == nil {
+		http.NotFound(response, request)
+		return providerID, frontendID, nil
+	}
+
+	if toggle.available != nil && !toggle.available(frontend) {
+		http.Error(response, fmt.Sprintf("%s is not configured on the frontend %s", toggle.name, frontendID), http.StatusNotFound)
+		return providerID, frontendID, nil
+	}
+
+	return providerID, frontendID, frontend
+}
+
+func (p Handler) getFrontendFromVars(vars map[string]string) (string, string, *types.Frontend) {
+	providerID := getProviderIDFromVars(vars)
+	frontendID := vars["frontend"]
+
+	currentConfigurations := p.CurrentConfigurations.Get().(types.Configurations)
+	if provider, ok := currentConfigurations[providerID]; ok && provider != nil {
+		if frontend, ok := provider.Frontends[frontendID]; ok {
+			return providerID, frontendID, frontend
+		}
+	}
+	return providerID, frontendID, nil
+}
+
+func renderToggle(response http.ResponseWriter, toggle frontendToggle, providerID, frontendID string, frontend *types.Frontend) {
+	toggleResp := &toggleResponse{
+		Configured: toggle.configured(frontend),
+	}
+	toggleResp.Enabled, toggleResp.Overridden = toggle.toggles.Get(providerID, frontendID)
+	if !toggleResp.Overridden {
+		toggleResp.Enabled = toggleResp.Configured
+	}
+
+	err := templatesRenderer.JSON(response, http.StatusOK, toggleResp)
+	if err != nil {
+		log.Error(err)
+	}
+}
+
 // healthResponse combines data returned by thoas/stats with statistics (if
 // they are enabled).
 type healthResponse struct {
@@ -250,3 +409,43 @@ func (p *Handler) getHealthHandler(response http.ResponseWriter, request *http.R
 		log.Error(err)
 	}
 }
+
+// drainResponse holds the drain mode state.
+type drainResponse struct {
+	Draining           bool       `json:"draining"`
+	Since              *time.Time `json:"since,omitempty"`
+	ClosingConnections bool       `json:"closingConnections"`
+}
+
+func (p Handler) getDrainHandler(response http.ResponseWriter, request *http.Request) {
+	p.renderDrain(response)
+}
+
+func (p Handler) putDrainHandler(response http.ResponseWriter, request *http.Request) {
+	if p.Drain.Drain() {
+		log.Info("Entering drain mode")
+	}
+	p.renderDrain(response)
+}
+
+func (p Handler) deleteDrainHandler(response http.ResponseWriter, request *http.Request) {
+	if p.Drain.Undrain() {
+		log.Info("Leaving drain mode")
+	}
+	p.renderDrain(response)
+}
+
+func (p Handler) renderDrain(response http.ResponseWriter) {
+	drainResp := &drainResponse{ClosingConnections: p.Drain.ClosingConnections()}
+
+	var since time.Time
+	drainResp.Draining, since = p.Drain.Draining()
+	if drainResp.Draining {
+		drainResp.Since = &since
+	}
+
+	err := templatesRenderer.JSON(response, http.StatusOK, drainResp)
+	if err != nil {
+		log.Error(err)
+	}
+}
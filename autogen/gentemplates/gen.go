@@ -162,6 +162,25 @@ var _templatesConsul_catalogTmpl = []byte(`[backends]
       [frontends."frontend-{{ $service.ServiceName }}".auth.forward]
         address = "{{ $auth.Forward.Address }}"
         trustForwardHeader = {{ $auth.Forward.TrustForwardHeader }}
+        {{if $auth.Forward.AuthRequestHeaders }}
+        authRequestHeaders = [{{range $auth.Forward.AuthRequestHeaders }}
+          "{{.}}",
+          {{end}}]
+        {{end}}
+        method = "{{ $auth.Forward.Method }}"
+        forwardBody = {{ $auth.Forward.ForwardBody }}
+        maxBodySize = {{ $auth.Forward.MaxBodySize }}
+
+        {{if $auth.Forward.Cache }}
+        [frontends."frontend-{{ $service.ServiceName }}".auth.forward.cache]
+          {{if $auth.Forward.Cache.KeyHeaders }}
+          keyHeaders = [{{range $auth.Forward.Cache.KeyHeaders }}
+            "{{.}}",
+            {{end}}]
+          {{end}}
+          ttl = "{{ $auth.Forward.Cache.TTL }}"
+          negativeTTL = "{{ $auth.Forward.Cache.NegativeTTL }}"
+        {{end}}
 
         {{if $auth.Forward.TLS }}
         [frontends."frontend-{{ $service.ServiceName }}".auth.forward.tls]
@@ -196,12 +215,161 @@ var _templatesConsul_catalogTmpl = []byte(`[backends]
       {{end}}
     {{end}}
 
+    {{ $tlsClientCertAuth := getTLSClientCertAuth $service.TraefikLabels }}
+    {{if $tlsClientCertAuth }}
+    [frontends."frontend-{{ $service.ServiceName }}".tlsClientCertAuth]
+      {{if $tlsClientCertAuth.CommonNames }}
+      commonNames = [{{range $tlsClientCertAuth.CommonNames }}
+        "{{.}}",
+        {{end}}]
+      {{end}}
+      {{if $tlsClientCertAuth.OrganizationalUnits }}
+      organizationalUnits = [{{range $tlsClientCertAuth.OrganizationalUnits }}
+        "{{.}}",
+        {{end}}]
+      {{end}}
+      {{if $tlsClientCertAuth.DNSNames }}
+      dnsNames = [{{range $tlsClientCertAuth.DNSNames }}
+        "{{.}}",
+        {{end}}]
+      {{end}}
+      {{if $tlsClientCertAuth.URIs }}
+      uris = [{{range $tlsClientCertAuth.URIs }}
+        "{{.}}",
+        {{end}}]
+      {{end}}
+      {{if $tlsClientCertAuth.Issuers }}
+      issuers = [{{range $tlsClientCertAuth.Issuers }}
+        "{{.}}",
+        {{end}}]
+      {{end}}
+      {{if $tlsClientCertAuth.Fingerprints }}
+      fingerprints = [{{range $tlsClientCertAuth.Fingerprints }}
+        "{{.}}",
+        {{end}}]
+      {{end}}
+    {{end}}
+
+    {{ $geoIP := getGeoIP $service.TraefikLabels }}
+    {{if $geoIP }}
+    [frontends."frontend-{{ $service.ServiceName }}".geoIP]
+      countryDatabase = "{{ $geoIP.CountryDatabase }}"
+      asnDatabase = "{{ $geoIP.ASNDatabase }}"
+      {{if $geoIP.AllowedCountries }}
+      allowedCountries = [{{range $geoIP.AllowedCountries }}
+        "{{.}}",
+        {{end}}]
+      {{end}}
+      {{if $geoIP.DeniedCountries }}
+      deniedCountries = [{{range $geoIP.DeniedCountries }}
+        "{{.}}",
+        {{end}}]
+      {{end}}
+      {{if $geoIP.AllowedASNs }}
+      allowedASNs = [{{range $geoIP.AllowedASNs }}
+        "{{.}}",
+        {{end}}]
+      {{end}}
+      {{if $geoIP.DeniedASNs }}
+      deniedASNs = [{{range $geoIP.DeniedASNs }}
+        "{{.}}",
+        {{end}}]
+      {{end}}
+      addHeaders = {{ $geoIP.AddHeaders }}
+    {{end}}
+
+    {{ $bodyRewrite := getBodyRewrite $service.TraefikLabels }}
+    {{if $bodyRewrite }}
+    [frontends."frontend-{{ $service.ServiceName }}".bodyRewrite]
+      {{if $bodyRewrite.ContentTypes }}
+      contentTypes = [{{range $bodyRewrite.ContentTypes }}
+        "{{.}}",
+        {{end}}]
+      {{end}}
+      maxBodySize = {{ $bodyRewrite.MaxBodySize }}
+      {{range $replacement := $bodyRewrite.Replacements }}
+      [[frontends."frontend-{{ $service.ServiceName }}".bodyRewrite.replacements]]
+        search = {{ printf "%q" $replacement.Search }}
+        regex = {{ printf "%q" $replacement.Regex }}
+        replacement = {{ printf "%q" $replacement.Replacement }}
+      {{end}}
+    {{end}}
+
+    {{ $maintenance := getMaintenance $service.TraefikLabels }}
+    {{if $maintenance }}
+    [frontends."frontend-{{ $service.ServiceName }}".maintenance]
+      enabled = {{ $maintenance.Enabled }}
+      statusCode = {{ $maintenance.StatusCode }}
+      retryAfter = "{{ $maintenance.RetryAfter }}"
+      page = {{ printf "%q" $maintenance.Page }}
+      {{if $maintenance.SourceRange }}
+      sourceRange = [{{range $maintenance.SourceRange }}
+        "{{.}}",
+        {{end}}]
+      {{end}}
+      bypassHeaderName = {{ printf "%q" $maintenance.BypassHeaderName }}
+      bypassHeaderValue = {{ printf "%q" $maintenance.BypassHeaderValue }}
+    {{end}}
+
+    {{ $faultInjection := getFaultInjection $service.TraefikLabels }}
+    {{if $faultInjection }}
+    [frontends."frontend-{{ $service.ServiceName }}".faultInjection]
+      enabled = {{ $faultInjection.Enabled }}
+      headerName = {{ printf "%q" $faultInjection.HeaderName }}
+      headerValue = {{ printf "%q" $faultInjection.HeaderValue }}
+      delayPercentage = {{ printf "%f" $faultInjection.DelayPercentage }}
+      delay = "{{ $faultInjection.Delay }}"
+      delayMax = "{{ $faultInjection.DelayMax }}"
+      delayDistribution = {{ printf "%q" $faultInjection.DelayDistribution }}
+      abortPercentage = {{ printf "%f" $faultInjection.AbortPercentage }}
+      abortStatusCode = {{ $faultInjection.AbortStatusCode }}
+      abortReset = {{ $faultInjection.AbortReset }}
+    {{end}}
+
+    {{ $sizeLimits := getSizeLimits $service.TraefikLabels }}
+    {{if $sizeLimits }}
+    [frontends."frontend-{{ $service.ServiceName }}".sizeLimits]
+      maxRequestBodyBytes = {{ $sizeLimits.MaxRequestBodyBytes }}
+      maxHeaderCount = {{ $sizeLimits.MaxHeaderCount }}
+      maxHeaderBytes = {{ $sizeLimits.MaxHeaderBytes }}
+    {{end}}
+
+    {{ $requestID := getRequestID $service.TraefikLabels }}
+    {{if $requestID }}
+    [frontends."frontend-{{ $service.ServiceName }}".requestID]
+      headerName = {{ printf "%q" $requestID.HeaderName }}
+      format = {{ printf "%q" $requestID.Format }}
+      insecure = {{ $requestID.Insecure }}
+      {{if $requestID.TrustedIPs }}
+      trustedIPs = [{{range $requestID.TrustedIPs }}
+        "{{.}}",
+        {{end}}]
+      {{end}}
+    {{end}}
+
     {{ $whitelist := getWhiteList $service.TraefikLabels }}
     {{if $whitelist }}
     [frontends."frontend-{{ $service.ServiceName }}".whiteList]
+      {{if $whitelist.SourceRange }}
       sourceRange = [{{range $whitelist.SourceRange }}
         "{{.}}",
         {{end}}]
+      {{end}}
+      {{if $whitelist.SourceSets }}
+      sourceSets = [{{range $whitelist.SourceSets }}
+        "{{.}}",
+        {{end}}]
+      {{end}}
+      {{if $whitelist.DenyRange }}
+      denyRange = [{{range $whitelist.DenyRange }}
+        "{{.}}",
+        {{end}}]
+      {{end}}
+      {{if $whitelist.DenySets }}
+      denySets = [{{range $whitelist.DenySets }}
+        "{{.}}",
+        {{end}}]
+      {{end}}
       {{if $whitelist.IPStrategy }}
       [frontends."frontend-{{ $service.ServiceName }}".whiteList.IPStrategy]
         depth = {{ $whitelist.IPStrategy.Depth }}
@@ -218,6 +386,30 @@ var _templatesConsul_catalogTmpl = []byte(`[backends]
       regex = "{{ $redirect.Regex }}"
       replacement = "{{ $redirect.Replacement }}"
       permanent = {{ $redirect.Permanent }}
+      {{if $redirect.StatusCode }}
+      statusCode = {{ $redirect.StatusCode }}
+      {{end}}
+      {{if $redirect.Scheme }}
+      scheme = "{{ $redirect.Scheme }}"
+      {{end}}
+      {{if $redirect.Host }}
+      host = "{{ $redirect.Host }}"
+      {{end}}
+      {{if $redirect.Port }}
+      port = "{{ $redirect.Port }}"
+      {{end}}
+      {{if $redirect.Path }}
+      path = "{{ $redirect.Path }}"
+      {{end}}
+      {{if $redirect.PathPrefix }}
+      pathPrefix = "{{ $redirect.PathPrefix }}"
+      {{end}}
+      {{if $redirect.DropQuery }}
+      dropQuery = true
+      {{end}}
+      {{if $redirect.AppRoot }}
+      appRoot = "{{ $redirect.AppRoot }}"
+      {{end}}
     {{end}}
 
     {{ $errorPages := getErrorPages $service.TraefikLabels }}
@@ -228,8 +420,13 @@ var _templatesConsul_catalogTmpl = []byte(`[backends]
         status = [{{range $page.Status }}
           "{{.}}",
           {{end}}]
+        {{if $page.Backend }}
         backend = "backend-{{ $page.Backend }}"
+        {{end}}
         query = "{{ $page.Query }}"
+        file = {{ printf "%q" $page.File }}
+        body = {{ printf "%q" $page.Body }}
+        contentType = {{ printf "%q" $page.ContentType }}
       {{end}}
     {{end}}
 
@@ -237,6 +434,15 @@ var _templatesConsul_catalogTmpl = []byte(`[backends]
     {{if $rateLimit }}
     [frontends."frontend-{{ $service.ServiceName }}".rateLimit]
       extractorFunc = "{{ $rateLimit.ExtractorFunc }}"
+      distributed = {{ $rateLimit.Distributed }}
+      statusCode = {{ $rateLimit.StatusCode }}
+      body = {{ printf "%q" $rateLimit.Body }}
+      contentType = {{ printf "%q" $rateLimit.ContentType }}
+      {{if $rateLimit.ExemptSourceRange }}
+      exemptSourceRange = [{{range $rateLimit.ExemptSourceRange }}
+        "{{.}}",
+        {{end}}]
+      {{end}}
       [frontends."frontend-{{ $service.ServiceName }}".rateLimit.rateSet]
         {{ range $limitName, $limit := $rateLimit.RateSet }}
         [frontends."frontend-{{ $service.ServiceName }}".rateLimit.rateSet."{{ $limitName }}"]
@@ -293,6 +499,27 @@ var _templatesConsul_catalogTmpl = []byte(`[backends]
         {{end}}
       {{end}}
 
+      {{range $operation := $headers.RequestOperations }}
+      [[frontends."frontend-{{ $service.ServiceName }}".headers.requestOperations]]
+        action = "{{ $operation.Action }}"
+        name = "{{ $operation.Name }}"
+        value = "{{ $operation.Value }}"
+        regex = "{{ $operation.Regex }}"
+      {{end}}
+
+      {{range $operation := $headers.ResponseOperations }}
+      [[frontends."frontend-{{ $service.ServiceName }}".headers.responseOperations]]
+        action = "{{ $operation.Action }}"
+        name = "{{ $operation.Name }}"
+        value = "{{ $operation.Value }}"
+        regex = "{{ $operation.Regex }}"
+        {{if $operation.StatusCodes }}
+        statusCodes = [{{range $operation.StatusCodes }}
+          "{{.}}",
+          {{end}}]
+        {{end}}
+      {{end}}
+
       {{if $headers.SSLProxyHeaders }}
       [frontends."frontend-{{ $service.ServiceName }}".headers.SSLProxyHeaders]
         {{range $k, $v := $headers.SSLProxyHeaders}}
@@ -305,6 +532,26 @@ var _templatesConsul_catalogTmpl = []byte(`[backends]
       rule = "{{ getFrontendRule $service }}"
 
 {{end}}
+
+{{if .UDPServices }}
+[udpBackends]
+{{range $serviceName, $service := .UDPServices }}
+  {{range $serverName, $server := getUDPServers $service.Nodes }}
+  [udpBackends."udp-backend-{{ $serviceName }}".servers."{{ $serverName }}"]
+    address = "{{ $server.Address }}"
+    weight = {{ $server.Weight }}
+  {{end}}
+{{end}}
+
+[udpFrontends]
+{{range $serviceName, $service := .UDPServices }}
+  [udpFrontends."udp-frontend-{{ $serviceName }}"]
+    backend = "udp-backend-{{ $serviceName }}"
+    entryPoints = [{{range getUDPEntryPoints $service.Service.TraefikLabels }}
+      "{{.}}",
+      {{end}}]
+{{end}}
+{{end}}
 `)
 
 func templatesConsul_catalogTmplBytes() ([]byte, error) {
@@ -377,6 +624,12 @@ var _templatesDockerTmpl = []byte(`{{$backendServers := .Servers}}
     retryExpression = "{{ $buffering.RetryExpression }}"
   {{end}}
 
+  {{ $proxyProtocol := getProxyProtocol $backend.SegmentLabels }}
+  {{if $proxyProtocol }}
+  [backends."backend-{{ $backendName }}".proxyProtocol]
+    version = {{ $proxyProtocol.Version }}
+  {{end}}
+
   {{range $serverName, $server := getServers $servers }}
   [backends."backend-{{ $backendName }}".servers."{{ $serverName }}"]
     url = "{{ $server.URL }}"
@@ -431,6 +684,25 @@ var _templatesDockerTmpl = []byte(`{{$backendServers := .Servers}}
       [frontends."frontend-{{ $frontendName }}".auth.forward]
         address = "{{ $auth.Forward.Address }}"
         trustForwardHeader = {{ $auth.Forward.TrustForwardHeader }}
+        {{if $auth.Forward.AuthRequestHeaders }}
+        authRequestHeaders = [{{range $auth.Forward.AuthRequestHeaders }}
+          "{{.}}",
+          {{end}}]
+        {{end}}
+        method = "{{ $auth.Forward.Method }}"
+        forwardBody = {{ $auth.Forward.ForwardBody }}
+        maxBodySize = {{ $auth.Forward.MaxBodySize }}
+
+        {{if $auth.Forward.Cache }}
+        [frontends."frontend-{{ $frontendName }}".auth.forward.cache]
+          {{if $auth.Forward.Cache.KeyHeaders }}
+          keyHeaders = [{{range $auth.Forward.Cache.KeyHeaders }}
+            "{{.}}",
+            {{end}}]
+          {{end}}
+          ttl = "{{ $auth.Forward.Cache.TTL }}"
+          negativeTTL = "{{ $auth.Forward.Cache.NegativeTTL }}"
+        {{end}}
 
         {{if $auth.Forward.TLS }}
         [frontends."frontend-{{ $frontendName }}".auth.forward.tls]
@@ -466,12 +738,161 @@ var _templatesDockerTmpl = []byte(`{{$backendServers := .Servers}}
       {{end}}
     {{end}}
 
+    {{ $tlsClientCertAuth := getTLSClientCertAuth $container.SegmentLabels }}
+    {{if $tlsClientCertAuth }}
+    [frontends."frontend-{{ $frontendName }}".tlsClientCertAuth]
+      {{if $tlsClientCertAuth.CommonNames }}
+      commonNames = [{{range $tlsClientCertAuth.CommonNames }}
+        "{{.}}",
+        {{end}}]
+      {{end}}
+      {{if $tlsClientCertAuth.OrganizationalUnits }}
+      organizationalUnits = [{{range $tlsClientCertAuth.OrganizationalUnits }}
+        "{{.}}",
+        {{end}}]
+      {{end}}
+      {{if $tlsClientCertAuth.DNSNames }}
+      dnsNames = [{{range $tlsClientCertAuth.DNSNames }}
+        "{{.}}",
+        {{end}}]
+      {{end}}
+      {{if $tlsClientCertAuth.URIs }}
+      uris = [{{range $tlsClientCertAuth.URIs }}
+        "{{.}}",
+        {{end}}]
+      {{end}}
+      {{if $tlsClientCertAuth.Issuers }}
+      issuers = [{{range $tlsClientCertAuth.Issuers }}
+        "{{.}}",
+        {{end}}]
+      {{end}}
+      {{if $tlsClientCertAuth.Fingerprints }}
+      fingerprints = [{{range $tlsClientCertAuth.Fingerprints }}
+        "{{.}}",
+        {{end}}]
+      {{end}}
+    {{end}}
+
+    {{ $geoIP := getGeoIP $container.SegmentLabels }}
+    {{if $geoIP }}
+    [frontends."frontend-{{ $frontendName }}".geoIP]
+      countryDatabase = "{{ $geoIP.CountryDatabase }}"
+      asnDatabase = "{{ $geoIP.ASNDatabase }}"
+      {{if $geoIP.AllowedCountries }}
+      allowedCountries = [{{range $geoIP.AllowedCountries }}
+        "{{.}}",
+        {{end}}]
+      {{end}}
+      {{if $geoIP.DeniedCountries }}
+      deniedCountries = [{{range $geoIP.DeniedCountries }}
+        "{{.}}",
+        {{end}}]
+      {{end}}
+      {{if $geoIP.AllowedASNs }}
+      allowedASNs = [{{range $geoIP.AllowedASNs }}
+        "{{.}}",
+        {{end}}]
+      {{end}}
+      {{if $geoIP.DeniedASNs }}
+      deniedASNs = [{{range $geoIP.DeniedASNs }}
+        "{{.}}",
+        {{end}}]
+      {{end}}
+      addHeaders = {{ $geoIP.AddHeaders }}
+    {{end}}
+
+    {{ $bodyRewrite := getBodyRewrite $container.SegmentLabels }}
+    {{if $bodyRewrite }}
+    [frontends."frontend-{{ $frontendName }}".bodyRewrite]
+      {{if $bodyRewrite.ContentTypes }}
+      contentTypes = [{{range $bodyRewrite.ContentTypes }}
+        "{{.}}",
+        {{end}}]
+      {{end}}
+      maxBodySize = {{ $bodyRewrite.MaxBodySize }}
+      {{range $replacement := $bodyRewrite.Replacements }}
+      [[frontends."frontend-{{ $frontendName }}".bodyRewrite.replacements]]
+        search = {{ printf "%q" $replacement.Search }}
+        regex = {{ printf "%q" $replacement.Regex }}
+        replacement = {{ printf "%q" $replacement.Replacement }}
+      {{end}}
+    {{end}}
+
+    {{ $maintenance := getMaintenance $container.SegmentLabels }}
+    {{if $maintenance }}
+    [frontends."frontend-{{ $frontendName }}".maintenance]
+      enabled = {{ $maintenance.Enabled }}
+      statusCode = {{ $maintenance.StatusCode }}
+      retryAfter = "{{ $maintenance.RetryAfter }}"
+      page = {{ printf "%q" $maintenance.Page }}
+      {{if $maintenance.SourceRange }}
+      sourceRange = [{{range $maintenance.SourceRange }}
+        "{{.}}",
+        {{end}}]
+      {{end}}
+      bypassHeaderName = {{ printf "%q" $maintenance.BypassHeaderName }}
+      bypassHeaderValue = {{ printf "%q" $maintenance.BypassHeaderValue }}
+    {{end}}
+
+    {{ $faultInjection := getFaultInjection $container.SegmentLabels }}
+    {{if $faultInjection }}
+    [frontends."frontend-{{ $frontendName }}".faultInjection]
+      enabled = {{ $faultInjection.Enabled }}
+      headerName = {{ printf "%q" $faultInjection.HeaderName }}
+      headerValue = {{ printf "%q" $faultInjection.HeaderValue }}
+      delayPercentage = {{ printf "%f" $faultInjection.DelayPercentage }}
+      delay = "{{ $faultInjection.Delay }}"
+      delayMax = "{{ $faultInjection.DelayMax }}"
+      delayDistribution = {{ printf "%q" $faultInjection.DelayDistribution }}
+      abortPercentage = {{ printf "%f" $faultInjection.AbortPercentage }}
+      abortStatusCode = {{ $faultInjection.AbortStatusCode }}
+      abortReset = {{ $faultInjection.AbortReset }}
+    {{end}}
+
+    {{ $sizeLimits := getSizeLimits $container.SegmentLabels }}
+    {{if $sizeLimits }}
+    [frontends."frontend-{{ $frontendName }}".sizeLimits]
+      maxRequestBodyBytes = {{ $sizeLimits.MaxRequestBodyBytes }}
+      maxHeaderCount = {{ $sizeLimits.MaxHeaderCount }}
+      maxHeaderBytes = {{ $sizeLimits.MaxHeaderBytes }}
+    {{end}}
+
+    {{ $requestID := getRequestID $container.SegmentLabels }}
+    {{if $requestID }}
+    [frontends."frontend-{{ $frontendName }}".requestID]
+      headerName = {{ printf "%q" $requestID.HeaderName }}
+      format = {{ printf "%q" $requestID.Format }}
+      insecure = {{ $requestID.Insecure }}
+      {{if $requestID.TrustedIPs }}
+      trustedIPs = [{{range $requestID.TrustedIPs }}
+        "{{.}}",
+        {{end}}]
+      {{end}}
+    {{end}}
+
     {{ $whitelist := getWhiteList $container.SegmentLabels }}
     {{if $whitelist }}
     [frontends."frontend-{{ $frontendName }}".whiteList]
+      {{if $whitelist.SourceRange }}
       sourceRange = [{{range $whitelist.SourceRange }}
         "{{.}}",
         {{end}}]
+      {{end}}
+      {{if $whitelist.SourceSets }}
+      sourceSets = [{{range $whitelist.SourceSets }}
+        "{{.}}",
+        {{end}}]
+      {{end}}
+      {{if $whitelist.DenyRange }}
+      denyRange = [{{range $whitelist.DenyRange }}
+        "{{.}}",
+        {{end}}]
+      {{end}}
+      {{if $whitelist.DenySets }}
+      denySets = [{{range $whitelist.DenySets }}
+        "{{.}}",
+        {{end}}]
+      {{end}}
       {{if $whitelist.IPStrategy }}
       [frontends."frontend-{{ $frontendName }}".whiteList.IPStrategy]
         depth = {{ $whitelist.IPStrategy.Depth }}
@@ -488,6 +909,30 @@ var _templatesDockerTmpl = []byte(`{{$backendServers := .Servers}}
       regex = "{{ $redirect.Regex }}"
       replacement = "{{ $redirect.Replacement }}"
       permanent = {{ $redirect.Permanent }}
+      {{if $redirect.StatusCode }}
+      statusCode = {{ $redirect.StatusCode }}
+      {{end}}
+      {{if $redirect.Scheme }}
+      scheme = "{{ $redirect.Scheme }}"
+      {{end}}
+      {{if $redirect.Host }}
+      host = "{{ $redirect.Host }}"
+      {{end}}
+      {{if $redirect.Port }}
+      port = "{{ $redirect.Port }}"
+      {{end}}
+      {{if $redirect.Path }}
+      path = "{{ $redirect.Path }}"
+      {{end}}
+      {{if $redirect.PathPrefix }}
+      pathPrefix = "{{ $redirect.PathPrefix }}"
+      {{end}}
+      {{if $redirect.DropQuery }}
+      dropQuery = true
+      {{end}}
+      {{if $redirect.AppRoot }}
+      appRoot = "{{ $redirect.AppRoot }}"
+      {{end}}
     {{end}}
 
     {{ $errorPages := getErrorPages $container.SegmentLabels }}
@@ -498,8 +943,13 @@ var _templatesDockerTmpl = []byte(`{{$backendServers := .Servers}}
         status = [{{range $page.Status }}
           "{{.}}",
           {{end}}]
+        {{if $page.Backend }}
         backend = "backend-{{ $page.Backend }}"
+        {{end}}
         query = "{{ $page.Query }}"
+        file = {{ printf "%q" $page.File }}
+        body = {{ printf "%q" $page.Body }}
+        contentType = {{ printf "%q" $page.ContentType }}
       {{end}}
     {{end}}
 
@@ -507,6 +957,15 @@ var _templatesDockerTmpl = []byte(`{{$backendServers := .Servers}}
     {{if $rateLimit }}
     [frontends."frontend-{{ $frontendName }}".rateLimit]
       extractorFunc = "{{ $rateLimit.ExtractorFunc }}"
+      distributed = {{ $rateLimit.Distributed }}
+      statusCode = {{ $rateLimit.StatusCode }}
+      body = {{ printf "%q" $rateLimit.Body }}
+      contentType = {{ printf "%q" $rateLimit.ContentType }}
+      {{if $rateLimit.ExemptSourceRange }}
+      exemptSourceRange = [{{range $rateLimit.ExemptSourceRange }}
+        "{{.}}",
+        {{end}}]
+      {{end}}
       [frontends."frontend-{{ $frontendName }}".rateLimit.rateSet]
         {{ range $limitName, $limit := $rateLimit.RateSet }}
         [frontends."frontend-{{ $frontendName }}".rateLimit.rateSet."{{ $limitName }}"]
@@ -563,6 +1022,27 @@ var _templatesDockerTmpl = []byte(`{{$backendServers := .Servers}}
         {{end}}
       {{end}}
 
+      {{range $operation := $headers.RequestOperations }}
+      [[frontends."frontend-{{ $frontendName }}".headers.requestOperations]]
+        action = "{{ $operation.Action }}"
+        name = "{{ $operation.Name }}"
+        value = "{{ $operation.Value }}"
+        regex = "{{ $operation.Regex }}"
+      {{end}}
+
+      {{range $operation := $headers.ResponseOperations }}
+      [[frontends."frontend-{{ $frontendName }}".headers.responseOperations]]
+        action = "{{ $operation.Action }}"
+        name = "{{ $operation.Name }}"
+        value = "{{ $operation.Value }}"
+        regex = "{{ $operation.Regex }}"
+        {{if $operation.StatusCodes }}
+        statusCodes = [{{range $operation.StatusCodes }}
+          "{{.}}",
+          {{end}}]
+        {{end}}
+      {{end}}
+
       {{if $headers.SSLProxyHeaders }}
       [frontends."frontend-{{ $frontendName }}".headers.SSLProxyHeaders]
         {{range $k, $v := $headers.SSLProxyHeaders }}
@@ -576,6 +1056,27 @@ var _templatesDockerTmpl = []byte(`{{$backendServers := .Servers}}
       rule = "{{ getFrontendRule $container $container.SegmentLabels }}"
 
 {{end}}
+
+{{if .UDPServices }}
+[udpBackends]
+{{range $serviceName, $containers := .UDPServices }}
+  {{range $serverName, $server := getUDPServers $containers }}
+  [udpBackends."udp-backend-{{ $serviceName }}".servers."{{ $serverName }}"]
+    address = "{{ $server.Address }}"
+    weight = {{ $server.Weight }}
+  {{end}}
+{{end}}
+
+[udpFrontends]
+{{range $serviceName, $containers := .UDPServices }}
+  {{ $container := index $containers 0 }}
+  [udpFrontends."udp-frontend-{{ $serviceName }}"]
+    backend = "udp-backend-{{ $serviceName }}"
+    entryPoints = [{{range getUDPEntryPoints $container.Labels }}
+      "{{.}}",
+      {{end}}]
+{{end}}
+{{end}}
 `)
 
 func templatesDockerTmplBytes() ([]byte, error) {
@@ -703,6 +1204,25 @@ var _templatesEcsTmpl = []byte(`[backends]
       [frontends."frontend-{{ $frontendName }}".auth.forward]
         address = "{{ $auth.Forward.Address }}"
         trustForwardHeader = {{ $auth.Forward.TrustForwardHeader }}
+        {{if $auth.Forward.AuthRequestHeaders }}
+        authRequestHeaders = [{{range $auth.Forward.AuthRequestHeaders }}
+          "{{.}}",
+          {{end}}]
+        {{end}}
+        method = "{{ $auth.Forward.Method }}"
+        forwardBody = {{ $auth.Forward.ForwardBody }}
+        maxBodySize = {{ $auth.Forward.MaxBodySize }}
+
+        {{if $auth.Forward.Cache }}
+        [frontends."frontend-{{ $frontendName }}".auth.forward.cache]
+          {{if $auth.Forward.Cache.KeyHeaders }}
+          keyHeaders = [{{range $auth.Forward.Cache.KeyHeaders }}
+            "{{.}}",
+            {{end}}]
+          {{end}}
+          ttl = "{{ $auth.Forward.Cache.TTL }}"
+          negativeTTL = "{{ $auth.Forward.Cache.NegativeTTL }}"
+        {{end}}
 
         {{if $auth.Forward.TLS }}
         [frontends."frontend-{{ $frontendName }}".auth.forward.tls]
@@ -737,12 +1257,161 @@ var _templatesEcsTmpl = []byte(`[backends]
       {{end}}
     {{end}}
 
+    {{ $tlsClientCertAuth := getTLSClientCertAuth $instance.SegmentLabels }}
+    {{if $tlsClientCertAuth }}
+    [frontends."frontend-{{ $frontendName }}".tlsClientCertAuth]
+      {{if $tlsClientCertAuth.CommonNames }}
+      commonNames = [{{range $tlsClientCertAuth.CommonNames }}
+        "{{.}}",
+        {{end}}]
+      {{end}}
+      {{if $tlsClientCertAuth.OrganizationalUnits }}
+      organizationalUnits = [{{range $tlsClientCertAuth.OrganizationalUnits }}
+        "{{.}}",
+        {{end}}]
+      {{end}}
+      {{if $tlsClientCertAuth.DNSNames }}
+      dnsNames = [{{range $tlsClientCertAuth.DNSNames }}
+        "{{.}}",
+        {{end}}]
+      {{end}}
+      {{if $tlsClientCertAuth.URIs }}
+      uris = [{{range $tlsClientCertAuth.URIs }}
+        "{{.}}",
+        {{end}}]
+      {{end}}
+      {{if $tlsClientCertAuth.Issuers }}
+      issuers = [{{range $tlsClientCertAuth.Issuers }}
+        "{{.}}",
+        {{end}}]
+      {{end}}
+      {{if $tlsClientCertAuth.Fingerprints }}
+      fingerprints = [{{range $tlsClientCertAuth.Fingerprints }}
+        "{{.}}",
+        {{end}}]
+      {{end}}
+    {{end}}
+
+    {{ $geoIP := getGeoIP $instance.SegmentLabels }}
+    {{if $geoIP }}
+    [frontends."frontend-{{ $frontendName }}".geoIP]
+      countryDatabase = "{{ $geoIP.CountryDatabase }}"
+      asnDatabase = "{{ $geoIP.ASNDatabase }}"
+      {{if $geoIP.AllowedCountries }}
+      allowedCountries = [{{range $geoIP.AllowedCountries }}
+        "{{.}}",
+        {{end}}]
+      {{end}}
+      {{if $geoIP.DeniedCountries }}
+      deniedCountries = [{{range $geoIP.DeniedCountries }}
+        "{{.}}",
+        {{end}}]
+      {{end}}
+      {{if $geoIP.AllowedASNs }}
+      allowedASNs = [{{range $geoIP.AllowedASNs }}
+        "{{.}}",
+        {{end}}]
+      {{end}}
+      {{if $geoIP.DeniedASNs }}
+      deniedASNs = [{{range $geoIP.DeniedASNs }}
+        "{{.}}",
+        {{end}}]
+      {{end}}
+      addHeaders = {{ $geoIP.AddHeaders }}
+    {{end}}
+
+    {{ $bodyRewrite := getBodyRewrite $instance.SegmentLabels }}
+    {{if $bodyRewrite }}
+    [frontends."frontend-{{ $frontendName }}".bodyRewrite]
+      {{if $bodyRewrite.ContentTypes }}
+      contentTypes = [{{range $bodyRewrite.ContentTypes }}
+        "{{.}}",
+        {{end}}]
+      {{end}}
+      maxBodySize = {{ $bodyRewrite.MaxBodySize }}
+      {{range $replacement := $bodyRewrite.Replacements }}
+      [[frontends."frontend-{{ $frontendName }}".bodyRewrite.replacements]]
+        search = {{ printf "%q" $replacement.Search }}
+        regex = {{ printf "%q" $replacement.Regex }}
+        replacement = {{ printf "%q" $replacement.Replacement }}
+      {{end}}
+    {{end}}
+
+    {{ $maintenance := getMaintenance $instance.SegmentLabels }}
+    {{if $maintenance }}
+    [frontends."frontend-{{ $frontendName }}".maintenance]
+      enabled = {{ $maintenance.Enabled }}
+      statusCode = {{ $maintenance.StatusCode }}
+      retryAfter = "{{ $maintenance.RetryAfter }}"
+      page = {{ printf "%q" $maintenance.Page }}
+      {{if $maintenance.SourceRange }}
+      sourceRange = [{{range $maintenance.SourceRange }}
+        "{{.}}",
+        {{end}}]
+      {{end}}
+      bypassHeaderName = {{ printf "%q" $maintenance.BypassHeaderName }}
+      bypassHeaderValue = {{ printf "%q" $maintenance.BypassHeaderValue }}
+    {{end}}
+
+    {{ $faultInjection := getFaultInjection $instance.SegmentLabels }}
+    {{if $faultInjection }}
+    [frontends."frontend-{{ $frontendName }}".faultInjection]
+      enabled = {{ $faultInjection.Enabled }}
+      headerName = {{ printf "%q" $faultInjection.HeaderName }}
+      headerValue = {{ printf "%q" $faultInjection.HeaderValue }}
+      delayPercentage = {{ printf "%f" $faultInjection.DelayPercentage }}
+      delay = "{{ $faultInjection.Delay }}"
+      delayMax = "{{ $faultInjection.DelayMax }}"
+      delayDistribution = {{ printf "%q" $faultInjection.DelayDistribution }}
+      abortPercentage = {{ printf "%f" $faultInjection.AbortPercentage }}
+      abortStatusCode = {{ $faultInjection.AbortStatusCode }}
+      abortReset = {{ $faultInjection.AbortReset }}
+    {{end}}
+
+    {{ $sizeLimits := getSizeLimits $instance.SegmentLabels }}
+    {{if $sizeLimits }}
+    [frontends."frontend-{{ $frontendName }}".sizeLimits]
+      maxRequestBodyBytes = {{ $sizeLimits.MaxRequestBodyBytes }}
+      maxHeaderCount = {{ $sizeLimits.MaxHeaderCount }}
+      maxHeaderBytes = {{ $sizeLimits.MaxHeaderBytes }}
+    {{end}}
+
+    {{ $requestID := getRequestID $instance.SegmentLabels }}
+    {{if $requestID }}
+    [frontends."frontend-{{ $frontendName }}".requestID]
+      headerName = {{ printf "%q" $requestID.HeaderName }}
+      format = {{ printf "%q" $requestID.Format }}
+      insecure = {{ $requestID.Insecure }}
+      {{if $requestID.TrustedIPs }}
+      trustedIPs = [{{range $requestID.TrustedIPs }}
+        "{{.}}",
+        {{end}}]
+      {{end}}
+    {{end}}
+
     {{ $whitelist := getWhiteList $instance.SegmentLabels }}
     {{if $whitelist }}
     [frontends."frontend-{{ $frontendName }}".whiteList]
+      {{if $whitelist.SourceRange }}
       sourceRange = [{{range $whitelist.SourceRange }}
         "{{.}}",
         {{end}}]
+      {{end}}
+      {{if $whitelist.SourceSets }}
+      sourceSets = [{{range $whitelist.SourceSets }}
+        "{{.}}",
+        {{end}}]
+      {{end}}
+      {{if $whitelist.DenyRange }}
+      denyRange = [{{range $whitelist.DenyRange }}
+        "{{.}}",
+        {{end}}]
+      {{end}}
+      {{if $whitelist.DenySets }}
+      denySets = [{{range $whitelist.DenySets }}
+        "{{.}}",
+        {{end}}]
+      {{end}}
       {{if $whitelist.IPStrategy }}
       [frontends."frontend-{{ $frontendName }}".whiteList.IPStrategy]
         depth = {{ $whitelist.IPStrategy.Depth }}
@@ -759,6 +1428,30 @@ var _templatesEcsTmpl = []byte(`[backends]
       regex = "{{ $redirect.Regex }}"
       replacement = "{{ $redirect.Replacement }}"
       permanent = {{ $redirect.Permanent }}
+      {{if $redirect.StatusCode }}
+      statusCode = {{ $redirect.StatusCode }}
+      {{end}}
+      {{if $redirect.Scheme }}
+      scheme = "{{ $redirect.Scheme }}"
+      {{end}}
+      {{if $redirect.Host }}
+      host = "{{ $redirect.Host }}"
+      {{end}}
+      {{if $redirect.Port }}
+      port = "{{ $redirect.Port }}"
+      {{end}}
+      {{if $redirect.Path }}
+      path = "{{ $redirect.Path }}"
+      {{end}}
+      {{if $redirect.PathPrefix }}
+      pathPrefix = "{{ $redirect.PathPrefix }}"
+      {{end}}
+      {{if $redirect.DropQuery }}
+      dropQuery = true
+      {{end}}
+      {{if $redirect.AppRoot }}
+      appRoot = "{{ $redirect.AppRoot }}"
+      {{end}}
     {{end}}
 
     {{ $errorPages := getErrorPages $instance.SegmentLabels }}
@@ -769,8 +1462,13 @@ var _templatesEcsTmpl = []byte(`[backends]
         status = [{{range $page.Status }}
           "{{.}}",
           {{end}}]
+        {{if $page.Backend }}
         backend = "backend-{{ $page.Backend }}"
+        {{end}}
         query = "{{ $page.Query }}"
+        file = {{ printf "%q" $page.File }}
+        body = {{ printf "%q" $page.Body }}
+        contentType = {{ printf "%q" $page.ContentType }}
       {{end}}
     {{end}}
 
@@ -778,6 +1476,15 @@ var _templatesEcsTmpl = []byte(`[backends]
     {{if $rateLimit }}
     [frontends."frontend-{{ $frontendName }}".rateLimit]
       extractorFunc = "{{ $rateLimit.ExtractorFunc }}"
+      distributed = {{ $rateLimit.Distributed }}
+      statusCode = {{ $rateLimit.StatusCode }}
+      body = {{ printf "%q" $rateLimit.Body }}
+      contentType = {{ printf "%q" $rateLimit.ContentType }}
+      {{if $rateLimit.ExemptSourceRange }}
+      exemptSourceRange = [{{range $rateLimit.ExemptSourceRange }}
+        "{{.}}",
+        {{end}}]
+      {{end}}
       [frontends."frontend-{{ $frontendName }}".rateLimit.rateSet]
         {{ range $limitName, $limit := $rateLimit.RateSet }}
         [frontends."frontend-{{ $frontendName }}".rateLimit.rateSet."{{ $limitName }}"]
@@ -834,6 +1541,27 @@ var _templatesEcsTmpl = []byte(`[backends]
         {{end}}
       {{end}}
 
+      {{range $operation := $headers.RequestOperations }}
+      [[frontends."frontend-{{ $frontendName }}".headers.requestOperations]]
+        action = "{{ $operation.Action }}"
+        name = "{{ $operation.Name }}"
+        value = "{{ $operation.Value }}"
+        regex = "{{ $operation.Regex }}"
+      {{end}}
+
+      {{range $operation := $headers.ResponseOperations }}
+      [[frontends."frontend-{{ $frontendName }}".headers.responseOperations]]
+        action = "{{ $operation.Action }}"
+        name = "{{ $operation.Name }}"
+        value = "{{ $operation.Value }}"
+        regex = "{{ $operation.Regex }}"
+        {{if $operation.StatusCodes }}
+        statusCodes = [{{range $operation.StatusCodes }}
+          "{{.}}",
+          {{end}}]
+        {{end}}
+      {{end}}
+
       {{if $headers.SSLProxyHeaders }}
       [frontends."frontend-{{ $frontendName }}".headers.SSLProxyHeaders]
         {{range $k, $v := $headers.SSLProxyHeaders }}
@@ -846,7 +1574,28 @@ var _templatesEcsTmpl = []byte(`[backends]
       rule = "{{ getFrontendRule $instance }}"
 
 {{end}}
-{{end}}`)
+{{end}}
+{{if .UDPServices }}
+[udpBackends]
+{{range $serviceName, $instances := .UDPServices }}
+  {{range $serverName, $server := getUDPServers $instances }}
+  [udpBackends."udp-backend-{{ $serviceName }}".servers."{{ $serverName }}"]
+    address = "{{ $server.Address }}"
+    weight = {{ $server.Weight }}
+  {{end}}
+{{end}}
+
+[udpFrontends]
+{{range $serviceName, $instances := .UDPServices }}
+  {{ $instance := index $instances 0 }}
+  [udpFrontends."udp-frontend-{{ $serviceName }}"]
+    backend = "udp-backend-{{ $serviceName }}"
+    entryPoints = [{{range getUDPEntryPoints $instance.TraefikLabels }}
+      "{{.}}",
+      {{end}}]
+{{end}}
+{{end}}
+`)
 
 func templatesEcsTmplBytes() ([]byte, error) {
 	return _templatesEcsTmpl, nil
@@ -1176,6 +1925,18 @@ var _templatesKvTmpl = []byte(`[backends]
     retryExpression = "{{ $buffering.RetryExpression }}"
   {{end}}
 
+  {{ $static := getStatic $backend }}
+  {{if $static }}
+  [backends."{{ $backendName }}".static]
+    {{if $static.IndexFiles }}
+    indexFiles = [{{range $static.IndexFiles }}
+      {{ printf "%q" . }},
+      {{end}}]
+    {{end}}
+    spa = {{ $static.SPA }}
+    browse = {{ $static.Browse }}
+  {{end}}
+
   {{range $serverName, $server := getServers $backend}}
   [backends."{{ $backendName }}".servers."{{ $serverName }}"]
     url = "{{ $server.URL }}"
@@ -1230,6 +1991,25 @@ var _templatesKvTmpl = []byte(`[backends]
       [frontends."{{ $frontendName }}".auth.forward]
         address = "{{ $auth.Forward.Address }}"
         trustForwardHeader = {{ $auth.Forward.TrustForwardHeader }}
+        {{if $auth.Forward.AuthRequestHeaders }}
+        authRequestHeaders = [{{range $auth.Forward.AuthRequestHeaders }}
+          "{{.}}",
+          {{end}}]
+        {{end}}
+        method = "{{ $auth.Forward.Method }}"
+        forwardBody = {{ $auth.Forward.ForwardBody }}
+        maxBodySize = {{ $auth.Forward.MaxBodySize }}
+
+        {{if $auth.Forward.Cache }}
+        [frontends."{{ $frontendName }}".auth.forward.cache]
+          {{if $auth.Forward.Cache.KeyHeaders }}
+          keyHeaders = [{{range $auth.Forward.Cache.KeyHeaders }}
+            "{{.}}",
+            {{end}}]
+          {{end}}
+          ttl = "{{ $auth.Forward.Cache.TTL }}"
+          negativeTTL = "{{ $auth.Forward.Cache.NegativeTTL }}"
+        {{end}}
 
         {{if $auth.Forward.TLS }}
         [frontends."{{ $frontendName }}".auth.forward.tls]
@@ -1264,12 +2044,161 @@ var _templatesKvTmpl = []byte(`[backends]
       {{end}}
     {{end}}
 
+    {{ $tlsClientCertAuth := getTLSClientCertAuth $frontend }}
+    {{if $tlsClientCertAuth }}
+    [frontends."{{ $frontendName }}".tlsClientCertAuth]
+      {{if $tlsClientCertAuth.CommonNames }}
+      commonNames = [{{range $tlsClientCertAuth.CommonNames }}
+        "{{.}}",
+        {{end}}]
+      {{end}}
+      {{if $tlsClientCertAuth.OrganizationalUnits }}
+      organizationalUnits = [{{range $tlsClientCertAuth.OrganizationalUnits }}
+        "{{.}}",
+        {{end}}]
+      {{end}}
+      {{if $tlsClientCertAuth.DNSNames }}
+      dnsNames = [{{range $tlsClientCertAuth.DNSNames }}
+        "{{.}}",
+        {{end}}]
+      {{end}}
+      {{if $tlsClientCertAuth.URIs }}
+      uris = [{{range $tlsClientCertAuth.URIs }}
+        "{{.}}",
+        {{end}}]
+      {{end}}
+      {{if $tlsClientCertAuth.Issuers }}
+      issuers = [{{range $tlsClientCertAuth.Issuers }}
+        "{{.}}",
+        {{end}}]
+      {{end}}
+      {{if $tlsClientCertAuth.Fingerprints }}
+      fingerprints = [{{range $tlsClientCertAuth.Fingerprints }}
+        "{{.}}",
+        {{end}}]
+      {{end}}
+    {{end}}
+
+    {{ $geoIP := getGeoIP $frontend }}
+    {{if $geoIP }}
+    [frontends."{{ $frontendName }}".geoIP]
+      countryDatabase = "{{ $geoIP.CountryDatabase }}"
+      asnDatabase = "{{ $geoIP.ASNDatabase }}"
+      {{if $geoIP.AllowedCountries }}
+      allowedCountries = [{{range $geoIP.AllowedCountries }}
+        "{{.}}",
+        {{end}}]
+      {{end}}
+      {{if $geoIP.DeniedCountries }}
+      deniedCountries = [{{range $geoIP.DeniedCountries }}
+        "{{.}}",
+        {{end}}]
+      {{end}}
+      {{if $geoIP.AllowedASNs }}
+      allowedASNs = [{{range $geoIP.AllowedASNs }}
+        "{{.}}",
+        {{end}}]
+      {{end}}
+      {{if $geoIP.DeniedASNs }}
+      deniedASNs = [{{range $geoIP.DeniedASNs }}
+        "{{.}}",
+        {{end}}]
+      {{end}}
+      addHeaders = {{ $geoIP.AddHeaders }}
+    {{end}}
+
+    {{ $bodyRewrite := getBodyRewrite $frontend }}
+    {{if $bodyRewrite }}
+    [frontends."{{ $frontendName }}".bodyRewrite]
+      {{if $bodyRewrite.ContentTypes }}
+      contentTypes = [{{range $bodyRewrite.ContentTypes }}
+        "{{.}}",
+        {{end}}]
+      {{end}}
+      maxBodySize = {{ $bodyRewrite.MaxBodySize }}
+      {{range $replacement := $bodyRewrite.Replacements }}
+      [[frontends."{{ $frontendName }}".bodyRewrite.replacements]]
+        search = {{ printf "%q" $replacement.Search }}
+        regex = {{ printf "%q" $replacement.Regex }}
+        replacement = {{ printf "%q" $replacement.Replacement }}
+      {{end}}
+    {{end}}
+
+    {{ $maintenance := getMaintenance $frontend }}
+    {{if $maintenance }}
+    [frontends."{{ $frontendName }}".maintenance]
+      enabled = {{ $maintenance.Enabled }}
+      statusCode = {{ $maintenance.StatusCode }}
+      retryAfter = "{{ $maintenance.RetryAfter }}"
+      page = {{ printf "%q" $maintenance.Page }}
+      {{if $maintenance.SourceRange }}
+      sourceRange = [{{range $maintenance.SourceRange }}
+        "{{.}}",
+        {{end}}]
+      {{end}}
+      bypassHeaderName = {{ printf "%q" $maintenance.BypassHeaderName }}
+      bypassHeaderValue = {{ printf "%q" $maintenance.BypassHeaderValue }}
+    {{end}}
+
+    {{ $faultInjection := getFaultInjection $frontend }}
+    {{if $faultInjection }}
+    [frontends."{{ $frontendName }}".faultInjection]
+      enabled = {{ $faultInjection.Enabled }}
+      headerName = {{ printf "%q" $faultInjection.HeaderName }}
+      headerValue = {{ printf "%q" $faultInjection.HeaderValue }}
+      delayPercentage = {{ printf "%f" $faultInjection.DelayPercentage }}
+      delay = "{{ $faultInjection.Delay }}"
+      delayMax = "{{ $faultInjection.DelayMax }}"
+      delayDistribution = {{ printf "%q" $faultInjection.DelayDistribution }}
+      abortPercentage = {{ printf "%f" $faultInjection.AbortPercentage }}
+      abortStatusCode = {{ $faultInjection.AbortStatusCode }}
+      abortReset = {{ $faultInjection.AbortReset }}
+    {{end}}
+
+    {{ $sizeLimits := getSizeLimits $frontend }}
+    {{if $sizeLimits }}
+    [frontends."{{ $frontendName }}".sizeLimits]
+      maxRequestBodyBytes = {{ $sizeLimits.MaxRequestBodyBytes }}
+      maxHeaderCount = {{ $sizeLimits.MaxHeaderCount }}
+      maxHeaderBytes = {{ $sizeLimits.MaxHeaderBytes }}
+    {{end}}
+
+    {{ $requestID := getRequestID $frontend }}
+    {{if $requestID }}
+    [frontends."{{ $frontendName }}".requestID]
+      headerName = {{ printf "%q" $requestID.HeaderName }}
+      format = {{ printf "%q" $requestID.Format }}
+      insecure = {{ $requestID.Insecure }}
+      {{if $requestID.TrustedIPs }}
+      trustedIPs = [{{range $requestID.TrustedIPs }}
+        "{{.}}",
+        {{end}}]
+      {{end}}
+    {{end}}
+
     {{ $whitelist := getWhiteList $frontend }}
     {{if $whitelist }}
     [frontends."{{ $frontendName }}".whiteList]
+      {{if $whitelist.SourceRange }}
       sourceRange = [{{range $whitelist.SourceRange }}
         "{{.}}",
         {{end}}]
+      {{end}}
+      {{if $whitelist.SourceSets }}
+      sourceSets = [{{range $whitelist.SourceSets }}
+        "{{.}}",
+        {{end}}]
+      {{end}}
+      {{if $whitelist.DenyRange }}
+      denyRange = [{{range $whitelist.DenyRange }}
+        "{{.}}",
+        {{end}}]
+      {{end}}
+      {{if $whitelist.DenySets }}
+      denySets = [{{range $whitelist.DenySets }}
+        "{{.}}",
+        {{end}}]
+      {{end}}
       {{if $whitelist.IPStrategy }}
       [frontends."{{ $frontendName }}".whiteList.IPStrategy]
         depth = {{ $whitelist.IPStrategy.Depth }}
@@ -1286,6 +2215,30 @@ var _templatesKvTmpl = []byte(`[backends]
       regex = "{{ $redirect.Regex }}"
       replacement = "{{ $redirect.Replacement }}"
       permanent = {{ $redirect.Permanent }}
+      {{if $redirect.StatusCode }}
+      statusCode = {{ $redirect.StatusCode }}
+      {{end}}
+      {{if $redirect.Scheme }}
+      scheme = "{{ $redirect.Scheme }}"
+      {{end}}
+      {{if $redirect.Host }}
+      host = "{{ $redirect.Host }}"
+      {{end}}
+      {{if $redirect.Port }}
+      port = "{{ $redirect.Port }}"
+      {{end}}
+      {{if $redirect.Path }}
+      path = "{{ $redirect.Path }}"
+      {{end}}
+      {{if $redirect.PathPrefix }}
+      pathPrefix = "{{ $redirect.PathPrefix }}"
+      {{end}}
+      {{if $redirect.DropQuery }}
+      dropQuery = true
+      {{end}}
+      {{if $redirect.AppRoot }}
+      appRoot = "{{ $redirect.AppRoot }}"
+      {{end}}
     {{end}}
 
     {{ $errorPages := getErrorPages $frontend }}
@@ -1296,8 +2249,13 @@ var _templatesKvTmpl = []byte(`[backends]
         status = [{{range $page.Status }}
           "{{.}}",
           {{end}}]
+        {{if $page.Backend}}
         backend = "{{$page.Backend}}"
+        {{end}}
         query = "{{$page.Query}}"
+        file = {{ printf "%q" $page.File }}
+        body = {{ printf "%q" $page.Body }}
+        contentType = {{ printf "%q" $page.ContentType }}
       {{end}}
     {{end}}
 
@@ -1305,6 +2263,15 @@ var _templatesKvTmpl = []byte(`[backends]
     {{if $rateLimit }}
     [frontends."{{ $frontendName }}".rateLimit]
       extractorFunc = "{{ $rateLimit.ExtractorFunc }}"
+      distributed = {{ $rateLimit.Distributed }}
+      statusCode = {{ $rateLimit.StatusCode }}
+      body = {{ printf "%q" $rateLimit.Body }}
+      contentType = {{ printf "%q" $rateLimit.ContentType }}
+      {{if $rateLimit.ExemptSourceRange }}
+      exemptSourceRange = [{{range $rateLimit.ExemptSourceRange }}
+        "{{.}}",
+        {{end}}]
+      {{end}}
       [frontends."{{ $frontendName }}".rateLimit.rateSet]
         {{range $limitName, $rateLimit := $rateLimit.RateSet }}
         [frontends."{{ $frontendName }}".rateLimit.rateSet."{{ $limitName }}"]
@@ -1361,6 +2328,27 @@ var _templatesKvTmpl = []byte(`[backends]
         {{end}}
       {{end}}
 
+      {{range $operation := $headers.RequestOperations }}
+      [[frontends."{{ $frontendName }}".headers.requestOperations]]
+        action = "{{ $operation.Action }}"
+        name = "{{ $operation.Name }}"
+        value = "{{ $operation.Value }}"
+        regex = "{{ $operation.Regex }}"
+      {{end}}
+
+      {{range $operation := $headers.ResponseOperations }}
+      [[frontends."{{ $frontendName }}".headers.responseOperations]]
+        action = "{{ $operation.Action }}"
+        name = "{{ $operation.Name }}"
+        value = "{{ $operation.Value }}"
+        regex = "{{ $operation.Regex }}"
+        {{if $operation.StatusCodes }}
+        statusCodes = [{{range $operation.StatusCodes }}
+          "{{.}}",
+          {{end}}]
+        {{end}}
+      {{end}}
+
       {{if $headers.SSLProxyHeaders }}
       [frontends."{{ $frontendName }}".headers.SSLProxyHeaders]
         {{range $k, $v := $headers.SSLProxyHeaders}}
@@ -1516,6 +2504,25 @@ var _templatesMarathonTmpl = []byte(`{{ $apps := .Applications }}
       [frontends."{{ $frontendName }}".auth.forward]
         address = "{{ $auth.Forward.Address }}"
         trustForwardHeader = {{ $auth.Forward.TrustForwardHeader }}
+        {{if $auth.Forward.AuthRequestHeaders }}
+        authRequestHeaders = [{{range $auth.Forward.AuthRequestHeaders }}
+          "{{.}}",
+          {{end}}]
+        {{end}}
+        method = "{{ $auth.Forward.Method }}"
+        forwardBody = {{ $auth.Forward.ForwardBody }}
+        maxBodySize = {{ $auth.Forward.MaxBodySize }}
+
+        {{if $auth.Forward.Cache }}
+        [frontends."{{ $frontendName }}".auth.forward.cache]
+          {{if $auth.Forward.Cache.KeyHeaders }}
+          keyHeaders = [{{range $auth.Forward.Cache.KeyHeaders }}
+            "{{.}}",
+            {{end}}]
+          {{end}}
+          ttl = "{{ $auth.Forward.Cache.TTL }}"
+          negativeTTL = "{{ $auth.Forward.Cache.NegativeTTL }}"
+        {{end}}
 
         {{if $auth.Forward.TLS }}
         [frontends."{{ $frontendName }}".auth.forward.tls]
@@ -1527,35 +2534,184 @@ var _templatesMarathonTmpl = []byte(`{{ $apps := .Applications }}
         {{end}}
       {{end}}
 
-      {{if $auth.Basic }}
-      [frontends."{{ $frontendName }}".auth.basic]
-        removeHeader = {{ $auth.Basic.RemoveHeader }}
-        {{if $auth.Basic.Users }}
-        users = [{{range $auth.Basic.Users }}
-          "{{.}}",
-          {{end}}]
-        {{end}}
-        usersFile = "{{ $auth.Basic.UsersFile }}"
+      {{if $auth.Basic }}
+      [frontends."{{ $frontendName }}".auth.basic]
+        removeHeader = {{ $auth.Basic.RemoveHeader }}
+        {{if $auth.Basic.Users }}
+        users = [{{range $auth.Basic.Users }}
+          "{{.}}",
+          {{end}}]
+        {{end}}
+        usersFile = "{{ $auth.Basic.UsersFile }}"
+      {{end}}
+
+      {{if $auth.Digest }}
+      [frontends."{{ $frontendName }}".auth.digest]
+        removeHeader = {{ $auth.Digest.RemoveHeader }}
+        {{if $auth.Digest.Users }}
+        users = [{{range $auth.Digest.Users }}
+          "{{.}}",
+          {{end}}]
+        {{end}}
+        usersFile = "{{ $auth.Digest.UsersFile }}"
+      {{end}}
+    {{end}}
+
+    {{ $tlsClientCertAuth := getTLSClientCertAuth $app.SegmentLabels }}
+    {{if $tlsClientCertAuth }}
+    [frontends."{{ $frontendName }}".tlsClientCertAuth]
+      {{if $tlsClientCertAuth.CommonNames }}
+      commonNames = [{{range $tlsClientCertAuth.CommonNames }}
+        "{{.}}",
+        {{end}}]
+      {{end}}
+      {{if $tlsClientCertAuth.OrganizationalUnits }}
+      organizationalUnits = [{{range $tlsClientCertAuth.OrganizationalUnits }}
+        "{{.}}",
+        {{end}}]
+      {{end}}
+      {{if $tlsClientCertAuth.DNSNames }}
+      dnsNames = [{{range $tlsClientCertAuth.DNSNames }}
+        "{{.}}",
+        {{end}}]
+      {{end}}
+      {{if $tlsClientCertAuth.URIs }}
+      uris = [{{range $tlsClientCertAuth.URIs }}
+        "{{.}}",
+        {{end}}]
+      {{end}}
+      {{if $tlsClientCertAuth.Issuers }}
+      issuers = [{{range $tlsClientCertAuth.Issuers }}
+        "{{.}}",
+        {{end}}]
+      {{end}}
+      {{if $tlsClientCertAuth.Fingerprints }}
+      fingerprints = [{{range $tlsClientCertAuth.Fingerprints }}
+        "{{.}}",
+        {{end}}]
+      {{end}}
+    {{end}}
+
+    {{ $geoIP := getGeoIP $app.SegmentLabels }}
+    {{if $geoIP }}
+    [frontends."{{ $frontendName }}".geoIP]
+      countryDatabase = "{{ $geoIP.CountryDatabase }}"
+      asnDatabase = "{{ $geoIP.ASNDatabase }}"
+      {{if $geoIP.AllowedCountries }}
+      allowedCountries = [{{range $geoIP.AllowedCountries }}
+        "{{.}}",
+        {{end}}]
+      {{end}}
+      {{if $geoIP.DeniedCountries }}
+      deniedCountries = [{{range $geoIP.DeniedCountries }}
+        "{{.}}",
+        {{end}}]
+      {{end}}
+      {{if $geoIP.AllowedASNs }}
+      allowedASNs = [{{range $geoIP.AllowedASNs }}
+        "{{.}}",
+        {{end}}]
+      {{end}}
+      {{if $geoIP.DeniedASNs }}
+      deniedASNs = [{{range $geoIP.DeniedASNs }}
+        "{{.}}",
+        {{end}}]
+      {{end}}
+      addHeaders = {{ $geoIP.AddHeaders }}
+    {{end}}
+
+    {{ $bodyRewrite := getBodyRewrite $app.SegmentLabels }}
+    {{if $bodyRewrite }}
+    [frontends."{{ $frontendName }}".bodyRewrite]
+      {{if $bodyRewrite.ContentTypes }}
+      contentTypes = [{{range $bodyRewrite.ContentTypes }}
+        "{{.}}",
+        {{end}}]
+      {{end}}
+      maxBodySize = {{ $bodyRewrite.MaxBodySize }}
+      {{range $replacement := $bodyRewrite.Replacements }}
+      [[frontends."{{ $frontendName }}".bodyRewrite.replacements]]
+        search = {{ printf "%q" $replacement.Search }}
+        regex = {{ printf "%q" $replacement.Regex }}
+        replacement = {{ printf "%q" $replacement.Replacement }}
+      {{end}}
+    {{end}}
+
+    {{ $maintenance := getMaintenance $app.SegmentLabels }}
+    {{if $maintenance }}
+    [frontends."{{ $frontendName }}".maintenance]
+      enabled = {{ $maintenance.Enabled }}
+      statusCode = {{ $maintenance.StatusCode }}
+      retryAfter = "{{ $maintenance.RetryAfter }}"
+      page = {{ printf "%q" $maintenance.Page }}
+      {{if $maintenance.SourceRange }}
+      sourceRange = [{{range $maintenance.SourceRange }}
+        "{{.}}",
+        {{end}}]
       {{end}}
+      bypassHeaderName = {{ printf "%q" $maintenance.BypassHeaderName }}
+      bypassHeaderValue = {{ printf "%q" $maintenance.BypassHeaderValue }}
+    {{end}}
 
-      {{if $auth.Digest }}
-      [frontends."{{ $frontendName }}".auth.digest]
-        removeHeader = {{ $auth.Digest.RemoveHeader }}
-        {{if $auth.Digest.Users }}
-        users = [{{range $auth.Digest.Users }}
-          "{{.}}",
-          {{end}}]
-        {{end}}
-        usersFile = "{{ $auth.Digest.UsersFile }}"
+    {{ $faultInjection := getFaultInjection $app.SegmentLabels }}
+    {{if $faultInjection }}
+    [frontends."{{ $frontendName }}".faultInjection]
+      enabled = {{ $faultInjection.Enabled }}
+      headerName = {{ printf "%q" $faultInjection.HeaderName }}
+      headerValue = {{ printf "%q" $faultInjection.HeaderValue }}
+      delayPercentage = {{ printf "%f" $faultInjection.DelayPercentage }}
+      delay = "{{ $faultInjection.Delay }}"
+      delayMax = "{{ $faultInjection.DelayMax }}"
+      delayDistribution = {{ printf "%q" $faultInjection.DelayDistribution }}
+      abortPercentage = {{ printf "%f" $faultInjection.AbortPercentage }}
+      abortStatusCode = {{ $faultInjection.AbortStatusCode }}
+      abortReset = {{ $faultInjection.AbortReset }}
+    {{end}}
+
+    {{ $sizeLimits := getSizeLimits $app.SegmentLabels }}
+    {{if $sizeLimits }}
+    [frontends."{{ $frontendName }}".sizeLimits]
+      maxRequestBodyBytes = {{ $sizeLimits.MaxRequestBodyBytes }}
+      maxHeaderCount = {{ $sizeLimits.MaxHeaderCount }}
+      maxHeaderBytes = {{ $sizeLimits.MaxHeaderBytes }}
+    {{end}}
+
+    {{ $requestID := getRequestID $app.SegmentLabels }}
+    {{if $requestID }}
+    [frontends."{{ $frontendName }}".requestID]
+      headerName = {{ printf "%q" $requestID.HeaderName }}
+      format = {{ printf "%q" $requestID.Format }}
+      insecure = {{ $requestID.Insecure }}
+      {{if $requestID.TrustedIPs }}
+      trustedIPs = [{{range $requestID.TrustedIPs }}
+        "{{.}}",
+        {{end}}]
       {{end}}
     {{end}}
 
     {{ $whitelist := getWhiteList $app.SegmentLabels }}
     {{if $whitelist }}
     [frontends."{{ $frontendName }}".whiteList]
+      {{if $whitelist.SourceRange }}
       sourceRange = [{{range $whitelist.SourceRange }}
         "{{.}}",
         {{end}}]
+      {{end}}
+      {{if $whitelist.SourceSets }}
+      sourceSets = [{{range $whitelist.SourceSets }}
+        "{{.}}",
+        {{end}}]
+      {{end}}
+      {{if $whitelist.DenyRange }}
+      denyRange = [{{range $whitelist.DenyRange }}
+        "{{.}}",
+        {{end}}]
+      {{end}}
+      {{if $whitelist.DenySets }}
+      denySets = [{{range $whitelist.DenySets }}
+        "{{.}}",
+        {{end}}]
+      {{end}}
       {{if $whitelist.IPStrategy }}
       [frontends."{{ $frontendName }}".whiteList.IPStrategy]
         depth = {{ $whitelist.IPStrategy.Depth }}
@@ -1572,6 +2728,30 @@ var _templatesMarathonTmpl = []byte(`{{ $apps := .Applications }}
       regex = "{{ $redirect.Regex }}"
       replacement = "{{ $redirect.Replacement }}"
       permanent = {{ $redirect.Permanent }}
+      {{if $redirect.StatusCode }}
+      statusCode = {{ $redirect.StatusCode }}
+      {{end}}
+      {{if $redirect.Scheme }}
+      scheme = "{{ $redirect.Scheme }}"
+      {{end}}
+      {{if $redirect.Host }}
+      host = "{{ $redirect.Host }}"
+      {{end}}
+      {{if $redirect.Port }}
+      port = "{{ $redirect.Port }}"
+      {{end}}
+      {{if $redirect.Path }}
+      path = "{{ $redirect.Path }}"
+      {{end}}
+      {{if $redirect.PathPrefix }}
+      pathPrefix = "{{ $redirect.PathPrefix }}"
+      {{end}}
+      {{if $redirect.DropQuery }}
+      dropQuery = true
+      {{end}}
+      {{if $redirect.AppRoot }}
+      appRoot = "{{ $redirect.AppRoot }}"
+      {{end}}
     {{end}}
 
     {{ $errorPages := getErrorPages $app.SegmentLabels }}
@@ -1582,8 +2762,13 @@ var _templatesMarathonTmpl = []byte(`{{ $apps := .Applications }}
         status = [{{range $page.Status }}
           "{{.}}",
           {{end}}]
+        {{if $page.Backend }}
         backend = "backend{{ $page.Backend }}"
+        {{end}}
         query = "{{ $page.Query }}"
+        file = {{ printf "%q" $page.File }}
+        body = {{ printf "%q" $page.Body }}
+        contentType = {{ printf "%q" $page.ContentType }}
       {{end}}
     {{end}}
 
@@ -1591,6 +2776,15 @@ var _templatesMarathonTmpl = []byte(`{{ $apps := .Applications }}
     {{if $rateLimit }}
     [frontends."{{ $frontendName }}".rateLimit]
       extractorFunc = "{{ $rateLimit.ExtractorFunc }}"
+      distributed = {{ $rateLimit.Distributed }}
+      statusCode = {{ $rateLimit.StatusCode }}
+      body = {{ printf "%q" $rateLimit.Body }}
+      contentType = {{ printf "%q" $rateLimit.ContentType }}
+      {{if $rateLimit.ExemptSourceRange }}
+      exemptSourceRange = [{{range $rateLimit.ExemptSourceRange }}
+        "{{.}}",
+        {{end}}]
+      {{end}}
       [frontends."{{ $frontendName }}".rateLimit.rateSet]
         {{ range $limitName, $limit := $rateLimit.RateSet }}
         [frontends."{{ $frontendName }}".rateLimit.rateSet."{{ $limitName }}"]
@@ -1647,6 +2841,27 @@ var _templatesMarathonTmpl = []byte(`{{ $apps := .Applications }}
         {{end}}
       {{end}}
 
+      {{range $operation := $headers.RequestOperations }}
+      [[frontends."{{ $frontendName }}".headers.requestOperations]]
+        action = "{{ $operation.Action }}"
+        name = "{{ $operation.Name }}"
+        value = "{{ $operation.Value }}"
+        regex = "{{ $operation.Regex }}"
+      {{end}}
+
+      {{range $operation := $headers.ResponseOperations }}
+      [[frontends."{{ $frontendName }}".headers.responseOperations]]
+        action = "{{ $operation.Action }}"
+        name = "{{ $operation.Name }}"
+        value = "{{ $operation.Value }}"
+        regex = "{{ $operation.Regex }}"
+        {{if $operation.StatusCodes }}
+        statusCodes = [{{range $operation.StatusCodes }}
+          "{{.}}",
+          {{end}}]
+        {{end}}
+      {{end}}
+
       {{if $headers.SSLProxyHeaders }}
       [frontends."{{ $frontendName }}".headers.SSLProxyHeaders]
         {{range $k, $v := $headers.SSLProxyHeaders }}
@@ -1659,6 +2874,26 @@ var _templatesMarathonTmpl = []byte(`{{ $apps := .Applications }}
     rule = "{{ getFrontendRule $app }}"
 
 {{end}}
+
+{{if .UDPApplications }}
+[udpBackends]
+{{range $serviceName, $app := .UDPApplications }}
+  {{range $serverName, $server := getUDPServers $app }}
+  [udpBackends."udp-backend-{{ $serviceName }}".servers."{{ $serverName }}"]
+    address = "{{ $server.Address }}"
+    weight = {{ $server.Weight }}
+  {{end}}
+{{end}}
+
+[udpFrontends]
+{{range $serviceName, $app := .UDPApplications }}
+  [udpFrontends."udp-frontend-{{ $serviceName }}"]
+    backend = "udp-backend-{{ $serviceName }}"
+    entryPoints = [{{range getUDPEntryPoints $app }}
+      "{{.}}",
+      {{end}}]
+{{end}}
+{{end}}
 `)
 
 func templatesMarathonTmplBytes() ([]byte, error) {
@@ -1787,6 +3022,25 @@ var _templatesMesosTmpl = []byte(`[backends]
       [frontends."frontend-{{ $frontendName }}".auth.forward]
         address = "{{ $auth.Forward.Address }}"
         trustForwardHeader = {{ $auth.Forward.TrustForwardHeader }}
+        {{if $auth.Forward.AuthRequestHeaders }}
+        authRequestHeaders = [{{range $auth.Forward.AuthRequestHeaders }}
+          "{{.}}",
+          {{end}}]
+        {{end}}
+        method = "{{ $auth.Forward.Method }}"
+        forwardBody = {{ $auth.Forward.ForwardBody }}
+        maxBodySize = {{ $auth.Forward.MaxBodySize }}
+
+        {{if $auth.Forward.Cache }}
+        [frontends."frontend-{{ $frontendName }}".auth.forward.cache]
+          {{if $auth.Forward.Cache.KeyHeaders }}
+          keyHeaders = [{{range $auth.Forward.Cache.KeyHeaders }}
+            "{{.}}",
+            {{end}}]
+          {{end}}
+          ttl = "{{ $auth.Forward.Cache.TTL }}"
+          negativeTTL = "{{ $auth.Forward.Cache.NegativeTTL }}"
+        {{end}}
 
         {{if $auth.Forward.TLS }}
         [frontends."frontend-{{ $frontendName }}".auth.forward.tls]
@@ -1821,12 +3075,161 @@ var _templatesMesosTmpl = []byte(`[backends]
       {{end}}
     {{end}}
           
+    {{ $tlsClientCertAuth := getTLSClientCertAuth $app.TraefikLabels }}
+    {{if $tlsClientCertAuth }}
+    [frontends."frontend-{{ $frontendName }}".tlsClientCertAuth]
+      {{if $tlsClientCertAuth.CommonNames }}
+      commonNames = [{{range $tlsClientCertAuth.CommonNames }}
+        "{{.}}",
+        {{end}}]
+      {{end}}
+      {{if $tlsClientCertAuth.OrganizationalUnits }}
+      organizationalUnits = [{{range $tlsClientCertAuth.OrganizationalUnits }}
+        "{{.}}",
+        {{end}}]
+      {{end}}
+      {{if $tlsClientCertAuth.DNSNames }}
+      dnsNames = [{{range $tlsClientCertAuth.DNSNames }}
+        "{{.}}",
+        {{end}}]
+      {{end}}
+      {{if $tlsClientCertAuth.URIs }}
+      uris = [{{range $tlsClientCertAuth.URIs }}
+        "{{.}}",
+        {{end}}]
+      {{end}}
+      {{if $tlsClientCertAuth.Issuers }}
+      issuers = [{{range $tlsClientCertAuth.Issuers }}
+        "{{.}}",
+        {{end}}]
+      {{end}}
+      {{if $tlsClientCertAuth.Fingerprints }}
+      fingerprints = [{{range $tlsClientCertAuth.Fingerprints }}
+        "{{.}}",
+        {{end}}]
+      {{end}}
+    {{end}}
+
+    {{ $geoIP := getGeoIP $app.TraefikLabels }}
+    {{if $geoIP }}
+    [frontends."frontend-{{ $frontendName }}".geoIP]
+      countryDatabase = "{{ $geoIP.CountryDatabase }}"
+      asnDatabase = "{{ $geoIP.ASNDatabase }}"
+      {{if $geoIP.AllowedCountries }}
+      allowedCountries = [{{range $geoIP.AllowedCountries }}
+        "{{.}}",
+        {{end}}]
+      {{end}}
+      {{if $geoIP.DeniedCountries }}
+      deniedCountries = [{{range $geoIP.DeniedCountries }}
+        "{{.}}",
+        {{end}}]
+      {{end}}
+      {{if $geoIP.AllowedASNs }}
+      allowedASNs = [{{range $geoIP.AllowedASNs }}
+        "{{.}}",
+        {{end}}]
+      {{end}}
+      {{if $geoIP.DeniedASNs }}
+      deniedASNs = [{{range $geoIP.DeniedASNs }}
+        "{{.}}",
+        {{end}}]
+      {{end}}
+      addHeaders = {{ $geoIP.AddHeaders }}
+    {{end}}
+
+    {{ $bodyRewrite := getBodyRewrite $app.TraefikLabels }}
+    {{if $bodyRewrite }}
+    [frontends."frontend-{{ $frontendName }}".bodyRewrite]
+      {{if $bodyRewrite.ContentTypes }}
+      contentTypes = [{{range $bodyRewrite.ContentTypes }}
+        "{{.}}",
+        {{end}}]
+      {{end}}
+      maxBodySize = {{ $bodyRewrite.MaxBodySize }}
+      {{range $replacement := $bodyRewrite.Replacements }}
+      [[frontends."frontend-{{ $frontendName }}".bodyRewrite.replacements]]
+        search = {{ printf "%q" $replacement.Search }}
+        regex = {{ printf "%q" $replacement.Regex }}
+        replacement = {{ printf "%q" $replacement.Replacement }}
+      {{end}}
+    {{end}}
+
+    {{ $maintenance := getMaintenance $app.TraefikLabels }}
+    {{if $maintenance }}
+    [frontends."frontend-{{ $frontendName }}".maintenance]
+      enabled = {{ $maintenance.Enabled }}
+      statusCode = {{ $maintenance.StatusCode }}
+      retryAfter = "{{ $maintenance.RetryAfter }}"
+      page = {{ printf "%q" $maintenance.Page }}
+      {{if $maintenance.SourceRange }}
+      sourceRange = [{{range $maintenance.SourceRange }}
+        "{{.}}",
+        {{end}}]
+      {{end}}
+      bypassHeaderName = {{ printf "%q" $maintenance.BypassHeaderName }}
+      bypassHeaderValue = {{ printf "%q" $maintenance.BypassHeaderValue }}
+    {{end}}
+
+    {{ $faultInjection := getFaultInjection $app.TraefikLabels }}
+    {{if $faultInjection }}
+    [frontends."frontend-{{ $frontendName }}".faultInjection]
+      enabled = {{ $faultInjection.Enabled }}
+      headerName = {{ printf "%q" $faultInjection.HeaderName }}
+      headerValue = {{ printf "%q" $faultInjection.HeaderValue }}
+      delayPercentage = {{ printf "%f" $faultInjection.DelayPercentage }}
+      delay = "{{ $faultInjection.Delay }}"
+      delayMax = "{{ $faultInjection.DelayMax }}"
+      delayDistribution = {{ printf "%q" $faultInjection.DelayDistribution }}
+      abortPercentage = {{ printf "%f" $faultInjection.AbortPercentage }}
+      abortStatusCode = {{ $faultInjection.AbortStatusCode }}
+      abortReset = {{ $faultInjection.AbortReset }}
+    {{end}}
+
+    {{ $sizeLimits := getSizeLimits $app.TraefikLabels }}
+    {{if $sizeLimits }}
+    [frontends."frontend-{{ $frontendName }}".sizeLimits]
+      maxRequestBodyBytes = {{ $sizeLimits.MaxRequestBodyBytes }}
+      maxHeaderCount = {{ $sizeLimits.MaxHeaderCount }}
+      maxHeaderBytes = {{ $sizeLimits.MaxHeaderBytes }}
+    {{end}}
+
+    {{ $requestID := getRequestID $app.TraefikLabels }}
+    {{if $requestID }}
+    [frontends."frontend-{{ $frontendName }}".requestID]
+      headerName = {{ printf "%q" $requestID.HeaderName }}
+      format = {{ printf "%q" $requestID.Format }}
+      insecure = {{ $requestID.Insecure }}
+      {{if $requestID.TrustedIPs }}
+      trustedIPs = [{{range $requestID.TrustedIPs }}
+        "{{.}}",
+        {{end}}]
+      {{end}}
+    {{end}}
+
     {{ $whitelist := getWhiteList $app.TraefikLabels }}
     {{if $whitelist }}
     [frontends."frontend-{{ $frontendName }}".whiteList]
+      {{if $whitelist.SourceRange }}
       sourceRange = [{{range $whitelist.SourceRange }}
         "{{.}}",
         {{end}}]
+      {{end}}
+      {{if $whitelist.SourceSets }}
+      sourceSets = [{{range $whitelist.SourceSets }}
+        "{{.}}",
+        {{end}}]
+      {{end}}
+      {{if $whitelist.DenyRange }}
+      denyRange = [{{range $whitelist.DenyRange }}
+        "{{.}}",
+        {{end}}]
+      {{end}}
+      {{if $whitelist.DenySets }}
+      denySets = [{{range $whitelist.DenySets }}
+        "{{.}}",
+        {{end}}]
+      {{end}}
       {{if $whitelist.IPStrategy }}
       [frontends."frontend-{{ $frontendName }}".whiteList.IPStrategy]
         depth = {{ $whitelist.IPStrategy.Depth }}
@@ -1843,6 +3246,30 @@ var _templatesMesosTmpl = []byte(`[backends]
       regex = "{{ $redirect.Regex }}"
       replacement = "{{ $redirect.Replacement }}"
       permanent = {{ $redirect.Permanent }}
+      {{if $redirect.StatusCode }}
+      statusCode = {{ $redirect.StatusCode }}
+      {{end}}
+      {{if $redirect.Scheme }}
+      scheme = "{{ $redirect.Scheme }}"
+      {{end}}
+      {{if $redirect.Host }}
+      host = "{{ $redirect.Host }}"
+      {{end}}
+      {{if $redirect.Port }}
+      port = "{{ $redirect.Port }}"
+      {{end}}
+      {{if $redirect.Path }}
+      path = "{{ $redirect.Path }}"
+      {{end}}
+      {{if $redirect.PathPrefix }}
+      pathPrefix = "{{ $redirect.PathPrefix }}"
+      {{end}}
+      {{if $redirect.DropQuery }}
+      dropQuery = true
+      {{end}}
+      {{if $redirect.AppRoot }}
+      appRoot = "{{ $redirect.AppRoot }}"
+      {{end}}
     {{end}}
 
     {{ $errorPages := getErrorPages $app.TraefikLabels }}
@@ -1853,8 +3280,13 @@ var _templatesMesosTmpl = []byte(`[backends]
         status = [{{range $page.Status }}
         "{{.}}",
         {{end}}]
+        {{if $page.Backend }}
         backend = "backend-{{ $page.Backend }}"
+        {{end}}
         query = "{{ $page.Query }}"
+        file = {{ printf "%q" $page.File }}
+        body = {{ printf "%q" $page.Body }}
+        contentType = {{ printf "%q" $page.ContentType }}
       {{end}}
     {{end}}
 
@@ -1862,6 +3294,15 @@ var _templatesMesosTmpl = []byte(`[backends]
     {{if $rateLimit }}
     [frontends."frontend-{{ $frontendName }}".rateLimit]
       extractorFunc = "{{ $rateLimit.ExtractorFunc }}"
+      distributed = {{ $rateLimit.Distributed }}
+      statusCode = {{ $rateLimit.StatusCode }}
+      body = {{ printf "%q" $rateLimit.Body }}
+      contentType = {{ printf "%q" $rateLimit.ContentType }}
+      {{if $rateLimit.ExemptSourceRange }}
+      exemptSourceRange = [{{range $rateLimit.ExemptSourceRange }}
+        "{{.}}",
+        {{end}}]
+      {{end}}
       [frontends."frontend-{{ $frontendName }}".rateLimit.rateSet]
         {{ range $limitName, $limit := $rateLimit.RateSet }}
         [frontends."frontend-{{ $frontendName }}".rateLimit.rateSet."{{ $limitName }}"]
@@ -1918,6 +3359,27 @@ var _templatesMesosTmpl = []byte(`[backends]
         {{end}}
       {{end}}
 
+      {{range $operation := $headers.RequestOperations }}
+      [[frontends."frontend-{{ $frontendName }}".headers.requestOperations]]
+        action = "{{ $operation.Action }}"
+        name = "{{ $operation.Name }}"
+        value = "{{ $operation.Value }}"
+        regex = "{{ $operation.Regex }}"
+      {{end}}
+
+      {{range $operation := $headers.ResponseOperations }}
+      [[frontends."frontend-{{ $frontendName }}".headers.responseOperations]]
+        action = "{{ $operation.Action }}"
+        name = "{{ $operation.Name }}"
+        value = "{{ $operation.Value }}"
+        regex = "{{ $operation.Regex }}"
+        {{if $operation.StatusCodes }}
+        statusCodes = [{{range $operation.StatusCodes }}
+          "{{.}}",
+          {{end}}]
+        {{end}}
+      {{end}}
+
       {{if $headers.SSLProxyHeaders }}
       [frontends."frontend-{{ $frontendName }}".headers.SSLProxyHeaders]
         {{range $k, $v := $headers.SSLProxyHeaders }}
@@ -2080,6 +3542,25 @@ var _templatesRancherTmpl = []byte(`{{ $backendServers := .Backends }}
       [frontends."frontend-{{ $frontendName }}".auth.forward]
         address = "{{ $auth.Forward.Address }}"
         trustForwardHeader = {{ $auth.Forward.TrustForwardHeader }}
+        {{if $auth.Forward.AuthRequestHeaders }}
+        authRequestHeaders = [{{range $auth.Forward.AuthRequestHeaders }}
+          "{{.}}",
+          {{end}}]
+        {{end}}
+        method = "{{ $auth.Forward.Method }}"
+        forwardBody = {{ $auth.Forward.ForwardBody }}
+        maxBodySize = {{ $auth.Forward.MaxBodySize }}
+
+        {{if $auth.Forward.Cache }}
+        [frontends."frontend-{{ $frontendName }}".auth.forward.cache]
+          {{if $auth.Forward.Cache.KeyHeaders }}
+          keyHeaders = [{{range $auth.Forward.Cache.KeyHeaders }}
+            "{{.}}",
+            {{end}}]
+          {{end}}
+          ttl = "{{ $auth.Forward.Cache.TTL }}"
+          negativeTTL = "{{ $auth.Forward.Cache.NegativeTTL }}"
+        {{end}}
 
         {{if $auth.Forward.TLS }}
         [frontends."frontend-{{ $frontendName }}".auth.forward.tls]
@@ -2114,12 +3595,161 @@ var _templatesRancherTmpl = []byte(`{{ $backendServers := .Backends }}
       {{end}}
     {{end}}
 
+    {{ $tlsClientCertAuth := getTLSClientCertAuth $service.SegmentLabels }}
+    {{if $tlsClientCertAuth }}
+    [frontends."frontend-{{ $frontendName }}".tlsClientCertAuth]
+      {{if $tlsClientCertAuth.CommonNames }}
+      commonNames = [{{range $tlsClientCertAuth.CommonNames }}
+        "{{.}}",
+        {{end}}]
+      {{end}}
+      {{if $tlsClientCertAuth.OrganizationalUnits }}
+      organizationalUnits = [{{range $tlsClientCertAuth.OrganizationalUnits }}
+        "{{.}}",
+        {{end}}]
+      {{end}}
+      {{if $tlsClientCertAuth.DNSNames }}
+      dnsNames = [{{range $tlsClientCertAuth.DNSNames }}
+        "{{.}}",
+        {{end}}]
+      {{end}}
+      {{if $tlsClientCertAuth.URIs }}
+      uris = [{{range $tlsClientCertAuth.URIs }}
+        "{{.}}",
+        {{end}}]
+      {{end}}
+      {{if $tlsClientCertAuth.Issuers }}
+      issuers = [{{range $tlsClientCertAuth.Issuers }}
+        "{{.}}",
+        {{end}}]
+      {{end}}
+      {{if $tlsClientCertAuth.Fingerprints }}
+      fingerprints = [{{range $tlsClientCertAuth.Fingerprints }}
+        "{{.}}",
+        {{end}}]
+      {{end}}
+    {{end}}
+
+    {{ $geoIP := getGeoIP $service.SegmentLabels }}
+    {{if $geoIP }}
+    [frontends."frontend-{{ $frontendName }}".geoIP]
+      countryDatabase = "{{ $geoIP.CountryDatabase }}"
+      asnDatabase = "{{ $geoIP.ASNDatabase }}"
+      {{if $geoIP.AllowedCountries }}
+      allowedCountries = [{{range $geoIP.AllowedCountries }}
+        "{{.}}",
+        {{end}}]
+      {{end}}
+      {{if $geoIP.DeniedCountries }}
+      deniedCountries = [{{range $geoIP.DeniedCountries }}
+        "{{.}}",
+        {{end}}]
+      {{end}}
+      {{if $geoIP.AllowedASNs }}
+      allowedASNs = [{{range $geoIP.AllowedASNs }}
+        "{{.}}",
+        {{end}}]
+      {{end}}
+      {{if $geoIP.DeniedASNs }}
+      deniedASNs = [{{range $geoIP.DeniedASNs }}
+        "{{.}}",
+        {{end}}]
+      {{end}}
+      addHeaders = {{ $geoIP.AddHeaders }}
+    {{end}}
+
+    {{ $bodyRewrite := getBodyRewrite $service.SegmentLabels }}
+    {{if $bodyRewrite }}
+    [frontends."frontend-{{ $frontendName }}".bodyRewrite]
+      {{if $bodyRewrite.ContentTypes }}
+      contentTypes = [{{range $bodyRewrite.ContentTypes }}
+        "{{.}}",
+        {{end}}]
+      {{end}}
+      maxBodySize = {{ $bodyRewrite.MaxBodySize }}
+      {{range $replacement := $bodyRewrite.Replacements }}
+      [[frontends."frontend-{{ $frontendName }}".bodyRewrite.replacements]]
+        search = {{ printf "%q" $replacement.Search }}
+        regex = {{ printf "%q" $replacement.Regex }}
+        replacement = {{ printf "%q" $replacement.Replacement }}
+      {{end}}
+    {{end}}
+
+    {{ $maintenance := getMaintenance $service.SegmentLabels }}
+    {{if $maintenance }}
+    [frontends."frontend-{{ $frontendName }}".maintenance]
+      enabled = {{ $maintenance.Enabled }}
+      statusCode = {{ $maintenance.StatusCode }}
+      retryAfter = "{{ $maintenance.RetryAfter }}"
+      page = {{ printf "%q" $maintenance.Page }}
+      {{if $maintenance.SourceRange }}
+      sourceRange = [{{range $maintenance.SourceRange }}
+        "{{.}}",
+        {{end}}]
+      {{end}}
+      bypassHeaderName = {{ printf "%q" $maintenance.BypassHeaderName }}
+      bypassHeaderValue = {{ printf "%q" $maintenance.BypassHeaderValue }}
+    {{end}}
+
+    {{ $faultInjection := getFaultInjection $service.SegmentLabels }}
+    {{if $faultInjection }}
+    [frontends."frontend-{{ $frontendName }}".faultInjection]
+      enabled = {{ $faultInjection.Enabled }}
+      headerName = {{ printf "%q" $faultInjection.HeaderName }}
+      headerValue = {{ printf "%q" $faultInjection.HeaderValue }}
+      delayPercentage = {{ printf "%f" $faultInjection.DelayPercentage }}
+      delay = "{{ $faultInjection.Delay }}"
+      delayMax = "{{ $faultInjection.DelayMax }}"
+      delayDistribution = {{ printf "%q" $faultInjection.DelayDistribution }}
+      abortPercentage = {{ printf "%f" $faultInjection.AbortPercentage }}
+      abortStatusCode = {{ $faultInjection.AbortStatusCode }}
+      abortReset = {{ $faultInjection.AbortReset }}
+    {{end}}
+
+    {{ $sizeLimits := getSizeLimits $service.SegmentLabels }}
+    {{if $sizeLimits }}
+    [frontends."frontend-{{ $frontendName }}".sizeLimits]
+      maxRequestBodyBytes = {{ $sizeLimits.MaxRequestBodyBytes }}
+      maxHeaderCount = {{ $sizeLimits.MaxHeaderCount }}
+      maxHeaderBytes = {{ $sizeLimits.MaxHeaderBytes }}
+    {{end}}
+
+    {{ $requestID := getRequestID $service.SegmentLabels }}
+    {{if $requestID }}
+    [frontends."frontend-{{ $frontendName }}".requestID]
+      headerName = {{ printf "%q" $requestID.HeaderName }}
+      format = {{ printf "%q" $requestID.Format }}
+      insecure = {{ $requestID.Insecure }}
+      {{if $requestID.TrustedIPs }}
+      trustedIPs = [{{range $requestID.TrustedIPs }}
+        "{{.}}",
+        {{end}}]
+      {{end}}
+    {{end}}
+
     {{ $whitelist := getWhiteList $service.SegmentLabels }}
     {{if $whitelist }}
     [frontends."frontend-{{ $frontendName }}".whiteList]
+      {{if $whitelist.SourceRange }}
       sourceRange = [{{range $whitelist.SourceRange }}
         "{{.}}",
         {{end}}]
+      {{end}}
+      {{if $whitelist.SourceSets }}
+      sourceSets = [{{range $whitelist.SourceSets }}
+        "{{.}}",
+        {{end}}]
+      {{end}}
+      {{if $whitelist.DenyRange }}
+      denyRange = [{{range $whitelist.DenyRange }}
+        "{{.}}",
+        {{end}}]
+      {{end}}
+      {{if $whitelist.DenySets }}
+      denySets = [{{range $whitelist.DenySets }}
+        "{{.}}",
+        {{end}}]
+      {{end}}
       {{if $whitelist.IPStrategy }}
       [frontends."frontend-{{ $frontendName }}".whiteList.IPStrategy]
         depth = {{ $whitelist.IPStrategy.Depth }}
@@ -2136,6 +3766,30 @@ var _templatesRancherTmpl = []byte(`{{ $backendServers := .Backends }}
       regex = "{{ $redirect.Regex }}"
       replacement = "{{ $redirect.Replacement }}"
       permanent = {{ $redirect.Permanent }}
+      {{if $redirect.StatusCode }}
+      statusCode = {{ $redirect.StatusCode }}
+      {{end}}
+      {{if $redirect.Scheme }}
+      scheme = "{{ $redirect.Scheme }}"
+      {{end}}
+      {{if $redirect.Host }}
+      host = "{{ $redirect.Host }}"
+      {{end}}
+      {{if $redirect.Port }}
+      port = "{{ $redirect.Port }}"
+      {{end}}
+      {{if $redirect.Path }}
+      path = "{{ $redirect.Path }}"
+      {{end}}
+      {{if $redirect.PathPrefix }}
+      pathPrefix = "{{ $redirect.PathPrefix }}"
+      {{end}}
+      {{if $redirect.DropQuery }}
+      dropQuery = true
+      {{end}}
+      {{if $redirect.AppRoot }}
+      appRoot = "{{ $redirect.AppRoot }}"
+      {{end}}
     {{end}}
 
     {{ $errorPages := getErrorPages $service.SegmentLabels }}
@@ -2146,8 +3800,13 @@ var _templatesRancherTmpl = []byte(`{{ $backendServers := .Backends }}
         status = [{{range $page.Status }}
         "{{.}}",
         {{end}}]
+        {{if $page.Backend }}
         backend = "backend-{{ $page.Backend }}"
+        {{end}}
         query = "{{ $page.Query }}"
+        file = {{ printf "%q" $page.File }}
+        body = {{ printf "%q" $page.Body }}
+        contentType = {{ printf "%q" $page.ContentType }}
       {{end}}
     {{end}}
 
@@ -2155,6 +3814,15 @@ var _templatesRancherTmpl = []byte(`{{ $backendServers := .Backends }}
     {{if $rateLimit }}
     [frontends."frontend-{{ $frontendName }}".rateLimit]
       extractorFunc = "{{ $rateLimit.ExtractorFunc }}"
+      distributed = {{ $rateLimit.Distributed }}
+      statusCode = {{ $rateLimit.StatusCode }}
+      body = {{ printf "%q" $rateLimit.Body }}
+      contentType = {{ printf "%q" $rateLimit.ContentType }}
+      {{if $rateLimit.ExemptSourceRange }}
+      exemptSourceRange = [{{range $rateLimit.ExemptSourceRange }}
+        "{{.}}",
+        {{end}}]
+      {{end}}
       [frontends."frontend-{{ $frontendName }}".rateLimit.rateSet]
         {{ range $limitName, $limit := $rateLimit.RateSet }}
         [frontends."frontend-{{ $frontendName }}".rateLimit.rateSet."{{ $limitName }}"]
@@ -2211,6 +3879,27 @@ var _templatesRancherTmpl = []byte(`{{ $backendServers := .Backends }}
         {{end}}
       {{end}}
 
+      {{range $operation := $headers.RequestOperations }}
+      [[frontends."frontend-{{ $frontendName }}".headers.requestOperations]]
+        action = "{{ $operation.Action }}"
+        name = "{{ $operation.Name }}"
+        value = "{{ $operation.Value }}"
+        regex = "{{ $operation.Regex }}"
+      {{end}}
+
+      {{range $operation := $headers.ResponseOperations }}
+      [[frontends."frontend-{{ $frontendName }}".headers.responseOperations]]
+        action = "{{ $operation.Action }}"
+        name = "{{ $operation.Name }}"
+        value = "{{ $operation.Value }}"
+        regex = "{{ $operation.Regex }}"
+        {{if $operation.StatusCodes }}
+        statusCodes = [{{range $operation.StatusCodes }}
+          "{{.}}",
+          {{end}}]
+        {{end}}
+      {{end}}
+
       {{if $headers.SSLProxyHeaders }}
       [frontends."frontend-{{ $frontendName }}".headers.SSLProxyHeaders]
         {{range $k, $v := $headers.SSLProxyHeaders }}
@@ -2223,6 +3912,26 @@ var _templatesRancherTmpl = []byte(`{{ $backendServers := .Backends }}
       rule = "{{ getFrontendRule $service.Name $service.SegmentLabels }}"
 
 {{end}}
+
+{{if .UDPServices }}
+[udpBackends]
+{{range $serviceName, $service := .UDPServices }}
+  {{range $serverName, $server := getUDPServers $service }}
+  [udpBackends."udp-backend-{{ $serviceName }}".servers."{{ $serverName }}"]
+    address = "{{ $server.Address }}"
+    weight = {{ $server.Weight }}
+  {{end}}
+{{end}}
+
+[udpFrontends]
+{{range $serviceName, $service := .UDPServices }}
+  [udpFrontends."udp-frontend-{{ $serviceName }}"]
+    backend = "udp-backend-{{ $serviceName }}"
+    entryPoints = [{{range getUDPEntryPoints $service.Labels }}
+      "{{.}}",
+      {{end}}]
+{{end}}
+{{end}}
 `)
 
 func templatesRancherTmplBytes() ([]byte, error) {
@@ -258,6 +258,15 @@ func NewTraefikDefaultPointersConfiguration() *TraefikConfiguration {
 		ResolvDepth:     5,
 	}
 
+	defaultRateLimitStore := configuration.RateLimitStore{
+		Backend:       "redis",
+		Address:       "localhost:6379",
+		Prefix:        "traefik/ratelimit/",
+		BatchSize:     configuration.DefaultRateLimitStoreBatchSize,
+		Timeout:       parse.Duration(configuration.DefaultRateLimitStoreTimeout),
+		RetryInterval: parse.Duration(configuration.DefaultRateLimitStoreRetryInterval),
+	}
+
 	defaultConfiguration := configuration.GlobalConfiguration{
 		Docker:             &defaultDocker,
 		File:               &defaultFile,
@@ -286,6 +295,7 @@ func NewTraefikDefaultPointersConfiguration() *TraefikConfiguration {
 		Metrics:            &defaultMetrics,
 		Tracing:            &defaultTracing,
 		HostResolver:       &defaultResolver,
+		RateLimitStore:     &defaultRateLimitStore,
 	}
 
 	return &TraefikConfiguration{
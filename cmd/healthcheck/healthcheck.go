@@ -1,9 +1,11 @@
 package healthcheck
 
 import (
+	"context"
 	"crypto/tls"
 	"errors"
 	"fmt"
+	"net"
 	"net/http"
 	"os"
 	"time"
@@ -58,6 +60,7 @@ func Do(globalConfiguration configuration.GlobalConfiguration) (*http.Response,
 
 	client := &http.Client{Timeout: 5 * time.Second}
 	protocol := "http"
+	address := pingEntryPoint.Address
 	if pingEntryPoint.TLS != nil {
 		protocol = "https"
 		tr := &http.Transport{
@@ -65,7 +68,19 @@ func Do(globalConfiguration configuration.GlobalConfiguration) (*http.Response,
 		}
 		client.Transport = tr
 	}
+
+	if socketPath := pingEntryPoint.UnixSocketPath(); len(socketPath) > 0 {
+		address = "localhost"
+		tr := &http.Transport{
+			TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				var dialer net.Dialer
+				return dialer.DialContext(ctx, "unix", socketPath)
+			},
+		}
+		client.Transport = tr
+	}
 	path := "/"
 
-	return client.Head(protocol + "://" + pingEntryPoint.Address + path + "ping")
+	return client.Head(protocol + "://" + address + path + "ping")
 }
@@ -235,6 +235,9 @@ func runCmd(globalConfiguration *configuration.GlobalConfiguration, configFile s
 		acmeProvider.SetConfigListenerChan(make(chan types.Configuration))
 		svr.AddListener(acmeProvider.ListenConfiguration)
 	}
+	if acmeProvider != nil {
+		svr.AddReadinessCheck(acmeProvider.CheckCertificates)
+	}
 	ctx := cmd.ContextWithSignal(context.Background())
 
 	if globalConfiguration.Ping != nil {
@@ -260,7 +263,8 @@ func runCmd(globalConfiguration *configuration.GlobalConfiguration, configFile s
 			tick := time.Tick(t)
 			for range tick {
 				_, errHealthCheck := healthcheck.Do(*globalConfiguration)
-				if globalConfiguration.Ping == nil || errHealthCheck == nil {
+				// the ping endpoint fails on purpose in drain mode
+				if globalConfiguration.Ping == nil || errHealthCheck == nil || svr.Draining() {
 					if ok, _ := daemon.SdNotify(false, "WATCHDOG=1"); !ok {
 						log.Error("Fail to tick watchdog")
 					}
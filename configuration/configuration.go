@@ -52,10 +52,25 @@ const (
 	// DefaultIdleTimeout before closing an idle connection.
 	DefaultIdleTimeout = 180 * time.Second
 
+	// DefaultUDPIdleTimeout before closing an inactive UDP session.
+	DefaultUDPIdleTimeout = 30 * time.Second
+
+	// DefaultUDPMaxSessions is the default maximum number of open sessions of a UDP entry point.
+	DefaultUDPMaxSessions = 1000
+
 	// DefaultGraceTimeout controls how long Traefik serves pending requests
 	// prior to shutting down.
 	DefaultGraceTimeout = 10 * time.Second
 
+	// DefaultRateLimitStoreBatchSize is the default number of requests reserved at once in the rate limit store.
+	DefaultRateLimitStoreBatchSize = 10
+
+	// DefaultRateLimitStoreTimeout is the default timeout of the operations on the rate limit store.
+	DefaultRateLimitStoreTimeout = 100 * time.Millisecond
+
+	// DefaultRateLimitStoreRetryInterval is the default time to wait before using the rate limit store again after a failure.
+	DefaultRateLimitStoreRetryInterval = 5 * time.Second
+
 	// DefaultAcmeCAServer is the default ACME API endpoint
 	DefaultAcmeCAServer = "https://acme-v02.api.letsencrypt.org/directory"
 )
@@ -104,6 +119,8 @@ type GlobalConfiguration struct {
 	Metrics                   *types.Metrics          `description:"Enable a metrics exporter" export:"true"`
 	Ping                      *ping.Handler           `description:"Enable ping" export:"true"`
 	HostResolver              *HostResolverConfig     `description:"Enable CNAME Flattening" export:"true"`
+	IPSets                    map[string]*types.IPSet `description:"Named IP lists, loaded from a file or an URL, for the white lists" export:"true"`
+	RateLimitStore            *RateLimitStore         `description:"Enable a store shared by the Traefik instances for the distributed rate limits" export:"true"`
 }
 
 // SetEffectiveConfiguration adds missing configuration parameters derived from existing ones.
@@ -137,6 +154,14 @@ func (gc *GlobalConfiguration) SetEffectiveConfiguration(configFile string) {
 			log.Infof("No tls.defaultCertificate given for %s: using the first item in tls.certificates as a fallback.", entryPointName)
 			entryPoint.TLS.DefaultCertificate = &entryPoint.TLS.Certificates[0]
 		}
+
+		if entryPoint.UDP != nil && entryPoint.UDP.IdleTimeout <= 0 {
+			entryPoint.UDP.IdleTimeout = parse.Duration(DefaultUDPIdleTimeout)
+		}
+
+		if entryPoint.UDP != nil && entryPoint.UDP.MaxSessions <= 0 {
+			entryPoint.UDP.MaxSessions = DefaultUDPMaxSessions
+		}
 	}
 
 	// Make sure LifeCycle isn't nil to spare nil checks elsewhere.
@@ -170,6 +195,10 @@ func (gc *GlobalConfiguration) SetEffectiveConfiguration(configFile string) {
 		gc.API.Debug = gc.Debug
 	}
 
+	if gc.RateLimitStore != nil {
+		gc.RateLimitStore.setDefaults()
+	}
+
 	if gc.File != nil {
 		gc.File.TraefikFile = configFile
 	}
@@ -392,9 +421,12 @@ type HealthCheckConfig struct {
 
 // RespondingTimeouts contains timeout configurations for incoming requests to the Traefik instance.
 type RespondingTimeouts struct {
-	ReadTimeout  parse.Duration `description:"ReadTimeout is the maximum duration for reading the entire request, including the body. If zero, no timeout is set" export:"true"`
-	WriteTimeout parse.Duration `description:"WriteTimeout is the maximum duration before timing out writes of the response. If zero, no timeout is set" export:"true"`
-	IdleTimeout  parse.Duration `description:"IdleTimeout is the maximum amount duration an idle (keep-alive) connection will remain idle before closing itself. Defaults to 180 seconds. If zero, no timeout is set" export:"true"`
+	ReadTimeout                   parse.Duration `description:"ReadTimeout is the maximum duration for reading the entire request, including the body. If zero, no timeout is set" export:"true"`
+	WriteTimeout                  parse.Duration `description:"WriteTimeout is the maximum duration before timing out writes of the response. If zero, no timeout is set" export:"true"`
+	IdleTimeout                   parse.Duration `description:"IdleTimeout is the maximum amount duration an idle (keep-alive) connection will remain idle before closing itself. Defaults to 180 seconds. If zero, no timeout is set" export:"true"`
+	ReadHeaderTimeout             parse.Duration `description:"ReadHeaderTimeout is the maximum duration for reading the request headers. If zero, the ReadTimeout applies" export:"true"`
+	MinRequestBodyRate            int64          `description:"MinRequestBodyRate is the minimum average transfer rate of the request bodies, in bytes per second. If zero, no minimum is enforced" export:"true"`
+	MinRequestBodyRateGracePeriod parse.Duration `description:"MinRequestBodyRateGracePeriod is the duration of a request body transfer before the minimum rate is enforced. Defaults to 5 seconds" export:"true"`
 }
 
 // ForwardingTimeouts contains timeout configurations for forwarding requests to the backend servers.
@@ -408,6 +440,40 @@ type ForwardingTimeouts struct {
 type LifeCycle struct {
 	RequestAcceptGraceTimeout parse.Duration `description:"Duration to keep accepting requests before Traefik initiates the graceful shutdown procedure"`
 	GraceTimeOut              parse.Duration `description:"Duration to give active requests a chance to finish before Traefik stops"`
+	DrainGracePeriod          parse.Duration `description:"Duration in drain mode before closing the keep-alive connections, zero keeps them open"`
+}
+
+// RateLimitStore holds the configuration of the store shared by the distributed rate limits
+type RateLimitStore struct {
+	Backend       string         `description:"Store of the counters: redis or cluster" export:"true"`
+	Address       string         `description:"Address of the Redis server" export:"true"`
+	Password      string         `description:"Password of the Redis server"`
+	DB            int            `description:"Database of the Redis server" export:"true"`
+	Prefix        string         `description:"Prefix of the keys of the counters" export:"true"`
+	BatchSize     int64          `description:"Number of requests reserved at once by each instance" export:"true"`
+	Timeout       parse.Duration `description:"Timeout of the operations on the store" export:"true"`
+	RetryInterval parse.Duration `description:"Time to wait before using the store again after a failure" export:"true"`
+}
+
+func (r *RateLimitStore) setDefaults() {
+	if len(r.Backend) == 0 {
+		r.Backend = "redis"
+	}
+	if len(r.Address) == 0 {
+		r.Address = "localhost:6379"
+	}
+	if len(r.Prefix) == 0 {
+		r.Prefix = "traefik/ratelimit/"
+	}
+	if r.BatchSize == 0 {
+		r.BatchSize = DefaultRateLimitStoreBatchSize
+	}
+	if r.Timeout == 0 {
+		r.Timeout = parse.Duration(DefaultRateLimitStoreTimeout)
+	}
+	if r.RetryInterval == 0 {
+		r.RetryInterval = parse.Duration(DefaultRateLimitStoreRetryInterval)
+	}
 }
 
 // HostResolverConfig contain configuration for CNAME Flattening
@@ -3,6 +3,7 @@ package configuration
 import (
 	"testing"
 
+	"github.com/containous/flaeg/parse"
 	"github.com/containous/traefik/acme"
 	"github.com/containous/traefik/middlewares/tracing"
 	"github.com/containous/traefik/middlewares/tracing/jaeger"
@@ -174,6 +175,63 @@ func TestSetEffectiveConfigurationTracing(t *testing.T) {
 	}
 }
 
+func TestSetEffectiveConfigurationRateLimitStore(t *testing.T) {
+	testCases := []struct {
+		desc           string
+		rateLimitStore *RateLimitStore
+		expected       *RateLimitStore
+	}{
+		{
+			desc:           "no rate limit store",
+			rateLimitStore: nil,
+			expected:       nil,
+		},
+		{
+			desc:           "rate limit store with the default values",
+			rateLimitStore: &RateLimitStore{},
+			expected: &RateLimitStore{
+				Backend:       "redis",
+				Address:       "localhost:6379",
+				Prefix:        "traefik/ratelimit/",
+				BatchSize:     DefaultRateLimitStoreBatchSize,
+				Timeout:       parse.Duration(DefaultRateLimitStoreTimeout),
+				RetryInterval: parse.Duration(DefaultRateLimitStoreRetryInterval),
+			},
+		},
+		{
+			desc: "cluster rate limit store",
+			rateLimitStore: &RateLimitStore{
+				Backend:   "cluster",
+				Prefix:    "ratelimit/",
+				BatchSize: 50,
+			},
+			expected: &RateLimitStore{
+				Backend:       "cluster",
+				Address:       "localhost:6379",
+				Prefix:        "ratelimit/",
+				BatchSize:     50,
+				Timeout:       parse.Duration(DefaultRateLimitStoreTimeout),
+				RetryInterval: parse.Duration(DefaultRateLimitStoreRetryInterval),
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			gc := &GlobalConfiguration{
+				RateLimitStore: test.rateLimitStore,
+			}
+
+			gc.SetEffectiveConfiguration(defaultConfigFile)
+
+			assert.Equal(t, test.expected, gc.RateLimitStore)
+		})
+	}
+}
+
 func TestInitACMEProvider(t *testing.T) {
 	testCases := []struct {
 		desc                  string
@@ -5,6 +5,7 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/containous/flaeg/parse"
 	"github.com/containous/traefik/log"
 	"github.com/containous/traefik/tls"
 	"github.com/containous/traefik/types"
@@ -12,15 +13,22 @@ import (
 
 // EntryPoint holds an entry point configuration of the reverse proxy (ip, port, TLS...)
 type EntryPoint struct {
-	Address          string
-	TLS              *tls.TLS          `export:"true"`
-	Redirect         *types.Redirect   `export:"true"`
-	Auth             *types.Auth       `export:"true"`
-	WhiteList        *types.WhiteList  `export:"true"`
-	Compress         *Compress         `export:"true"`
-	ProxyProtocol    *ProxyProtocol    `export:"true"`
-	ForwardedHeaders *ForwardedHeaders `export:"true"`
-	ClientIPStrategy *types.IPStrategy `export:"true"`
+	Address             string
+	MaxConnections      int                 `description:"Maximum number of open connections (default: no limit)" export:"true"`
+	MaxConnectionsPerIP int                 `description:"Maximum number of open connections per client IP (default: no limit)" export:"true"`
+	TLS                 *tls.TLS            `export:"true"`
+	Redirect            *types.Redirect     `export:"true"`
+	Auth                *types.Auth         `export:"true"`
+	WhiteList           *types.WhiteList    `export:"true"`
+	Compress            *Compress           `export:"true"`
+	ProxyProtocol       *ProxyProtocol      `export:"true"`
+	ForwardedHeaders    *ForwardedHeaders   `export:"true"`
+	ClientIPStrategy    *types.IPStrategy   `export:"true"`
+	RequestID           *types.RequestID    `export:"true"`
+	SizeLimits          *types.SizeLimits   `export:"true"`
+	UDP                 *UDP                `export:"true"`
+	UnixSocket          *UnixSocket         `export:"true"`
+	RespondingTimeouts  *RespondingTimeouts `description:"Timeouts for incoming requests to the entry point, replacing the global ones" export:"true"`
 }
 
 // Compress contains compress configuration
@@ -38,6 +46,31 @@ type ForwardedHeaders struct {
 	TrustedIPs []string
 }
 
+// UDP makes the entry point listen for UDP datagrams instead of TCP connections
+type UDP struct {
+	IdleTimeout parse.Duration `description:"Duration after which an inactive UDP session is closed (default: 30s)" export:"true"`
+	MaxSessions int            `description:"Maximum number of open UDP sessions, the datagrams of the new clients being dropped beyond (default: 1000)" export:"true"`
+}
+
+// UnixSocket holds the options of the Unix domain socket created by a unix:// entry point
+type UnixSocket struct {
+	Mode  string `description:"File mode of the socket, in octal (e.g. 0660)" export:"true"`
+	Owner string `description:"User owning the socket, as a name or a numeric ID" export:"true"`
+	Group string `description:"Group owning the socket, as a name or a numeric ID" export:"true"`
+}
+
+// UnixSocketPrefix is the scheme prefix of the entry point addresses and server URLs targeting Unix domain sockets
+const UnixSocketPrefix = "unix://"
+
+// UnixSocketPath returns the path of the Unix domain socket the entry point listens on,
+// or an empty string if the entry point listens on a network address.
+func (ep *EntryPoint) UnixSocketPath() string {
+	if !strings.HasPrefix(ep.Address, UnixSocketPrefix) {
+		return ""
+	}
+	return strings.TrimPrefix(ep.Address, UnixSocketPrefix)
+}
+
 // EntryPoints holds entry points configuration of the reverse proxy (ip, port, TLS...)
 type EntryPoints map[string]*EntryPoint
 
@@ -79,28 +112,50 @@ func (ep *EntryPoints) Set(value string) error {
 	}
 
 	(*ep)[result["name"]] = &EntryPoint{
-		Address:          result["address"],
-		TLS:              configTLS,
-		Auth:             makeEntryPointAuth(result),
-		Redirect:         makeEntryPointRedirect(result),
-		Compress:         compress,
-		WhiteList:        makeWhiteList(result),
-		ProxyProtocol:    makeEntryPointProxyProtocol(result),
-		ForwardedHeaders: makeEntryPointForwardedHeaders(result),
-		ClientIPStrategy: makeIPStrategy("clientipstrategy", result),
+		Address:             result["address"],
+		TLS:                 configTLS,
+		Auth:                makeEntryPointAuth(result),
+		Redirect:            makeEntryPointRedirect(result),
+		Compress:            compress,
+		WhiteList:           makeWhiteList(result),
+		ProxyProtocol:       makeEntryPointProxyProtocol(result),
+		ForwardedHeaders:    makeEntryPointForwardedHeaders(result),
+		ClientIPStrategy:    makeIPStrategy("clientipstrategy", result),
+		RequestID:           makeEntryPointRequestID(result),
+		SizeLimits:          makeEntryPointSizeLimits(result),
+		UDP:                 makeEntryPointUDP(result),
+		UnixSocket:          makeEntryPointUnixSocket(result),
+		RespondingTimeouts:  makeEntryPointRespondingTimeouts(result),
+		MaxConnections:      toInt(result, "maxconnections"),
+		MaxConnectionsPerIP: toInt(result, "maxconnectionsperip"),
 	}
 
 	return nil
 }
 
 func makeWhiteList(result map[string]string) *types.WhiteList {
+	whiteList := &types.WhiteList{}
+
 	if rawRange, ok := result["whitelist_sourcerange"]; ok {
-		return &types.WhiteList{
-			SourceRange: strings.Split(rawRange, ","),
-			IPStrategy:  makeIPStrategy("whitelist_ipstrategy", result),
-		}
+		whiteList.SourceRange = strings.Split(rawRange, ",")
 	}
-	return nil
+	if rawSets, ok := result["whitelist_sourcesets"]; ok {
+		whiteList.SourceSets = strings.Split(rawSets, ",")
+	}
+	if rawRange, ok := result["whitelist_denyrange"]; ok {
+		whiteList.DenyRange = strings.Split(rawRange, ",")
+	}
+	if rawSets, ok := result["whitelist_denysets"]; ok {
+		whiteList.DenySets = strings.Split(rawSets, ",")
+	}
+
+	if whiteList.SourceRange == nil && whiteList.SourceSets == nil &&
+		whiteList.DenyRange == nil && whiteList.DenySets == nil {
+		return nil
+	}
+
+	whiteList.IPStrategy = makeIPStrategy("whitelist_ipstrategy", result)
+	return whiteList
 }
 
 func makeIPStrategy(prefix string, result map[string]string) *types.IPStrategy {
@@ -158,11 +213,30 @@ func makeEntryPointAuth(result map[string]string) *types.Auth {
 			authResponseHeaders = strings.Split(v, ",")
 		}
 
+		var authRequestHeaders []string
+		if v, ok := result["auth_forward_authrequestheaders"]; ok {
+			authRequestHeaders = strings.Split(v, ",")
+		}
+
+		var cache *types.ForwardCache
+		if v, ok := result["auth_forward_cache_keyheaders"]; ok {
+			cache = &types.ForwardCache{
+				KeyHeaders:  strings.Split(v, ","),
+				TTL:         toDuration(result, "auth_forward_cache_ttl"),
+				NegativeTTL: toDuration(result, "auth_forward_cache_negativettl"),
+			}
+		}
+
 		forward = &types.Forward{
 			Address:             address,
 			TLS:                 clientTLS,
 			TrustForwardHeader:  toBool(result, "auth_forward_trustforwardheader"),
 			AuthResponseHeaders: authResponseHeaders,
+			AuthRequestHeaders:  authRequestHeaders,
+			Method:              result["auth_forward_method"],
+			ForwardBody:         toBool(result, "auth_forward_forwardbody"),
+			MaxBodySize:         int64(toInt(result, "auth_forward_maxbodysize")),
+			Cache:               cache,
 		}
 	}
 
@@ -215,15 +289,97 @@ func makeEntryPointForwardedHeaders(result map[string]string) *ForwardedHeaders
 	return forwardedHeaders
 }
 
+func makeEntryPointRequestID(result map[string]string) *types.RequestID {
+	if !toBool(result, "requestid") && len(result["requestid_headername"]) == 0 && len(result["requestid_format"]) == 0 &&
+		len(result["requestid_insecure"]) == 0 && len(result["requestid_trustedips"]) == 0 {
+		return nil
+	}
+
+	requestID := &types.RequestID{
+		HeaderName: result["requestid_headername"],
+		Format:     result["requestid_format"],
+		Insecure:   toBool(result, "requestid_insecure"),
+	}
+
+	if len(result["requestid_trustedips"]) > 0 {
+		requestID.TrustedIPs = strings.Split(result["requestid_trustedips"], ",")
+	}
+
+	return requestID
+}
+
+func makeEntryPointSizeLimits(result map[string]string) *types.SizeLimits {
+	if len(result["sizelimits_maxrequestbodybytes"]) == 0 && len(result["sizelimits_maxheadercount"]) == 0 &&
+		len(result["sizelimits_maxheaderbytes"]) == 0 {
+		return nil
+	}
+
+	return &types.SizeLimits{
+		MaxRequestBodyBytes: toInt64(result, "sizelimits_maxrequestbodybytes"),
+		MaxHeaderCount:      toInt(result, "sizelimits_maxheadercount"),
+		MaxHeaderBytes:      toInt64(result, "sizelimits_maxheaderbytes"),
+	}
+}
+
+func makeEntryPointUDP(result map[string]string) *UDP {
+	if !toBool(result, "udp") && len(result["udp_idletimeout"]) == 0 && len(result["udp_maxsessions"]) == 0 {
+		return nil
+	}
+
+	return &UDP{
+		IdleTimeout: toDuration(result, "udp_idletimeout"),
+		MaxSessions: toInt(result, "udp_maxsessions"),
+	}
+}
+
+func makeEntryPointUnixSocket(result map[string]string) *UnixSocket {
+	if len(result["unixsocket_mode"]) == 0 && len(result["unixsocket_owner"]) == 0 && len(result["unixsocket_group"]) == 0 {
+		return nil
+	}
+
+	return &UnixSocket{
+		Mode:  result["unixsocket_mode"],
+		Owner: result["unixsocket_owner"],
+		Group: result["unixsocket_group"],
+	}
+}
+
+func makeEntryPointRespondingTimeouts(result map[string]string) *RespondingTimeouts {
+	if len(result["respondingtimeouts_readtimeout"]) == 0 && len(result["respondingtimeouts_writetimeout"]) == 0 &&
+		len(result["respondingtimeouts_idletimeout"]) == 0 && len(result["respondingtimeouts_readheadertimeout"]) == 0 &&
+		len(result["respondingtimeouts_minrequestbodyrate"]) == 0 && len(result["respondingtimeouts_minrequestbodyrategraceperiod"]) == 0 {
+		return nil
+	}
+
+	return &RespondingTimeouts{
+		ReadTimeout:                   toDuration(result, "respondingtimeouts_readtimeout"),
+		WriteTimeout:                  toDuration(result, "respondingtimeouts_writetimeout"),
+		IdleTimeout:                   toDuration(result, "respondingtimeouts_idletimeout"),
+		ReadHeaderTimeout:             toDuration(result, "respondingtimeouts_readheadertimeout"),
+		MinRequestBodyRate:            toInt64(result, "respondingtimeouts_minrequestbodyrate"),
+		MinRequestBodyRateGracePeriod: toDuration(result, "respondingtimeouts_minrequestbodyrategraceperiod"),
+	}
+}
+
 func makeEntryPointRedirect(result map[string]string) *types.Redirect {
 	var redirect *types.Redirect
 
-	if len(result["redirect_entrypoint"]) > 0 || len(result["redirect_regex"]) > 0 || len(result["redirect_replacement"]) > 0 {
+	if len(result["redirect_entrypoint"]) > 0 || len(result["redirect_regex"]) > 0 || len(result["redirect_replacement"]) > 0 ||
+		len(result["redirect_scheme"]) > 0 || len(result["redirect_host"]) > 0 || len(result["redirect_port"]) > 0 ||
+		len(result["redirect_path"]) > 0 || len(result["redirect_pathprefix"]) > 0 || len(result["redirect_approot"]) > 0 {
 		redirect = &types.Redirect{
 			EntryPoint:  result["redirect_entrypoint"],
 			Regex:       result["redirect_regex"],
 			Replacement: result["redirect_replacement"],
 			Permanent:   toBool(result, "redirect_permanent"),
+			StatusCode:  toInt(result, "redirect_statuscode"),
+			Scheme:      result["redirect_scheme"],
+			Host:        result["redirect_host"],
+			Port:        result["redirect_port"],
+			Path:        result["redirect_path"],
+			PathPrefix:  result["redirect_pathprefix"],
+			DropQuery:   toBool(result, "redirect_dropquery"),
+			AppRoot:     result["redirect_approot"],
 		}
 	}
 
@@ -320,3 +476,24 @@ func toInt(conf map[string]string, key string) int {
 	}
 	return 0
 }
+
+func toInt64(conf map[string]string, key string) int64 {
+	if val, ok := conf[key]; ok {
+		intVal, err := strconv.ParseInt(val, 10, 64)
+		if err != nil {
+			return 0
+		}
+		return intVal
+	}
+	return 0
+}
+
+func toDuration(conf map[string]string, key string) parse.Duration {
+	var duration parse.Duration
+	if val, ok := conf[key]; ok {
+		if err := duration.Set(val); err != nil {
+			return 0
+		}
+	}
+	return duration
+}
@@ -2,7 +2,9 @@ package configuration
 
 import (
 	"testing"
+	"time"
 
+	"github.com/containous/flaeg/parse"
 	"github.com/containous/traefik/tls"
 	"github.com/containous/traefik/types"
 	"github.com/stretchr/testify/assert"
@@ -213,7 +215,17 @@ func TestEntryPoints_Set(t *testing.T) {
 				"Auth.Forward.TLS.Cert:path/to/foo.cert " +
 				"Auth.Forward.TLS.Key:path/to/foo.key " +
 				"Auth.Forward.TLS.InsecureSkipVerify:true " +
+				"Auth.Forward.AuthRequestHeaders:Authorization,X-Signature " +
+				"Auth.Forward.Method:POST " +
+				"Auth.Forward.ForwardBody:true " +
+				"Auth.Forward.MaxBodySize:2048 " +
+				"Auth.Forward.Cache.KeyHeaders:Authorization " +
+				"Auth.Forward.Cache.TTL:30s " +
+				"Auth.Forward.Cache.NegativeTTL:5s " +
 				"WhiteList.SourceRange:10.42.0.0/16,152.89.1.33/32,afed:be44::/16 " +
+				"WhiteList.SourceSets:offices " +
+				"WhiteList.DenyRange:10.42.1.0/24 " +
+				"WhiteList.DenySets:abuse,tor " +
 				"WhiteList.IPStrategy.depth:3 " +
 				"WhiteList.IPStrategy.ExcludedIPs:10.0.0.3/24,20.0.0.3/24 " +
 				"ClientIPStrategy.depth:3 " +
@@ -272,6 +284,15 @@ func TestEntryPoints_Set(t *testing.T) {
 							InsecureSkipVerify: true,
 						},
 						TrustForwardHeader: true,
+						AuthRequestHeaders: []string{"Authorization", "X-Signature"},
+						Method:             "POST",
+						ForwardBody:        true,
+						MaxBodySize:        2048,
+						Cache: &types.ForwardCache{
+							KeyHeaders:  []string{"Authorization"},
+							TTL:         parse.Duration(30 * time.Second),
+							NegativeTTL: parse.Duration(5 * time.Second),
+						},
 					},
 					HeaderField: "X-WebAuth-User",
 				},
@@ -281,6 +302,9 @@ func TestEntryPoints_Set(t *testing.T) {
 						"152.89.1.33/32",
 						"afed:be44::/16",
 					},
+					SourceSets: []string{"offices"},
+					DenyRange:  []string{"10.42.1.0/24"},
+					DenySets:   []string{"abuse", "tor"},
 					IPStrategy: &types.IPStrategy{
 						Depth: 3,
 						ExcludedIPs: []string{
@@ -450,6 +474,125 @@ func TestEntryPoints_Set(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:                   "Redirect URL components",
+			expression:             "Name:foo Redirect.Scheme:https Redirect.Port:8443 Redirect.PathPrefix:/app Redirect.DropQuery:true Redirect.StatusCode:308",
+			expectedEntryPointName: "foo",
+			expectedEntryPoint: &EntryPoint{
+				ForwardedHeaders: &ForwardedHeaders{},
+				Redirect: &types.Redirect{
+					Scheme:     "https",
+					Port:       "8443",
+					PathPrefix: "/app",
+					DropQuery:  true,
+					StatusCode: 308,
+				},
+			},
+		},
+		{
+			name:                   "Redirect app root",
+			expression:             "Name:foo Redirect.AppRoot:/app",
+			expectedEntryPointName: "foo",
+			expectedEntryPoint: &EntryPoint{
+				ForwardedHeaders: &ForwardedHeaders{},
+				Redirect:         &types.Redirect{AppRoot: "/app"},
+			},
+		},
+		{
+			name:                   "RequestID enabled",
+			expression:             "Name:foo RequestID:true",
+			expectedEntryPointName: "foo",
+			expectedEntryPoint: &EntryPoint{
+				ForwardedHeaders: &ForwardedHeaders{},
+				RequestID:        &types.RequestID{},
+			},
+		},
+		{
+			name:                   "RequestID options",
+			expression:             "Name:foo RequestID.HeaderName:X-Correlation-Id RequestID.Format:ulid RequestID.TrustedIPs:10.0.0.3/24,20.0.0.3/24",
+			expectedEntryPointName: "foo",
+			expectedEntryPoint: &EntryPoint{
+				ForwardedHeaders: &ForwardedHeaders{},
+				RequestID: &types.RequestID{
+					HeaderName: "X-Correlation-Id",
+					Format:     "ulid",
+					TrustedIPs: []string{"10.0.0.3/24", "20.0.0.3/24"},
+				},
+			},
+		},
+		{
+			name:                   "SizeLimits options",
+			expression:             "Name:foo SizeLimits.MaxRequestBodyBytes:10485760 SizeLimits.MaxHeaderCount:100 SizeLimits.MaxHeaderBytes:8192",
+			expectedEntryPointName: "foo",
+			expectedEntryPoint: &EntryPoint{
+				ForwardedHeaders: &ForwardedHeaders{},
+				SizeLimits: &types.SizeLimits{
+					MaxRequestBodyBytes: 10485760,
+					MaxHeaderCount:      100,
+					MaxHeaderBytes:      8192,
+				},
+			},
+		},
+		{
+			name:                   "UDP enabled",
+			expression:             "Name:foo Address::53 UDP:true",
+			expectedEntryPointName: "foo",
+			expectedEntryPoint: &EntryPoint{
+				Address:          ":53",
+				ForwardedHeaders: &ForwardedHeaders{},
+				UDP:              &UDP{},
+			},
+		},
+		{
+			name:                   "UDP options",
+			expression:             "Name:foo Address::53 UDP.IdleTimeout:1m UDP.MaxSessions:100",
+			expectedEntryPointName: "foo",
+			expectedEntryPoint: &EntryPoint{
+				Address:          ":53",
+				ForwardedHeaders: &ForwardedHeaders{},
+				UDP:              &UDP{IdleTimeout: parse.Duration(time.Minute), MaxSessions: 100},
+			},
+		},
+		{
+			name:                   "Unix socket options",
+			expression:             "Name:foo Address:unix:///run/traefik/http.sock UnixSocket.Mode:0660 UnixSocket.Owner:traefik UnixSocket.Group:www-data",
+			expectedEntryPointName: "foo",
+			expectedEntryPoint: &EntryPoint{
+				Address:          "unix:///run/traefik/http.sock",
+				ForwardedHeaders: &ForwardedHeaders{},
+				UnixSocket: &UnixSocket{
+					Mode:  "0660",
+					Owner: "traefik",
+					Group: "www-data",
+				},
+			},
+		},
+		{
+			name:                   "Responding timeouts",
+			expression:             "Name:foo Address::80 RespondingTimeouts.ReadTimeout:0 RespondingTimeouts.ReadHeaderTimeout:10s RespondingTimeouts.IdleTimeout:1m RespondingTimeouts.MinRequestBodyRate:1024 RespondingTimeouts.MinRequestBodyRateGracePeriod:10s",
+			expectedEntryPointName: "foo",
+			expectedEntryPoint: &EntryPoint{
+				Address:          ":80",
+				ForwardedHeaders: &ForwardedHeaders{},
+				RespondingTimeouts: &RespondingTimeouts{
+					ReadHeaderTimeout:             parse.Duration(10 * time.Second),
+					IdleTimeout:                   parse.Duration(time.Minute),
+					MinRequestBodyRate:            1024,
+					MinRequestBodyRateGracePeriod: parse.Duration(10 * time.Second),
+				},
+			},
+		},
+		{
+			name:                   "Connection limits",
+			expression:             "Name:foo Address::80 MaxConnections:10000 MaxConnectionsPerIP:100",
+			expectedEntryPointName: "foo",
+			expectedEntryPoint: &EntryPoint{
+				Address:             ":80",
+				MaxConnections:      10000,
+				MaxConnectionsPerIP: 100,
+				ForwardedHeaders:    &ForwardedHeaders{},
+			},
+		},
 		{
 			name:                   "ProxyProtocol insecure true",
 			expression:             "Name:foo ProxyProtocol.insecure:true",
@@ -513,3 +656,32 @@ func TestEntryPoints_Set(t *testing.T) {
 		})
 	}
 }
+
+func TestEntryPoint_UnixSocketPath(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		address  string
+		expected string
+	}{
+		{
+			desc:     "network address",
+			address:  ":80",
+			expected: "",
+		},
+		{
+			desc:     "Unix domain socket",
+			address:  "unix:///run/traefik/http.sock",
+			expected: "/run/traefik/http.sock",
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			ep := &EntryPoint{Address: test.address}
+			assert.Equal(t, test.expected, ep.UnixSocketPath())
+		})
+	}
+}
@@ -2,9 +2,11 @@ package configuration
 
 import (
 	"encoding/json"
+	"sync"
 
 	"github.com/containous/traefik/log"
 	"github.com/containous/traefik/provider"
+	"github.com/containous/traefik/provider/rest"
 	"github.com/containous/traefik/safe"
 	"github.com/containous/traefik/types"
 )
@@ -13,12 +15,21 @@ import (
 type ProviderAggregator struct {
 	providers   []provider.Provider
 	constraints types.Constraints
+	deliveries  *deliveries
+}
+
+// deliveries tracks the providers which have delivered their first configuration.
+type deliveries struct {
+	lock     sync.Mutex
+	expected int
+	names    map[string]struct{}
 }
 
 // NewProviderAggregator return an aggregate of all the providers configured in GlobalConfiguration
 func NewProviderAggregator(gc *GlobalConfiguration) ProviderAggregator {
 	provider := ProviderAggregator{
 		constraints: gc.Constraints,
+		deliveries:  &deliveries{names: make(map[string]struct{})},
 	}
 	if gc.Docker != nil {
 		provider.quietAddProvider(gc.Docker)
@@ -85,9 +96,31 @@ func (p *ProviderAggregator) AddProvider(provider provider.Provider) error {
 		return err
 	}
 	p.providers = append(p.providers, provider)
+	if p.deliveries != nil && deliversConfiguration(provider) {
+		p.deliveries.lock.Lock()
+		p.deliveries.expected++
+		p.deliveries.lock.Unlock()
+	}
 	return nil
 }
 
+// DeliveredProviders returns the names of the providers which have delivered their first configuration,
+// and whether all the providers did.
+func (p ProviderAggregator) DeliveredProviders() ([]string, bool) {
+	if p.deliveries == nil {
+		return nil, true
+	}
+
+	p.deliveries.lock.Lock()
+	defer p.deliveries.lock.Unlock()
+
+	var names []string
+	for name := range p.deliveries.names {
+		names = append(names, name)
+	}
+	return names, len(p.deliveries.names) >= p.deliveries.expected
+}
+
 // Init the provider
 func (p ProviderAggregator) Init(_ types.Constraints) error {
 	return nil
@@ -95,6 +128,7 @@ func (p ProviderAggregator) Init(_ types.Constraints) error {
 
 // Provide call the provide method of every providers
 func (p ProviderAggregator) Provide(configurationChan chan<- types.ConfigMessage, pool *safe.Pool) error {
+	deliveries := p.deliveries
 	for _, p := range p.providers {
 		jsonConf, err := json.Marshal(p)
 		if err != nil {
@@ -102,8 +136,12 @@ func (p ProviderAggregator) Provide(configurationChan chan<- types.ConfigMessage
 		}
 		log.Infof("Starting provider %T %s", p, jsonConf)
 		currentProvider := p
+		providerConfigurationChan := configurationChan
+		if deliveries != nil && deliversConfiguration(p) {
+			providerConfigurationChan = deliveries.watch(configurationChan, pool)
+		}
 		safe.Go(func() {
-			err := currentProvider.Provide(configurationChan, pool)
+			err := currentProvider.Provide(providerConfigurationChan, pool)
 			if err != nil {
 				log.Errorf("Error starting provider %T: %v", p, err)
 			}
@@ -111,3 +149,34 @@ func (p ProviderAggregator) Provide(configurationChan chan<- types.ConfigMessage
 	}
 	return nil
 }
+
+// watch returns a channel forwarding the configurations of a provider, recording the delivery of its first one.
+func (d *deliveries) watch(configurationChan chan<- types.ConfigMessage, pool *safe.Pool) chan<- types.ConfigMessage {
+	providerConfigurationChan := make(chan types.ConfigMessage)
+	pool.Go(func(stop chan bool) {
+		for {
+			select {
+			case <-stop:
+				return
+			case configMsg := <-providerConfigurationChan:
+				d.lock.Lock()
+				d.names[configMsg.ProviderName] = struct{}{}
+				d.lock.Unlock()
+
+				select {
+				case <-stop:
+					return
+				case configurationChan <- configMsg:
+				}
+			}
+		}
+	})
+	return providerConfigurationChan
+}
+
+// deliversConfiguration tells whether the provider sends a configuration on its own,
+// the REST provider only sends the ones put through the API.
+func deliversConfiguration(provider provider.Provider) bool {
+	_, ok := provider.(*rest.Provider)
+	return !ok
+}
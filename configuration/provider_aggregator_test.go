@@ -0,0 +1,64 @@
+package configuration
+
+import (
+	"context"
+	"testing"
+
+	"github.com/containous/traefik/provider/rest"
+	"github.com/containous/traefik/safe"
+	"github.com/containous/traefik/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type providerMock struct {
+	name    string
+	deliver chan struct{}
+}
+
+func (p *providerMock) Provide(configurationChan chan<- types.ConfigMessage, pool *safe.Pool) error {
+	go func() {
+		<-p.deliver
+		configurationChan <- types.ConfigMessage{ProviderName: p.name, Configuration: &types.Configuration{}}
+	}()
+	return nil
+}
+
+func (p *providerMock) Init(constraints types.Constraints) error {
+	return nil
+}
+
+func TestProviderAggregatorDeliveredProviders(t *testing.T) {
+	docker := &providerMock{name: "docker", deliver: make(chan struct{})}
+	file := &providerMock{name: "file", deliver: make(chan struct{})}
+
+	aggregator := NewProviderAggregator(&GlobalConfiguration{})
+	require.NoError(t, aggregator.AddProvider(docker))
+	require.NoError(t, aggregator.AddProvider(file))
+	// the REST provider only delivers the configurations put through the API
+	require.NoError(t, aggregator.AddProvider(&rest.Provider{}))
+
+	names, delivered := aggregator.DeliveredProviders()
+	assert.Empty(t, names)
+	assert.False(t, delivered)
+
+	pool := safe.NewPool(context.Background())
+	defer pool.Cleanup()
+
+	configurationChan := make(chan types.ConfigMessage)
+	require.NoError(t, aggregator.Provide(configurationChan, pool))
+
+	close(file.deliver)
+	assert.Equal(t, "file", (<-configurationChan).ProviderName)
+
+	names, delivered = aggregator.DeliveredProviders()
+	assert.Equal(t, []string{"file"}, names)
+	assert.False(t, delivered)
+
+	close(docker.deliver)
+	assert.Equal(t, "docker", (<-configurationChan).ProviderName)
+
+	names, delivered = aggregator.DeliveredProviders()
+	assert.ElementsMatch(t, []string{"docker", "file"}, names)
+	assert.True(t, delivered)
+}
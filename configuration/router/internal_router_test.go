@@ -61,6 +61,11 @@ func TestNewInternalRouterAggregatorWithAuth(t *testing.T) {
 			testedURL:          "/ping",
 			expectedStatusCode: 200,
 		},
+		{
+			desc:               "Readiness without auth",
+			testedURL:          "/ready",
+			expectedStatusCode: 200,
+		},
 		{
 			desc:               "acme without auth",
 			testedURL:          "/.well-known/acme-challenge/token",
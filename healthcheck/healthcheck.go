@@ -62,11 +62,14 @@ func (b *BackendConfig) newRequest(serverURL *url.URL) (*http.Request, error) {
 	u := &url.URL{}
 	*u = *serverURL
 
-	if len(b.Scheme) > 0 {
+	// the unix:// servers are only reachable through their socket, with the transport registered for their scheme
+	unixSocket := u.Scheme == "unix"
+
+	if len(b.Scheme) > 0 && !unixSocket {
 		u.Scheme = b.Scheme
 	}
 
-	if b.Port != 0 {
+	if b.Port != 0 && !unixSocket {
 		u.Host = net.JoinHostPort(u.Hostname(), strconv.Itoa(b.Port))
 	}
 
@@ -189,6 +192,11 @@ func NewBackendConfig(options Options, backendName string) *BackendConfig {
 // checkHealth returns a nil error in case it was successful and otherwise
 // a non-nil error with a meaningful description why the health check failed.
 func checkHealth(serverURL *url.URL, backend *BackendConfig) error {
+	// the static files are served by Traefik itself
+	if serverURL.Scheme == "file" {
+		return nil
+	}
+
 	req, err := backend.newRequest(serverURL)
 	if err != nil {
 		return fmt.Errorf("failed to create HTTP request: %s", err)
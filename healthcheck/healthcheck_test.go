@@ -151,6 +151,17 @@ func TestSetBackendsConfiguration(t *testing.T) {
 	}
 }
 
+func TestCheckHealthStaticFiles(t *testing.T) {
+	backend := NewBackendConfig(Options{
+		Path:    "/path",
+		Timeout: healthCheckTimeout,
+		LB:      &testLoadBalancer{RWMutex: &sync.RWMutex{}},
+	}, "backendName")
+
+	err := checkHealth(testhelpers.MustParseURL("file:///srv/site"), backend)
+	assert.NoError(t, err)
+}
+
 func TestNewRequest(t *testing.T) {
 	testCases := []struct {
 		desc      string
@@ -204,6 +215,16 @@ func TestNewRequest(t *testing.T) {
 			},
 			expected: "http://backend1:80/test",
 		},
+		{
+			desc:      "Unix domain socket ignores the scheme and port overrides",
+			serverURL: "unix://2f72756e2f6170702e736f636b",
+			options: Options{
+				Scheme: "https",
+				Path:   "/health",
+				Port:   8080,
+			},
+			expected: "unix://2f72756e2f6170702e736f636b/health",
+		},
 	}
 
 	for _, test := range testCases {
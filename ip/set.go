@@ -0,0 +1,237 @@
+package ip
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/containous/traefik/log"
+)
+
+const (
+	// DefaultSetFileCheckInterval is the default interval between two checks of an IP set file for changes.
+	DefaultSetFileCheckInterval = 10 * time.Second
+	// DefaultSetURLRefreshInterval is the default interval between two downloads of an IP set.
+	DefaultSetURLRefreshInterval = time.Hour
+
+	// maxSetSize bounds the size of the downloaded IP sets.
+	maxSetSize = 64 << 20
+
+	// setRetryInterval is the first interval between two attempts to load an IP set which was never loaded,
+	// doubled after each failure up to the refresh interval.
+	setRetryInterval = time.Second
+)
+
+// Set is a named list of IPs and CIDRs, loaded from a file or an URL and refreshed periodically.
+type Set struct {
+	name            string
+	file            string
+	url             string
+	refreshInterval time.Duration
+	retryInterval   time.Duration
+	client          *http.Client
+
+	lock         sync.RWMutex
+	loaded       bool
+	checker      *Checker
+	modTime      time.Time
+	fileSize     int64
+	etag         string
+	lastModified string
+}
+
+// NewSet creates a new IP set, reading its content from the file or the URL.
+func NewSet(name string, file string, url string, refreshInterval time.Duration) (*Set, error) {
+	if (len(file) > 0) == (len(url) > 0) {
+		return nil, fmt.Errorf("IP set %s: exactly one of file or URL must be provided", name)
+	}
+
+	if refreshInterval <= 0 {
+		refreshInterval = DefaultSetFileCheckInterval
+		if len(url) > 0 {
+			refreshInterval = DefaultSetURLRefreshInterval
+		}
+	}
+
+	return &Set{
+		name:            name,
+		file:            file,
+		url:             url,
+		refreshInterval: refreshInterval,
+		retryInterval:   setRetryInterval,
+		client:          &http.Client{Timeout: 30 * time.Second},
+	}, nil
+}
+
+// Name returns the name of the set.
+func (s *Set) Name() string {
+	return s.name
+}
+
+// ContainsIP checks if provided address is in the set.
+func (s *Set) ContainsIP(addr net.IP) bool {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	return s.checker != nil && s.checker.ContainsIP(addr)
+}
+
+// Loaded returns whether the content of the set has been loaded at least once.
+func (s *Set) Loaded() bool {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	return s.loaded
+}
+
+// Load reads the set from its source, when it has changed since the last load.
+// On error, the previous content of the set is kept.
+func (s *Set) Load() error {
+	if len(s.file) > 0 {
+		return s.loadFile()
+	}
+	return s.loadURL()
+}
+
+// Refresh reloads the set periodically, until stop is closed or receives a value.
+// Until its first successful load, the set is loaded again after a short backoff rather than the refresh interval.
+func (s *Set) Refresh(stop chan bool) {
+	retryInterval := s.retryInterval
+
+	for {
+		interval := s.refreshInterval
+		if !s.Loaded() && retryInterval < interval {
+			interval = retryInterval
+			retryInterval *= 2
+		}
+
+		timer := time.NewTimer(interval)
+		select {
+		case <-stop:
+			timer.Stop()
+			return
+		case <-timer.C:
+			if err := s.Load(); err != nil {
+				log.Errorf("Unable to refresh the IP set %s: %v", s.name, err)
+			}
+		}
+	}
+}
+
+func (s *Set) loadFile() error {
+	info, err := os.Stat(s.file)
+	if err != nil {
+		return err
+	}
+
+	s.lock.RLock()
+	unchanged := info.ModTime().Equal(s.modTime) && info.Size() == s.fileSize
+	s.lock.RUnlock()
+	if unchanged {
+		return nil
+	}
+
+	f, err := os.Open(s.file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	entries, err := parseSet(f)
+	if err != nil {
+		return err
+	}
+
+	return s.update(entries, func() {
+		s.modTime = info.ModTime()
+		s.fileSize = info.Size()
+	})
+}
+
+func (s *Set) loadURL() error {
+	req, err := http.NewRequest(http.MethodGet, s.url, nil)
+	if err != nil {
+		return err
+	}
+
+	s.lock.RLock()
+	if len(s.etag) > 0 {
+		req.Header.Set("If-None-Match", s.etag)
+	}
+	if len(s.lastModified) > 0 {
+		req.Header.Set("If-Modified-Since", s.lastModified)
+	}
+	s.lock.RUnlock()
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotModified:
+		return nil
+	default:
+		return fmt.Errorf("unexpected status code %d from %s", resp.StatusCode, s.url)
+	}
+
+	entries, err := parseSet(io.LimitReader(resp.Body, maxSetSize))
+	if err != nil {
+		return err
+	}
+
+	return s.update(entries, func() {
+		s.etag = resp.Header.Get("ETag")
+		s.lastModified = resp.Header.Get("Last-Modified")
+	})
+}
+
+// update replaces the content of the set, and records the version of the source with setVersion.
+func (s *Set) update(entries []string, setVersion func()) error {
+	var checker *Checker
+	if len(entries) > 0 {
+		var err error
+		checker, err = NewChecker(entries)
+		if err != nil {
+			return err
+		}
+	}
+
+	s.lock.Lock()
+	s.checker = checker
+	s.loaded = true
+	setVersion()
+	s.lock.Unlock()
+
+	log.Debugf("IP set %s loaded with %d entries", s.name, len(entries))
+	return nil
+}
+
+// parseSet reads one IP or CIDR per line. Empty lines, and anything following a '#' or a ';', are ignored.
+func parseSet(r io.Reader) ([]string, error) {
+	var entries []string
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if i := strings.IndexAny(line, "#;"); i >= 0 {
+			line = line[:i]
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		entries = append(entries, fields[0])
+	}
+
+	return entries, scanner.Err()
+}
@@ -0,0 +1,208 @@
+package ip
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewSet(t *testing.T) {
+	testCases := []struct {
+		desc             string
+		file             string
+		url              string
+		refreshInterval  time.Duration
+		expectedInterval time.Duration
+		expectedError    bool
+	}{
+		{
+			desc:          "no source",
+			expectedError: true,
+		},
+		{
+			desc:          "file and URL",
+			file:          "/foo/bar",
+			url:           "http://foo.bar",
+			expectedError: true,
+		},
+		{
+			desc:             "file with default interval",
+			file:             "/foo/bar",
+			expectedInterval: DefaultSetFileCheckInterval,
+		},
+		{
+			desc:             "URL with default interval",
+			url:              "http://foo.bar",
+			expectedInterval: DefaultSetURLRefreshInterval,
+		},
+		{
+			desc:             "URL with interval",
+			url:              "http://foo.bar",
+			refreshInterval:  time.Minute,
+			expectedInterval: time.Minute,
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			set, err := NewSet("foo", test.file, test.url, test.refreshInterval)
+			if test.expectedError {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedInterval, set.refreshInterval)
+		})
+	}
+}
+
+func TestParseSet(t *testing.T) {
+	content := `# Abuse feed
+1.2.3.0/24 ; SBL123
+
+10.0.0.1   # single IP
+  2001:db8::/32
+`
+
+	entries, err := parseSet(strings.NewReader(content))
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"1.2.3.0/24", "10.0.0.1", "2001:db8::/32"}, entries)
+}
+
+func TestSetLoadFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ipset")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "abuse.txt")
+	require.NoError(t, ioutil.WriteFile(path, []byte("1.2.3.0/24\n"), 0644))
+
+	set, err := NewSet("abuse", path, "", 0)
+	require.NoError(t, err)
+	assert.False(t, set.Loaded())
+
+	require.NoError(t, set.Load())
+	assert.True(t, set.Loaded())
+	assert.True(t, set.ContainsIP(net.ParseIP("1.2.3.4")))
+	assert.False(t, set.ContainsIP(net.ParseIP("5.6.7.8")))
+
+	require.NoError(t, ioutil.WriteFile(path, []byte("5.6.7.0/24\n"), 0644))
+	future := time.Now().Add(time.Minute)
+	require.NoError(t, os.Chtimes(path, future, future))
+
+	require.NoError(t, set.Load())
+	assert.False(t, set.ContainsIP(net.ParseIP("1.2.3.4")))
+	assert.True(t, set.ContainsIP(net.ParseIP("5.6.7.8")))
+
+	// An invalid file is ignored, the previous content is kept.
+	require.NoError(t, ioutil.WriteFile(path, []byte("foo\n"), 0644))
+	future = future.Add(time.Minute)
+	require.NoError(t, os.Chtimes(path, future, future))
+
+	assert.Error(t, set.Load())
+	assert.True(t, set.Loaded())
+	assert.True(t, set.ContainsIP(net.ParseIP("5.6.7.8")))
+}
+
+func TestSetLoadURL(t *testing.T) {
+	content := "1.2.3.0/24\n"
+	var requests int
+
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		requests++
+		etag := `"` + content + `"`
+		if req.Header.Get("If-None-Match") == etag {
+			rw.WriteHeader(http.StatusNotModified)
+			return
+		}
+		rw.Header().Set("ETag", etag)
+		rw.Write([]byte(content))
+	}))
+	defer server.Close()
+
+	set, err := NewSet("abuse", "", server.URL, 0)
+	require.NoError(t, err)
+
+	require.NoError(t, set.Load())
+	assert.True(t, set.ContainsIP(net.ParseIP("1.2.3.4")))
+
+	require.NoError(t, set.Load())
+	assert.True(t, set.ContainsIP(net.ParseIP("1.2.3.4")))
+
+	content = "5.6.7.0/24\n"
+	require.NoError(t, set.Load())
+	assert.False(t, set.ContainsIP(net.ParseIP("1.2.3.4")))
+	assert.True(t, set.ContainsIP(net.ParseIP("5.6.7.8")))
+
+	assert.Equal(t, 3, requests)
+}
+
+func TestSetLoadURLError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	set, err := NewSet("abuse", "", server.URL, 0)
+	require.NoError(t, err)
+
+	assert.Error(t, set.Load())
+	assert.False(t, set.Loaded())
+	assert.False(t, set.ContainsIP(net.ParseIP("1.2.3.4")))
+}
+
+func TestSetRefreshRetry(t *testing.T) {
+	var lock sync.Mutex
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		lock.Lock()
+		defer lock.Unlock()
+
+		requests++
+		if requests < 3 {
+			rw.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		fmt.Fprintln(rw, "1.2.3.4")
+	}))
+	defer server.Close()
+
+	set, err := NewSet("abuse", "", server.URL, time.Hour)
+	require.NoError(t, err)
+	set.retryInterval = 10 * time.Millisecond
+
+	require.Error(t, set.Load())
+
+	stop := make(chan bool)
+	defer close(stop)
+	go set.Refresh(stop)
+
+	// the failed first load is retried long before the refresh interval
+	deadline := time.Now().Add(5 * time.Second)
+	for !set.Loaded() && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	assert.True(t, set.Loaded())
+	assert.True(t, set.ContainsIP(net.ParseIP("1.2.3.4")))
+
+	lock.Lock()
+	defer lock.Unlock()
+	assert.Equal(t, 3, requests)
+}
@@ -29,6 +29,10 @@ const (
 	ddEntrypointReqsName          = "entrypoint.request.total"
 	ddEntrypointReqDurationName   = "entrypoint.request.duration"
 	ddEntrypointOpenConnsName     = "entrypoint.connections.open"
+	ddEntrypointRejectedName      = "entrypoint.request.rejected.total"
+	ddEntrypointUDPDatagramsName  = "entrypoint.udp.datagrams.total"
+	ddEntrypointUDPBytesName      = "entrypoint.udp.bytes.total"
+	ddEntrypointRejectedConnsName = "entrypoint.connections.rejected.total"
 	ddOpenConnsName               = "backend.connections.open"
 	ddServerUpName                = "backend.server.up"
 )
@@ -48,6 +52,10 @@ func RegisterDatadog(config *types.Datadog) Registry {
 		entrypointReqsCounter:          datadogClient.NewCounter(ddEntrypointReqsName, 1.0),
 		entrypointReqDurationHistogram: datadogClient.NewHistogram(ddEntrypointReqDurationName, 1.0),
 		entrypointOpenConnsGauge:       datadogClient.NewGauge(ddEntrypointOpenConnsName),
+		entrypointRejectedReqsCounter:  datadogClient.NewCounter(ddEntrypointRejectedName, 1.0),
+		entrypointUDPDatagramsCounter:  datadogClient.NewCounter(ddEntrypointUDPDatagramsName, 1.0),
+		entrypointUDPBytesCounter:      datadogClient.NewCounter(ddEntrypointUDPBytesName, 1.0),
+		entrypointRejectedConnsCounter: datadogClient.NewCounter(ddEntrypointRejectedConnsName, 1.0),
 		backendReqsCounter:             datadogClient.NewCounter(ddMetricsBackendReqsName, 1.0),
 		backendReqDurationHistogram:    datadogClient.NewHistogram(ddMetricsBackendLatencyName, 1.0),
 		backendRetriesCounter:          datadogClient.NewCounter(ddRetriesTotalName, 1.0),
@@ -33,6 +33,10 @@ func TestDatadog(t *testing.T) {
 		"traefik.entrypoint.request.total:1.000000|c|#entrypoint:test\n",
 		"traefik.entrypoint.request.duration:10000.000000|h|#entrypoint:test\n",
 		"traefik.entrypoint.connections.open:1.000000|g|#entrypoint:test\n",
+		"traefik.entrypoint.request.rejected.total:1.000000|c|#entrypoint:test,reason:header_count\n",
+		"traefik.entrypoint.udp.datagrams.total:1.000000|c|#entrypoint:test,direction:in\n",
+		"traefik.entrypoint.udp.bytes.total:512.000000|c|#entrypoint:test,direction:in\n",
+		"traefik.entrypoint.connections.rejected.total:1.000000|c|#entrypoint:test,reason:max_connections\n",
 		"traefik.backend.server.up:1.000000|g|#backend:test,url:http://127.0.0.1,one:two\n",
 	}
 
@@ -47,6 +51,10 @@ func TestDatadog(t *testing.T) {
 		datadogRegistry.EntrypointReqsCounter().With("entrypoint", "test").Add(1)
 		datadogRegistry.EntrypointReqDurationHistogram().With("entrypoint", "test").Observe(10000)
 		datadogRegistry.EntrypointOpenConnsGauge().With("entrypoint", "test").Set(1)
+		datadogRegistry.EntrypointRejectedReqsCounter().With("entrypoint", "test", "reason", "header_count").Add(1)
+		datadogRegistry.EntrypointUDPDatagramsCounter().With("entrypoint", "test", "direction", "in").Add(1)
+		datadogRegistry.EntrypointUDPBytesCounter().With("entrypoint", "test", "direction", "in").Add(512)
+		datadogRegistry.EntrypointRejectedConnsCounter().With("entrypoint", "test", "reason", "max_connections").Add(1)
 		datadogRegistry.BackendServerUpGauge().With("backend", "test", "url", "http://127.0.0.1", "one", "two").Set(1)
 	})
 }
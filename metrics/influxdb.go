@@ -35,6 +35,10 @@ const (
 	influxDBEntrypointReqsName          = "traefik.entrypoint.requests.total"
 	influxDBEntrypointReqDurationName   = "traefik.entrypoint.request.duration"
 	influxDBEntrypointOpenConnsName     = "traefik.entrypoint.connections.open"
+	influxDBEntrypointRejectedName      = "traefik.entrypoint.requests.rejected.total"
+	influxDBEntrypointUDPDatagramsName  = "traefik.entrypoint.udp.datagrams.total"
+	influxDBEntrypointUDPBytesName      = "traefik.entrypoint.udp.bytes.total"
+	influxDBEntrypointRejectedConnsName = "traefik.entrypoint.connections.rejected.total"
 	influxDBOpenConnsName               = "traefik.backend.connections.open"
 	influxDBServerUpName                = "traefik.backend.server.up"
 )
@@ -57,6 +61,10 @@ func RegisterInfluxDB(config *types.InfluxDB) Registry {
 		entrypointReqsCounter:          influxDBClient.NewCounter(influxDBEntrypointReqsName),
 		entrypointReqDurationHistogram: influxDBClient.NewHistogram(influxDBEntrypointReqDurationName),
 		entrypointOpenConnsGauge:       influxDBClient.NewGauge(influxDBEntrypointOpenConnsName),
+		entrypointRejectedReqsCounter:  influxDBClient.NewCounter(influxDBEntrypointRejectedName),
+		entrypointUDPDatagramsCounter:  influxDBClient.NewCounter(influxDBEntrypointUDPDatagramsName),
+		entrypointUDPBytesCounter:      influxDBClient.NewCounter(influxDBEntrypointUDPBytesName),
+		entrypointRejectedConnsCounter: influxDBClient.NewCounter(influxDBEntrypointRejectedConnsName),
 		backendReqsCounter:             influxDBClient.NewCounter(influxDBMetricsBackendReqsName),
 		backendReqDurationHistogram:    influxDBClient.NewHistogram(influxDBMetricsBackendLatencyName),
 		backendRetriesCounter:          influxDBClient.NewCounter(influxDBRetriesTotalName),
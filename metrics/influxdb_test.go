@@ -53,13 +53,20 @@ func TestInfluxDB(t *testing.T) {
 		`(traefik\.entrypoint\.requests\.total,entrypoint=test(?:[a-z=0-9A-Z,:/.]+)? count=1) [\d]{19}`,
 		`(traefik\.entrypoint\.request\.duration(?:,code=[\d]{3})?,entrypoint=test(?:[a-z=0-9A-Z,:/.]+)? p50=10000,p90=10000,p95=10000,p99=10000) [\d]{19}`,
 		`(traefik\.entrypoint\.connections\.open,entrypoint=test value=1) [\d]{19}`,
+		`(traefik\.entrypoint\.requests\.rejected\.total,entrypoint=test,reason=header_count count=1) [\d]{19}`,
+		`(traefik\.entrypoint\.udp\.datagrams\.total,direction=in,entrypoint=test count=1) [\d]{19}`,
+		`(traefik\.entrypoint\.udp\.bytes\.total,direction=in,entrypoint=test count=512) [\d]{19}`,
+		`(traefik\.entrypoint\.connections\.rejected\.total,entrypoint=test,reason=max_connections count=1) [\d]{19}`,
 	}
 
 	msgEntrypoint := udp.ReceiveString(t, func() {
 		influxDBRegistry.EntrypointReqsCounter().With("entrypoint", "test").Add(1)
 		influxDBRegistry.EntrypointReqDurationHistogram().With("entrypoint", "test").Observe(10000)
 		influxDBRegistry.EntrypointOpenConnsGauge().With("entrypoint", "test").Set(1)
-
+		influxDBRegistry.EntrypointRejectedReqsCounter().With("entrypoint", "test", "reason", "header_count").Add(1)
+		influxDBRegistry.EntrypointUDPDatagramsCounter().With("entrypoint", "test", "direction", "in").Add(1)
+		influxDBRegistry.EntrypointUDPBytesCounter().With("entrypoint", "test", "direction", "in").Add(512)
+		influxDBRegistry.EntrypointRejectedConnsCounter().With("entrypoint", "test", "reason", "max_connections").Add(1)
 	})
 
 	assertMessage(t, msgEntrypoint, expectedEntrypoint)
@@ -20,6 +20,10 @@ type Registry interface {
 	EntrypointReqsCounter() metrics.Counter
 	EntrypointReqDurationHistogram() metrics.Histogram
 	EntrypointOpenConnsGauge() metrics.Gauge
+	EntrypointRejectedReqsCounter() metrics.Counter
+	EntrypointUDPDatagramsCounter() metrics.Counter
+	EntrypointUDPBytesCounter() metrics.Counter
+	EntrypointRejectedConnsCounter() metrics.Counter
 
 	// backend metrics
 	BackendReqsCounter() metrics.Counter
@@ -46,6 +50,10 @@ func NewMultiRegistry(registries []Registry) Registry {
 	var entrypointReqsCounter []metrics.Counter
 	var entrypointReqDurationHistogram []metrics.Histogram
 	var entrypointOpenConnsGauge []metrics.Gauge
+	var entrypointRejectedReqsCounter []metrics.Counter
+	var entrypointUDPDatagramsCounter []metrics.Counter
+	var entrypointUDPBytesCounter []metrics.Counter
+	var entrypointRejectedConnsCounter []metrics.Counter
 	var backendReqsCounter []metrics.Counter
 	var backendReqDurationHistogram []metrics.Histogram
 	var backendOpenConnsGauge []metrics.Gauge
@@ -74,6 +82,18 @@ func NewMultiRegistry(registries []Registry) Registry {
 		if r.EntrypointOpenConnsGauge() != nil {
 			entrypointOpenConnsGauge = append(entrypointOpenConnsGauge, r.EntrypointOpenConnsGauge())
 		}
+		if r.EntrypointRejectedReqsCounter() != nil {
+			entrypointRejectedReqsCounter = append(entrypointRejectedReqsCounter, r.EntrypointRejectedReqsCounter())
+		}
+		if r.EntrypointUDPDatagramsCounter() != nil {
+			entrypointUDPDatagramsCounter = append(entrypointUDPDatagramsCounter, r.EntrypointUDPDatagramsCounter())
+		}
+		if r.EntrypointUDPBytesCounter() != nil {
+			entrypointUDPBytesCounter = append(entrypointUDPBytesCounter, r.EntrypointUDPBytesCounter())
+		}
+		if r.EntrypointRejectedConnsCounter() != nil {
+			entrypointRejectedConnsCounter = append(entrypointRejectedConnsCounter, r.EntrypointRejectedConnsCounter())
+		}
 		if r.BackendReqsCounter() != nil {
 			backendReqsCounter = append(backendReqsCounter, r.BackendReqsCounter())
 		}
@@ -100,6 +120,10 @@ func NewMultiRegistry(registries []Registry) Registry {
 		entrypointReqsCounter:          multi.NewCounter(entrypointReqsCounter...),
 		entrypointReqDurationHistogram: multi.NewHistogram(entrypointReqDurationHistogram...),
 		entrypointOpenConnsGauge:       multi.NewGauge(entrypointOpenConnsGauge...),
+		entrypointRejectedReqsCounter:  multi.NewCounter(entrypointRejectedReqsCounter...),
+		entrypointUDPDatagramsCounter:  multi.NewCounter(entrypointUDPDatagramsCounter...),
+		entrypointUDPBytesCounter:      multi.NewCounter(entrypointUDPBytesCounter...),
+		entrypointRejectedConnsCounter: multi.NewCounter(entrypointRejectedConnsCounter...),
 		backendReqsCounter:             multi.NewCounter(backendReqsCounter...),
 		backendReqDurationHistogram:    multi.NewHistogram(backendReqDurationHistogram...),
 		backendOpenConnsGauge:          multi.NewGauge(backendOpenConnsGauge...),
@@ -117,6 +141,10 @@ type standardRegistry struct {
 	entrypointReqsCounter          metrics.Counter
 	entrypointReqDurationHistogram metrics.Histogram
 	entrypointOpenConnsGauge       metrics.Gauge
+	entrypointRejectedReqsCounter  metrics.Counter
+	entrypointUDPDatagramsCounter  metrics.Counter
+	entrypointUDPBytesCounter      metrics.Counter
+	entrypointRejectedConnsCounter metrics.Counter
 	backendReqsCounter             metrics.Counter
 	backendReqDurationHistogram    metrics.Histogram
 	backendOpenConnsGauge          metrics.Gauge
@@ -156,6 +184,22 @@ func (r *standardRegistry) EntrypointOpenConnsGauge() metrics.Gauge {
 	return r.entrypointOpenConnsGauge
 }
 
+func (r *standardRegistry) EntrypointRejectedReqsCounter() metrics.Counter {
+	return r.entrypointRejectedReqsCounter
+}
+
+func (r *standardRegistry) EntrypointUDPDatagramsCounter() metrics.Counter {
+	return r.entrypointUDPDatagramsCounter
+}
+
+func (r *standardRegistry) EntrypointUDPBytesCounter() metrics.Counter {
+	return r.entrypointUDPBytesCounter
+}
+
+func (r *standardRegistry) EntrypointRejectedConnsCounter() metrics.Counter {
+	return r.entrypointRejectedConnsCounter
+}
+
 func (r *standardRegistry) BackendReqsCounter() metrics.Counter {
 	return r.backendReqsCounter
 }
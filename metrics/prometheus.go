@@ -27,10 +27,14 @@ const (
 	configLastReloadFailureName    = metricConfigPrefix + "last_reload_failure"
 
 	// entrypoint
-	metricEntryPointPrefix    = MetricNamePrefix + "entrypoint_"
-	entrypointReqsTotalName   = metricEntryPointPrefix + "requests_total"
-	entrypointReqDurationName = metricEntryPointPrefix + "request_duration_seconds"
-	entrypointOpenConnsName   = metricEntryPointPrefix + "open_connections"
+	metricEntryPointPrefix     = MetricNamePrefix + "entrypoint_"
+	entrypointReqsTotalName    = metricEntryPointPrefix + "requests_total"
+	entrypointReqDurationName  = metricEntryPointPrefix + "request_duration_seconds"
+	entrypointOpenConnsName    = metricEntryPointPrefix + "open_connections"
+	entrypointRejectedName     = metricEntryPointPrefix + "rejected_requests_total"
+	entrypointUDPDatagramsName = metricEntryPointPrefix + "udp_datagrams_total"
+	entrypointUDPBytesName     = metricEntryPointPrefix + "udp_bytes_total"
+	entrypointRejectedConnName = metricEntryPointPrefix + "rejected_connections_total"
 
 	// backend level.
 
@@ -117,6 +121,22 @@ func initStandardRegistry(config *types.Prometheus) Registry {
 		Name: entrypointOpenConnsName,
 		Help: "How many open connections exist on an entrypoint, partitioned by method and protocol.",
 	}, []string{"method", "protocol", "entrypoint"})
+	entrypointRejected := newCounterFrom(promState.collectors, stdprometheus.CounterOpts{
+		Name: entrypointRejectedName,
+		Help: "How many HTTP requests were rejected on an entrypoint before reaching a backend, partitioned by reason.",
+	}, []string{"reason", "entrypoint"})
+	entrypointUDPDatagrams := newCounterFrom(promState.collectors, stdprometheus.CounterOpts{
+		Name: entrypointUDPDatagramsName,
+		Help: "How many UDP datagrams were forwarded on an entrypoint, partitioned by direction.",
+	}, []string{"direction", "entrypoint"})
+	entrypointUDPBytes := newCounterFrom(promState.collectors, stdprometheus.CounterOpts{
+		Name: entrypointUDPBytesName,
+		Help: "How many bytes of UDP datagrams were forwarded on an entrypoint, partitioned by direction.",
+	}, []string{"direction", "entrypoint"})
+	entrypointRejectedConns := newCounterFrom(promState.collectors, stdprometheus.CounterOpts{
+		Name: entrypointRejectedConnName,
+		Help: "How many connections were closed by an entrypoint because of its connection limits, partitioned by reason.",
+	}, []string{"reason", "entrypoint"})
 
 	backendReqs := newCounterFrom(promState.collectors, stdprometheus.CounterOpts{
 		Name: backendReqsTotalName,
@@ -148,6 +168,10 @@ func initStandardRegistry(config *types.Prometheus) Registry {
 		entrypointReqs.cv.Describe,
 		entrypointReqDurations.hv.Describe,
 		entrypointOpenConns.gv.Describe,
+		entrypointRejected.cv.Describe,
+		entrypointUDPDatagrams.cv.Describe,
+		entrypointUDPBytes.cv.Describe,
+		entrypointRejectedConns.cv.Describe,
 		backendReqs.cv.Describe,
 		backendReqDurations.hv.Describe,
 		backendOpenConns.gv.Describe,
@@ -164,6 +188,10 @@ func initStandardRegistry(config *types.Prometheus) Registry {
 		entrypointReqsCounter:          entrypointReqs,
 		entrypointReqDurationHistogram: entrypointReqDurations,
 		entrypointOpenConnsGauge:       entrypointOpenConns,
+		entrypointRejectedReqsCounter:  entrypointRejected,
+		entrypointUDPDatagramsCounter:  entrypointUDPDatagrams,
+		entrypointUDPBytesCounter:      entrypointUDPBytes,
+		entrypointRejectedConnsCounter: entrypointRejectedConns,
 		backendReqsCounter:             backendReqs,
 		backendReqDurationHistogram:    backendReqDurations,
 		backendOpenConnsGauge:          backendOpenConns,
@@ -196,6 +224,12 @@ func OnConfigurationUpdate(configurations types.Configurations) {
 			}
 		}
 
+		for _, frontend := range config.UDPFrontends {
+			for _, entrypointName := range frontend.EntryPoints {
+				dynamicConfig.entrypoints[entrypointName] = true
+			}
+		}
+
 		for backendName, backend := range config.Backends {
 			dynamicConfig.backends[backendName] = make(map[string]bool)
 			for _, server := range backend.Servers {
@@ -125,6 +125,22 @@ func TestPrometheus(t *testing.T) {
 		EntrypointOpenConnsGauge().
 		With("method", http.MethodGet, "protocol", "http", "entrypoint", "http").
 		Set(1)
+	prometheusRegistry.
+		EntrypointRejectedReqsCounter().
+		With("reason", "header_count", "entrypoint", "http").
+		Add(1)
+	prometheusRegistry.
+		EntrypointUDPDatagramsCounter().
+		With("direction", "in", "entrypoint", "http").
+		Add(1)
+	prometheusRegistry.
+		EntrypointUDPBytesCounter().
+		With("direction", "in", "entrypoint", "http").
+		Add(512)
+	prometheusRegistry.
+		EntrypointRejectedConnsCounter().
+		With("reason", "max_connections", "entrypoint", "http").
+		Add(1)
 
 	prometheusRegistry.
 		BackendReqsCounter().
@@ -201,6 +217,38 @@ func TestPrometheus(t *testing.T) {
 			},
 			assert: buildGaugeAssert(t, entrypointOpenConnsName, 1),
 		},
+		{
+			name: entrypointRejectedName,
+			labels: map[string]string{
+				"reason":     "header_count",
+				"entrypoint": "http",
+			},
+			assert: buildCounterAssert(t, entrypointRejectedName, 1),
+		},
+		{
+			name: entrypointUDPDatagramsName,
+			labels: map[string]string{
+				"direction":  "in",
+				"entrypoint": "http",
+			},
+			assert: buildCounterAssert(t, entrypointUDPDatagramsName, 1),
+		},
+		{
+			name: entrypointUDPBytesName,
+			labels: map[string]string{
+				"direction":  "in",
+				"entrypoint": "http",
+			},
+			assert: buildCounterAssert(t, entrypointUDPBytesName, 512),
+		},
+		{
+			name: entrypointRejectedConnName,
+			labels: map[string]string{
+				"reason":     "max_connections",
+				"entrypoint": "http",
+			},
+			assert: buildCounterAssert(t, entrypointRejectedConnName, 1),
+		},
 		{
 			name: backendReqsTotalName,
 			labels: map[string]string{
@@ -281,6 +329,11 @@ func TestPrometheusMetricRemoval(t *testing.T) {
 			th.WithBackendNew("backend1", th.WithServersNew(th.WithServerNew("http://localhost:9000"))),
 		),
 	)
+	configurations["udpProviderName"] = &types.Configuration{
+		UDPFrontends: map[string]*types.UDPFrontend{
+			"dns": {EntryPoints: []string{"dns"}, Backend: "dns"},
+		},
+	}
 	OnConfigurationUpdate(configurations)
 
 	// Register some metrics manually that are not part of the active configuration.
@@ -315,6 +368,16 @@ func TestPrometheusMetricRemoval(t *testing.T) {
 
 	assertMetricsExist(t, mustScrape(), entrypointReqsTotalName)
 	assertMetricsExist(t, mustScrape(), entrypointReqsTotalName)
+
+	prometheusRegistry.
+		EntrypointUDPDatagramsCounter().
+		With("entrypoint", "dns", "direction", "in").
+		Add(1)
+
+	delayForTrackingCompletion()
+
+	assertMetricsExist(t, mustScrape(), entrypointUDPDatagramsName)
+	assertMetricsExist(t, mustScrape(), entrypointUDPDatagramsName)
 }
 
 func TestPrometheusRemovedMetricsReset(t *testing.T) {
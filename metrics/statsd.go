@@ -28,6 +28,10 @@ const (
 	statsdEntrypointReqsName          = "entrypoint.request.total"
 	statsdEntrypointReqDurationName   = "entrypoint.request.duration"
 	statsdEntrypointOpenConnsName     = "entrypoint.connections.open"
+	statsdEntrypointRejectedName      = "entrypoint.request.rejected.total"
+	statsdEntrypointUDPDatagramsName  = "entrypoint.udp.datagrams.total"
+	statsdEntrypointUDPBytesName      = "entrypoint.udp.bytes.total"
+	statsdEntrypointRejectedConnsName = "entrypoint.connections.rejected.total"
 	statsdOpenConnsName               = "backend.connections.open"
 	statsdServerUpName                = "backend.server.up"
 )
@@ -47,6 +51,10 @@ func RegisterStatsd(config *types.Statsd) Registry {
 		entrypointReqsCounter:          statsdClient.NewCounter(statsdEntrypointReqsName, 1.0),
 		entrypointReqDurationHistogram: statsdClient.NewTiming(statsdEntrypointReqDurationName, 1.0),
 		entrypointOpenConnsGauge:       statsdClient.NewGauge(statsdEntrypointOpenConnsName),
+		entrypointRejectedReqsCounter:  statsdClient.NewCounter(statsdEntrypointRejectedName, 1.0),
+		entrypointUDPDatagramsCounter:  statsdClient.NewCounter(statsdEntrypointUDPDatagramsName, 1.0),
+		entrypointUDPBytesCounter:      statsdClient.NewCounter(statsdEntrypointUDPBytesName, 1.0),
+		entrypointRejectedConnsCounter: statsdClient.NewCounter(statsdEntrypointRejectedConnsName, 1.0),
 		backendReqsCounter:             statsdClient.NewCounter(statsdMetricsBackendReqsName, 1.0),
 		backendReqDurationHistogram:    statsdClient.NewTiming(statsdMetricsBackendLatencyName, 1.0),
 		backendRetriesCounter:          statsdClient.NewCounter(statsdRetriesTotalName, 1.0),
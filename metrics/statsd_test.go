@@ -31,6 +31,10 @@ func TestStatsD(t *testing.T) {
 		"traefik.entrypoint.request.total:1.000000|c\n",
 		"traefik.entrypoint.request.duration:10000.000000|ms",
 		"traefik.entrypoint.connections.open:1.000000|g\n",
+		"traefik.entrypoint.request.rejected.total:1.000000|c\n",
+		"traefik.entrypoint.udp.datagrams.total:1.000000|c\n",
+		"traefik.entrypoint.udp.bytes.total:512.000000|c\n",
+		"traefik.entrypoint.connections.rejected.total:1.000000|c\n",
 		"traefik.backend.server.up:1.000000|g\n",
 	}
 
@@ -45,6 +49,10 @@ func TestStatsD(t *testing.T) {
 		statsdRegistry.EntrypointReqsCounter().With("entrypoint", "test").Add(1)
 		statsdRegistry.EntrypointReqDurationHistogram().With("entrypoint", "test").Observe(10000)
 		statsdRegistry.EntrypointOpenConnsGauge().With("entrypoint", "test").Set(1)
+		statsdRegistry.EntrypointRejectedReqsCounter().With("entrypoint", "test", "reason", "header_count").Add(1)
+		statsdRegistry.EntrypointUDPDatagramsCounter().With("entrypoint", "test", "direction", "in").Add(1)
+		statsdRegistry.EntrypointUDPBytesCounter().With("entrypoint", "test", "direction", "in").Add(512)
+		statsdRegistry.EntrypointRejectedConnsCounter().With("entrypoint", "test", "reason", "max_connections").Add(1)
 		statsdRegistry.BackendServerUpGauge().With("backend:test", "url", "http://127.0.0.1").Set(1)
 	})
 }
@@ -66,6 +66,12 @@ const (
 	Overhead = "Overhead"
 	// RetryAttempts is the map key used for the amount of attempts the request was retried.
 	RetryAttempts = "RetryAttempts"
+	// RequestID is the map key used for the ID of the request, when the entrypoint ensures one.
+	RequestID = "RequestID"
+	// GeoCountry is the map key used for the ISO code of the country of the client IP, when GeoIP is enabled.
+	GeoCountry = "GeoCountry"
+	// GeoASN is the map key used for the autonomous system number of the client IP, when GeoIP is enabled.
+	GeoASN = "GeoASN"
 )
 
 // These are written out in the default case when no config is provided to specify keys of interest.
@@ -106,6 +112,9 @@ func init() {
 	allCoreKeys[StartLocal] = struct{}{}
 	allCoreKeys[Overhead] = struct{}{}
 	allCoreKeys[RetryAttempts] = struct{}{}
+	allCoreKeys[RequestID] = struct{}{}
+	allCoreKeys[GeoCountry] = struct{}{}
+	allCoreKeys[GeoASN] = struct{}{}
 }
 
 // CoreLogData holds the fields computed from the request/response.
@@ -1,6 +1,9 @@
 package auth
 
 import (
+	"bytes"
+	"errors"
+	"io"
 	"io/ioutil"
 	"net"
 	"net/http"
@@ -16,10 +19,18 @@ import (
 const (
 	xForwardedURI    = "X-Forwarded-Uri"
 	xForwardedMethod = "X-Forwarded-Method"
+
+	defaultMaxBodySize int64 = 1024 * 1024
 )
 
+var errBodyTooLarge = errors.New("request body too large")
+
 // Forward the authentication to a external server
 func Forward(config *types.Forward, w http.ResponseWriter, r *http.Request, next http.HandlerFunc) {
+	forwardWithCache(config, nil, w, r, next)
+}
+
+func forwardWithCache(config *types.Forward, cache *forwardCache, w http.ResponseWriter, r *http.Request, next http.HandlerFunc) {
 	// Ensure our request client does not follow redirects
 	httpClient := http.Client{
 		CheckRedirect: func(r *http.Request, via []*http.Request) error {
@@ -40,7 +51,33 @@ func Forward(config *types.Forward, w http.ResponseWriter, r *http.Request, next
 		}
 	}
 
-	forwardReq, err := http.NewRequest(http.MethodGet, config.Address, nil)
+	var body []byte
+	if config.ForwardBody && r.Body != nil && r.Body != http.NoBody {
+		var err error
+		body, err = readBody(r, config.MaxBodySize)
+		if err == errBodyTooLarge {
+			tracing.SetErrorAndDebugLog(r, "Request body too large to be forwarded to %s", config.Address)
+			w.WriteHeader(http.StatusRequestEntityTooLarge)
+			return
+		}
+		if err != nil {
+			tracing.SetErrorAndDebugLog(r, "Error reading request body. Cause: %s", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+	}
+
+	method := http.MethodGet
+	if config.Method != "" {
+		method = strings.ToUpper(config.Method)
+	}
+
+	var forwardBody io.Reader
+	if body != nil {
+		forwardBody = bytes.NewReader(body)
+	}
+
+	forwardReq, err := http.NewRequest(method, config.Address, forwardBody)
 	tracing.LogRequest(tracing.GetSpan(r), forwardReq)
 	if err != nil {
 		tracing.SetErrorAndDebugLog(r, "Error calling %s. Cause %s", config.Address, err)
@@ -48,7 +85,17 @@ func Forward(config *types.Forward, w http.ResponseWriter, r *http.Request, next
 		return
 	}
 
-	writeHeader(r, forwardReq, config.TrustForwardHeader)
+	writeHeader(r, forwardReq, config.TrustForwardHeader, config.AuthRequestHeaders)
+
+	var cacheKey string
+	if cache != nil {
+		cacheKey = cache.key(forwardReq, body)
+		if decision := cache.get(cacheKey); decision != nil {
+			log.Debugf("Using cached authentication decision from %s. StatusCode: %d", config.Address, decision.statusCode)
+			applyDecision(config, decision, w, r, next)
+			return
+		}
+	}
 
 	tracing.InjectRequestHeaders(forwardReq)
 
@@ -59,7 +106,7 @@ func Forward(config *types.Forward, w http.ResponseWriter, r *http.Request, next
 		return
 	}
 
-	body, readError := ioutil.ReadAll(forwardResponse.Body)
+	responseBody, readError := ioutil.ReadAll(forwardResponse.Body)
 	if readError != nil {
 		tracing.SetErrorAndDebugLog(r, "Error reading body %s. Cause: %s", config.Address, readError)
 		w.WriteHeader(http.StatusInternalServerError)
@@ -67,13 +114,18 @@ func Forward(config *types.Forward, w http.ResponseWriter, r *http.Request, next
 	}
 	defer forwardResponse.Body.Close()
 
+	decision := &forwardDecision{
+		statusCode: forwardResponse.StatusCode,
+		header:     make(http.Header),
+	}
+
 	// Pass the forward response's body and selected headers if it
 	// didn't return a response within the range of [200, 300).
-	if forwardResponse.StatusCode < http.StatusOK || forwardResponse.StatusCode >= http.StatusMultipleChoices {
+	if !decision.granted() {
 		log.Debugf("Remote error %s. StatusCode: %d", config.Address, forwardResponse.StatusCode)
 
-		utils.CopyHeaders(w.Header(), forwardResponse.Header)
-		utils.RemoveHeaders(w.Header(), forward.HopHeaders...)
+		utils.CopyHeaders(decision.header, forwardResponse.Header)
+		utils.RemoveHeaders(decision.header, forward.HopHeaders...)
 
 		// Grab the location header, if any.
 		redirectURL, err := forwardResponse.Location()
@@ -86,28 +138,79 @@ func Forward(config *types.Forward, w http.ResponseWriter, r *http.Request, next
 			}
 		} else if redirectURL.String() != "" {
 			// Set the location in our response if one was sent back.
-			w.Header().Set("Location", redirectURL.String())
+			decision.header.Set("Location", redirectURL.String())
 		}
 
-		tracing.LogResponseCode(tracing.GetSpan(r), forwardResponse.StatusCode)
-		w.WriteHeader(forwardResponse.StatusCode)
+		decision.body = responseBody
+	} else {
+		for _, headerName := range config.AuthResponseHeaders {
+			decision.header.Set(headerName, forwardResponse.Header.Get(headerName))
+		}
+	}
+
+	if cache != nil {
+		cache.set(cacheKey, decision)
+	}
+
+	applyDecision(config, decision, w, r, next)
+}
+
+func applyDecision(config *types.Forward, decision *forwardDecision, w http.ResponseWriter, r *http.Request, next http.HandlerFunc) {
+	if !decision.granted() {
+		utils.CopyHeaders(w.Header(), decision.header)
 
-		if _, err = w.Write(body); err != nil {
+		tracing.LogResponseCode(tracing.GetSpan(r), decision.statusCode)
+		w.WriteHeader(decision.statusCode)
+
+		if _, err := w.Write(decision.body); err != nil {
 			log.Error(err)
 		}
 		return
 	}
 
 	for _, headerName := range config.AuthResponseHeaders {
-		r.Header.Set(headerName, forwardResponse.Header.Get(headerName))
+		r.Header.Set(headerName, decision.header.Get(headerName))
 	}
 
 	r.RequestURI = r.URL.RequestURI()
 	next(w, r)
 }
 
-func writeHeader(req *http.Request, forwardReq *http.Request, trustForwardHeader bool) {
-	utils.CopyHeaders(forwardReq.Header, req.Header)
+// readBody reads the request body up to maxSize bytes, and replaces it so it can be read again by the next handlers.
+func readBody(req *http.Request, maxSize int64) ([]byte, error) {
+	if maxSize <= 0 {
+		maxSize = defaultMaxBodySize
+	}
+
+	if req.ContentLength > maxSize {
+		return nil, errBodyTooLarge
+	}
+
+	body, err := ioutil.ReadAll(io.LimitReader(req.Body, maxSize+1))
+	if err != nil {
+		return nil, err
+	}
+
+	if int64(len(body)) > maxSize {
+		return nil, errBodyTooLarge
+	}
+
+	req.Body.Close()
+	req.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	return body, nil
+}
+
+func writeHeader(req *http.Request, forwardReq *http.Request, trustForwardHeader bool, allowedHeaders []string) {
+	if len(allowedHeaders) > 0 {
+		for _, headerName := range allowedHeaders {
+			if values, ok := req.Header[http.CanonicalHeaderKey(headerName)]; ok {
+				forwardReq.Header[http.CanonicalHeaderKey(headerName)] = append([]string(nil), values...)
+			}
+		}
+	} else {
+		utils.CopyHeaders(forwardReq.Header, req.Header)
+	}
 	utils.RemoveHeaders(forwardReq.Header, forward.HopHeaders...)
 
 	if clientIP, _, err := net.SplitHostPort(req.RemoteAddr); err == nil {
@@ -0,0 +1,143 @@
+package auth
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/containous/traefik/types"
+	"github.com/vulcand/oxy/forward"
+)
+
+// maxForwardCacheEntries bounds the number of decisions kept in memory by a forward authentication cache.
+const maxForwardCacheEntries = 10000
+
+// forwardDecision holds the response of the authentication server.
+type forwardDecision struct {
+	statusCode int
+	header     http.Header
+	body       []byte
+	expiration time.Time
+}
+
+func (d *forwardDecision) granted() bool {
+	return d.statusCode >= http.StatusOK && d.statusCode < http.StatusMultipleChoices
+}
+
+// forwardCache keeps the decisions of the authentication server, keyed on a set of request headers.
+type forwardCache struct {
+	keyHeaders  []string
+	ttl         time.Duration
+	negativeTTL time.Duration
+
+	lock    sync.RWMutex
+	entries map[string]*forwardDecision
+}
+
+func newForwardCache(config *types.ForwardCache) (*forwardCache, error) {
+	if config == nil {
+		return nil, nil
+	}
+
+	if len(config.KeyHeaders) == 0 {
+		return nil, errors.New("forward authentication cache requires at least one key header")
+	}
+
+	var keyHeaders []string
+	for _, headerName := range config.KeyHeaders {
+		keyHeaders = append(keyHeaders, http.CanonicalHeaderKey(strings.TrimSpace(headerName)))
+	}
+
+	return &forwardCache{
+		keyHeaders:  keyHeaders,
+		ttl:         time.Duration(config.TTL),
+		negativeTTL: time.Duration(config.NegativeTTL),
+		entries:     make(map[string]*forwardDecision),
+	}, nil
+}
+
+// key builds the cache key from the headers of the request sent to the authentication server.
+// An empty key is returned when none of the key headers are present: such requests are never cached.
+// The method, host and URI of the request are always part of the key, the decisions depending on the requested resource.
+func (c *forwardCache) key(forwardReq *http.Request, body []byte) string {
+	var values []string
+	var found bool
+	for _, headerName := range c.keyHeaders {
+		value := strings.Join(forwardReq.Header[headerName], ",")
+		if value != "" {
+			found = true
+		}
+		values = append(values, value)
+	}
+
+	if !found {
+		return ""
+	}
+
+	values = append(values,
+		forwardReq.Header.Get(xForwardedMethod),
+		forwardReq.Header.Get(forward.XForwardedHost),
+		forwardReq.Header.Get(xForwardedURI))
+
+	if body != nil {
+		sum := sha256.Sum256(body)
+		values = append(values, hex.EncodeToString(sum[:]))
+	}
+
+	return strings.Join(values, "\x00")
+}
+
+func (c *forwardCache) get(key string) *forwardDecision {
+	if key == "" {
+		return nil
+	}
+
+	c.lock.RLock()
+	decision, ok := c.entries[key]
+	c.lock.RUnlock()
+
+	if !ok || time.Now().After(decision.expiration) {
+		return nil
+	}
+
+	return decision
+}
+
+func (c *forwardCache) set(key string, decision *forwardDecision) {
+	if key == "" {
+		return
+	}
+
+	ttl := c.negativeTTL
+	if decision.granted() {
+		ttl = c.ttl
+	}
+
+	if ttl <= 0 {
+		return
+	}
+
+	now := time.Now()
+	decision.expiration = now.Add(ttl)
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if len(c.entries) >= maxForwardCacheEntries {
+		for k, entry := range c.entries {
+			if now.After(entry.expiration) {
+				delete(c.entries, k)
+			}
+		}
+
+		if len(c.entries) >= maxForwardCacheEntries {
+			return
+		}
+	}
+
+	c.entries[key] = decision
+}
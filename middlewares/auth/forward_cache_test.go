@@ -0,0 +1,66 @@
+package auth
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/containous/flaeg/parse"
+	"github.com/containous/traefik/testhelpers"
+	"github.com/containous/traefik/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewForwardCache(t *testing.T) {
+	cache, err := newForwardCache(nil)
+	require.NoError(t, err)
+	assert.Nil(t, cache)
+
+	_, err = newForwardCache(&types.ForwardCache{TTL: parse.Duration(time.Minute)})
+	assert.Error(t, err)
+
+	cache, err = newForwardCache(&types.ForwardCache{KeyHeaders: []string{"authorization"}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Authorization"}, cache.keyHeaders)
+}
+
+func TestForwardCacheKey(t *testing.T) {
+	cache, err := newForwardCache(&types.ForwardCache{KeyHeaders: []string{"Authorization", "Cookie"}})
+	require.NoError(t, err)
+
+	req := testhelpers.MustNewRequest(http.MethodGet, "http://foo.bar", nil)
+	assert.Empty(t, cache.key(req, nil))
+
+	req.Header.Set("Cookie", "session=1")
+	key := cache.key(req, nil)
+	assert.NotEmpty(t, key)
+
+	req.Header.Set("Authorization", "token")
+	assert.NotEqual(t, key, cache.key(req, nil))
+	assert.NotEqual(t, cache.key(req, []byte("foo")), cache.key(req, []byte("bar")))
+
+	// the requested resource is always part of the key
+	for _, headerName := range []string{"X-Forwarded-Method", "X-Forwarded-Host", "X-Forwarded-Uri"} {
+		key = cache.key(req, nil)
+		req.Header.Set(headerName, "foo")
+		assert.NotEqual(t, key, cache.key(req, nil), headerName)
+	}
+}
+
+func TestForwardCacheExpiration(t *testing.T) {
+	cache, err := newForwardCache(&types.ForwardCache{
+		KeyHeaders: []string{"Authorization"},
+		TTL:        parse.Duration(time.Minute),
+	})
+	require.NoError(t, err)
+
+	cache.set("granted", &forwardDecision{statusCode: http.StatusOK})
+	cache.set("denied", &forwardDecision{statusCode: http.StatusForbidden})
+
+	assert.NotNil(t, cache.get("granted"))
+	assert.Nil(t, cache.get("denied"), "negative decisions must not be cached without negative TTL")
+
+	cache.entries["granted"].expiration = time.Now().Add(-time.Second)
+	assert.Nil(t, cache.get("granted"))
+}
@@ -5,8 +5,11 @@ import (
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/containous/flaeg/parse"
 	"github.com/containous/traefik/middlewares/tracing"
 	"github.com/containous/traefik/testhelpers"
 	"github.com/containous/traefik/types"
@@ -229,11 +232,209 @@ func TestForwardAuthFailResponseHeaders(t *testing.T) {
 	assert.Equal(t, "Forbidden\n", string(body), "they should be equal")
 }
 
+func TestForwardAuthBody(t *testing.T) {
+	testCases := []struct {
+		desc               string
+		forwardBody        bool
+		maxBodySize        int64
+		body               string
+		expectedStatusCode int
+		expectedAuthBody   string
+	}{
+		{
+			desc:               "body not forwarded",
+			body:               "payload",
+			expectedStatusCode: http.StatusOK,
+			expectedAuthBody:   "",
+		},
+		{
+			desc:               "body forwarded",
+			forwardBody:        true,
+			body:               "payload",
+			expectedStatusCode: http.StatusOK,
+			expectedAuthBody:   "payload",
+		},
+		{
+			desc:               "body too large",
+			forwardBody:        true,
+			maxBodySize:        3,
+			body:               "payload",
+			expectedStatusCode: http.StatusRequestEntityTooLarge,
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			var authBody string
+			var authMethod string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, err := ioutil.ReadAll(r.Body)
+				require.NoError(t, err)
+				authBody = string(body)
+				authMethod = r.Method
+			}))
+			defer server.Close()
+
+			middleware, err := NewAuthenticator(&types.Auth{
+				Forward: &types.Forward{
+					Address:     server.URL,
+					Method:      http.MethodPost,
+					ForwardBody: test.forwardBody,
+					MaxBodySize: test.maxBodySize,
+				},
+			}, &tracing.Tracing{})
+			require.NoError(t, err)
+
+			handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, err := ioutil.ReadAll(r.Body)
+				require.NoError(t, err)
+				fmt.Fprint(w, string(body))
+			})
+			n := negroni.New(middleware)
+			n.UseHandler(handler)
+			ts := httptest.NewServer(n)
+			defer ts.Close()
+
+			req := testhelpers.MustNewRequest(http.MethodPost, ts.URL, strings.NewReader(test.body))
+			res, err := http.DefaultClient.Do(req)
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedStatusCode, res.StatusCode)
+
+			if test.expectedStatusCode != http.StatusOK {
+				return
+			}
+
+			body, err := ioutil.ReadAll(res.Body)
+			require.NoError(t, err)
+			assert.Equal(t, test.body, string(body))
+			assert.Equal(t, test.expectedAuthBody, authBody)
+			assert.Equal(t, http.MethodPost, authMethod)
+		})
+	}
+}
+
+func TestForwardAuthCache(t *testing.T) {
+	var calls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if r.Header.Get("Authorization") != "valid" {
+			http.Error(w, "Forbidden", http.StatusForbidden)
+			return
+		}
+		w.Header().Set("X-Auth-User", "user@example.com")
+	}))
+	defer server.Close()
+
+	middleware, err := NewAuthenticator(&types.Auth{
+		Forward: &types.Forward{
+			Address:             server.URL,
+			AuthResponseHeaders: []string{"X-Auth-User"},
+			Cache: &types.ForwardCache{
+				KeyHeaders:  []string{"Authorization"},
+				TTL:         parse.Duration(time.Minute),
+				NegativeTTL: parse.Duration(time.Minute),
+			},
+		},
+	}, &tracing.Tracing{})
+	require.NoError(t, err)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, r.Header.Get("X-Auth-User"))
+	})
+	n := negroni.New(middleware)
+	n.UseHandler(handler)
+	ts := httptest.NewServer(n)
+	defer ts.Close()
+
+	testCases := []struct {
+		authorization      string
+		expectedStatusCode int
+		expectedBody       string
+		expectedCalls      int
+	}{
+		{authorization: "valid", expectedStatusCode: http.StatusOK, expectedBody: "user@example.com", expectedCalls: 1},
+		{authorization: "valid", expectedStatusCode: http.StatusOK, expectedBody: "user@example.com", expectedCalls: 1},
+		{authorization: "invalid", expectedStatusCode: http.StatusForbidden, expectedBody: "Forbidden\n", expectedCalls: 2},
+		{authorization: "invalid", expectedStatusCode: http.StatusForbidden, expectedBody: "Forbidden\n", expectedCalls: 2},
+		{authorization: "", expectedStatusCode: http.StatusForbidden, expectedBody: "Forbidden\n", expectedCalls: 3},
+		{authorization: "", expectedStatusCode: http.StatusForbidden, expectedBody: "Forbidden\n", expectedCalls: 4},
+	}
+
+	for _, test := range testCases {
+		req := testhelpers.MustNewRequest(http.MethodGet, ts.URL, nil)
+		if test.authorization != "" {
+			req.Header.Set("Authorization", test.authorization)
+		}
+
+		res, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		assert.Equal(t, test.expectedStatusCode, res.StatusCode)
+
+		body, err := ioutil.ReadAll(res.Body)
+		require.NoError(t, err)
+		assert.Equal(t, test.expectedBody, string(body))
+		assert.Equal(t, test.expectedCalls, calls)
+	}
+}
+
+func TestForwardAuthCacheURI(t *testing.T) {
+	var calls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if r.Header.Get("X-Forwarded-Uri") != "/public" {
+			http.Error(w, "Forbidden", http.StatusForbidden)
+		}
+	}))
+	defer server.Close()
+
+	middleware, err := NewAuthenticator(&types.Auth{
+		Forward: &types.Forward{
+			Address: server.URL,
+			Cache: &types.ForwardCache{
+				KeyHeaders:  []string{"Authorization"},
+				TTL:         parse.Duration(time.Minute),
+				NegativeTTL: parse.Duration(time.Minute),
+			},
+		},
+	}, &tracing.Tracing{})
+	require.NoError(t, err)
+
+	n := negroni.New(middleware)
+	n.UseHandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+	ts := httptest.NewServer(n)
+	defer ts.Close()
+
+	testCases := []struct {
+		path               string
+		expectedStatusCode int
+		expectedCalls      int
+	}{
+		{path: "/public", expectedStatusCode: http.StatusOK, expectedCalls: 1},
+		{path: "/admin", expectedStatusCode: http.StatusForbidden, expectedCalls: 2},
+		{path: "/public", expectedStatusCode: http.StatusOK, expectedCalls: 2},
+		{path: "/admin", expectedStatusCode: http.StatusForbidden, expectedCalls: 2},
+	}
+
+	for _, test := range testCases {
+		req := testhelpers.MustNewRequest(http.MethodGet, ts.URL+test.path, nil)
+		req.Header.Set("Authorization", "token")
+
+		res, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		res.Body.Close()
+
+		assert.Equal(t, test.expectedStatusCode, res.StatusCode, test.path)
+		assert.Equal(t, test.expectedCalls, calls, test.path)
+	}
+}
+
 func Test_writeHeader(t *testing.T) {
 	testCases := []struct {
 		name                      string
 		headers                   map[string]string
 		trustForwardHeader        bool
+		allowedHeaders            []string
 		emptyHost                 bool
 		expectedHeaders           map[string]string
 		checkForUnexpectedHeaders bool
@@ -358,6 +559,23 @@ func Test_writeHeader(t *testing.T) {
 			},
 			checkForUnexpectedHeaders: true,
 		},
+		{
+			name: "only allowed headers",
+			headers: map[string]string{
+				"Accept":         "application/json",
+				"Authorization":  "Bearer token",
+				"X-CustomHeader": "CustomHeader",
+			},
+			allowedHeaders: []string{"authorization"},
+			expectedHeaders: map[string]string{
+				"Authorization":      "Bearer token",
+				"X-Forwarded-Proto":  "http",
+				"X-Forwarded-Host":   "foo.bar",
+				"X-Forwarded-Uri":    "/path?q=1",
+				"X-Forwarded-Method": "GET",
+			},
+			checkForUnexpectedHeaders: true,
+		},
 	}
 
 	for _, test := range testCases {
@@ -374,7 +592,7 @@ func Test_writeHeader(t *testing.T) {
 
 			forwardReq := testhelpers.MustNewRequest(http.MethodGet, "http://foo.bar/path?q=1", nil)
 
-			writeHeader(req, forwardReq, test.trustForwardHeader)
+			writeHeader(req, forwardReq, test.trustForwardHeader, test.allowedHeaders)
 
 			actualHeaders := forwardReq.Header
 			expectedHeaders := test.expectedHeaders
@@ -0,0 +1,291 @@
+package middlewares
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/containous/traefik/log"
+	"github.com/containous/traefik/types"
+)
+
+// DefaultBodyRewriteMaxBodySize is the default maximum size of the rewritten bodies.
+const DefaultBodyRewriteMaxBodySize = 1 << 20
+
+// DefaultBodyRewriteContentTypes are the content types rewritten by default.
+var DefaultBodyRewriteContentTypes = []string{"text/html", "application/json", "application/javascript", "text/javascript"}
+
+// bodyTemplateData holds the request data available in the body replacements.
+type bodyTemplateData struct {
+	Prefix string
+	Host   string
+}
+
+type bodyReplacement struct {
+	search      []byte
+	regex       *regexp.Regexp
+	replacement string
+	tmpl        *template.Template
+}
+
+// BodyRewriter rewrites the bodies of the responses
+type BodyRewriter struct {
+	contentTypes []string
+	maxBodySize  int64
+	replacements []*bodyReplacement
+}
+
+// NewBodyRewriter constructs a new BodyRewriter from the body rewrite configuration
+func NewBodyRewriter(config *types.BodyRewrite) (*BodyRewriter, error) {
+	if config == nil {
+		return nil, nil
+	}
+
+	if len(config.Replacements) == 0 {
+		return nil, errors.New("no body replacement provided")
+	}
+
+	rewriter := &BodyRewriter{
+		contentTypes: DefaultBodyRewriteContentTypes,
+		maxBodySize:  config.MaxBodySize,
+	}
+
+	if len(config.ContentTypes) > 0 {
+		rewriter.contentTypes = nil
+		for _, contentType := range config.ContentTypes {
+			rewriter.contentTypes = append(rewriter.contentTypes, strings.ToLower(strings.TrimSpace(contentType)))
+		}
+	}
+
+	if rewriter.maxBodySize <= 0 {
+		rewriter.maxBodySize = DefaultBodyRewriteMaxBodySize
+	}
+
+	for i, replacement := range config.Replacements {
+		r, err := newBodyReplacement(replacement)
+		if err != nil {
+			return nil, fmt.Errorf("invalid body replacement %d: %v", i, err)
+		}
+		rewriter.replacements = append(rewriter.replacements, r)
+	}
+
+	return rewriter, nil
+}
+
+func newBodyReplacement(replacement types.BodyReplacement) (*bodyReplacement, error) {
+	if (len(replacement.Search) > 0) == (len(replacement.Regex) > 0) {
+		return nil, errors.New("exactly one of search or regex must be provided")
+	}
+
+	r := &bodyReplacement{
+		replacement: replacement.Replacement,
+	}
+
+	if len(replacement.Regex) > 0 {
+		regex, err := regexp.Compile(replacement.Regex)
+		if err != nil {
+			return nil, err
+		}
+		r.regex = regex
+	} else {
+		r.search = []byte(replacement.Search)
+	}
+
+	if strings.Contains(replacement.Replacement, "{{") {
+		tmpl, err := template.New("replacement").Option("missingkey=zero").Parse(replacement.Replacement)
+		if err != nil {
+			return nil, err
+		}
+		r.tmpl = tmpl
+	}
+
+	return r, nil
+}
+
+func (r *bodyReplacement) apply(body []byte, data *bodyTemplateData) ([]byte, error) {
+	replacement := r.replacement
+	if r.tmpl != nil {
+		var buf bytes.Buffer
+		if err := r.tmpl.Execute(&buf, data); err != nil {
+			return nil, err
+		}
+		replacement = buf.String()
+	}
+
+	if r.regex != nil {
+		return r.regex.ReplaceAll(body, []byte(replacement)), nil
+	}
+	return bytes.Replace(body, r.search, []byte(replacement), -1), nil
+}
+
+// ModifyResponseBody applies the replacements to the body of the response.
+// The bodies are read up to the maximum size, the larger ones and the event streams are streamed unmodified.
+func (b *BodyRewriter) ModifyResponseBody(res *http.Response) error {
+	if !b.isRewritable(res) {
+		return nil
+	}
+
+	encoded, err := ioutil.ReadAll(io.LimitReader(res.Body, b.maxBodySize+1))
+	if err != nil {
+		return err
+	}
+
+	if int64(len(encoded)) > b.maxBodySize {
+		log.Debugf("Response body larger than %d bytes, not rewritten", b.maxBodySize)
+		res.Body = &readCloser{Reader: io.MultiReader(bytes.NewReader(encoded), res.Body), Closer: res.Body}
+		return nil
+	}
+
+	if err := res.Body.Close(); err != nil {
+		log.Debugf("Error while closing the response body: %v", err)
+	}
+	res.Body = ioutil.NopCloser(bytes.NewReader(encoded))
+
+	gzipped := isGzip(res.Header)
+
+	body := encoded
+	if gzipped {
+		body, err = b.gunzip(encoded)
+		if err != nil {
+			log.Debugf("Response body not rewritten: %v", err)
+			return nil
+		}
+	}
+
+	rewritten := body
+	data := newBodyTemplateData(res.Request)
+	for _, replacement := range b.replacements {
+		rewritten, err = replacement.apply(rewritten, data)
+		if err != nil {
+			log.Errorf("Error while rendering a body replacement: %v", err)
+			return nil
+		}
+	}
+
+	if bytes.Equal(rewritten, body) {
+		return nil
+	}
+
+	if gzipped {
+		rewritten, err = gzipBytes(rewritten)
+		if err != nil {
+			return err
+		}
+	}
+
+	res.Body = ioutil.NopCloser(bytes.NewReader(rewritten))
+	res.ContentLength = int64(len(rewritten))
+	res.TransferEncoding = nil
+	res.Header.Set("Content-Length", strconv.Itoa(len(rewritten)))
+	// the validators of the original body don't apply to the rewritten one
+	res.Header.Del("Etag")
+	res.Header.Del("Content-Md5")
+
+	return nil
+}
+
+func (b *BodyRewriter) isRewritable(res *http.Response) bool {
+	// the bodies of an unknown length are read up to the maximum size, to know whether they can be rewritten
+	if res.Body == nil || res.Body == http.NoBody || res.ContentLength == 0 || res.ContentLength > b.maxBodySize {
+		return false
+	}
+
+	if res.Request != nil && res.Request.Method == http.MethodHead {
+		return false
+	}
+
+	switch {
+	case res.StatusCode < http.StatusOK,
+		res.StatusCode == http.StatusNoContent,
+		res.StatusCode == http.StatusPartialContent,
+		res.StatusCode == http.StatusNotModified:
+		return false
+	}
+
+	switch strings.ToLower(res.Header.Get("Content-Encoding")) {
+	case "", "identity", "gzip":
+	default:
+		return false
+	}
+
+	contentType, _, err := mime.ParseMediaType(res.Header.Get("Content-Type"))
+	if err != nil || contentType == "text/event-stream" {
+		return false
+	}
+
+	for _, ct := range b.contentTypes {
+		if ct == contentType || strings.HasSuffix(ct, "/*") && strings.HasPrefix(contentType, strings.TrimSuffix(ct, "*")) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (b *BodyRewriter) gunzip(encoded []byte) ([]byte, error) {
+	reader, err := gzip.NewReader(bytes.NewReader(encoded))
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	body, err := ioutil.ReadAll(io.LimitReader(reader, b.maxBodySize+1))
+	if err != nil {
+		return nil, err
+	}
+
+	if int64(len(body)) > b.maxBodySize {
+		return nil, fmt.Errorf("decompressed body larger than %d bytes", b.maxBodySize)
+	}
+
+	return body, nil
+}
+
+func gzipBytes(body []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	writer := gzip.NewWriter(&buf)
+	if _, err := writer.Write(body); err != nil {
+		return nil, err
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func isGzip(header http.Header) bool {
+	return strings.EqualFold(header.Get("Content-Encoding"), "gzip")
+}
+
+// newBodyTemplateData returns the data of the forwarded request.
+// The prefix is only trusted when it has been stripped by Traefik, and not sent by the client.
+func newBodyTemplateData(req *http.Request) *bodyTemplateData {
+	data := &bodyTemplateData{}
+	if req == nil {
+		return data
+	}
+
+	data.Host = req.Host
+
+	if _, ok := req.Context().Value(StripPrefixKey).(string); ok {
+		if prefixes := req.Header[ForwardedPrefixHeader]; len(prefixes) > 0 {
+			data.Prefix = strings.TrimSuffix(prefixes[len(prefixes)-1], "/")
+		}
+	}
+
+	return data
+}
+
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
@@ -0,0 +1,428 @@
+package middlewares
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/containous/traefik/testhelpers"
+	"github.com/containous/traefik/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vulcand/oxy/forward"
+)
+
+func TestNewBodyRewriterInvalid(t *testing.T) {
+	testCases := []struct {
+		desc   string
+		config *types.BodyRewrite
+	}{
+		{
+			desc:   "no replacement",
+			config: &types.BodyRewrite{ContentTypes: []string{"text/html"}},
+		},
+		{
+			desc: "no search nor regex",
+			config: &types.BodyRewrite{
+				Replacements: []types.BodyReplacement{{Replacement: "foo"}},
+			},
+		},
+		{
+			desc: "search and regex",
+			config: &types.BodyRewrite{
+				Replacements: []types.BodyReplacement{{Search: "foo", Regex: "foo", Replacement: "bar"}},
+			},
+		},
+		{
+			desc: "invalid regex",
+			config: &types.BodyRewrite{
+				Replacements: []types.BodyReplacement{{Regex: "(foo", Replacement: "bar"}},
+			},
+		},
+		{
+			desc: "invalid template",
+			config: &types.BodyRewrite{
+				Replacements: []types.BodyReplacement{{Search: "foo", Replacement: "{{ .Prefix"}},
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := NewBodyRewriter(test.config)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func gzipString(t *testing.T, s string) string {
+	t.Helper()
+
+	var buf bytes.Buffer
+	writer := gzip.NewWriter(&buf)
+	_, err := writer.Write([]byte(s))
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+
+	return buf.String()
+}
+
+func gunzipString(t *testing.T, s string) string {
+	t.Helper()
+
+	reader, err := gzip.NewReader(strings.NewReader(s))
+	require.NoError(t, err)
+
+	body, err := ioutil.ReadAll(reader)
+	require.NoError(t, err)
+
+	return string(body)
+}
+
+func TestBodyRewriter(t *testing.T) {
+	prefixReplacement := types.BodyReplacement{
+		Regex:       `(href|src)="/`,
+		Replacement: `$1="{{ .Prefix }}/`,
+	}
+
+	testCases := []struct {
+		desc             string
+		config           *types.BodyRewrite
+		method           string
+		statusCode       int
+		header           http.Header
+		body             string
+		strippedPrefix   string
+		clientPrefix     string
+		gzip             bool
+		unknownLength    bool
+		expectedBody     string
+		expectedModified bool
+	}{
+		{
+			desc: "search and replace",
+			config: &types.BodyRewrite{
+				Replacements: []types.BodyReplacement{
+					{Search: "foo", Replacement: "bar"},
+					{Search: "bar", Replacement: "baz"},
+				},
+			},
+			header:           http.Header{"Content-Type": {"text/html; charset=utf-8"}},
+			body:             "<p>foo</p>",
+			expectedBody:     "<p>baz</p>",
+			expectedModified: true,
+		},
+		{
+			desc: "regex with the stripped prefix",
+			config: &types.BodyRewrite{
+				Replacements: []types.BodyReplacement{prefixReplacement},
+			},
+			header:           http.Header{"Content-Type": {"text/html"}},
+			body:             `<a href="/foo">foo</a><img src="/bar.png">`,
+			strippedPrefix:   "/app/",
+			expectedBody:     `<a href="/app/foo">foo</a><img src="/app/bar.png">`,
+			expectedModified: true,
+		},
+		{
+			desc: "prefix sent by the client is ignored",
+			config: &types.BodyRewrite{
+				Replacements: []types.BodyReplacement{prefixReplacement},
+			},
+			header:       http.Header{"Content-Type": {"text/html"}},
+			body:         `<a href="/foo">foo</a>`,
+			clientPrefix: "/evil",
+			expectedBody: `<a href="/foo">foo</a>`,
+		},
+		{
+			desc: "gzip body",
+			config: &types.BodyRewrite{
+				Replacements: []types.BodyReplacement{{Search: "foo", Replacement: "bar"}},
+			},
+			header:           http.Header{"Content-Type": {"application/json"}, "Content-Encoding": {"gzip"}},
+			body:             `{"foo": "foo"}`,
+			gzip:             true,
+			expectedBody:     `{"bar": "bar"}`,
+			expectedModified: true,
+		},
+		{
+			desc: "content type not rewritten",
+			config: &types.BodyRewrite{
+				Replacements: []types.BodyReplacement{{Search: "foo", Replacement: "bar"}},
+			},
+			header:       http.Header{"Content-Type": {"image/png"}},
+			body:         "foo",
+			expectedBody: "foo",
+		},
+		{
+			desc: "content type wildcard",
+			config: &types.BodyRewrite{
+				ContentTypes: []string{"text/*"},
+				Replacements: []types.BodyReplacement{{Search: "foo", Replacement: "bar"}},
+			},
+			header:           http.Header{"Content-Type": {"text/css"}},
+			body:             "foo",
+			expectedBody:     "bar",
+			expectedModified: true,
+		},
+		{
+			desc: "unsupported content encoding",
+			config: &types.BodyRewrite{
+				Replacements: []types.BodyReplacement{{Search: "foo", Replacement: "bar"}},
+			},
+			header:       http.Header{"Content-Type": {"text/html"}, "Content-Encoding": {"br"}},
+			body:         "foo",
+			expectedBody: "foo",
+		},
+		{
+			desc: "body larger than the maximum size",
+			config: &types.BodyRewrite{
+				MaxBodySize:  5,
+				Replacements: []types.BodyReplacement{{Search: "foo", Replacement: "bar"}},
+			},
+			header:       http.Header{"Content-Type": {"text/html"}},
+			body:         "foo foo foo",
+			expectedBody: "foo foo foo",
+		},
+		{
+			desc: "HEAD request",
+			config: &types.BodyRewrite{
+				Replacements: []types.BodyReplacement{{Search: "foo", Replacement: "bar"}},
+			},
+			method:       http.MethodHead,
+			header:       http.Header{"Content-Type": {"text/html"}},
+			body:         "foo",
+			expectedBody: "foo",
+		},
+		{
+			desc: "partial content",
+			config: &types.BodyRewrite{
+				Replacements: []types.BodyReplacement{{Search: "foo", Replacement: "bar"}},
+			},
+			statusCode:   http.StatusPartialContent,
+			header:       http.Header{"Content-Type": {"text/html"}},
+			body:         "foo",
+			expectedBody: "foo",
+		},
+		{
+			desc: "unknown length",
+			config: &types.BodyRewrite{
+				Replacements: []types.BodyReplacement{{Search: "foo", Replacement: "bar"}},
+			},
+			header:           http.Header{"Content-Type": {"text/html"}},
+			body:             "foo",
+			unknownLength:    true,
+			expectedBody:     "bar",
+			expectedModified: true,
+		},
+		{
+			desc: "unknown length larger than the maximum size",
+			config: &types.BodyRewrite{
+				MaxBodySize:  5,
+				Replacements: []types.BodyReplacement{{Search: "foo", Replacement: "bar"}},
+			},
+			header:        http.Header{"Content-Type": {"text/html"}},
+			body:          "foo foo foo",
+			unknownLength: true,
+			expectedBody:  "foo foo foo",
+		},
+		{
+			desc: "event stream",
+			config: &types.BodyRewrite{
+				ContentTypes: []string{"text/*"},
+				Replacements: []types.BodyReplacement{{Search: "foo", Replacement: "bar"}},
+			},
+			header:       http.Header{"Content-Type": {"text/event-stream"}},
+			body:         "data: foo\n\n",
+			expectedBody: "data: foo\n\n",
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			rewriter, err := NewBodyRewriter(test.config)
+			require.NoError(t, err)
+
+			method := test.method
+			if len(method) == 0 {
+				method = http.MethodGet
+			}
+
+			req := httptest.NewRequest(method, "http://foo.com/", nil)
+			if len(test.clientPrefix) > 0 {
+				req.Header.Add(ForwardedPrefixHeader, test.clientPrefix)
+			}
+			if len(test.strippedPrefix) > 0 {
+				req.Header.Add(ForwardedPrefixHeader, test.strippedPrefix)
+				req = req.WithContext(context.WithValue(req.Context(), StripPrefixKey, test.strippedPrefix+"foo"))
+			}
+
+			statusCode := test.statusCode
+			if statusCode == 0 {
+				statusCode = http.StatusOK
+			}
+
+			body := test.body
+			if test.gzip {
+				body = gzipString(t, body)
+			}
+
+			header := test.header
+			header.Set("Etag", `"foo"`)
+
+			contentLength := int64(len(body))
+			if test.unknownLength {
+				contentLength = -1
+			}
+
+			res := &http.Response{
+				StatusCode:    statusCode,
+				Header:        header,
+				Body:          ioutil.NopCloser(strings.NewReader(body)),
+				ContentLength: contentLength,
+				Request:       req,
+			}
+
+			err = rewriter.ModifyResponseBody(res)
+			require.NoError(t, err)
+
+			rewritten, err := ioutil.ReadAll(res.Body)
+			require.NoError(t, err)
+
+			if test.gzip {
+				assert.Equal(t, test.expectedBody, gunzipString(t, string(rewritten)))
+			} else {
+				assert.Equal(t, test.expectedBody, string(rewritten))
+			}
+
+			if test.expectedModified {
+				assert.EqualValues(t, len(rewritten), res.ContentLength)
+				assert.Empty(t, res.Header.Get("Etag"))
+			} else {
+				assert.Equal(t, `"foo"`, res.Header.Get("Etag"))
+			}
+		})
+	}
+}
+
+func TestBodyRewriterChunked(t *testing.T) {
+	testCases := []struct {
+		desc                     string
+		maxBodySize              int64
+		expectedBody             string
+		expectedContentLength    int64
+		expectedTransferEncoding []string
+	}{
+		{
+			desc:                  "rewritten",
+			expectedBody:          "<p>bar</p><p>bar</p>",
+			expectedContentLength: 20,
+		},
+		{
+			desc:                     "larger than the maximum size",
+			maxBodySize:              15,
+			expectedBody:             "<p>foo</p><p>foo</p>",
+			expectedContentLength:    -1,
+			expectedTransferEncoding: []string{"chunked"},
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			rewriter, err := NewBodyRewriter(&types.BodyRewrite{
+				MaxBodySize:  test.maxBodySize,
+				Replacements: []types.BodyReplacement{{Search: "foo", Replacement: "bar"}},
+			})
+			require.NoError(t, err)
+
+			backend := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				rw.Header().Set("Content-Type", "text/html")
+				rw.Write([]byte("<p>foo</p>"))
+				rw.(http.Flusher).Flush()
+				rw.Write([]byte("<p>foo</p>"))
+			}))
+			defer backend.Close()
+
+			fwd, err := forward.New(forward.Stream(true), forward.ResponseModifier(rewriter.ModifyResponseBody))
+			require.NoError(t, err)
+
+			proxy := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				req.URL = testhelpers.MustParseURL(backend.URL)
+				fwd.ServeHTTP(rw, req)
+			}))
+			defer proxy.Close()
+
+			resp, err := http.Get(proxy.URL)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+
+			body, err := ioutil.ReadAll(resp.Body)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expectedBody, string(body))
+			assert.Equal(t, test.expectedContentLength, resp.ContentLength)
+			assert.Equal(t, test.expectedTransferEncoding, resp.TransferEncoding)
+		})
+	}
+}
+
+func TestBodyRewriterEventStream(t *testing.T) {
+	rewriter, err := NewBodyRewriter(&types.BodyRewrite{
+		ContentTypes: []string{"text/*"},
+		Replacements: []types.BodyReplacement{{Search: "foo", Replacement: "bar"}},
+	})
+	require.NoError(t, err)
+
+	next := make(chan struct{})
+	backend := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.Header().Set("Content-Type", "text/event-stream")
+		rw.Write([]byte("data: foo 1\n"))
+		rw.(http.Flusher).Flush()
+
+		// the second event is only sent once the first one is received by the client
+		<-next
+		rw.Write([]byte("data: foo 2\n"))
+	}))
+	defer backend.Close()
+
+	fwd, err := forward.New(forward.Stream(true), forward.ResponseModifier(rewriter.ModifyResponseBody))
+	require.NoError(t, err)
+
+	proxy := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		req.URL = testhelpers.MustParseURL(backend.URL)
+		fwd.ServeHTTP(rw, req)
+	}))
+	defer proxy.Close()
+
+	resp, err := http.Get(proxy.URL)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, []string{"chunked"}, resp.TransferEncoding)
+
+	reader := bufio.NewReader(resp.Body)
+
+	line, err := reader.ReadString('\n')
+	require.NoError(t, err)
+	assert.Equal(t, "data: foo 1\n", line)
+
+	close(next)
+
+	line, err = reader.ReadString('\n')
+	require.NoError(t, err)
+	assert.Equal(t, "data: foo 2\n", line)
+}
@@ -0,0 +1,252 @@
+package bodyrate
+
+import (
+	"io"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/containous/traefik/log"
+	"github.com/go-kit/kit/metrics"
+)
+
+// DefaultGracePeriod is the duration of a request body transfer before the minimum rate is enforced.
+const DefaultGracePeriod = 5 * time.Second
+
+// reasonRequestBodyRate is the reason of the rejections, used as label of the metrics.
+const reasonRequestBodyRate = "request_body_rate"
+
+// Conns keeps the open connections of an HTTP server by remote address,
+// for the middleware to find the connection of a request and set its read deadline.
+type Conns struct {
+	lock  sync.Mutex
+	addrs map[net.Conn]string
+	conns map[string][]net.Conn
+}
+
+// NewConns creates an empty set of connections.
+func NewConns() *Conns {
+	return &Conns{
+		addrs: make(map[net.Conn]string),
+		conns: make(map[string][]net.Conn),
+	}
+}
+
+// ConnState records the connections once active, and forgets them when closed or hijacked.
+// It is meant to be called from the ConnState of the HTTP server.
+func (c *Conns) ConnState(conn net.Conn, state http.ConnState) {
+	switch state {
+	case http.StateActive:
+		c.lock.Lock()
+		_, ok := c.addrs[conn]
+		c.lock.Unlock()
+		if ok {
+			return
+		}
+
+		// the remote address of a PROXY protocol connection is only known once its header is read,
+		// which is why the connections are not recorded when accepted
+		addr := conn.RemoteAddr().String()
+
+		c.lock.Lock()
+		c.addrs[conn] = addr
+		c.conns[addr] = append(c.conns[addr], conn)
+		c.lock.Unlock()
+	case http.StateHijacked, http.StateClosed:
+		c.lock.Lock()
+		defer c.lock.Unlock()
+
+		addr, ok := c.addrs[conn]
+		if !ok {
+			return
+		}
+
+		delete(c.addrs, conn)
+		conns := c.conns[addr]
+		for i, cn := range conns {
+			if cn == conn {
+				conns = append(conns[:i], conns[i+1:]...)
+				break
+			}
+		}
+		if len(conns) == 0 {
+			delete(c.conns, addr)
+		} else {
+			c.conns[addr] = conns
+		}
+	}
+}
+
+// get returns the connection of the remote address, unless several connections share it (as the clients of a Unix domain socket do).
+func (c *Conns) get(remoteAddr string) (net.Conn, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	conns := c.conns[remoteAddr]
+	if len(conns) != 1 {
+		return nil, false
+	}
+	return conns[0], true
+}
+
+// MinRate is a middleware disconnecting the clients sending a request body below a minimum average rate.
+// The rate is enforced once the grace period is over, through the read deadline of the connection.
+type MinRate struct {
+	conns           *Conns
+	rate            int64
+	gracePeriod     time.Duration
+	readTimeout     time.Duration
+	rejectedCounter metrics.Counter
+}
+
+// NewMinRate creates a minimum rate middleware, the rate being in bytes per second.
+// The connections of the requests are taken from conns, which the server must keep up to date.
+// The read timeout of the server still bounds the reading of the request.
+// The rejected requests are counted with the counter (which can be nil).
+func NewMinRate(conns *Conns, rate int64, gracePeriod time.Duration, readTimeout time.Duration, rejectedCounter metrics.Counter) *MinRate {
+	if gracePeriod <= 0 {
+		gracePeriod = DefaultGracePeriod
+	}
+
+	return &MinRate{
+		conns:           conns,
+		rate:            rate,
+		gracePeriod:     gracePeriod,
+		readTimeout:     readTimeout,
+		rejectedCounter: rejectedCounter,
+	}
+}
+
+func (m *MinRate) ServeHTTP(rw http.ResponseWriter, req *http.Request, next http.HandlerFunc) {
+	// the connection of an HTTP/2 request is shared with the other streams
+	if req.ProtoMajor != 1 || req.Body == nil || req.Body == http.NoBody {
+		next(rw, req)
+		return
+	}
+
+	conn, ok := m.conns.get(req.RemoteAddr)
+	if !ok {
+		next(rw, req)
+		return
+	}
+
+	reader := &rateReader{
+		ReadCloser:  req.Body,
+		conn:        conn,
+		rate:        m.rate,
+		gracePeriod: m.gracePeriod,
+		start:       time.Now(),
+	}
+	if m.readTimeout > 0 {
+		reader.serverDeadline = reader.start.Add(m.readTimeout)
+	}
+
+	req.Body = reader
+	next(rw, req)
+
+	if reader.tooSlow() {
+		log.Debugf("Request body from %s sent below %d bytes per second", req.RemoteAddr, m.rate)
+		if m.rejectedCounter != nil {
+			m.rejectedCounter.With("reason", reasonRequestBodyRate).Add(1)
+		}
+	}
+}
+
+// rateReader moves the read deadline of the connection forward as the body is read,
+// so that the reads time out when the average rate falls below the minimum.
+type rateReader struct {
+	io.ReadCloser
+	conn           net.Conn
+	rate           int64
+	gracePeriod    time.Duration
+	start          time.Time
+	serverDeadline time.Time
+
+	lock     sync.Mutex
+	read     int64
+	deadline time.Time
+	done     bool
+	slow     bool
+}
+
+func (r *rateReader) Read(p []byte) (int, error) {
+	if !r.setDeadline() {
+		return r.ReadCloser.Read(p)
+	}
+
+	n, err := r.ReadCloser.Read(p)
+
+	r.lock.Lock()
+	r.read += int64(n)
+	if netErr, ok := err.(net.Error); ok && netErr.Timeout() && !r.deadline.Equal(r.serverDeadline) {
+		// the expired deadline is kept, for the server not to wait for the rest of the body before closing the connection
+		r.slow = true
+		r.done = true
+	}
+	r.lock.Unlock()
+
+	if err == io.EOF {
+		r.finish(false)
+	} else if err != nil {
+		r.finish(true)
+	}
+	return n, err
+}
+
+func (r *rateReader) Close() error {
+	r.finish(true)
+	return r.ReadCloser.Close()
+}
+
+// setDeadline sets the time by which the next bytes must be received, and returns false once the body is read.
+func (r *rateReader) setDeadline() bool {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if r.done {
+		return false
+	}
+
+	deadline := r.start.Add(r.gracePeriod)
+	if minDeadline := r.start.Add(time.Duration(float64(r.read) / float64(r.rate) * float64(time.Second))); minDeadline.After(deadline) {
+		deadline = minDeadline
+	}
+	if !r.serverDeadline.IsZero() && r.serverDeadline.Before(deadline) {
+		deadline = r.serverDeadline
+	}
+
+	r.deadline = deadline
+	if err := r.conn.SetReadDeadline(deadline); err != nil {
+		log.Debugf("Unable to set the read deadline of the connection: %v", err)
+	}
+	return true
+}
+
+// finish stops moving the read deadline, and restores the one of the server if the body is not read to the end.
+// Once the body is read, the server has already cleared the deadline for its background read,
+// which must not time out while the handler is still running.
+func (r *rateReader) finish(restore bool) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if r.done {
+		return
+	}
+
+	r.done = true
+	if !restore {
+		return
+	}
+
+	if err := r.conn.SetReadDeadline(r.serverDeadline); err != nil {
+		log.Debugf("Unable to set the read deadline of the connection: %v", err)
+	}
+}
+
+func (r *rateReader) tooSlow() bool {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	return r.slow
+}
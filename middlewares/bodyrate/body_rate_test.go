@@ -0,0 +1,212 @@
+package bodyrate
+
+import (
+	"bufio"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/containous/traefik/testhelpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMinRate(t *testing.T) {
+	testCases := []struct {
+		desc               string
+		gracePeriod        time.Duration
+		readTimeout        time.Duration
+		pause              time.Duration
+		expectedStatusCode int
+		expectedRejected   bool
+	}{
+		{
+			desc:               "body sent at once",
+			gracePeriod:        100 * time.Millisecond,
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			desc:               "pause within the grace period",
+			gracePeriod:        time.Second,
+			pause:              200 * time.Millisecond,
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			desc:               "pause after the grace period",
+			gracePeriod:        100 * time.Millisecond,
+			pause:              time.Second,
+			expectedStatusCode: http.StatusRequestTimeout,
+			expectedRejected:   true,
+		},
+		{
+			desc:               "read timeout of the server",
+			gracePeriod:        time.Second,
+			readTimeout:        100 * time.Millisecond,
+			pause:              500 * time.Millisecond,
+			expectedStatusCode: http.StatusRequestTimeout,
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			counter := &testhelpers.CollectingCounter{}
+			conns := NewConns()
+			minRate := NewMinRate(conns, 1000, test.gracePeriod, test.readTimeout, counter)
+
+			served := make(chan struct{})
+			backend := httptest.NewUnstartedServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				defer close(served)
+
+				minRate.ServeHTTP(rw, req, func(rw http.ResponseWriter, req *http.Request) {
+					body, err := ioutil.ReadAll(req.Body)
+					if err != nil {
+						rw.WriteHeader(http.StatusRequestTimeout)
+						return
+					}
+					fmt.Fprint(rw, len(body))
+				})
+			}))
+			backend.Config.ConnState = conns.ConnState
+			backend.Start()
+			defer backend.Close()
+
+			conn, err := net.Dial("tcp", backend.Listener.Addr().String())
+			require.NoError(t, err)
+			defer conn.Close()
+
+			_, err = fmt.Fprint(conn, "POST / HTTP/1.1\r\nHost: localhost\r\nContent-Length: 100\r\n\r\n"+strings.Repeat("a", 10))
+			require.NoError(t, err)
+
+			if test.pause > 0 {
+				time.Sleep(test.pause)
+			}
+
+			// the connection may be closed already
+			fmt.Fprint(conn, strings.Repeat("a", 90))
+
+			resp, err := http.ReadResponse(bufio.NewReader(conn), nil)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(t, test.expectedStatusCode, resp.StatusCode)
+
+			<-served
+			if test.expectedRejected {
+				assert.Equal(t, float64(1), counter.CounterValue)
+				assert.Equal(t, []string{"reason", reasonRequestBodyRate}, counter.LastLabelValues)
+			} else {
+				assert.Zero(t, counter.CounterValue)
+			}
+		})
+	}
+}
+
+func TestMinRateHandlerAfterBody(t *testing.T) {
+	conns := NewConns()
+	minRate := NewMinRate(conns, 1000, time.Second, 100*time.Millisecond, nil)
+
+	backend := httptest.NewUnstartedServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		minRate.ServeHTTP(rw, req, func(rw http.ResponseWriter, req *http.Request) {
+			body, err := ioutil.ReadAll(req.Body)
+			if err != nil {
+				rw.WriteHeader(http.StatusRequestTimeout)
+				return
+			}
+
+			// the handler outlives the read timeout once the body is read
+			time.Sleep(300 * time.Millisecond)
+			if req.Context().Err() != nil {
+				return
+			}
+			fmt.Fprint(rw, len(body))
+		})
+	}))
+	backend.Config.ConnState = conns.ConnState
+	backend.Start()
+	defer backend.Close()
+
+	resp, err := http.Post(backend.URL, "text/plain", strings.NewReader(strings.Repeat("a", 100)))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "100", string(body))
+}
+
+func TestConns(t *testing.T) {
+	conns := NewConns()
+
+	first := &fakeConn{addr: "@"}
+	second := &fakeConn{addr: "@"}
+	other := &fakeConn{addr: "10.0.0.1:1234"}
+
+	conns.ConnState(first, http.StateActive)
+	conns.ConnState(other, http.StateActive)
+	conns.ConnState(other, http.StateActive)
+
+	conn, ok := conns.get("@")
+	assert.True(t, ok)
+	assert.Equal(t, first, conn)
+
+	conn, ok = conns.get("10.0.0.1:1234")
+	assert.True(t, ok)
+	assert.Equal(t, other, conn)
+
+	// the connections sharing an address cannot be told apart
+	conns.ConnState(second, http.StateActive)
+	_, ok = conns.get("@")
+	assert.False(t, ok)
+
+	conns.ConnState(first, http.StateClosed)
+	conn, ok = conns.get("@")
+	assert.True(t, ok)
+	assert.Equal(t, second, conn)
+
+	conns.ConnState(second, http.StateHijacked)
+	conns.ConnState(other, http.StateClosed)
+	_, ok = conns.get("@")
+	assert.False(t, ok)
+	assert.Empty(t, conns.addrs)
+	assert.Empty(t, conns.conns)
+}
+
+type fakeConn struct {
+	net.Conn
+	addr string
+}
+
+func (c *fakeConn) RemoteAddr() net.Addr {
+	return fakeAddr(c.addr)
+}
+
+type fakeAddr string
+
+func (a fakeAddr) Network() string { return "fake" }
+func (a fakeAddr) String() string  { return string(a) }
+
+func TestMinRateWithoutConnection(t *testing.T) {
+	minRate := NewMinRate(NewConns(), 1000, 0, 0, nil)
+	assert.Equal(t, DefaultGracePeriod, minRate.gracePeriod)
+
+	req := httptest.NewRequest(http.MethodPost, "http://localhost", strings.NewReader("body"))
+	recorder := httptest.NewRecorder()
+
+	minRate.ServeHTTP(recorder, req, func(rw http.ResponseWriter, req *http.Request) {
+		body, err := ioutil.ReadAll(req.Body)
+		require.NoError(t, err)
+		fmt.Fprint(rw, string(body))
+	})
+
+	assert.Equal(t, "body", recorder.Body.String())
+}
@@ -0,0 +1,89 @@
+package drain
+
+import (
+	"net/http"
+	"sync"
+	"time"
+)
+
+// State holds the drain mode, during which the external load balancers are expected to move the traffic away.
+type State struct {
+	gracePeriod time.Duration
+
+	lock     sync.RWMutex
+	draining bool
+	since    time.Time
+}
+
+// NewState creates a drain state, closing the keep-alive connections once in drain mode for the grace period.
+// A zero grace period keeps the connections open.
+func NewState(gracePeriod time.Duration) *State {
+	return &State{gracePeriod: gracePeriod}
+}
+
+// Drain enters the drain mode, and returns false if already in drain mode.
+func (s *State) Drain() bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.draining {
+		return false
+	}
+
+	s.draining = true
+	s.since = time.Now()
+	return true
+}
+
+// Undrain leaves the drain mode, and returns false if not in drain mode.
+func (s *State) Undrain() bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if !s.draining {
+		return false
+	}
+
+	s.draining = false
+	s.since = time.Time{}
+	return true
+}
+
+// Draining returns whether in drain mode, and since when.
+func (s *State) Draining() (bool, time.Time) {
+	if s == nil {
+		return false, time.Time{}
+	}
+
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	return s.draining, s.since
+}
+
+// ClosingConnections returns whether the grace period of the drain mode is over.
+func (s *State) ClosingConnections() bool {
+	draining, since := s.Draining()
+	return draining && s.gracePeriod > 0 && time.Since(since) >= s.gracePeriod
+}
+
+// Handler asks the clients to close their keep-alive HTTP/1 connections once the grace period of the drain mode is over,
+// the in-flight requests still complete.
+type Handler struct {
+	state *State
+}
+
+// NewHandler creates a middleware closing the connections according to the drain state.
+func NewHandler(state *State) *Handler {
+	return &Handler{state: state}
+}
+
+func (h *Handler) ServeHTTP(rw http.ResponseWriter, req *http.Request, next http.HandlerFunc) {
+	// the Connection header is forbidden in HTTP/2, and its connections cannot be closed one by one:
+	// they are kept open until the clients or the idle timeout close them
+	if req.ProtoMajor == 1 && h.state.ClosingConnections() {
+		rw.Header().Set("Connection", "close")
+	}
+
+	next(rw, req)
+}
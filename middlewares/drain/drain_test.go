@@ -0,0 +1,111 @@
+package drain
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestState(t *testing.T) {
+	state := NewState(0)
+
+	draining, _ := state.Draining()
+	assert.False(t, draining)
+	assert.False(t, state.Undrain())
+
+	assert.True(t, state.Drain())
+	assert.False(t, state.Drain())
+
+	draining, since := state.Draining()
+	assert.True(t, draining)
+	assert.False(t, since.IsZero())
+
+	assert.True(t, state.Undrain())
+
+	draining, since = state.Draining()
+	assert.False(t, draining)
+	assert.True(t, since.IsZero())
+}
+
+func TestNilState(t *testing.T) {
+	var state *State
+
+	draining, _ := state.Draining()
+	assert.False(t, draining)
+	assert.False(t, state.ClosingConnections())
+}
+
+func TestHandler(t *testing.T) {
+	testCases := []struct {
+		desc            string
+		gracePeriod     time.Duration
+		drain           bool
+		http2           bool
+		expectedClosing bool
+	}{
+		{
+			desc: "not draining",
+		},
+		{
+			desc:        "draining without grace period",
+			gracePeriod: 0,
+			drain:       true,
+		},
+		{
+			desc:        "draining during the grace period",
+			gracePeriod: time.Hour,
+			drain:       true,
+		},
+		{
+			desc:            "draining after the grace period",
+			gracePeriod:     time.Nanosecond,
+			drain:           true,
+			expectedClosing: true,
+		},
+		{
+			desc:        "draining HTTP/2 after the grace period",
+			gracePeriod: time.Nanosecond,
+			drain:       true,
+			http2:       true,
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			state := NewState(test.gracePeriod)
+			if test.drain {
+				state.Drain()
+				time.Sleep(time.Millisecond)
+			}
+
+			handler := NewHandler(state)
+
+			recorder := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "http://localhost", nil)
+			if test.http2 {
+				req.Proto, req.ProtoMajor, req.ProtoMinor = "HTTP/2.0", 2, 0
+			}
+
+			called := false
+			handler.ServeHTTP(recorder, req, func(rw http.ResponseWriter, req *http.Request) {
+				called = true
+				rw.WriteHeader(http.StatusOK)
+			})
+
+			assert.True(t, called, "the in-flight request should complete")
+			assert.Equal(t, http.StatusOK, recorder.Code)
+
+			if test.expectedClosing {
+				assert.Equal(t, "close", recorder.Header().Get("Connection"))
+			} else {
+				assert.Empty(t, recorder.Header().Get("Connection"))
+			}
+		})
+	}
+}
@@ -5,14 +5,20 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+	htmltemplate "html/template"
+	"io"
+	"io/ioutil"
+	"mime"
 	"net"
 	"net/http"
 	"net/url"
 	"strconv"
 	"strings"
+	"text/template"
 
 	"github.com/containous/traefik/log"
 	"github.com/containous/traefik/middlewares"
+	"github.com/containous/traefik/middlewares/requestid"
 	"github.com/containous/traefik/types"
 	"github.com/vulcand/oxy/forward"
 	"github.com/vulcand/oxy/utils"
@@ -21,6 +27,22 @@ import (
 // Compile time validation that the response recorder implements http interfaces correctly.
 var _ middlewares.Stateful = &responseRecorderWithCloseNotify{}
 
+// DefaultContentType is the content type of the error pages rendered from a template, when none is configured.
+const DefaultContentType = "text/html; charset=utf-8"
+
+// pageTemplate is implemented by both the text and HTML templates.
+type pageTemplate interface {
+	Execute(w io.Writer, data interface{}) error
+}
+
+// pageData holds the data available in the error page templates.
+type pageData struct {
+	StatusCode int
+	StatusText string
+	RequestID  string
+	Host       string
+}
+
 // Handler is a middleware that provides the custom error pages
 type Handler struct {
 	BackendName    string
@@ -28,13 +50,16 @@ type Handler struct {
 	httpCodeRanges types.HTTPCodeRanges
 	backendURL     string
 	backendQuery   string
+	template       pageTemplate
+	contentType    string
 	FallbackURL    string // Deprecated
 }
 
-// NewHandler initializes the utils.ErrorHandler for the custom error pages
+// NewHandler initializes the utils.ErrorHandler for the custom error pages.
+// The backend name can be empty when the error page is rendered from a template.
 func NewHandler(errorPage *types.ErrorPage, backendName string) (*Handler, error) {
-	if len(backendName) == 0 {
-		return nil, errors.New("error pages: backend name is mandatory ")
+	if len(backendName) == 0 && !errorPage.HasTemplate() {
+		return nil, errors.New("error pages: backend name or template is mandatory")
 	}
 
 	httpCodeRanges, err := types.NewHTTPCodeRanges(errorPage.Status)
@@ -42,12 +67,53 @@ func NewHandler(errorPage *types.ErrorPage, backendName string) (*Handler, error
 		return nil, err
 	}
 
-	return &Handler{
+	h := &Handler{
 		BackendName:    backendName,
 		httpCodeRanges: httpCodeRanges,
 		backendQuery:   errorPage.Query,
 		backendURL:     "http://0.0.0.0",
-	}, nil
+		contentType:    errorPage.ContentType,
+	}
+
+	if len(h.contentType) == 0 {
+		h.contentType = DefaultContentType
+	}
+
+	if errorPage.HasTemplate() {
+		h.template, err = newPageTemplate(errorPage, h.contentType)
+		if err != nil {
+			return nil, fmt.Errorf("error pages: %v", err)
+		}
+	}
+
+	return h, nil
+}
+
+// newPageTemplate parses the template of the error page.
+// The HTML pages are escaped, as the host and the request ID can come from the client.
+func newPageTemplate(errorPage *types.ErrorPage, contentType string) (pageTemplate, error) {
+	if len(errorPage.File) > 0 && len(errorPage.Body) > 0 {
+		return nil, errors.New("only one of file or body can be used")
+	}
+
+	body := errorPage.Body
+	if len(errorPage.File) > 0 {
+		content, err := ioutil.ReadFile(errorPage.File)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read the template: %v", err)
+		}
+		body = string(content)
+	}
+
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return nil, fmt.Errorf("invalid content type %q: %v", contentType, err)
+	}
+
+	if mediaType == "text/html" {
+		return htmltemplate.New("errorPage").Option("missingkey=zero").Parse(body)
+	}
+	return template.New("errorPage").Option("missingkey=zero").Parse(body)
 }
 
 // PostLoad adds backend handler if available
@@ -68,7 +134,7 @@ func (h *Handler) PostLoad(backendHandler http.Handler) error {
 }
 
 func (h *Handler) ServeHTTP(w http.ResponseWriter, req *http.Request, next http.HandlerFunc) {
-	if h.backendHandler == nil {
+	if h.backendHandler == nil && h.template == nil {
 		log.Error("Error pages: no backend handler.")
 		next.ServeHTTP(w, req)
 		return
@@ -82,31 +148,11 @@ func (h *Handler) ServeHTTP(w http.ResponseWriter, req *http.Request, next http.
 		if recorder.GetCode() >= block[0] && recorder.GetCode() <= block[1] {
 			log.Errorf("Caught HTTP Status Code %d, returning error page", recorder.GetCode())
 
-			var query string
-			if len(h.backendQuery) > 0 {
-				query = "/" + strings.TrimPrefix(h.backendQuery, "/")
-				query = strings.Replace(query, "{status}", strconv.Itoa(recorder.GetCode()), -1)
-			}
-
-			pageReq, err := newRequest(h.backendURL + query)
-			if err != nil {
-				log.Error(err)
-				w.WriteHeader(recorder.GetCode())
-				fmt.Fprint(w, http.StatusText(recorder.GetCode()))
+			if h.backendHandler != nil && h.serveBackendPage(w, req, recorder.GetCode()) {
 				return
 			}
 
-			recorderErrorPage := newResponseRecorder(w)
-			utils.CopyHeaders(pageReq.Header, req.Header)
-
-			h.backendHandler.ServeHTTP(recorderErrorPage, pageReq.WithContext(req.Context()))
-
-			utils.CopyHeaders(w.Header(), recorderErrorPage.Header())
-			w.WriteHeader(recorder.GetCode())
-
-			if _, err = w.Write(recorderErrorPage.GetBody().Bytes()); err != nil {
-				log.Error(err)
-			}
+			h.serveTemplatePage(w, req, recorder)
 			return
 		}
 	}
@@ -117,6 +163,78 @@ func (h *Handler) ServeHTTP(w http.ResponseWriter, req *http.Request, next http.
 	w.Write(recorder.GetBody().Bytes())
 }
 
+// serveBackendPage serves the error page fetched from the backend.
+// It returns false, without writing anything, when the backend is unavailable and the page can be rendered from the template instead.
+func (h *Handler) serveBackendPage(w http.ResponseWriter, req *http.Request, code int) bool {
+	var query string
+	if len(h.backendQuery) > 0 {
+		query = "/" + strings.TrimPrefix(h.backendQuery, "/")
+		query = strings.Replace(query, "{status}", strconv.Itoa(code), -1)
+	}
+
+	pageReq, err := newRequest(h.backendURL + query)
+	if err != nil {
+		log.Error(err)
+		if h.template != nil {
+			return false
+		}
+
+		w.WriteHeader(code)
+		fmt.Fprint(w, http.StatusText(code))
+		return true
+	}
+
+	recorderErrorPage := newResponseRecorder(w)
+	utils.CopyHeaders(pageReq.Header, req.Header)
+
+	h.backendHandler.ServeHTTP(recorderErrorPage, pageReq.WithContext(req.Context()))
+
+	if h.template != nil && recorderErrorPage.GetCode() >= http.StatusInternalServerError {
+		log.Errorf("Error pages: the backend %s answered with the status code %d, using the template", h.BackendName, recorderErrorPage.GetCode())
+		return false
+	}
+
+	utils.CopyHeaders(w.Header(), recorderErrorPage.Header())
+	w.WriteHeader(code)
+
+	if _, err = w.Write(recorderErrorPage.GetBody().Bytes()); err != nil {
+		log.Error(err)
+	}
+	return true
+}
+
+// serveTemplatePage serves the error page rendered from the template.
+// The headers of the original response are kept, except the ones describing its body.
+func (h *Handler) serveTemplatePage(w http.ResponseWriter, req *http.Request, recorder responseRecorder) {
+	code := recorder.GetCode()
+
+	data := &pageData{
+		StatusCode: code,
+		StatusText: http.StatusText(code),
+		RequestID:  requestid.FromContext(req.Context()),
+		Host:       req.Host,
+	}
+
+	var body bytes.Buffer
+	if err := h.template.Execute(&body, data); err != nil {
+		log.Errorf("Error pages: unable to render the template: %v", err)
+		body.Reset()
+		body.WriteString(http.StatusText(code))
+	}
+
+	utils.CopyHeaders(w.Header(), recorder.Header())
+	for _, header := range []string{"Content-Encoding", "Content-Length", "Content-Range", "Etag", "Last-Modified"} {
+		w.Header().Del(header)
+	}
+	w.Header().Set("Content-Type", h.contentType)
+	w.Header().Set("Content-Length", strconv.Itoa(body.Len()))
+	w.WriteHeader(code)
+
+	if _, err := w.Write(body.Bytes()); err != nil {
+		log.Error(err)
+	}
+}
+
 func newRequest(baseURL string) (*http.Request, error) {
 	u, err := url.Parse(baseURL)
 	if err != nil {
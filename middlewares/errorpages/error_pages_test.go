@@ -1,17 +1,24 @@
 package errorpages
 
 import (
+	"context"
 	"fmt"
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"strconv"
 	"testing"
 
+	"github.com/containous/traefik/middlewares"
+	"github.com/containous/traefik/middlewares/requestid"
 	"github.com/containous/traefik/testhelpers"
 	"github.com/containous/traefik/types"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/urfave/negroni"
+	"github.com/vulcand/oxy/roundrobin"
 )
 
 func TestHandler(t *testing.T) {
@@ -335,6 +342,187 @@ func TestHandlerOldWayIntegration(t *testing.T) {
 	}
 }
 
+func TestNewHandlerTemplateInvalid(t *testing.T) {
+	testCases := []struct {
+		desc      string
+		errorPage *types.ErrorPage
+	}{
+		{
+			desc:      "no backend nor template",
+			errorPage: &types.ErrorPage{Status: []string{"500-599"}},
+		},
+		{
+			desc:      "file and body",
+			errorPage: &types.ErrorPage{Status: []string{"500-599"}, File: "error.html", Body: "error"},
+		},
+		{
+			desc:      "missing file",
+			errorPage: &types.ErrorPage{Status: []string{"500-599"}, File: "/does/not/exist.html"},
+		},
+		{
+			desc:      "invalid template",
+			errorPage: &types.ErrorPage{Status: []string{"500-599"}, Body: "{{ .StatusCode"},
+		},
+		{
+			desc:      "invalid content type",
+			errorPage: &types.ErrorPage{Status: []string{"500-599"}, Body: "error", ContentType: "text/"},
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := NewHandler(test.errorPage, "")
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestHandlerTemplate(t *testing.T) {
+	dir, err := ioutil.TempDir("", "errorpages")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	file := filepath.Join(dir, "error.html")
+	err = ioutil.WriteFile(file, []byte("<h1>{{ .StatusCode }} {{ .StatusText }}</h1><p>{{ .Host }}</p>"), 0644)
+	require.NoError(t, err)
+
+	emptyBalancer, err := roundrobin.New(http.NotFoundHandler())
+	require.NoError(t, err)
+
+	testCases := []struct {
+		desc                string
+		errorPage           *types.ErrorPage
+		backendName         string
+		backendErrorHandler http.HandlerFunc
+		next                http.Handler
+		host                string
+		requestID           string
+		expectedCode        int
+		expectedBody        string
+		expectedHeader      http.Header
+	}{
+		{
+			desc:      "template file",
+			errorPage: &types.ErrorPage{Status: []string{"500-599"}, File: file},
+			next: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusInternalServerError)
+			}),
+			host:           "foo.com",
+			expectedCode:   http.StatusInternalServerError,
+			expectedBody:   "<h1>500 Internal Server Error</h1><p>foo.com</p>",
+			expectedHeader: http.Header{"Content-Type": {DefaultContentType}},
+		},
+		{
+			desc:      "HTML escaping",
+			errorPage: &types.ErrorPage{Status: []string{"500-599"}, File: file},
+			next: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusInternalServerError)
+			}),
+			host:         "<script>",
+			expectedCode: http.StatusInternalServerError,
+			expectedBody: "<h1>500 Internal Server Error</h1><p>&lt;script&gt;</p>",
+		},
+		{
+			desc: "inline JSON body",
+			errorPage: &types.ErrorPage{
+				Status:      []string{"400-499"},
+				Body:        `{"status": {{ .StatusCode }}, "requestId": "{{ .RequestID }}"}`,
+				ContentType: "application/json",
+			},
+			next: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Retry-After", "60")
+				w.Header().Set("Content-Type", "text/plain")
+				w.WriteHeader(http.StatusTooManyRequests)
+				fmt.Fprint(w, "slow down")
+			}),
+			requestID:      "foo",
+			expectedCode:   http.StatusTooManyRequests,
+			expectedBody:   `{"status": 429, "requestId": "foo"}`,
+			expectedHeader: http.Header{"Content-Type": {"application/json"}, "Retry-After": {"60"}},
+		},
+		{
+			desc:      "status not in the range",
+			errorPage: &types.ErrorPage{Status: []string{"500-599"}, Body: "error"},
+			next: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusNotFound)
+				fmt.Fprint(w, "not found")
+			}),
+			expectedCode: http.StatusNotFound,
+			expectedBody: "not found",
+		},
+		{
+			desc:         "error generated by Traefik",
+			errorPage:    &types.ErrorPage{Status: []string{"503"}, Body: "{{ .StatusCode }} no server available"},
+			next:         middlewares.NewEmptyBackendHandler(emptyBalancer),
+			expectedCode: http.StatusServiceUnavailable,
+			expectedBody: "503 no server available",
+		},
+		{
+			desc:        "backend page preferred to the template",
+			errorPage:   &types.ErrorPage{Status: []string{"500-599"}, Backend: "error", Body: "template"},
+			backendName: "error",
+			backendErrorHandler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				fmt.Fprint(w, "backend page")
+			}),
+			next: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusBadGateway)
+			}),
+			expectedCode: http.StatusBadGateway,
+			expectedBody: "backend page",
+		},
+		{
+			desc:        "template used when the backend is down",
+			errorPage:   &types.ErrorPage{Status: []string{"500-599"}, Backend: "error", Body: "template"},
+			backendName: "error",
+			backendErrorHandler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusBadGateway)
+				fmt.Fprint(w, "Bad Gateway")
+			}),
+			next: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusInternalServerError)
+			}),
+			expectedCode: http.StatusInternalServerError,
+			expectedBody: "template",
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			errorPageHandler, err := NewHandler(test.errorPage, test.backendName)
+			require.NoError(t, err)
+
+			if test.backendErrorHandler != nil {
+				errorPageHandler.backendHandler = test.backendErrorHandler
+			}
+
+			req := httptest.NewRequest(http.MethodGet, "http://localhost/test", nil)
+			if len(test.host) > 0 {
+				req.Host = test.host
+			}
+			if len(test.requestID) > 0 {
+				req = req.WithContext(context.WithValue(req.Context(), requestid.RequestIDKey, test.requestID))
+			}
+
+			n := negroni.New()
+			n.Use(errorPageHandler)
+			n.UseHandler(test.next)
+
+			recorder := httptest.NewRecorder()
+			n.ServeHTTP(recorder, req)
+
+			assert.Equal(t, test.expectedCode, recorder.Code)
+			assert.Equal(t, test.expectedBody, recorder.Body.String())
+			for name, values := range test.expectedHeader {
+				assert.Equal(t, values, recorder.Header()[name], name)
+			}
+		})
+	}
+}
+
 func TestNewResponseRecorder(t *testing.T) {
 	testCases := []struct {
 		desc     string
@@ -0,0 +1,224 @@
+package faultinjection
+
+import (
+	"crypto/tls"
+	"fmt"
+	"math"
+	"math/rand"
+	"net/http"
+	"time"
+
+	"github.com/containous/traefik/log"
+	"github.com/containous/traefik/middlewares/toggles"
+	"github.com/containous/traefik/middlewares/tracing"
+	"github.com/containous/traefik/tcp"
+	"github.com/containous/traefik/types"
+)
+
+const (
+	// DefaultAbortStatusCode is the default status code of the aborted requests.
+	DefaultAbortStatusCode = http.StatusServiceUnavailable
+
+	// DistributionFixed delays the requests by the configured delay.
+	DistributionFixed = "fixed"
+	// DistributionUniform delays the requests by a random delay between the configured delay and maximum delay.
+	DistributionUniform = "uniform"
+	// DistributionExponential delays the requests by a random delay, exponentially distributed around the configured delay.
+	DistributionExponential = "exponential"
+)
+
+// FaultInjection is a middleware delaying or aborting a percentage of the requests, to simulate the failures of the backend.
+type FaultInjection struct {
+	provider          string
+	frontend          string
+	enabled           bool
+	toggles           *toggles.Store
+	headerName        string
+	headerValue       string
+	delayPercentage   float64
+	delay             time.Duration
+	delayMax          time.Duration
+	delayDistribution string
+	abortPercentage   float64
+	abortStatusCode   int
+	abortReset        bool
+	random            func() float64
+}
+
+// NewFaultInjection creates a fault injection middleware for a frontend, from its configuration
+// and the toggles set at runtime.
+func NewFaultInjection(providerName, frontendName string, config *types.FaultInjection, toggleStore *toggles.Store) (*FaultInjection, error) {
+	f := &FaultInjection{
+		provider:          providerName,
+		frontend:          frontendName,
+		enabled:           config.Enabled,
+		toggles:           toggleStore,
+		headerName:        config.HeaderName,
+		headerValue:       config.HeaderValue,
+		delayPercentage:   config.DelayPercentage,
+		delay:             time.Duration(config.Delay),
+		delayMax:          time.Duration(config.DelayMax),
+		delayDistribution: config.DelayDistribution,
+		abortPercentage:   config.AbortPercentage,
+		abortStatusCode:   config.AbortStatusCode,
+		abortReset:        config.AbortReset,
+		random:            rand.Float64,
+	}
+
+	if f.delayPercentage < 0 || f.delayPercentage > 100 {
+		return nil, fmt.Errorf("invalid delay percentage %v", f.delayPercentage)
+	}
+	if f.abortPercentage < 0 || f.abortPercentage > 100 {
+		return nil, fmt.Errorf("invalid abort percentage %v", f.abortPercentage)
+	}
+
+	if f.delay < 0 || f.delayMax < 0 {
+		return nil, fmt.Errorf("invalid delay %s, maximum delay %s", f.delay, f.delayMax)
+	}
+
+	switch f.delayDistribution {
+	case "":
+		f.delayDistribution = DistributionFixed
+	case DistributionFixed, DistributionExponential:
+	case DistributionUniform:
+		if f.delayMax <= f.delay {
+			return nil, fmt.Errorf("the maximum delay (%s) must be greater than the delay (%s) with the uniform distribution", f.delayMax, f.delay)
+		}
+	default:
+		return nil, fmt.Errorf("unknown delay distribution %q", f.delayDistribution)
+	}
+
+	if f.abortStatusCode == 0 {
+		f.abortStatusCode = DefaultAbortStatusCode
+	}
+	if f.abortStatusCode < http.StatusOK || f.abortStatusCode > 599 {
+		return nil, fmt.Errorf("invalid abort status code %d", f.abortStatusCode)
+	}
+
+	return f, nil
+}
+
+// IsEnabled returns whether the faults are injected in the frontend.
+func (f *FaultInjection) IsEnabled() bool {
+	if enabled, ok := f.toggles.Get(f.provider, f.frontend); ok {
+		return enabled
+	}
+	return f.enabled
+}
+
+func (f *FaultInjection) ServeHTTP(rw http.ResponseWriter, req *http.Request, next http.HandlerFunc) {
+	if !f.IsEnabled() || !f.isTargeted(req) {
+		next.ServeHTTP(rw, req)
+		return
+	}
+
+	if delay := f.nextDelay(); delay > 0 {
+		log.Debugf("Fault injection: delaying the request by %s in frontend %s", delay, f.frontend)
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-req.Context().Done():
+			timer.Stop()
+			return
+		}
+	}
+
+	if !f.roll(f.abortPercentage) {
+		next.ServeHTTP(rw, req)
+		return
+	}
+
+	tracing.SetErrorAndDebugLog(req, "fault injection: request aborted in frontend %s", f.frontend)
+
+	if f.abortReset {
+		reset(rw, req)
+		return
+	}
+
+	http.Error(rw, http.StatusText(f.abortStatusCode), f.abortStatusCode)
+}
+
+// isTargeted returns whether the faults can be injected in the request.
+func (f *FaultInjection) isTargeted(req *http.Request) bool {
+	if len(f.headerName) == 0 {
+		return true
+	}
+
+	values, ok := req.Header[http.CanonicalHeaderKey(f.headerName)]
+	if !ok {
+		return false
+	}
+
+	if len(f.headerValue) == 0 {
+		return true
+	}
+
+	for _, value := range values {
+		if value == f.headerValue {
+			return true
+		}
+	}
+	return false
+}
+
+// nextDelay returns the delay of the request, if it is delayed.
+func (f *FaultInjection) nextDelay() time.Duration {
+	if !f.roll(f.delayPercentage) {
+		return 0
+	}
+
+	switch f.delayDistribution {
+	case DistributionUniform:
+		return f.delay + time.Duration(f.random()*float64(f.delayMax-f.delay))
+	case DistributionExponential:
+		delay := time.Duration(-math.Log(1-f.random()) * float64(f.delay))
+		if f.delayMax > 0 && delay > f.delayMax {
+			return f.delayMax
+		}
+		return delay
+	default:
+		return f.delay
+	}
+}
+
+// roll returns true for the given percentage of the calls.
+func (f *FaultInjection) roll(percentage float64) bool {
+	return percentage > 0 && f.random()*100 < percentage
+}
+
+type lingerer interface {
+	SetLinger(sec int) error
+}
+
+// reset closes the connection of the client without answering.
+func reset(rw http.ResponseWriter, req *http.Request) {
+	if req.ProtoMajor == 1 {
+		if hijacker, ok := rw.(http.Hijacker); ok {
+			conn, _, err := hijacker.Hijack()
+			if err == nil {
+				// the TLS connection would send a close notify alert first
+				if tlsConn, ok := conn.(*tls.Conn); ok {
+					if rawConn, ok := tcp.UnderlyingConn(tlsConn); ok {
+						conn = rawConn
+					} else {
+						log.Debugf("Fault injection: unable to reset the connection: the TLS connection from %s is not found", conn.RemoteAddr())
+					}
+				}
+
+				// sends a RST instead of a FIN, the wrappers of the listeners forwarding the option
+				if l, ok := conn.(lingerer); !ok {
+					log.Debugf("Fault injection: unable to reset the connection: the linger option is not supported by %T", conn)
+				} else if err := l.SetLinger(0); err != nil {
+					log.Debugf("Fault injection: unable to reset the connection: %v", err)
+				}
+				conn.Close()
+				return
+			}
+			log.Debugf("Fault injection: unable to hijack the connection: %v", err)
+		}
+	}
+
+	// the HTTP/2 streams are reset by aborting the handler
+	panic(http.ErrAbortHandler)
+}
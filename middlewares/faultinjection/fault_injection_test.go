@@ -0,0 +1,361 @@
+package faultinjection
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/containous/flaeg/parse"
+	"github.com/containous/traefik/middlewares/toggles"
+	"github.com/containous/traefik/tcp"
+	"github.com/containous/traefik/testhelpers"
+	"github.com/containous/traefik/tls/generate"
+	"github.com/containous/traefik/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/negroni"
+)
+
+func TestNewFaultInjectionInvalid(t *testing.T) {
+	testCases := []struct {
+		desc   string
+		config *types.FaultInjection
+	}{
+		{
+			desc:   "invalid delay percentage",
+			config: &types.FaultInjection{DelayPercentage: 101},
+		},
+		{
+			desc:   "invalid abort percentage",
+			config: &types.FaultInjection{AbortPercentage: -1},
+		},
+		{
+			desc:   "negative delay",
+			config: &types.FaultInjection{Delay: parse.Duration(-time.Second)},
+		},
+		{
+			desc:   "unknown distribution",
+			config: &types.FaultInjection{DelayDistribution: "pareto"},
+		},
+		{
+			desc: "uniform distribution without maximum delay",
+			config: &types.FaultInjection{
+				Delay:             parse.Duration(time.Second),
+				DelayDistribution: DistributionUniform,
+			},
+		},
+		{
+			desc:   "invalid abort status code",
+			config: &types.FaultInjection{AbortStatusCode: 42},
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := NewFaultInjection("file", "frontend", test.config, nil)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestFaultInjection(t *testing.T) {
+	testCases := []struct {
+		desc               string
+		config             *types.FaultInjection
+		random             float64
+		header             http.Header
+		expectedStatusCode int
+		expectedBody       string
+	}{
+		{
+			desc:               "disabled",
+			config:             &types.FaultInjection{AbortPercentage: 100},
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       "backend",
+		},
+		{
+			desc:               "aborted",
+			config:             &types.FaultInjection{Enabled: true, AbortPercentage: 50},
+			random:             0.3,
+			expectedStatusCode: http.StatusServiceUnavailable,
+			expectedBody:       "Service Unavailable\n",
+		},
+		{
+			desc:               "aborted with a status code",
+			config:             &types.FaultInjection{Enabled: true, AbortPercentage: 50, AbortStatusCode: http.StatusBadGateway},
+			random:             0.3,
+			expectedStatusCode: http.StatusBadGateway,
+			expectedBody:       "Bad Gateway\n",
+		},
+		{
+			desc:               "not aborted",
+			config:             &types.FaultInjection{Enabled: true, AbortPercentage: 50},
+			random:             0.7,
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       "backend",
+		},
+		{
+			desc: "aborted with the header",
+			config: &types.FaultInjection{
+				Enabled:         true,
+				AbortPercentage: 100,
+				HeaderName:      "X-Chaos",
+			},
+			header:             http.Header{"X-Chaos": {"1"}},
+			expectedStatusCode: http.StatusServiceUnavailable,
+			expectedBody:       "Service Unavailable\n",
+		},
+		{
+			desc: "not aborted without the header",
+			config: &types.FaultInjection{
+				Enabled:         true,
+				AbortPercentage: 100,
+				HeaderName:      "X-Chaos",
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       "backend",
+		},
+		{
+			desc: "aborted with the header value",
+			config: &types.FaultInjection{
+				Enabled:         true,
+				AbortPercentage: 100,
+				HeaderName:      "X-Chaos",
+				HeaderValue:     "abort",
+			},
+			header:             http.Header{"X-Chaos": {"delay", "abort"}},
+			expectedStatusCode: http.StatusServiceUnavailable,
+			expectedBody:       "Service Unavailable\n",
+		},
+		{
+			desc: "not aborted with another header value",
+			config: &types.FaultInjection{
+				Enabled:         true,
+				AbortPercentage: 100,
+				HeaderName:      "X-Chaos",
+				HeaderValue:     "abort",
+			},
+			header:             http.Header{"X-Chaos": {"delay"}},
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       "backend",
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			f, err := NewFaultInjection("file", "frontend", test.config, nil)
+			require.NoError(t, err)
+			f.random = func() float64 { return test.random }
+
+			req := httptest.NewRequest(http.MethodGet, "http://foo.com/", nil)
+			for name, values := range test.header {
+				req.Header[name] = values
+			}
+
+			recorder := httptest.NewRecorder()
+			f.ServeHTTP(recorder, req, func(rw http.ResponseWriter, req *http.Request) {
+				rw.Write([]byte("backend"))
+			})
+
+			assert.Equal(t, test.expectedStatusCode, recorder.Code)
+			assert.Equal(t, test.expectedBody, recorder.Body.String())
+		})
+	}
+}
+
+func TestFaultInjectionNextDelay(t *testing.T) {
+	testCases := []struct {
+		desc          string
+		config        *types.FaultInjection
+		random        float64
+		expectedDelay time.Duration
+	}{
+		{
+			desc:          "no delay percentage",
+			config:        &types.FaultInjection{Delay: parse.Duration(time.Second)},
+			expectedDelay: 0,
+		},
+		{
+			desc:          "not delayed",
+			config:        &types.FaultInjection{DelayPercentage: 10, Delay: parse.Duration(time.Second)},
+			random:        0.5,
+			expectedDelay: 0,
+		},
+		{
+			desc:          "fixed",
+			config:        &types.FaultInjection{DelayPercentage: 100, Delay: parse.Duration(time.Second)},
+			random:        0.5,
+			expectedDelay: time.Second,
+		},
+		{
+			desc: "uniform",
+			config: &types.FaultInjection{
+				DelayPercentage:   100,
+				Delay:             parse.Duration(time.Second),
+				DelayMax:          parse.Duration(3 * time.Second),
+				DelayDistribution: DistributionUniform,
+			},
+			random:        0.5,
+			expectedDelay: 2 * time.Second,
+		},
+		{
+			desc: "exponential",
+			config: &types.FaultInjection{
+				DelayPercentage:   100,
+				Delay:             parse.Duration(time.Second),
+				DelayDistribution: DistributionExponential,
+			},
+			// 1 - e^-2
+			random:        0.8646647167633873,
+			expectedDelay: 2 * time.Second,
+		},
+		{
+			desc: "exponential with a maximum delay",
+			config: &types.FaultInjection{
+				DelayPercentage:   100,
+				Delay:             parse.Duration(time.Second),
+				DelayMax:          parse.Duration(1500 * time.Millisecond),
+				DelayDistribution: DistributionExponential,
+			},
+			random:        0.8646647167633873,
+			expectedDelay: 1500 * time.Millisecond,
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			f, err := NewFaultInjection("file", "frontend", test.config, nil)
+			require.NoError(t, err)
+			f.random = func() float64 { return test.random }
+
+			assert.InDelta(t, float64(test.expectedDelay), float64(f.nextDelay()), float64(time.Millisecond))
+		})
+	}
+}
+
+func TestFaultInjectionDelay(t *testing.T) {
+	f, err := NewFaultInjection("file", "frontend", &types.FaultInjection{
+		Enabled:         true,
+		DelayPercentage: 100,
+		Delay:           parse.Duration(50 * time.Millisecond),
+	}, nil)
+	require.NoError(t, err)
+
+	next := func(rw http.ResponseWriter, req *http.Request) {
+		rw.Write([]byte("backend"))
+	}
+
+	start := time.Now()
+	recorder := httptest.NewRecorder()
+	f.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "http://foo.com/", nil), next)
+
+	assert.True(t, time.Since(start) >= 50*time.Millisecond)
+	assert.Equal(t, "backend", recorder.Body.String())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	recorder = httptest.NewRecorder()
+	f.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "http://foo.com/", nil).WithContext(ctx), next)
+
+	assert.Empty(t, recorder.Body.String())
+}
+
+func TestFaultInjectionReset(t *testing.T) {
+	cert, err := generate.DefaultCertificate()
+	require.NoError(t, err)
+
+	testCases := []struct {
+		desc      string
+		tlsConfig *tls.Config
+	}{
+		{
+			desc: "HTTP",
+		},
+		{
+			desc:      "HTTPS",
+			tlsConfig: &tls.Config{Certificates: []tls.Certificate{*cert}},
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			f, err := NewFaultInjection("file", "frontend", &types.FaultInjection{
+				Enabled:         true,
+				AbortPercentage: 100,
+				AbortReset:      true,
+			}, nil)
+			require.NoError(t, err)
+
+			n := negroni.New(f)
+			n.UseHandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				rw.Write([]byte("backend"))
+			})
+
+			// the listeners of an entry point, the route of a TCP frontend making the listener peek the connections
+			listener, err := net.Listen("tcp", "127.0.0.1:0")
+			require.NoError(t, err)
+			limitListener := tcp.NewLimitListener(listener, 10, 10, &testhelpers.CollectingCounter{})
+
+			router := tcp.NewRouter()
+			err = router.AddRoute("tcp-frontend", []string{"tcp.foo.com"}, true, tcp.HandlerFunc(func(conn net.Conn) { conn.Close() }))
+			require.NoError(t, err)
+
+			tcpListener := tcp.NewListener(limitListener, tcp.NewRouterSwitcher(router), test.tlsConfig, time.Second, time.Minute)
+			defer tcpListener.Close()
+
+			server := &http.Server{Handler: n, TLSConfig: test.tlsConfig}
+			if test.tlsConfig != nil {
+				go server.ServeTLS(tcpListener, "", "")
+			} else {
+				go server.Serve(tcpListener)
+			}
+
+			var conn net.Conn
+			if test.tlsConfig != nil {
+				conn, err = tls.Dial("tcp", listener.Addr().String(), &tls.Config{InsecureSkipVerify: true, ServerName: "www.foo.com"})
+			} else {
+				conn, err = net.Dial("tcp", listener.Addr().String())
+			}
+			require.NoError(t, err)
+			defer conn.Close()
+
+			_, err = conn.Write([]byte("GET / HTTP/1.1\r\nHost: www.foo.com\r\n\r\n"))
+			require.NoError(t, err)
+
+			_, err = conn.Read(make([]byte, 1024))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), "connection reset by peer")
+		})
+	}
+}
+
+func TestFaultInjectionToggles(t *testing.T) {
+	store := toggles.NewStore()
+
+	f, err := NewFaultInjection("file", "frontend", &types.FaultInjection{AbortPercentage: 100}, store)
+	require.NoError(t, err)
+	assert.False(t, f.IsEnabled())
+
+	store.Set("file", "frontend", true)
+	assert.True(t, f.IsEnabled())
+
+	store.Reset("file", "frontend")
+	assert.False(t, f.IsEnabled())
+}
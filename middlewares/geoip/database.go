@@ -0,0 +1,149 @@
+package geoip
+
+import (
+	"io/ioutil"
+	"net"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/containous/traefik/log"
+	"github.com/containous/traefik/safe"
+)
+
+// databaseCheckInterval is the delay between two checks of the database file for changes.
+var databaseCheckInterval = 10 * time.Second
+
+var (
+	databasesLock sync.Mutex
+	databases     = make(map[string]*database)
+)
+
+// database is a MaxMind DB file, reloaded in the background when it changes on disk.
+// Databases are shared by all the frontends using the same file.
+type database struct {
+	path string
+	stop chan struct{}
+
+	lock    sync.RWMutex
+	reader  *reader
+	modTime time.Time
+	size    int64
+}
+
+func openDatabase(path string) (*database, error) {
+	databasesLock.Lock()
+	defer databasesLock.Unlock()
+
+	if db, ok := databases[path]; ok {
+		return db, nil
+	}
+
+	db := &database{path: path, stop: make(chan struct{})}
+	if err := db.load(); err != nil {
+		return nil, err
+	}
+
+	databases[path] = db
+
+	interval := databaseCheckInterval
+	safe.Go(func() {
+		db.watch(interval)
+	})
+
+	return db, nil
+}
+
+// CloseUnusedDatabases stops watching the databases whose path is not in the given ones, and forgets them.
+// It is called once a configuration is applied, with the databases of its frontends.
+func CloseUnusedDatabases(paths []string) {
+	used := make(map[string]bool)
+	for _, path := range paths {
+		used[path] = true
+	}
+
+	databasesLock.Lock()
+	defer databasesLock.Unlock()
+
+	for path, db := range databases {
+		if !used[path] {
+			close(db.stop)
+			delete(databases, path)
+			log.Debugf("GeoIP database %s closed", path)
+		}
+	}
+}
+
+func (db *database) load() error {
+	info, err := os.Stat(db.path)
+	if err != nil {
+		return err
+	}
+
+	content, err := ioutil.ReadFile(db.path)
+	if err != nil {
+		return err
+	}
+
+	reader, err := newReader(content)
+	if err != nil {
+		return err
+	}
+
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	db.reader = reader
+	db.modTime = info.ModTime()
+	db.size = info.Size()
+
+	return nil
+}
+
+// watch checks the database file for changes until the database is closed.
+func (db *database) watch(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-db.stop:
+			return
+		case <-ticker.C:
+			db.reloadIfChanged()
+		}
+	}
+}
+
+// reloadIfChanged reloads the database when the file has changed since the last load.
+// On error, the previous version of the database is kept.
+func (db *database) reloadIfChanged() {
+	db.lock.RLock()
+	modTime, size := db.modTime, db.size
+	db.lock.RUnlock()
+
+	info, err := os.Stat(db.path)
+	if err != nil {
+		log.Errorf("Unable to check the GeoIP database %s: %v", db.path, err)
+		return
+	}
+
+	if info.ModTime().Equal(modTime) && info.Size() == size {
+		return
+	}
+
+	if err := db.load(); err != nil {
+		log.Errorf("Unable to reload the GeoIP database %s: %v", db.path, err)
+		return
+	}
+
+	log.Infof("GeoIP database %s reloaded", db.path)
+}
+
+func (db *database) lookup(ip net.IP, path ...string) (interface{}, error) {
+	db.lock.RLock()
+	reader := db.reader
+	db.lock.RUnlock()
+
+	return reader.lookup(ip, path...)
+}
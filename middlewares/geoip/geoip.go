@@ -0,0 +1,221 @@
+package geoip
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/containous/traefik/ip"
+	"github.com/containous/traefik/log"
+	"github.com/containous/traefik/middlewares"
+	"github.com/containous/traefik/middlewares/accesslog"
+	"github.com/containous/traefik/middlewares/tracing"
+	"github.com/containous/traefik/types"
+)
+
+// Headers added to the request when enabled.
+const (
+	CountryHeader = "X-Geo-Country"
+	ASNHeader     = "X-Geo-ASN"
+)
+
+// GeoIP is a middleware that filters and enriches the requests according to the location of the client IP.
+type GeoIP struct {
+	countryDB        *database
+	asnDB            *database
+	allowedCountries map[string]struct{}
+	deniedCountries  map[string]struct{}
+	allowedASNs      map[uint64]struct{}
+	deniedASNs       map[uint64]struct{}
+	addHeaders       bool
+	strategy         ip.Strategy
+}
+
+// NewGeoIP builds a new GeoIP given a frontend configuration.
+func NewGeoIP(config *types.GeoIP, strategy ip.Strategy) (*GeoIP, error) {
+	if config == nil {
+		return nil, nil
+	}
+
+	g := &GeoIP{
+		allowedCountries: toCountrySet(config.AllowedCountries),
+		deniedCountries:  toCountrySet(config.DeniedCountries),
+		addHeaders:       config.AddHeaders,
+		strategy:         strategy,
+	}
+
+	var err error
+	g.allowedASNs, err = toASNSet(config.AllowedASNs)
+	if err != nil {
+		return nil, err
+	}
+
+	g.deniedASNs, err = toASNSet(config.DeniedASNs)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(config.CountryDatabase) > 0 {
+		g.countryDB, err = openDatabase(config.CountryDatabase)
+		if err != nil {
+			return nil, fmt.Errorf("unable to open the country database: %v", err)
+		}
+	} else if len(g.allowedCountries) > 0 || len(g.deniedCountries) > 0 {
+		return nil, errors.New("country rules require a country database")
+	}
+
+	if len(config.ASNDatabase) > 0 {
+		g.asnDB, err = openDatabase(config.ASNDatabase)
+		if err != nil {
+			return nil, fmt.Errorf("unable to open the ASN database: %v", err)
+		}
+	} else if len(g.allowedASNs) > 0 || len(g.deniedASNs) > 0 {
+		return nil, errors.New("ASN rules require an ASN database")
+	}
+
+	if g.countryDB == nil && g.asnDB == nil {
+		return nil, errors.New("no GeoIP database provided")
+	}
+
+	return g, nil
+}
+
+func (g *GeoIP) ServeHTTP(rw http.ResponseWriter, req *http.Request, next http.HandlerFunc) {
+	clientIP := parseIP(g.strategy.GetIP(req))
+	country, asn := g.locate(clientIP)
+
+	if table, ok := req.Context().Value(accesslog.DataTableKey).(*accesslog.LogData); ok {
+		if len(country) > 0 {
+			table.Core[accesslog.GeoCountry] = country
+		}
+		if asn > 0 {
+			table.Core[accesslog.GeoASN] = asn
+		}
+	}
+
+	if !g.isAllowed(country, asn) {
+		tracing.SetErrorAndDebugLog(req, "request %s - rejecting: %s (country %q, ASN %d) is not allowed", req.URL, clientIP, country, asn)
+		middlewares.Reject(rw)
+		return
+	}
+
+	if g.addHeaders {
+		// Never trust the location sent by the client.
+		req.Header.Del(CountryHeader)
+		req.Header.Del(ASNHeader)
+
+		if len(country) > 0 {
+			req.Header.Set(CountryHeader, country)
+		}
+		if asn > 0 {
+			req.Header.Set(ASNHeader, strconv.FormatUint(asn, 10))
+		}
+	}
+
+	next.ServeHTTP(rw, req)
+}
+
+// locate returns the ISO code of the country and the autonomous system number of the IP, when they are known.
+func (g *GeoIP) locate(clientIP net.IP) (string, uint64) {
+	if clientIP == nil {
+		return "", 0
+	}
+
+	var country string
+	if g.countryDB != nil {
+		var err error
+		country, err = lookupCountry(g.countryDB, clientIP)
+		if err != nil {
+			log.Errorf("Unable to look up the country of %s: %v", clientIP, err)
+		}
+	}
+
+	var asn uint64
+	if g.asnDB != nil {
+		value, err := g.asnDB.lookup(clientIP, "autonomous_system_number")
+		if err != nil {
+			log.Errorf("Unable to look up the ASN of %s: %v", clientIP, err)
+		}
+		asn, _ = value.(uint64)
+	}
+
+	return country, asn
+}
+
+func (g *GeoIP) isAllowed(country string, asn uint64) bool {
+	if len(g.allowedCountries) > 0 {
+		if _, ok := g.allowedCountries[country]; !ok {
+			return false
+		}
+	}
+
+	if _, ok := g.deniedCountries[country]; ok {
+		return false
+	}
+
+	if len(g.allowedASNs) > 0 {
+		if _, ok := g.allowedASNs[asn]; !ok {
+			return false
+		}
+	}
+
+	if _, ok := g.deniedASNs[asn]; ok {
+		return false
+	}
+
+	return true
+}
+
+// lookupCountry returns the country where the IP is located,
+// or the country in which the IP is registered when the location is unknown.
+// Only the ISO codes of the records are decoded, not the localized names of the City databases.
+func lookupCountry(db *database, clientIP net.IP) (string, error) {
+	for _, key := range []string{"country", "registered_country"} {
+		value, err := db.lookup(clientIP, key, "iso_code")
+		if err != nil {
+			return "", err
+		}
+
+		if isoCode, ok := value.(string); ok && len(isoCode) > 0 {
+			return isoCode, nil
+		}
+	}
+	return "", nil
+}
+
+func parseIP(addr string) net.IP {
+	if host, _, err := net.SplitHostPort(addr); err == nil {
+		addr = host
+	}
+	return net.ParseIP(strings.TrimSpace(addr))
+}
+
+func toCountrySet(countries []string) map[string]struct{} {
+	set := make(map[string]struct{})
+	for _, country := range countries {
+		if country = strings.TrimSpace(country); len(country) > 0 {
+			set[strings.ToUpper(country)] = struct{}{}
+		}
+	}
+	return set
+}
+
+func toASNSet(asns []string) (map[uint64]struct{}, error) {
+	set := make(map[uint64]struct{})
+	for _, rawASN := range asns {
+		rawASN = strings.TrimPrefix(strings.ToUpper(strings.TrimSpace(rawASN)), "AS")
+		if len(rawASN) == 0 {
+			continue
+		}
+
+		asn, err := strconv.ParseUint(rawASN, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid ASN %q: %v", rawASN, err)
+		}
+		set[asn] = struct{}{}
+	}
+	return set, nil
+}
@@ -0,0 +1,390 @@
+package geoip
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/containous/traefik/ip"
+	"github.com/containous/traefik/testhelpers"
+	"github.com/containous/traefik/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeDatabase(t *testing.T, path string, networks map[string]map[string]interface{}) {
+	t.Helper()
+
+	err := ioutil.WriteFile(path, buildDatabase(t, 6, networks), 0644)
+	require.NoError(t, err)
+}
+
+func TestNewGeoIP(t *testing.T) {
+	dir, err := ioutil.TempDir("", "geoip")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	countryDB := filepath.Join(dir, "country.mmdb")
+	writeDatabase(t, countryDB, map[string]map[string]interface{}{})
+
+	testCases := []struct {
+		desc          string
+		config        *types.GeoIP
+		expectedError bool
+	}{
+		{
+			desc: "no database",
+			config: &types.GeoIP{
+				AddHeaders: true,
+			},
+			expectedError: true,
+		},
+		{
+			desc: "country rules without country database",
+			config: &types.GeoIP{
+				ASNDatabase:     countryDB,
+				DeniedCountries: []string{"FR"},
+			},
+			expectedError: true,
+		},
+		{
+			desc: "ASN rules without ASN database",
+			config: &types.GeoIP{
+				CountryDatabase: countryDB,
+				AllowedASNs:     []string{"AS3215"},
+			},
+			expectedError: true,
+		},
+		{
+			desc: "invalid ASN",
+			config: &types.GeoIP{
+				ASNDatabase: countryDB,
+				DeniedASNs:  []string{"ASfoo"},
+			},
+			expectedError: true,
+		},
+		{
+			desc: "missing database file",
+			config: &types.GeoIP{
+				CountryDatabase: filepath.Join(dir, "missing.mmdb"),
+			},
+			expectedError: true,
+		},
+		{
+			desc: "valid configuration",
+			config: &types.GeoIP{
+				CountryDatabase: countryDB,
+				AllowedASNs:     []string{"AS3215"},
+				ASNDatabase:     countryDB,
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			geoIP, err := NewGeoIP(test.config, &ip.RemoteAddrStrategy{})
+			if test.expectedError {
+				assert.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				assert.NotNil(t, geoIP)
+			}
+		})
+	}
+}
+
+func TestGeoIP(t *testing.T) {
+	dir, err := ioutil.TempDir("", "geoip")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	countryDB := filepath.Join(dir, "country.mmdb")
+	writeDatabase(t, countryDB, map[string]map[string]interface{}{
+		"1.2.3.0/24": {
+			"country": map[string]interface{}{"iso_code": "FR"},
+		},
+		"5.6.7.0/24": {
+			"registered_country": map[string]interface{}{"iso_code": "KP"},
+		},
+		"2001:db8::/32": {
+			"country": map[string]interface{}{"iso_code": "DE"},
+		},
+	})
+
+	asnDB := filepath.Join(dir, "asn.mmdb")
+	writeDatabase(t, asnDB, map[string]map[string]interface{}{
+		"1.2.3.0/24": {
+			"autonomous_system_number":       uint64(3215),
+			"autonomous_system_organization": "Orange",
+		},
+		"5.6.7.0/24": {
+			"autonomous_system_number": uint64(64496),
+		},
+	})
+
+	testCases := []struct {
+		desc            string
+		config          *types.GeoIP
+		strategy        ip.Strategy
+		remoteAddr      string
+		xForwardedFor   string
+		headers         map[string]string
+		expectedStatus  int
+		expectedHeaders map[string]string
+	}{
+		{
+			desc: "allowed country",
+			config: &types.GeoIP{
+				CountryDatabase:  countryDB,
+				AllowedCountries: []string{"fr", "DE"},
+			},
+			remoteAddr:     "1.2.3.4:1234",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			desc: "not allowed country",
+			config: &types.GeoIP{
+				CountryDatabase:  countryDB,
+				AllowedCountries: []string{"DE"},
+			},
+			remoteAddr:     "1.2.3.4:1234",
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			desc: "unknown country with allowed countries",
+			config: &types.GeoIP{
+				CountryDatabase:  countryDB,
+				AllowedCountries: []string{"FR"},
+			},
+			remoteAddr:     "10.0.0.1:1234",
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			desc: "denied registered country",
+			config: &types.GeoIP{
+				CountryDatabase: countryDB,
+				DeniedCountries: []string{"KP"},
+			},
+			remoteAddr:     "5.6.7.8:1234",
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			desc: "unknown country with denied countries",
+			config: &types.GeoIP{
+				CountryDatabase: countryDB,
+				DeniedCountries: []string{"KP"},
+			},
+			remoteAddr:     "10.0.0.1:1234",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			desc: "allowed IPv6 country",
+			config: &types.GeoIP{
+				CountryDatabase:  countryDB,
+				AllowedCountries: []string{"DE"},
+			},
+			remoteAddr:     "[2001:db8::1]:1234",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			desc: "allowed ASN",
+			config: &types.GeoIP{
+				ASNDatabase: asnDB,
+				AllowedASNs: []string{"AS3215"},
+			},
+			remoteAddr:     "1.2.3.4:1234",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			desc: "denied ASN",
+			config: &types.GeoIP{
+				ASNDatabase: asnDB,
+				DeniedASNs:  []string{"64496"},
+			},
+			remoteAddr:     "5.6.7.8:1234",
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			desc: "IP from the X-Forwarded-For header",
+			config: &types.GeoIP{
+				CountryDatabase: countryDB,
+				DeniedCountries: []string{"KP"},
+			},
+			strategy:       &ip.DepthStrategy{Depth: 1},
+			remoteAddr:     "1.2.3.4:1234",
+			xForwardedFor:  "1.2.3.4, 5.6.7.8",
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			desc: "add headers",
+			config: &types.GeoIP{
+				CountryDatabase: countryDB,
+				ASNDatabase:     asnDB,
+				AddHeaders:      true,
+			},
+			remoteAddr:     "1.2.3.4:1234",
+			expectedStatus: http.StatusOK,
+			expectedHeaders: map[string]string{
+				CountryHeader: "FR",
+				ASNHeader:     "3215",
+			},
+		},
+		{
+			desc: "remove the headers sent by the client",
+			config: &types.GeoIP{
+				CountryDatabase: countryDB,
+				ASNDatabase:     asnDB,
+				AddHeaders:      true,
+			},
+			remoteAddr: "10.0.0.1:1234",
+			headers: map[string]string{
+				CountryHeader: "FR",
+				ASNHeader:     "3215",
+			},
+			expectedStatus: http.StatusOK,
+			expectedHeaders: map[string]string{
+				CountryHeader: "",
+				ASNHeader:     "",
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			strategy := test.strategy
+			if strategy == nil {
+				strategy = &ip.RemoteAddrStrategy{}
+			}
+
+			geoIP, err := NewGeoIP(test.config, strategy)
+			require.NoError(t, err)
+
+			req := testhelpers.MustNewRequest(http.MethodGet, "http://foo.bar", nil)
+			req.RemoteAddr = test.remoteAddr
+			if len(test.xForwardedFor) > 0 {
+				req.Header.Set("X-Forwarded-For", test.xForwardedFor)
+			}
+			for name, value := range test.headers {
+				req.Header.Set(name, value)
+			}
+
+			var forwardedHeaders http.Header
+			recorder := httptest.NewRecorder()
+			geoIP.ServeHTTP(recorder, req, func(rw http.ResponseWriter, req *http.Request) {
+				forwardedHeaders = req.Header
+			})
+
+			assert.Equal(t, test.expectedStatus, recorder.Code)
+			for name, value := range test.expectedHeaders {
+				assert.Equal(t, value, forwardedHeaders.Get(name), name)
+			}
+		})
+	}
+}
+
+func TestDatabaseReload(t *testing.T) {
+	defer func(interval time.Duration) { databaseCheckInterval = interval }(databaseCheckInterval)
+	databaseCheckInterval = 10 * time.Millisecond
+	defer CloseUnusedDatabases(nil)
+
+	dir, err := ioutil.TempDir("", "geoip")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "country.mmdb")
+	writeDatabase(t, path, map[string]map[string]interface{}{
+		"1.2.3.0/24": {
+			"country": map[string]interface{}{"iso_code": "FR"},
+		},
+	})
+
+	geoIP, err := NewGeoIP(&types.GeoIP{CountryDatabase: path}, &ip.RemoteAddrStrategy{})
+	require.NoError(t, err)
+
+	country, _ := geoIP.locate(parseIP("1.2.3.4"))
+	assert.Equal(t, "FR", country)
+
+	writeDatabase(t, path, map[string]map[string]interface{}{
+		"1.2.3.0/24": {
+			"country": map[string]interface{}{"iso_code": "DE"},
+		},
+	})
+	future := time.Now().Add(time.Minute)
+	require.NoError(t, os.Chtimes(path, future, future))
+
+	waitForCountry(t, geoIP, "1.2.3.4", "DE")
+
+	// An invalid database is ignored, the previous one is kept.
+	require.NoError(t, ioutil.WriteFile(path, []byte("foo"), 0644))
+	future = future.Add(time.Minute)
+	require.NoError(t, os.Chtimes(path, future, future))
+
+	time.Sleep(5 * databaseCheckInterval)
+	country, _ = geoIP.locate(parseIP("1.2.3.4"))
+	assert.Equal(t, "DE", country)
+}
+
+// waitForCountry waits for the background reload of the database to locate the IP in the country.
+func waitForCountry(t *testing.T, geoIP *GeoIP, addr string, expected string) {
+	t.Helper()
+
+	var country string
+	for i := 0; i < 100; i++ {
+		country, _ = geoIP.locate(parseIP(addr))
+		if country == expected {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	assert.Equal(t, expected, country)
+}
+
+func TestCloseUnusedDatabases(t *testing.T) {
+	defer CloseUnusedDatabases(nil)
+
+	dir, err := ioutil.TempDir("", "geoip")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	countryPath := filepath.Join(dir, "country.mmdb")
+	writeDatabase(t, countryPath, map[string]map[string]interface{}{
+		"1.2.3.0/24": {
+			"country": map[string]interface{}{"iso_code": "FR"},
+		},
+	})
+
+	asnPath := filepath.Join(dir, "asn.mmdb")
+	writeDatabase(t, asnPath, map[string]map[string]interface{}{
+		"1.2.3.0/24": {"autonomous_system_number": uint64(64496)},
+	})
+
+	countryDB, err := openDatabase(countryPath)
+	require.NoError(t, err)
+	asnDB, err := openDatabase(asnPath)
+	require.NoError(t, err)
+
+	CloseUnusedDatabases([]string{countryPath})
+
+	databasesLock.Lock()
+	assert.Equal(t, countryDB, databases[countryPath])
+	assert.NotContains(t, databases, asnPath)
+	databasesLock.Unlock()
+
+	select {
+	case <-asnDB.stop:
+	default:
+		assert.Fail(t, "the unused database is still watched")
+	}
+
+	// a database used again is opened again
+	reopened, err := openDatabase(asnPath)
+	require.NoError(t, err)
+	assert.False(t, reopened == asnDB)
+}
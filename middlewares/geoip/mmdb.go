@@ -0,0 +1,469 @@
+package geoip
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"net"
+)
+
+// Reader of the MaxMind DB file format, as described in https://maxmind.github.io/MaxMind-DB/
+
+const (
+	dataTypeExtended  = 0
+	dataTypePointer   = 1
+	dataTypeString    = 2
+	dataTypeDouble    = 3
+	dataTypeBytes     = 4
+	dataTypeUint16    = 5
+	dataTypeUint32    = 6
+	dataTypeMap       = 7
+	dataTypeInt32     = 8
+	dataTypeUint64    = 9
+	dataTypeUint128   = 10
+	dataTypeArray     = 11
+	dataTypeContainer = 12
+	dataTypeEndMarker = 13
+	dataTypeBool      = 14
+	dataTypeFloat     = 15
+
+	// dataSectionSeparatorSize is the size of the zeroed section between the search tree and the data section.
+	dataSectionSeparatorSize = 16
+
+	// maxDecodingDepth protects the decoder against corrupted files.
+	maxDecodingDepth = 32
+)
+
+var (
+	metadataStartMarker = []byte("\xAB\xCD\xEFMaxMind.com")
+
+	errInvalidDatabase = errors.New("invalid MaxMind DB file")
+)
+
+type reader struct {
+	buffer     []byte
+	data       decoder
+	nodeCount  uint
+	recordSize uint
+	ipVersion  uint
+	ipv4Start  uint
+}
+
+func newReader(buffer []byte) (*reader, error) {
+	metadataStart := bytes.LastIndex(buffer, metadataStartMarker)
+	if metadataStart == -1 {
+		return nil, fmt.Errorf("%v: metadata not found", errInvalidDatabase)
+	}
+
+	metadata := decoder{buffer: buffer[metadataStart+len(metadataStartMarker):]}
+	rawMetadata, _, err := metadata.decode(0, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	meta, ok := rawMetadata.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%v: unexpected metadata type %T", errInvalidDatabase, rawMetadata)
+	}
+
+	r := &reader{
+		buffer:     buffer,
+		nodeCount:  toUint(meta["node_count"]),
+		recordSize: toUint(meta["record_size"]),
+		ipVersion:  toUint(meta["ip_version"]),
+	}
+
+	if r.recordSize != 24 && r.recordSize != 28 && r.recordSize != 32 {
+		return nil, fmt.Errorf("%v: unsupported record size %d", errInvalidDatabase, r.recordSize)
+	}
+
+	if r.ipVersion != 4 && r.ipVersion != 6 {
+		return nil, fmt.Errorf("%v: unsupported IP version %d", errInvalidDatabase, r.ipVersion)
+	}
+
+	searchTreeSize := r.nodeCount * r.recordSize / 4
+	if searchTreeSize+dataSectionSeparatorSize > uint(metadataStart) {
+		return nil, fmt.Errorf("%v: search tree larger than the file", errInvalidDatabase)
+	}
+	r.data = decoder{buffer: buffer[searchTreeSize+dataSectionSeparatorSize : metadataStart]}
+
+	// IPv4 addresses are stored in the ::/96 subtree of the IPv6 databases.
+	if r.ipVersion == 6 {
+		for i := 0; i < 96 && r.ipv4Start < r.nodeCount; i++ {
+			r.ipv4Start = r.readNode(r.ipv4Start, 0)
+		}
+	}
+
+	return r, nil
+}
+
+// lookup returns the value found by following the map keys of the path in the record of the IP,
+// the whole record without path, or nil when the IP or the path is not in the database.
+func (r *reader) lookup(ip net.IP, path ...string) (interface{}, error) {
+	var node uint
+	if ipv4 := ip.To4(); ipv4 != nil {
+		ip = ipv4
+		node = r.ipv4Start
+	} else if r.ipVersion == 4 {
+		return nil, fmt.Errorf("cannot look up the IPv6 address %s in an IPv4-only database", ip)
+	}
+
+	bitCount := uint(len(ip) * 8)
+	for i := uint(0); i < bitCount && node < r.nodeCount; i++ {
+		bit := uint(ip[i>>3]>>(7-(i%8))) & 1
+		node = r.readNode(node, bit)
+	}
+
+	switch {
+	case node == r.nodeCount:
+		return nil, nil
+	case node < r.nodeCount:
+		return nil, fmt.Errorf("%v: search tree exhausted for %s", errInvalidDatabase, ip)
+	}
+
+	return r.data.decodePath(node-r.nodeCount-dataSectionSeparatorSize, path, 0)
+}
+
+func (r *reader) readNode(node uint, bit uint) uint {
+	nodeSize := r.recordSize / 4
+	offset := node * nodeSize
+	if offset+nodeSize > uint(len(r.buffer)) {
+		// Points to "no data", the lookup stops.
+		return r.nodeCount
+	}
+	b := r.buffer[offset : offset+nodeSize]
+
+	switch r.recordSize {
+	case 24:
+		return uintFromBytes(0, b[bit*3:bit*3+3])
+	case 28:
+		if bit == 0 {
+			return uintFromBytes(uint(b[3]&0xF0)>>4, b[0:3])
+		}
+		return uintFromBytes(uint(b[3]&0x0F), b[4:7])
+	default:
+		return uintFromBytes(0, b[bit*4:bit*4+4])
+	}
+}
+
+type decoder struct {
+	buffer []byte
+}
+
+// decode returns the value stored at the offset, and the offset of the next value.
+func (d *decoder) decode(offset uint, depth int) (interface{}, uint, error) {
+	if depth > maxDecodingDepth {
+		return nil, 0, fmt.Errorf("%v: maximum data structure depth exceeded", errInvalidDatabase)
+	}
+
+	dataType, size, offset, err := d.decodeControl(offset)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if dataType == dataTypePointer {
+		value, _, err := d.decode(size, depth+1)
+		return value, offset, err
+	}
+
+	return d.decodeValue(dataType, size, offset, depth)
+}
+
+// decodePath returns the value found by following the map keys of the path from the value stored at the offset,
+// or nil when a key is missing. The values out of the path are skipped without being decoded.
+func (d *decoder) decodePath(offset uint, path []string, depth int) (interface{}, error) {
+	if len(path) == 0 {
+		value, _, err := d.decode(offset, depth)
+		return value, err
+	}
+
+	if depth > maxDecodingDepth {
+		return nil, fmt.Errorf("%v: maximum data structure depth exceeded", errInvalidDatabase)
+	}
+
+	dataType, size, offset, err := d.decodeControl(offset)
+	if err != nil {
+		return nil, err
+	}
+
+	if dataType == dataTypePointer {
+		return d.decodePath(size, path, depth+1)
+	}
+
+	if dataType != dataTypeMap {
+		return nil, nil
+	}
+
+	for i := uint(0); i < size; i++ {
+		key, next, err := d.decodeKey(offset, depth+1)
+		if err != nil {
+			return nil, err
+		}
+
+		if string(key) == path[0] {
+			return d.decodePath(next, path[1:], depth+1)
+		}
+
+		offset, err = d.skip(next, depth+1)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return nil, nil
+}
+
+// decodeKey returns the bytes of the map key stored at the offset, and the offset of the next value.
+func (d *decoder) decodeKey(offset uint, depth int) ([]byte, uint, error) {
+	if depth > maxDecodingDepth {
+		return nil, 0, fmt.Errorf("%v: maximum data structure depth exceeded", errInvalidDatabase)
+	}
+
+	dataType, size, offset, err := d.decodeControl(offset)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if dataType == dataTypePointer {
+		key, _, err := d.decodeKey(size, depth+1)
+		return key, offset, err
+	}
+
+	if dataType != dataTypeString {
+		return nil, 0, fmt.Errorf("%v: unexpected map key type %d", errInvalidDatabase, dataType)
+	}
+
+	if offset+size > uint(len(d.buffer)) {
+		return nil, 0, fmt.Errorf("%v: unexpected end of the data section", errInvalidDatabase)
+	}
+	return d.buffer[offset : offset+size], offset + size, nil
+}
+
+// skip returns the offset of the value following the one stored at the offset, without decoding it.
+func (d *decoder) skip(offset uint, depth int) (uint, error) {
+	if depth > maxDecodingDepth {
+		return 0, fmt.Errorf("%v: maximum data structure depth exceeded", errInvalidDatabase)
+	}
+
+	dataType, size, offset, err := d.decodeControl(offset)
+	if err != nil {
+		return 0, err
+	}
+
+	switch dataType {
+	case dataTypePointer, dataTypeBool:
+		return offset, nil
+	case dataTypeMap, dataTypeArray:
+		entries := size
+		if dataType == dataTypeMap {
+			entries *= 2
+		}
+
+		for i := uint(0); i < entries; i++ {
+			offset, err = d.skip(offset, depth+1)
+			if err != nil {
+				return 0, err
+			}
+		}
+		return offset, nil
+	default:
+		if offset+size > uint(len(d.buffer)) {
+			return 0, fmt.Errorf("%v: unexpected end of the data section", errInvalidDatabase)
+		}
+		return offset + size, nil
+	}
+}
+
+// decodeControl returns the type and the size of the value stored at the offset, and the offset of its content.
+// The size of a pointer is the offset it points to, and its content is empty.
+func (d *decoder) decodeControl(offset uint) (uint, uint, uint, error) {
+	if offset >= uint(len(d.buffer)) {
+		return 0, 0, 0, fmt.Errorf("%v: offset %d out of the data section", errInvalidDatabase, offset)
+	}
+
+	ctrl := d.buffer[offset]
+	offset++
+
+	dataType := uint(ctrl >> 5)
+	if dataType == dataTypePointer {
+		pointer, next, err := d.decodePointer(ctrl, offset)
+		return dataType, pointer, next, err
+	}
+
+	if dataType == dataTypeExtended {
+		if offset >= uint(len(d.buffer)) {
+			return 0, 0, 0, fmt.Errorf("%v: unexpected end of the data section", errInvalidDatabase)
+		}
+		dataType = 7 + uint(d.buffer[offset])
+		offset++
+	}
+
+	size, offset, err := d.decodeSize(ctrl, offset)
+	return dataType, size, offset, err
+}
+
+func (d *decoder) decodePointer(ctrl byte, offset uint) (uint, uint, error) {
+	pointerSize := uint((ctrl>>3)&0x3) + 1
+	if offset+pointerSize > uint(len(d.buffer)) {
+		return 0, 0, fmt.Errorf("%v: unexpected end of the data section", errInvalidDatabase)
+	}
+
+	var prefix uint
+	if pointerSize != 4 {
+		prefix = uint(ctrl & 0x7)
+	}
+
+	pointer := uintFromBytes(prefix, d.buffer[offset:offset+pointerSize])
+	switch pointerSize {
+	case 2:
+		pointer += 2048
+	case 3:
+		pointer += 526336
+	}
+
+	return pointer, offset + pointerSize, nil
+}
+
+func (d *decoder) decodeSize(ctrl byte, offset uint) (uint, uint, error) {
+	size := uint(ctrl & 0x1f)
+	if size < 29 {
+		return size, offset, nil
+	}
+
+	bytesToRead := size - 28
+	if offset+bytesToRead > uint(len(d.buffer)) {
+		return 0, 0, fmt.Errorf("%v: unexpected end of the data section", errInvalidDatabase)
+	}
+
+	value := uintFromBytes(0, d.buffer[offset:offset+bytesToRead])
+	switch size {
+	case 29:
+		size = 29 + value
+	case 30:
+		size = 285 + value
+	default:
+		size = 65821 + value
+	}
+
+	return size, offset + bytesToRead, nil
+}
+
+func (d *decoder) decodeValue(dataType uint, size uint, offset uint, depth int) (interface{}, uint, error) {
+	switch dataType {
+	case dataTypeMap, dataTypeArray:
+		// Each entry takes at least one byte.
+		if size > uint(len(d.buffer))-offset {
+			return nil, 0, fmt.Errorf("%v: unexpected end of the data section", errInvalidDatabase)
+		}
+		if dataType == dataTypeMap {
+			return d.decodeMap(size, offset, depth)
+		}
+		return d.decodeArray(size, offset, depth)
+	case dataTypeBool:
+		return size != 0, offset, nil
+	}
+
+	if offset+size > uint(len(d.buffer)) {
+		return nil, 0, fmt.Errorf("%v: unexpected end of the data section", errInvalidDatabase)
+	}
+	b := d.buffer[offset : offset+size]
+	next := offset + size
+
+	switch dataType {
+	case dataTypeString:
+		return string(b), next, nil
+	case dataTypeBytes:
+		return append([]byte(nil), b...), next, nil
+	case dataTypeDouble:
+		if size != 8 {
+			return nil, 0, fmt.Errorf("%v: invalid double size %d", errInvalidDatabase, size)
+		}
+		return math.Float64frombits(binary.BigEndian.Uint64(b)), next, nil
+	case dataTypeFloat:
+		if size != 4 {
+			return nil, 0, fmt.Errorf("%v: invalid float size %d", errInvalidDatabase, size)
+		}
+		return math.Float32frombits(binary.BigEndian.Uint32(b)), next, nil
+	case dataTypeUint16, dataTypeUint32, dataTypeUint64:
+		if size > 8 {
+			return nil, 0, fmt.Errorf("%v: invalid unsigned integer size %d", errInvalidDatabase, size)
+		}
+		var value uint64
+		for _, c := range b {
+			value = value<<8 | uint64(c)
+		}
+		return value, next, nil
+	case dataTypeInt32:
+		if size > 4 {
+			return nil, 0, fmt.Errorf("%v: invalid int32 size %d", errInvalidDatabase, size)
+		}
+		var value uint32
+		for _, c := range b {
+			value = value<<8 | uint32(c)
+		}
+		return int64(int32(value)), next, nil
+	case dataTypeUint128:
+		if size > 16 {
+			return nil, 0, fmt.Errorf("%v: invalid uint128 size %d", errInvalidDatabase, size)
+		}
+		return new(big.Int).SetBytes(b), next, nil
+	default:
+		return nil, 0, fmt.Errorf("%v: unsupported data type %d", errInvalidDatabase, dataType)
+	}
+}
+
+func (d *decoder) decodeMap(size uint, offset uint, depth int) (interface{}, uint, error) {
+	values := make(map[string]interface{}, size)
+	for i := uint(0); i < size; i++ {
+		rawKey, next, err := d.decode(offset, depth+1)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		key, ok := rawKey.(string)
+		if !ok {
+			return nil, 0, fmt.Errorf("%v: unexpected map key type %T", errInvalidDatabase, rawKey)
+		}
+
+		value, next, err := d.decode(next, depth+1)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		values[key] = value
+		offset = next
+	}
+	return values, offset, nil
+}
+
+func (d *decoder) decodeArray(size uint, offset uint, depth int) (interface{}, uint, error) {
+	values := make([]interface{}, 0, size)
+	for i := uint(0); i < size; i++ {
+		value, next, err := d.decode(offset, depth+1)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		values = append(values, value)
+		offset = next
+	}
+	return values, offset, nil
+}
+
+func uintFromBytes(prefix uint, b []byte) uint {
+	value := prefix
+	for _, c := range b {
+		value = value<<8 | uint(c)
+	}
+	return value
+}
+
+func toUint(value interface{}) uint {
+	if v, ok := value.(uint64); ok {
+		return uint(v)
+	}
+	return 0
+}
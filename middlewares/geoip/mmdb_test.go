@@ -0,0 +1,409 @@
+package geoip
+
+import (
+	"bytes"
+	"net"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// buildDatabase builds a MaxMind DB file with 24 bits records, mapping the networks to their records.
+func buildDatabase(t *testing.T, ipVersion uint64, networks map[string]map[string]interface{}) []byte {
+	t.Helper()
+
+	type record struct {
+		node int
+		data map[string]interface{}
+	}
+	// A node with a negative index in a record means "no data".
+	nodes := [][2]record{{{node: -1}, {node: -1}}}
+
+	for cidr, data := range networks {
+		_, network, err := net.ParseCIDR(cidr)
+		require.NoError(t, err)
+
+		ones, _ := network.Mask.Size()
+		ipNet := network.IP
+		if ipVersion == 6 && len(ipNet.To4()) == net.IPv4len && len(network.Mask) == net.IPv4len {
+			// IPv4 networks are stored in the ::/96 subtree.
+			ipNet = append(make(net.IP, 12), ipNet.To4()...)
+			ones += 96
+		} else if ipVersion == 4 {
+			ipNet = ipNet.To4()
+		}
+
+		node := 0
+		for i := 0; i < ones; i++ {
+			bit := (ipNet[i/8] >> uint(7-i%8)) & 1
+			if i == ones-1 {
+				nodes[node][bit] = record{data: data}
+				break
+			}
+			if nodes[node][bit].node <= 0 {
+				nodes = append(nodes, [2]record{{node: -1}, {node: -1}})
+				nodes[node][bit] = record{node: len(nodes) - 1}
+			}
+			node = nodes[node][bit].node
+		}
+	}
+
+	nodeCount := len(nodes)
+
+	var data bytes.Buffer
+	var tree bytes.Buffer
+	for _, n := range nodes {
+		for _, r := range n {
+			var value int
+			switch {
+			case r.data != nil:
+				value = nodeCount + dataSectionSeparatorSize + data.Len()
+				encodeValue(&data, r.data)
+			case r.node < 0:
+				value = nodeCount
+			default:
+				value = r.node
+			}
+			tree.Write([]byte{byte(value >> 16), byte(value >> 8), byte(value)})
+		}
+	}
+
+	var buffer bytes.Buffer
+	buffer.Write(tree.Bytes())
+	buffer.Write(make([]byte, dataSectionSeparatorSize))
+	buffer.Write(data.Bytes())
+	buffer.Write(metadataStartMarker)
+	encodeValue(&buffer, map[string]interface{}{
+		"node_count":                  uint64(nodeCount),
+		"record_size":                 uint64(24),
+		"ip_version":                  ipVersion,
+		"binary_format_major_version": uint64(2),
+		"database_type":               "Test",
+	})
+
+	return buffer.Bytes()
+}
+
+func encodeValue(buffer *bytes.Buffer, value interface{}) {
+	switch v := value.(type) {
+	case string:
+		encodeControl(buffer, dataTypeString, len(v))
+		buffer.WriteString(v)
+	case uint64:
+		var b []byte
+		for ; v > 0; v >>= 8 {
+			b = append([]byte{byte(v)}, b...)
+		}
+		encodeControl(buffer, dataTypeUint32, len(b))
+		buffer.Write(b)
+	case bool:
+		size := 0
+		if v {
+			size = 1
+		}
+		encodeControl(buffer, dataTypeBool, size)
+	case []interface{}:
+		encodeControl(buffer, dataTypeArray, len(v))
+		for _, item := range v {
+			encodeValue(buffer, item)
+		}
+	case map[string]interface{}:
+		encodeControl(buffer, dataTypeMap, len(v))
+
+		var keys []string
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			encodeValue(buffer, key)
+			encodeValue(buffer, v[key])
+		}
+	default:
+		panic("unsupported type")
+	}
+}
+
+func encodeControl(buffer *bytes.Buffer, dataType int, size int) {
+	var extendedSize []byte
+	switch {
+	case size >= 285:
+		panic("unsupported size")
+	case size >= 29:
+		extendedSize = []byte{byte(size - 29)}
+		size = 29
+	}
+
+	if dataType > dataTypeMap {
+		buffer.WriteByte(byte(size))
+		buffer.WriteByte(byte(dataType - 7))
+	} else {
+		buffer.WriteByte(byte(dataType<<5 | size))
+	}
+	buffer.Write(extendedSize)
+}
+
+func TestReaderLookup(t *testing.T) {
+	networks := map[string]map[string]interface{}{
+		"1.2.3.0/24": {
+			"country": map[string]interface{}{"iso_code": "FR"},
+		},
+		"5.6.0.0/16": {
+			"autonomous_system_number": uint64(3215),
+			"anycast":                  true,
+			"tags":                     []interface{}{"foo", "bar"},
+		},
+		"2001:db8::/32": {
+			"country": map[string]interface{}{"iso_code": "DE"},
+		},
+	}
+
+	testCases := []struct {
+		desc          string
+		ipVersion     uint64
+		ip            string
+		expected      interface{}
+		expectedError bool
+	}{
+		{
+			desc:      "IPv4 database, IP in a network",
+			ipVersion: 4,
+			ip:        "1.2.3.4",
+			expected:  networks["1.2.3.0/24"],
+		},
+		{
+			desc:      "IPv4 database, IP in a network with several data types",
+			ipVersion: 4,
+			ip:        "5.6.7.8",
+			expected:  networks["5.6.0.0/16"],
+		},
+		{
+			desc:      "IPv4 database, unknown IP",
+			ipVersion: 4,
+			ip:        "1.2.4.1",
+		},
+		{
+			desc:          "IPv4 database, IPv6",
+			ipVersion:     4,
+			ip:            "2001:db8::1",
+			expectedError: true,
+		},
+		{
+			desc:      "IPv6 database, IPv4 in a network",
+			ipVersion: 6,
+			ip:        "1.2.3.4",
+			expected:  networks["1.2.3.0/24"],
+		},
+		{
+			desc:      "IPv6 database, IPv6 in a network",
+			ipVersion: 6,
+			ip:        "2001:db8::1",
+			expected:  networks["2001:db8::/32"],
+		},
+		{
+			desc:      "IPv6 database, unknown IPv6",
+			ipVersion: 6,
+			ip:        "2001:db9::1",
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			dbNetworks := networks
+			if test.ipVersion == 4 {
+				dbNetworks = map[string]map[string]interface{}{
+					"1.2.3.0/24": networks["1.2.3.0/24"],
+					"5.6.0.0/16": networks["5.6.0.0/16"],
+				}
+			}
+
+			r, err := newReader(buildDatabase(t, test.ipVersion, dbNetworks))
+			require.NoError(t, err)
+
+			record, err := r.lookup(net.ParseIP(test.ip))
+			if test.expectedError {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, record)
+		})
+	}
+}
+
+func TestReaderLookupPath(t *testing.T) {
+	networks := map[string]map[string]interface{}{
+		"1.2.3.0/24": {
+			"city": map[string]interface{}{
+				"names": map[string]interface{}{"de": "Paris", "en": "Paris", "fr": "Paris"},
+			},
+			"continent": map[string]interface{}{
+				"code":  "EU",
+				"names": map[string]interface{}{"de": "Europa", "en": "Europe"},
+			},
+			"country": map[string]interface{}{
+				"is_in_european_union": true,
+				"iso_code":             "FR",
+				"names":                map[string]interface{}{"de": "Frankreich", "en": "France"},
+			},
+			"registered_country": map[string]interface{}{"iso_code": "FR"},
+			"subdivisions": []interface{}{
+				map[string]interface{}{"iso_code": "IDF"},
+			},
+			"autonomous_system_number": uint64(3215),
+		},
+	}
+
+	r, err := newReader(buildDatabase(t, 4, networks))
+	require.NoError(t, err)
+
+	testCases := []struct {
+		desc     string
+		path     []string
+		expected interface{}
+	}{
+		{
+			desc:     "after maps and arrays",
+			path:     []string{"country", "iso_code"},
+			expected: "FR",
+		},
+		{
+			desc:     "top level value",
+			path:     []string{"autonomous_system_number"},
+			expected: uint64(3215),
+		},
+		{
+			desc:     "map",
+			path:     []string{"registered_country"},
+			expected: map[string]interface{}{"iso_code": "FR"},
+		},
+		{
+			desc: "missing key",
+			path: []string{"country", "confidence"},
+		},
+		{
+			desc: "key in a value which is not a map",
+			path: []string{"subdivisions", "iso_code"},
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			value, err := r.lookup(net.ParseIP("1.2.3.4"), test.path...)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expected, value)
+		})
+	}
+}
+
+func TestNewReaderInvalid(t *testing.T) {
+	testCases := []struct {
+		desc   string
+		buffer []byte
+	}{
+		{
+			desc:   "empty file",
+			buffer: []byte{},
+		},
+		{
+			desc:   "metadata not a map",
+			buffer: append(append([]byte{}, metadataStartMarker...), 0x43, 'f', 'o', 'o'),
+		},
+		{
+			desc: "unsupported record size",
+			buffer: func() []byte {
+				var buffer bytes.Buffer
+				buffer.Write(metadataStartMarker)
+				encodeValue(&buffer, map[string]interface{}{
+					"node_count":  uint64(0),
+					"record_size": uint64(20),
+					"ip_version":  uint64(4),
+				})
+				return buffer.Bytes()
+			}(),
+		},
+		{
+			desc: "search tree larger than the file",
+			buffer: func() []byte {
+				var buffer bytes.Buffer
+				buffer.Write(metadataStartMarker)
+				encodeValue(&buffer, map[string]interface{}{
+					"node_count":  uint64(1000),
+					"record_size": uint64(24),
+					"ip_version":  uint64(4),
+				})
+				return buffer.Bytes()
+			}(),
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := newReader(test.buffer)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestDecoderPointer(t *testing.T) {
+	d := decoder{buffer: []byte{
+		// "foo"
+		0x43, 'f', 'o', 'o',
+		// map with one entry: pointer to "foo" => pointer to "foo"
+		0xE1, 0x20, 0x00, 0x20, 0x00,
+	}}
+
+	value, next, err := d.decode(4, 0)
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]interface{}{"foo": "foo"}, value)
+	assert.Equal(t, uint(9), next)
+}
+
+func TestDecoderPathPointer(t *testing.T) {
+	d := decoder{buffer: []byte{
+		// "foo"
+		0x43, 'f', 'o', 'o',
+		// map with two entries: "bar" => pointer to "foo", pointer to "foo" => "baz"
+		0xE2, 0x43, 'b', 'a', 'r', 0x20, 0x00, 0x20, 0x00, 0x43, 'b', 'a', 'z',
+		// pointer to the map
+		0x20, 0x04,
+	}}
+
+	value, err := d.decodePath(17, []string{"foo"}, 0)
+	require.NoError(t, err)
+	assert.Equal(t, "baz", value)
+
+	value, err = d.decodePath(17, []string{"bar"}, 0)
+	require.NoError(t, err)
+	assert.Equal(t, "foo", value)
+
+	next, err := d.skip(4, 0)
+	require.NoError(t, err)
+	assert.Equal(t, uint(17), next)
+}
+
+func TestDecoderLoop(t *testing.T) {
+	// A pointer to itself.
+	d := decoder{buffer: []byte{0x20, 0x00}}
+
+	_, _, err := d.decode(0, 0)
+	assert.Error(t, err)
+
+	_, err = d.decodePath(0, []string{"foo"}, 0)
+	assert.Error(t, err)
+}
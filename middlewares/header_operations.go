@@ -0,0 +1,184 @@
+package middlewares
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"regexp"
+	"strings"
+	"text/template"
+
+	"github.com/containous/mux"
+	"github.com/containous/traefik/ip"
+	"github.com/containous/traefik/log"
+	"github.com/containous/traefik/types"
+)
+
+const headerTemplateDataKey key = "HeaderTemplateData"
+
+// RequestIDKey is the key of the request ID in the context of the request, set by the request ID middleware of the entry points or the frontends.
+const RequestIDKey key = "RequestID"
+
+// headerTemplateData holds the request data available in the header operation values.
+type headerTemplateData struct {
+	ClientIP  string
+	RequestID string
+	Host      string
+	SNI       string
+	Method    string
+	Path      string
+	Vars      map[string]string
+}
+
+// newHeaderTemplateData returns the data of the request, the client IP being selected with the strategy (the remote address when nil).
+func newHeaderTemplateData(r *http.Request, strategy ip.Strategy) *headerTemplateData {
+	clientIP := r.RemoteAddr
+	if strategy != nil {
+		clientIP = strategy.GetIP(r)
+	}
+
+	data := &headerTemplateData{
+		ClientIP:  clientIP,
+		RequestID: requestIDFromContext(r.Context()),
+		Host:      GetCanonizedHost(r.Context()),
+		Method:    r.Method,
+		Path:      r.URL.Path,
+		Vars:      mux.Vars(r),
+	}
+
+	if host, _, err := net.SplitHostPort(clientIP); err == nil {
+		data.ClientIP = host
+	}
+
+	if len(data.Host) == 0 {
+		data.Host = types.CanonicalDomain(parseHost(r.Host))
+	}
+
+	if r.TLS != nil {
+		data.SNI = r.TLS.ServerName
+	}
+
+	return data
+}
+
+// requestIDFromContext returns the ID of the request, when the request ID is enabled on the entry point.
+func requestIDFromContext(ctx context.Context) string {
+	requestID, _ := ctx.Value(RequestIDKey).(string)
+	return requestID
+}
+
+// getHeaderTemplateData returns the data saved by the headers middleware,
+// so the response operations see the request as it was received and not as it was forwarded.
+func getHeaderTemplateData(r *http.Request, strategy ip.Strategy) *headerTemplateData {
+	if data, ok := r.Context().Value(headerTemplateDataKey).(*headerTemplateData); ok {
+		return data
+	}
+	return newHeaderTemplateData(r, strategy)
+}
+
+type headerOperation struct {
+	action      string
+	name        string
+	value       string
+	tmpl        *template.Template
+	regex       *regexp.Regexp
+	statusCodes types.HTTPCodeRanges
+}
+
+func newHeaderOperations(operations []types.HeaderOperation, response bool) ([]*headerOperation, error) {
+	var ops []*headerOperation
+	for i, operation := range operations {
+		op, err := newHeaderOperation(operation, response)
+		if err != nil {
+			return nil, fmt.Errorf("invalid header operation %d (%s %s): %v", i, operation.Action, operation.Name, err)
+		}
+		ops = append(ops, op)
+	}
+	return ops, nil
+}
+
+func newHeaderOperation(operation types.HeaderOperation, response bool) (*headerOperation, error) {
+	if len(operation.Name) == 0 {
+		return nil, errors.New("missing header name")
+	}
+
+	op := &headerOperation{
+		action: strings.ToLower(operation.Action),
+		name:   http.CanonicalHeaderKey(operation.Name),
+		value:  operation.Value,
+	}
+
+	switch op.action {
+	case types.HeaderOperationSet, types.HeaderOperationAppend, types.HeaderOperationRemove:
+	case types.HeaderOperationReplace:
+		if len(operation.Regex) == 0 {
+			return nil, errors.New("missing regex")
+		}
+
+		regex, err := regexp.Compile(operation.Regex)
+		if err != nil {
+			return nil, err
+		}
+		op.regex = regex
+	default:
+		return nil, fmt.Errorf("unknown action %q", operation.Action)
+	}
+
+	if strings.Contains(operation.Value, "{{") {
+		tmpl, err := template.New(op.name).Option("missingkey=zero").Parse(operation.Value)
+		if err != nil {
+			return nil, err
+		}
+		op.tmpl = tmpl
+	}
+
+	if len(operation.StatusCodes) > 0 {
+		if !response {
+			return nil, errors.New("status codes are only supported on response operations")
+		}
+
+		statusCodes, err := types.NewHTTPCodeRanges(operation.StatusCodes)
+		if err != nil {
+			return nil, err
+		}
+		op.statusCodes = statusCodes
+	}
+
+	return op, nil
+}
+
+func (o *headerOperation) apply(header http.Header, data *headerTemplateData) {
+	if o.action == types.HeaderOperationRemove {
+		header.Del(o.name)
+		return
+	}
+
+	value := o.value
+	if o.tmpl != nil {
+		var buf bytes.Buffer
+		if err := o.tmpl.Execute(&buf, data); err != nil {
+			log.Errorf("Error while rendering the value of the header %s: %v", o.name, err)
+			return
+		}
+		value = buf.String()
+	}
+
+	switch o.action {
+	case types.HeaderOperationSet:
+		header.Set(o.name, value)
+	case types.HeaderOperationAppend:
+		header.Add(o.name, value)
+	case types.HeaderOperationReplace:
+		values := header[o.name]
+		for i, v := range values {
+			values[i] = o.regex.ReplaceAllString(v, value)
+		}
+	}
+}
+
+func withHeaderTemplateData(r *http.Request, data *headerTemplateData) *http.Request {
+	return r.WithContext(context.WithValue(r.Context(), headerTemplateDataKey, data))
+}
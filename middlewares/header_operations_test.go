@@ -0,0 +1,263 @@
+package middlewares
+
+import (
+	"context"
+	"crypto/tls"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/containous/mux"
+	"github.com/containous/traefik/testhelpers"
+	"github.com/containous/traefik/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewHeaderFromStructInvalidOperations(t *testing.T) {
+	testCases := []struct {
+		desc    string
+		headers *types.Headers
+	}{
+		{
+			desc: "unknown action",
+			headers: &types.Headers{
+				RequestOperations: []types.HeaderOperation{{Action: "rename", Name: "X-Foo"}},
+			},
+		},
+		{
+			desc: "missing name",
+			headers: &types.Headers{
+				RequestOperations: []types.HeaderOperation{{Action: "set", Value: "bar"}},
+			},
+		},
+		{
+			desc: "replace without regex",
+			headers: &types.Headers{
+				RequestOperations: []types.HeaderOperation{{Action: "replace", Name: "X-Foo", Value: "bar"}},
+			},
+		},
+		{
+			desc: "invalid template",
+			headers: &types.Headers{
+				RequestOperations: []types.HeaderOperation{{Action: "set", Name: "X-Foo", Value: "{{ .ClientIP"}},
+			},
+		},
+		{
+			desc: "status codes on request operation",
+			headers: &types.Headers{
+				RequestOperations: []types.HeaderOperation{{Action: "set", Name: "X-Foo", Value: "bar", StatusCodes: []string{"200"}}},
+			},
+		},
+		{
+			desc: "invalid status codes",
+			headers: &types.Headers{
+				ResponseOperations: []types.HeaderOperation{{Action: "set", Name: "X-Foo", Value: "bar", StatusCodes: []string{"5xx"}}},
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := NewHeaderFromStruct(test.headers, nil)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestHeaderRequestOperations(t *testing.T) {
+	testCases := []struct {
+		desc       string
+		operations []types.HeaderOperation
+		ipStrategy *types.IPStrategy
+		reqHeaders map[string][]string
+		requestID  string
+		expected   map[string][]string
+	}{
+		{
+			desc: "set, append and remove in order",
+			operations: []types.HeaderOperation{
+				{Action: "set", Name: "X-Foo", Value: "foo"},
+				{Action: "append", Name: "X-Foo", Value: "bar"},
+				{Action: "remove", Name: "X-Secret"},
+			},
+			reqHeaders: map[string][]string{
+				"X-Foo":    {"old"},
+				"X-Secret": {"s3cr3t"},
+			},
+			expected: map[string][]string{
+				"X-Foo":    {"foo", "bar"},
+				"X-Secret": nil,
+			},
+		},
+		{
+			desc: "regex replace",
+			operations: []types.HeaderOperation{
+				{Action: "replace", Name: "X-Forwarded-Url", Regex: "^http://(.*)$", Value: "https://$1"},
+			},
+			reqHeaders: map[string][]string{
+				"X-Forwarded-Url": {"http://foo.bar/baz"},
+			},
+			expected: map[string][]string{
+				"X-Forwarded-Url": {"https://foo.bar/baz"},
+			},
+		},
+		{
+			desc: "templated values",
+			operations: []types.HeaderOperation{
+				{Action: "set", Name: "X-Client-Ip", Value: "{{ .ClientIP }}"},
+				{Action: "set", Name: "X-Host", Value: "{{ .Host }}"},
+				{Action: "set", Name: "X-Sni", Value: "{{ .SNI }}"},
+				{Action: "set", Name: "X-Request-Id-Copy", Value: "{{ .RequestID }}"},
+				{Action: "set", Name: "X-User-Id", Value: "user-{{ .Vars.id }}"},
+			},
+			reqHeaders: map[string][]string{
+				// the ID is the one of the entry point, whatever its header
+				"X-Request-Id": {"forged"},
+			},
+			requestID: "abc123",
+			expected: map[string][]string{
+				"X-Client-Ip":       {"10.0.0.1"},
+				"X-Host":            {"foo.bar"},
+				"X-Sni":             {"foo.bar"},
+				"X-Request-Id-Copy": {"abc123"},
+				"X-User-Id":         {"user-42"},
+			},
+		},
+		{
+			desc: "client IP from the X-Forwarded-For depth",
+			operations: []types.HeaderOperation{
+				{Action: "set", Name: "X-Client-Ip", Value: "{{ .ClientIP }}"},
+			},
+			ipStrategy: &types.IPStrategy{Depth: 2},
+			reqHeaders: map[string][]string{
+				"X-Forwarded-For": {"1.2.3.4, 10.0.0.2"},
+			},
+			expected: map[string][]string{
+				"X-Client-Ip": {"1.2.3.4"},
+			},
+		},
+		{
+			desc: "client IP from the excluded IPs",
+			operations: []types.HeaderOperation{
+				{Action: "set", Name: "X-Client-Ip", Value: "{{ .ClientIP }}"},
+			},
+			ipStrategy: &types.IPStrategy{ExcludedIPs: []string{"10.0.0.0/8"}},
+			reqHeaders: map[string][]string{
+				"X-Forwarded-For": {"1.2.3.4, 10.0.0.2"},
+			},
+			expected: map[string][]string{
+				"X-Client-Ip": {"1.2.3.4"},
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			strategy, err := test.ipStrategy.Get()
+			require.NoError(t, err)
+
+			header, err := NewHeaderFromStruct(&types.Headers{RequestOperations: test.operations}, strategy)
+			require.NoError(t, err)
+
+			var reqHeaders http.Header
+			router := mux.NewRouter()
+			router.Path("/users/{id}").HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				header.ServeHTTP(rw, req, func(rw http.ResponseWriter, req *http.Request) {
+					reqHeaders = req.Header
+				})
+			})
+
+			req := testhelpers.MustNewRequest(http.MethodGet, "https://foo.bar:8443/users/42", nil)
+			req.RemoteAddr = "10.0.0.1:34567"
+			req.TLS = &tls.ConnectionState{ServerName: "foo.bar"}
+			for name, values := range test.reqHeaders {
+				req.Header[name] = values
+			}
+			if len(test.requestID) > 0 {
+				req = req.WithContext(context.WithValue(req.Context(), RequestIDKey, test.requestID))
+			}
+
+			router.ServeHTTP(httptest.NewRecorder(), req)
+
+			require.NotNil(t, reqHeaders)
+			for name, values := range test.expected {
+				assert.Equal(t, values, reqHeaders[name], name)
+			}
+		})
+	}
+}
+
+func TestHeaderResponseOperations(t *testing.T) {
+	header, err := NewHeaderFromStruct(&types.Headers{
+		ResponseOperations: []types.HeaderOperation{
+			{Action: "remove", Name: "Server"},
+			{Action: "set", Name: "Cache-Control", Value: "no-store", StatusCodes: []string{"500-599"}},
+			{Action: "append", Name: "X-Served-For", Value: "{{ .Host }}"},
+		},
+	}, nil)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		desc       string
+		statusCode int
+		expected   map[string][]string
+	}{
+		{
+			desc:       "success response",
+			statusCode: http.StatusOK,
+			expected: map[string][]string{
+				"Server":        nil,
+				"Cache-Control": {"max-age=60"},
+				"X-Served-For":  {"foo.bar"},
+			},
+		},
+		{
+			desc:       "error response",
+			statusCode: http.StatusBadGateway,
+			expected: map[string][]string{
+				"Server":        nil,
+				"Cache-Control": {"no-store"},
+				"X-Served-For":  {"foo.bar"},
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			var forwardedReq *http.Request
+			req := testhelpers.MustNewRequest(http.MethodGet, "http://foo.bar/", nil)
+			header.ServeHTTP(httptest.NewRecorder(), req, func(rw http.ResponseWriter, req *http.Request) {
+				forwardedReq = req
+			})
+
+			// The request sent to the backend does not keep the client Host.
+			forwardedReq.Host = "backend:8080"
+
+			res := &http.Response{
+				StatusCode: test.statusCode,
+				Request:    forwardedReq,
+				Header: http.Header{
+					"Server":        {"nginx"},
+					"Cache-Control": {"max-age=60"},
+				},
+			}
+
+			err := header.ModifyResponseHeaders(res)
+			require.NoError(t, err)
+
+			for name, values := range test.expected {
+				assert.Equal(t, values, res.Header[name], name)
+			}
+		})
+	}
+}
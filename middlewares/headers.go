@@ -5,6 +5,7 @@ package middlewares
 import (
 	"net/http"
 
+	"github.com/containous/traefik/ip"
 	"github.com/containous/traefik/types"
 )
 
@@ -21,12 +22,28 @@ type HeaderOptions struct {
 type HeaderStruct struct {
 	// Customize headers with a headerOptions struct.
 	opt HeaderOptions
+	// Ordered operations, applied after the custom headers.
+	requestOperations  []*headerOperation
+	responseOperations []*headerOperation
+	// Selection of the client IP of the operations.
+	ipStrategy ip.Strategy
 }
 
 // NewHeaderFromStruct constructs a new header instance from supplied frontend header struct.
-func NewHeaderFromStruct(headers *types.Headers) *HeaderStruct {
+// The client IP of the operations is selected with the strategy of the entry point.
+func NewHeaderFromStruct(headers *types.Headers, ipStrategy ip.Strategy) (*HeaderStruct, error) {
 	if headers == nil || !headers.HasCustomHeadersDefined() {
-		return nil
+		return nil, nil
+	}
+
+	requestOperations, err := newHeaderOperations(headers.RequestOperations, false)
+	if err != nil {
+		return nil, err
+	}
+
+	responseOperations, err := newHeaderOperations(headers.ResponseOperations, true)
+	if err != nil {
+		return nil, err
 	}
 
 	return &HeaderStruct{
@@ -34,10 +51,17 @@ func NewHeaderFromStruct(headers *types.Headers) *HeaderStruct {
 			CustomRequestHeaders:  headers.CustomRequestHeaders,
 			CustomResponseHeaders: headers.CustomResponseHeaders,
 		},
-	}
+		requestOperations:  requestOperations,
+		responseOperations: responseOperations,
+		ipStrategy:         ipStrategy,
+	}, nil
 }
 
 func (s *HeaderStruct) ServeHTTP(w http.ResponseWriter, r *http.Request, next http.HandlerFunc) {
+	if len(s.requestOperations) > 0 || len(s.responseOperations) > 0 {
+		r = withHeaderTemplateData(r, newHeaderTemplateData(r, s.ipStrategy))
+	}
+
 	s.ModifyRequestHeaders(r)
 	// If there is a next, call it.
 	if next != nil {
@@ -55,6 +79,13 @@ func (s *HeaderStruct) ModifyRequestHeaders(r *http.Request) {
 			r.Header.Set(header, value)
 		}
 	}
+
+	if len(s.requestOperations) > 0 {
+		data := getHeaderTemplateData(r, s.ipStrategy)
+		for _, op := range s.requestOperations {
+			op.apply(r.Header, data)
+		}
+	}
 }
 
 // ModifyResponseHeaders set or delete response headers
@@ -67,5 +98,14 @@ func (s *HeaderStruct) ModifyResponseHeaders(res *http.Response) error {
 			res.Header.Set(header, value)
 		}
 	}
+
+	if len(s.responseOperations) > 0 && res.Request != nil {
+		data := getHeaderTemplateData(res.Request, s.ipStrategy)
+		for _, op := range s.responseOperations {
+			if op.statusCodes == nil || op.statusCodes.Contains(res.StatusCode) {
+				op.apply(res.Header, data)
+			}
+		}
+	}
 	return nil
 }
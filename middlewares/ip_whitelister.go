@@ -2,11 +2,13 @@ package middlewares
 
 import (
 	"fmt"
+	"net"
 	"net/http"
 
 	"github.com/containous/traefik/ip"
 	"github.com/containous/traefik/log"
 	"github.com/containous/traefik/middlewares/tracing"
+	"github.com/containous/traefik/types"
 	"github.com/pkg/errors"
 	"github.com/urfave/negroni"
 )
@@ -15,36 +17,84 @@ import (
 type IPWhiteLister struct {
 	handler     negroni.Handler
 	whiteLister *ip.Checker
+	allowedSets []*ip.Set
+	denyLister  *ip.Checker
+	deniedSets  []*ip.Set
 	strategy    ip.Strategy
 }
 
 // NewIPWhiteLister builds a new IPWhiteLister given a list of CIDR-Strings to whitelist
 func NewIPWhiteLister(whiteList []string, strategy ip.Strategy) (*IPWhiteLister, error) {
-	if len(whiteList) == 0 {
+	return NewIPFilter(&types.WhiteList{SourceRange: whiteList}, nil, strategy)
+}
+
+// NewIPFilter builds a new IPWhiteLister given a white list configuration,
+// which allows and denies CIDRs inline or through the named IP sets.
+func NewIPFilter(whiteList *types.WhiteList, ipSets map[string]*ip.Set, strategy ip.Strategy) (*IPWhiteLister, error) {
+	if len(whiteList.SourceRange) == 0 && len(whiteList.SourceSets) == 0 &&
+		len(whiteList.DenyRange) == 0 && len(whiteList.DenySets) == 0 {
 		return nil, errors.New("no white list provided")
 	}
 
-	checker, err := ip.NewChecker(whiteList)
+	whiteLister := IPWhiteLister{
+		strategy: strategy,
+	}
+
+	var err error
+	if len(whiteList.SourceRange) > 0 {
+		whiteLister.whiteLister, err = ip.NewChecker(whiteList.SourceRange)
+		if err != nil {
+			return nil, fmt.Errorf("parsing CIDR whitelist %s: %v", whiteList.SourceRange, err)
+		}
+	}
+
+	if len(whiteList.DenyRange) > 0 {
+		whiteLister.denyLister, err = ip.NewChecker(whiteList.DenyRange)
+		if err != nil {
+			return nil, fmt.Errorf("parsing CIDR deny list %s: %v", whiteList.DenyRange, err)
+		}
+	}
+
+	whiteLister.allowedSets, err = getIPSets(whiteList.SourceSets, ipSets)
 	if err != nil {
-		return nil, fmt.Errorf("parsing CIDR whitelist %s: %v", whiteList, err)
+		return nil, err
 	}
 
-	whiteLister := IPWhiteLister{
-		strategy:    strategy,
-		whiteLister: checker,
+	whiteLister.deniedSets, err = getIPSets(whiteList.DenySets, ipSets)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, set := range whiteLister.deniedSets {
+		if !set.Loaded() {
+			log.Errorf("The denied IP set %s is not loaded: all the requests are rejected until it is", set.Name())
+		}
 	}
 
 	whiteLister.handler = negroni.HandlerFunc(whiteLister.handle)
-	log.Debugf("configured IP white list: %s", whiteList)
+	log.Debugf("configured IP white list: %s, IP sets: %s, deny list: %s, denied IP sets: %s",
+		whiteList.SourceRange, whiteList.SourceSets, whiteList.DenyRange, whiteList.DenySets)
 
 	return &whiteLister, nil
 }
 
+func getIPSets(names []string, ipSets map[string]*ip.Set) ([]*ip.Set, error) {
+	var sets []*ip.Set
+	for _, name := range names {
+		set, ok := ipSets[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown IP set %q", name)
+		}
+		sets = append(sets, set)
+	}
+	return sets, nil
+}
+
 func (wl *IPWhiteLister) handle(w http.ResponseWriter, r *http.Request, next http.HandlerFunc) {
-	err := wl.whiteLister.IsAuthorized(wl.strategy.GetIP(r))
+	err := wl.isAuthorized(wl.strategy.GetIP(r))
 	if err != nil {
 		tracing.SetErrorAndDebugLog(r, "request %+v - rejecting: %v", r, err)
-		reject(w)
+		Reject(w)
 		return
 	}
 	log.Debugf("Accept %s: %+v", wl.strategy.GetIP(r), r)
@@ -52,11 +102,55 @@ func (wl *IPWhiteLister) handle(w http.ResponseWriter, r *http.Request, next htt
 	next.ServeHTTP(w, r)
 }
 
+// isAuthorized checks that the address is not denied, and that it is allowed when an allow list is defined.
+func (wl *IPWhiteLister) isAuthorized(addr string) error {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		host = addr
+	}
+
+	clientIP := net.ParseIP(host)
+	if clientIP == nil {
+		return fmt.Errorf("unable to parse address: %q", addr)
+	}
+
+	if wl.denyLister != nil && wl.denyLister.ContainsIP(clientIP) {
+		return fmt.Errorf("%q matched the deny list", addr)
+	}
+
+	for _, set := range wl.deniedSets {
+		// an unknown deny list must not let everybody in
+		if !set.Loaded() {
+			return fmt.Errorf("the denied IP set %s is not loaded", set.Name())
+		}
+		if set.ContainsIP(clientIP) {
+			return fmt.Errorf("%q matched the denied IP set %s", addr, set.Name())
+		}
+	}
+
+	if wl.whiteLister == nil && len(wl.allowedSets) == 0 {
+		return nil
+	}
+
+	if wl.whiteLister != nil && wl.whiteLister.ContainsIP(clientIP) {
+		return nil
+	}
+
+	for _, set := range wl.allowedSets {
+		if set.ContainsIP(clientIP) {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%q matched none of the trusted IPs", addr)
+}
+
 func (wl *IPWhiteLister) ServeHTTP(rw http.ResponseWriter, r *http.Request, next http.HandlerFunc) {
 	wl.handler.ServeHTTP(rw, r, next)
 }
 
-func reject(w http.ResponseWriter) {
+// Reject answers the request with a 403 Forbidden.
+func Reject(w http.ResponseWriter) {
 	statusCode := http.StatusForbidden
 
 	w.WriteHeader(statusCode)
@@ -1,11 +1,15 @@
 package middlewares
 
 import (
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/containous/traefik/ip"
+	"github.com/containous/traefik/types"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -90,3 +94,184 @@ func TestIPWhiteLister_ServeHTTP(t *testing.T) {
 		})
 	}
 }
+
+func TestNewIPFilter(t *testing.T) {
+	testCases := []struct {
+		desc          string
+		whiteList     *types.WhiteList
+		expectedError string
+	}{
+		{
+			desc:          "empty white list",
+			whiteList:     &types.WhiteList{},
+			expectedError: "no white list provided",
+		},
+		{
+			desc: "invalid deny list",
+			whiteList: &types.WhiteList{
+				DenyRange: []string{"foo"},
+			},
+			expectedError: "parsing CIDR deny list [foo]: parsing CIDR trusted IPs <nil>: invalid CIDR address: foo",
+		},
+		{
+			desc: "unknown IP set",
+			whiteList: &types.WhiteList{
+				SourceSets: []string{"bar"},
+			},
+			expectedError: `unknown IP set "bar"`,
+		},
+		{
+			desc: "deny list only",
+			whiteList: &types.WhiteList{
+				DenyRange: []string{"10.10.10.10"},
+				DenySets:  []string{"foo"},
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			set, err := ip.NewSet("foo", "/foo/bar", "", 0)
+			require.NoError(t, err)
+
+			whiteLister, err := NewIPFilter(test.whiteList, map[string]*ip.Set{"foo": set}, &ip.RemoteAddrStrategy{})
+
+			if len(test.expectedError) > 0 {
+				assert.EqualError(t, err, test.expectedError)
+			} else {
+				require.NoError(t, err)
+				assert.NotNil(t, whiteLister)
+			}
+		})
+	}
+}
+
+func TestIPFilter_ServeHTTP(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ipset")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	ipSets := make(map[string]*ip.Set)
+	for name, content := range map[string]string{
+		"abuse":   "30.30.30.0/24\n",
+		"offices": "20.20.20.0/24\n",
+	} {
+		path := filepath.Join(dir, name)
+		require.NoError(t, ioutil.WriteFile(path, []byte(content), 0644))
+
+		set, err := ip.NewSet(name, path, "", 0)
+		require.NoError(t, err)
+		require.NoError(t, set.Load())
+
+		ipSets[name] = set
+	}
+
+	unavailable, err := ip.NewSet("unavailable", filepath.Join(dir, "missing"), "", 0)
+	require.NoError(t, err)
+	require.Error(t, unavailable.Load())
+	ipSets["unavailable"] = unavailable
+
+	testCases := []struct {
+		desc       string
+		whiteList  *types.WhiteList
+		remoteAddr string
+		expected   int
+	}{
+		{
+			desc: "not denied with deny list",
+			whiteList: &types.WhiteList{
+				DenyRange: []string{"30.30.30.30"},
+			},
+			remoteAddr: "20.20.20.20:1234",
+			expected:   200,
+		},
+		{
+			desc: "denied with deny list",
+			whiteList: &types.WhiteList{
+				DenyRange: []string{"30.30.30.30"},
+			},
+			remoteAddr: "30.30.30.30:1234",
+			expected:   403,
+		},
+		{
+			desc: "denied with denied IP set",
+			whiteList: &types.WhiteList{
+				DenySets: []string{"abuse"},
+			},
+			remoteAddr: "30.30.30.31:1234",
+			expected:   403,
+		},
+		{
+			desc: "denied IP set not loaded",
+			whiteList: &types.WhiteList{
+				DenySets: []string{"unavailable"},
+			},
+			remoteAddr: "20.20.20.20:1234",
+			expected:   403,
+		},
+		{
+			desc: "allowed IP set not loaded",
+			whiteList: &types.WhiteList{
+				SourceSets: []string{"unavailable"},
+			},
+			remoteAddr: "20.20.20.20:1234",
+			expected:   403,
+		},
+		{
+			desc: "authorized with allowed IP set",
+			whiteList: &types.WhiteList{
+				SourceRange: []string{"10.10.10.10"},
+				SourceSets:  []string{"offices"},
+			},
+			remoteAddr: "20.20.20.21:1234",
+			expected:   200,
+		},
+		{
+			desc: "non authorized with allowed IP set",
+			whiteList: &types.WhiteList{
+				SourceSets: []string{"offices"},
+			},
+			remoteAddr: "10.10.10.10:1234",
+			expected:   403,
+		},
+		{
+			desc: "deny list takes precedence over the white list",
+			whiteList: &types.WhiteList{
+				SourceRange: []string{"20.20.20.0/24"},
+				DenyRange:   []string{"20.20.20.20"},
+			},
+			remoteAddr: "20.20.20.20:1234",
+			expected:   403,
+		},
+		{
+			desc: "invalid remote address",
+			whiteList: &types.WhiteList{
+				DenyRange: []string{"30.30.30.30"},
+			},
+			remoteAddr: "foo",
+			expected:   403,
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			whiteLister, err := NewIPFilter(test.whiteList, ipSets, &ip.RemoteAddrStrategy{})
+			require.NoError(t, err)
+
+			recorder := httptest.NewRecorder()
+
+			req := httptest.NewRequest(http.MethodGet, "http://10.10.10.10", nil)
+			req.RemoteAddr = test.remoteAddr
+
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+
+			whiteLister.ServeHTTP(recorder, req, next)
+
+			assert.Equal(t, test.expected, recorder.Code)
+		})
+	}
+}
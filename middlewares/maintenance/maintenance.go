@@ -0,0 +1,161 @@
+package maintenance
+
+import (
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"mime"
+	"net"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/containous/traefik/ip"
+	"github.com/containous/traefik/log"
+	"github.com/containous/traefik/middlewares/toggles"
+	"github.com/containous/traefik/middlewares/tracing"
+	"github.com/containous/traefik/types"
+)
+
+const (
+	// DefaultStatusCode is the default status code of the maintenance page.
+	DefaultStatusCode = http.StatusServiceUnavailable
+	// DefaultRetryAfter is the default delay announced in the Retry-After header.
+	DefaultRetryAfter = 5 * time.Minute
+)
+
+// Maintenance is a middleware answering the requests with a maintenance page, when the maintenance mode is enabled.
+type Maintenance struct {
+	provider          string
+	frontend          string
+	enabled           bool
+	toggles           *toggles.Store
+	statusCode        int
+	retryAfter        string
+	page              []byte
+	contentType       string
+	bypassChecker     *ip.Checker
+	bypassHeaderName  string
+	bypassHeaderValue string
+	strategy          ip.Strategy
+}
+
+// NewMaintenance creates a maintenance middleware for a frontend, from its configuration (which can be nil)
+// and the toggles set at runtime.
+func NewMaintenance(providerName, frontendName string, config *types.Maintenance, toggleStore *toggles.Store, strategy ip.Strategy) (*Maintenance, error) {
+	if config == nil {
+		config = &types.Maintenance{}
+	}
+
+	m := &Maintenance{
+		provider:          providerName,
+		frontend:          frontendName,
+		enabled:           config.Enabled,
+		toggles:           toggleStore,
+		statusCode:        config.StatusCode,
+		bypassHeaderName:  config.BypassHeaderName,
+		bypassHeaderValue: config.BypassHeaderValue,
+		strategy:          strategy,
+	}
+
+	if m.statusCode == 0 {
+		m.statusCode = DefaultStatusCode
+	}
+	if m.statusCode < http.StatusOK || m.statusCode > 599 {
+		return nil, fmt.Errorf("invalid maintenance status code %d", m.statusCode)
+	}
+
+	retryAfter := time.Duration(config.RetryAfter)
+	if retryAfter == 0 {
+		retryAfter = DefaultRetryAfter
+	}
+	if retryAfter > 0 {
+		m.retryAfter = strconv.Itoa(int(retryAfter.Seconds()))
+	}
+
+	if len(config.BypassHeaderName) > 0 && len(config.BypassHeaderValue) == 0 {
+		return nil, errors.New("a bypass header value is required with the bypass header name")
+	}
+
+	if len(config.SourceRange) > 0 {
+		checker, err := ip.NewChecker(config.SourceRange)
+		if err != nil {
+			return nil, fmt.Errorf("parsing CIDR maintenance bypass list %s: %v", config.SourceRange, err)
+		}
+		m.bypassChecker = checker
+	}
+
+	m.page = []byte(http.StatusText(m.statusCode))
+	m.contentType = "text/plain; charset=utf-8"
+	if len(config.Page) > 0 {
+		page, err := ioutil.ReadFile(config.Page)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read the maintenance page: %v", err)
+		}
+		m.page = page
+
+		m.contentType = mime.TypeByExtension(filepath.Ext(config.Page))
+		if len(m.contentType) == 0 {
+			m.contentType = http.DetectContentType(page)
+		}
+	}
+
+	return m, nil
+}
+
+// IsEnabled returns whether the frontend is in maintenance.
+func (m *Maintenance) IsEnabled() bool {
+	if enabled, ok := m.toggles.Get(m.provider, m.frontend); ok {
+		return enabled
+	}
+	return m.enabled
+}
+
+func (m *Maintenance) ServeHTTP(rw http.ResponseWriter, req *http.Request, next http.HandlerFunc) {
+	if !m.IsEnabled() || m.isBypassed(req) {
+		next.ServeHTTP(rw, req)
+		return
+	}
+
+	tracing.SetErrorAndDebugLog(req, "frontend %s in maintenance", m.frontend)
+
+	rw.Header().Set("Content-Type", m.contentType)
+	rw.Header().Set("Cache-Control", "no-store")
+	if len(m.retryAfter) > 0 {
+		rw.Header().Set("Retry-After", m.retryAfter)
+	}
+	rw.WriteHeader(m.statusCode)
+
+	if req.Method == http.MethodHead {
+		return
+	}
+
+	if _, err := rw.Write(m.page); err != nil {
+		log.Debugf("Unable to write the maintenance page: %v", err)
+	}
+}
+
+func (m *Maintenance) isBypassed(req *http.Request) bool {
+	if len(m.bypassHeaderName) > 0 {
+		value := req.Header.Get(m.bypassHeaderName)
+		if len(value) > 0 && subtle.ConstantTimeCompare([]byte(value), []byte(m.bypassHeaderValue)) == 1 {
+			return true
+		}
+	}
+
+	if m.bypassChecker != nil {
+		addr := m.strategy.GetIP(req)
+		host, _, err := net.SplitHostPort(addr)
+		if err != nil {
+			host = addr
+		}
+
+		if clientIP := net.ParseIP(host); clientIP != nil && m.bypassChecker.ContainsIP(clientIP) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,222 @@
+package maintenance
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/containous/flaeg/parse"
+	"github.com/containous/traefik/ip"
+	"github.com/containous/traefik/middlewares/toggles"
+	"github.com/containous/traefik/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewMaintenanceInvalid(t *testing.T) {
+	testCases := []struct {
+		desc   string
+		config *types.Maintenance
+	}{
+		{
+			desc:   "invalid status code",
+			config: &types.Maintenance{StatusCode: 42},
+		},
+		{
+			desc:   "invalid source range",
+			config: &types.Maintenance{SourceRange: []string{"foo"}},
+		},
+		{
+			desc:   "bypass header without value",
+			config: &types.Maintenance{BypassHeaderName: "X-Bypass"},
+		},
+		{
+			desc:   "missing page",
+			config: &types.Maintenance{Page: "/does/not/exist.html"},
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := NewMaintenance("file", "frontend", test.config, nil, &ip.RemoteAddrStrategy{})
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestMaintenance(t *testing.T) {
+	dir, err := ioutil.TempDir("", "maintenance")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	page := filepath.Join(dir, "maintenance.html")
+	err = ioutil.WriteFile(page, []byte("<h1>Back soon</h1>"), 0644)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		desc                string
+		config              *types.Maintenance
+		remoteAddr          string
+		header              http.Header
+		method              string
+		expectedStatusCode  int
+		expectedBody        string
+		expectedContentType string
+		expectedRetryAfter  string
+	}{
+		{
+			desc:               "disabled",
+			config:             &types.Maintenance{},
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       "backend",
+		},
+		{
+			desc:                "enabled with the defaults",
+			config:              &types.Maintenance{Enabled: true},
+			expectedStatusCode:  http.StatusServiceUnavailable,
+			expectedBody:        "Service Unavailable",
+			expectedContentType: "text/plain; charset=utf-8",
+			expectedRetryAfter:  "300",
+		},
+		{
+			desc: "enabled with a page",
+			config: &types.Maintenance{
+				Enabled:    true,
+				StatusCode: http.StatusOK,
+				RetryAfter: parse.Duration(time.Minute),
+				Page:       page,
+			},
+			expectedStatusCode:  http.StatusOK,
+			expectedBody:        "<h1>Back soon</h1>",
+			expectedContentType: "text/html; charset=utf-8",
+			expectedRetryAfter:  "60",
+		},
+		{
+			desc:                "HEAD request",
+			config:              &types.Maintenance{Enabled: true},
+			method:              http.MethodHead,
+			expectedStatusCode:  http.StatusServiceUnavailable,
+			expectedContentType: "text/plain; charset=utf-8",
+			expectedRetryAfter:  "300",
+		},
+		{
+			desc: "bypassed by the source range",
+			config: &types.Maintenance{
+				Enabled:     true,
+				SourceRange: []string{"10.0.0.0/8"},
+			},
+			remoteAddr:         "10.1.2.3:1234",
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       "backend",
+		},
+		{
+			desc: "not bypassed by the source range",
+			config: &types.Maintenance{
+				Enabled:     true,
+				SourceRange: []string{"10.0.0.0/8"},
+			},
+			remoteAddr:          "192.168.1.1:1234",
+			expectedStatusCode:  http.StatusServiceUnavailable,
+			expectedBody:        "Service Unavailable",
+			expectedContentType: "text/plain; charset=utf-8",
+			expectedRetryAfter:  "300",
+		},
+		{
+			desc: "bypassed by the header",
+			config: &types.Maintenance{
+				Enabled:           true,
+				BypassHeaderName:  "X-Bypass",
+				BypassHeaderValue: "secret",
+			},
+			header:             http.Header{"X-Bypass": {"secret"}},
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       "backend",
+		},
+		{
+			desc: "not bypassed by a wrong header value",
+			config: &types.Maintenance{
+				Enabled:           true,
+				BypassHeaderName:  "X-Bypass",
+				BypassHeaderValue: "secret",
+			},
+			header:              http.Header{"X-Bypass": {"guess"}},
+			expectedStatusCode:  http.StatusServiceUnavailable,
+			expectedBody:        "Service Unavailable",
+			expectedContentType: "text/plain; charset=utf-8",
+			expectedRetryAfter:  "300",
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			m, err := NewMaintenance("file", "frontend", test.config, nil, &ip.RemoteAddrStrategy{})
+			require.NoError(t, err)
+
+			method := test.method
+			if len(method) == 0 {
+				method = http.MethodGet
+			}
+
+			req := httptest.NewRequest(method, "http://foo.com/", nil)
+			if len(test.remoteAddr) > 0 {
+				req.RemoteAddr = test.remoteAddr
+			}
+			for name, values := range test.header {
+				req.Header[name] = values
+			}
+
+			recorder := httptest.NewRecorder()
+			m.ServeHTTP(recorder, req, func(rw http.ResponseWriter, req *http.Request) {
+				rw.Write([]byte("backend"))
+			})
+
+			assert.Equal(t, test.expectedStatusCode, recorder.Code)
+			assert.Equal(t, test.expectedBody, recorder.Body.String())
+			assert.Equal(t, test.expectedRetryAfter, recorder.Header().Get("Retry-After"))
+			if len(test.expectedContentType) > 0 {
+				assert.Equal(t, test.expectedContentType, recorder.Header().Get("Content-Type"))
+			}
+		})
+	}
+}
+
+func TestMaintenanceToggles(t *testing.T) {
+	store := toggles.NewStore()
+
+	m, err := NewMaintenance("file", "frontend", &types.Maintenance{}, store, &ip.RemoteAddrStrategy{})
+	require.NoError(t, err)
+	assert.False(t, m.IsEnabled())
+
+	store.Set("file", "frontend", true)
+	assert.True(t, m.IsEnabled())
+
+	store.Set("file", "other", false)
+	assert.True(t, m.IsEnabled())
+
+	store.Set("file", "frontend", false)
+	assert.False(t, m.IsEnabled())
+
+	enabled, ok := store.Get("file", "frontend")
+	assert.False(t, enabled)
+	assert.True(t, ok)
+
+	store.Reset("file", "frontend")
+	_, ok = store.Get("file", "frontend")
+	assert.False(t, ok)
+	assert.False(t, m.IsEnabled())
+
+	m, err = NewMaintenance("file", "frontend", &types.Maintenance{Enabled: true}, store, &ip.RemoteAddrStrategy{})
+	require.NoError(t, err)
+	assert.True(t, m.IsEnabled())
+
+	store.Set("file", "frontend", false)
+	assert.False(t, m.IsEnabled())
+}
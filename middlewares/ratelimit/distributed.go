@@ -0,0 +1,225 @@
+package ratelimit
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/containous/traefik/types"
+	"github.com/mailgun/timetools"
+)
+
+// distributedLimiter limits the requests with counters shared with the other Traefik instances through a store.
+// The requests are counted in fixed windows of the rate periods, and limited to the average of the rates.
+// To limit the round trips to the store, each instance reserves the requests by batches and serves them locally.
+type distributedLimiter struct {
+	name          string
+	rates         []rate
+	maxPeriod     time.Duration
+	store         Store
+	batchSize     int64
+	retryInterval time.Duration
+	clock         timetools.TimeProvider
+
+	mu               sync.Mutex
+	reservations     map[string]*reservation
+	unavailableUntil time.Time
+	nextCleanup      time.Time
+}
+
+type rate struct {
+	name   string
+	period time.Duration
+	limit  int64
+}
+
+// reservation holds the requests of a window reserved by the instance, and not served yet.
+type reservation struct {
+	remaining int64
+	// unreserved is the number of requests not reserved yet by any instance, when the store was last seen.
+	unreserved int64
+	exhausted  bool
+	expires    time.Time
+}
+
+func newDistributedLimiter(name string, rateSet map[string]*types.Rate, store Store, batchSize int64, retryInterval time.Duration,
+	clock timetools.TimeProvider) (*distributedLimiter, error) {
+	if len(rateSet) == 0 {
+		return nil, errors.New("no rate")
+	}
+	if batchSize <= 0 {
+		return nil, fmt.Errorf("invalid batch size %d", batchSize)
+	}
+
+	d := &distributedLimiter{
+		name:          name,
+		store:         store,
+		batchSize:     batchSize,
+		retryInterval: retryInterval,
+		clock:         clock,
+		reservations:  make(map[string]*reservation),
+	}
+
+	for rateName, r := range rateSet {
+		if r.Period <= 0 || r.Average <= 0 {
+			return nil, fmt.Errorf("invalid rate %s: period %s, average %d", rateName, time.Duration(r.Period), r.Average)
+		}
+		d.rates = append(d.rates, rate{name: rateName, period: time.Duration(r.Period), limit: r.Average})
+
+		if time.Duration(r.Period) > d.maxPeriod {
+			d.maxPeriod = time.Duration(r.Period)
+		}
+	}
+
+	// the rates are always consumed in the same order
+	sort.Slice(d.rates, func(i, j int) bool { return d.rates[i].name < d.rates[j].name })
+
+	return d, nil
+}
+
+// consume consumes the amount of requests in all the rates,
+// and returns the quota of the most restrictive one, delayed until the next window when it is exceeded.
+func (d *distributedLimiter) consume(source string, amount int64) (*quota, error) {
+	now := d.clock.UtcNow()
+
+	var q *quota
+	for _, r := range d.rates {
+		window := now.UnixNano() / int64(r.period)
+		reset := time.Duration(int64(r.period) - now.UnixNano()%int64(r.period))
+		key := fmt.Sprintf("%s/%s/%s/%d", url.PathEscape(d.name), url.PathEscape(r.name), url.PathEscape(source), window)
+
+		remaining, taken, exhausted := d.take(key, amount)
+		if !taken && !exhausted {
+			granted, unreserved, err := d.reserve(key, r, amount)
+			if err != nil {
+				return nil, err
+			}
+
+			remaining, taken = d.add(key, granted, unreserved, amount, now.Add(reset))
+		}
+
+		rateQuota := &quota{limit: r.limit, remaining: remaining, reset: reset}
+		if !taken {
+			rateQuota.delay = reset
+			return rateQuota, nil
+		}
+
+		if q == nil || rateQuota.isLower(q) {
+			q = rateQuota
+		}
+	}
+
+	return q, nil
+}
+
+// take takes the amount of requests from the local reservation,
+// and returns the estimated number of requests left in the window.
+func (d *distributedLimiter) take(key string, amount int64) (int64, bool, bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	res, ok := d.reservations[key]
+	if !ok {
+		return 0, false, false
+	}
+
+	if res.remaining >= amount {
+		res.remaining -= amount
+		return res.left(), true, false
+	}
+
+	return res.left(), false, res.exhausted
+}
+
+// reserve reserves a batch of requests in the store,
+// and returns the number of requests granted and the number of requests still unreserved in the window.
+func (d *distributedLimiter) reserve(key string, r rate, amount int64) (int64, int64, error) {
+	batch := d.batchSize
+	if amount > batch {
+		batch = amount
+	}
+
+	total, err := d.store.IncrBy(key, batch, r.period)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	granted := r.limit - (total - batch)
+	if granted > batch {
+		granted = batch
+	}
+	if granted < 0 {
+		granted = 0
+	}
+
+	unreserved := r.limit - total
+	if unreserved < 0 {
+		unreserved = 0
+	}
+
+	return granted, unreserved, nil
+}
+
+// add adds the granted requests to the local reservation, and takes the amount of requests from it.
+func (d *distributedLimiter) add(key string, granted, unreserved, amount int64, expires time.Time) (int64, bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.cleanup()
+
+	res, ok := d.reservations[key]
+	if !ok {
+		res = &reservation{expires: expires}
+		d.reservations[key] = res
+	}
+
+	res.remaining += granted
+	res.unreserved = unreserved
+
+	if res.remaining < amount {
+		// the limit of the window is reached by all the instances
+		res.exhausted = true
+		return res.left(), false
+	}
+
+	res.remaining -= amount
+	return res.left(), true
+}
+
+// left returns the estimated number of requests left in the window, for all the instances.
+func (res *reservation) left() int64 {
+	return res.unreserved + res.remaining
+}
+
+// cleanup removes the reservations of the past windows.
+func (d *distributedLimiter) cleanup() {
+	now := d.clock.UtcNow()
+	if now.Before(d.nextCleanup) {
+		return
+	}
+
+	for key, res := range d.reservations {
+		if !now.Before(res.expires) {
+			delete(d.reservations, key)
+		}
+	}
+
+	d.nextCleanup = now.Add(d.maxPeriod)
+}
+
+func (d *distributedLimiter) isStoreAvailable() bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return !d.clock.UtcNow().Before(d.unavailableUntil)
+}
+
+func (d *distributedLimiter) setStoreUnavailable() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.unavailableUntil = d.clock.UtcNow().Add(d.retryInterval)
+}
@@ -0,0 +1,147 @@
+package ratelimit
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/containous/flaeg/parse"
+	"github.com/containous/traefik/types"
+	"github.com/mailgun/timetools"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type storeMock struct {
+	mu       sync.Mutex
+	counters map[string]int64
+	calls    int
+	err      error
+}
+
+func (s *storeMock) IncrBy(key string, n int64, ttl time.Duration) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.calls++
+	if s.err != nil {
+		return 0, s.err
+	}
+
+	s.counters[key] += n
+	return s.counters[key], nil
+}
+
+func newDistributedMock(t *testing.T, shared Store, batchSize int64, clock timetools.TimeProvider) *RateLimiter {
+	next := http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusOK)
+	})
+
+	config := &types.RateLimit{
+		ExtractorFunc: "client.ip",
+		RateSet: map[string]*types.Rate{
+			"minute": {Period: parse.Duration(time.Minute), Average: 25, Burst: 5},
+		},
+	}
+
+	r, err := newRateLimiter("frontend", next, config, shared, batchSize, 10*time.Second, clock)
+	require.NoError(t, err)
+
+	return r
+}
+
+func serve(handler http.Handler) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "http://localhost", nil)
+	req.RemoteAddr = "10.0.0.1:1234"
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, req)
+	return recorder
+}
+
+func TestNewDistributedLimiterInvalid(t *testing.T) {
+	clock := &timetools.RealTime{}
+
+	_, err := newDistributedLimiter("frontend", nil, &storeMock{}, 10, time.Second, clock)
+	assert.Error(t, err)
+
+	_, err = newDistributedLimiter("frontend", map[string]*types.Rate{"foo": {Average: 10}}, &storeMock{}, 10, time.Second, clock)
+	assert.Error(t, err)
+
+	_, err = newDistributedLimiter("frontend", map[string]*types.Rate{"foo": {Period: parse.Duration(time.Second), Average: 10}}, &storeMock{}, 0, time.Second, clock)
+	assert.Error(t, err)
+}
+
+func TestDistributedSharedLimit(t *testing.T) {
+	shared := &storeMock{counters: make(map[string]int64)}
+	clock := &timetools.FreezedTime{CurrentTime: time.Date(2018, 7, 1, 10, 0, 30, 0, time.UTC)}
+
+	// two instances sharing the store
+	instances := []*RateLimiter{
+		newDistributedMock(t, shared, 10, clock),
+		newDistributedMock(t, shared, 10, clock),
+	}
+
+	var accepted int
+	for i := 0; i < 40; i++ {
+		code := serve(instances[i%2]).Code
+		if code == http.StatusOK {
+			accepted++
+		} else {
+			assert.Equal(t, http.StatusTooManyRequests, code)
+		}
+	}
+
+	assert.Equal(t, 25, accepted)
+	// the requests are reserved by batches
+	assert.True(t, shared.calls < 10, "%d calls", shared.calls)
+
+	// the next window
+	clock.CurrentTime = clock.CurrentTime.Add(time.Minute)
+	assert.Equal(t, http.StatusOK, serve(instances[0]).Code)
+}
+
+func TestDistributedHeaders(t *testing.T) {
+	shared := &storeMock{counters: make(map[string]int64)}
+	clock := &timetools.FreezedTime{CurrentTime: time.Date(2018, 7, 1, 10, 0, 30, 0, time.UTC)}
+	r := newDistributedMock(t, shared, 10, clock)
+
+	recorder := serve(r)
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, "25", recorder.Header().Get("RateLimit-Limit"))
+	// 15 requests not reserved in the store, and 9 reserved by the instance
+	assert.Equal(t, "24", recorder.Header().Get("RateLimit-Remaining"))
+	assert.Equal(t, "30", recorder.Header().Get("RateLimit-Reset"))
+
+	for i := 0; i < 24; i++ {
+		require.Equal(t, http.StatusOK, serve(r).Code)
+	}
+
+	recorder = serve(r)
+	assert.Equal(t, http.StatusTooManyRequests, recorder.Code)
+	assert.Equal(t, "0", recorder.Header().Get("RateLimit-Remaining"))
+	assert.Equal(t, "30", recorder.Header().Get("Retry-After"))
+}
+
+func TestDistributedFallback(t *testing.T) {
+	shared := &storeMock{counters: make(map[string]int64), err: errors.New("connection refused")}
+	clock := &timetools.FreezedTime{CurrentTime: time.Date(2018, 7, 1, 10, 0, 30, 0, time.UTC)}
+	r := newDistributedMock(t, shared, 10, clock)
+
+	// the local rate limits are used
+	for i := 0; i < 5; i++ {
+		assert.Equal(t, http.StatusOK, serve(r).Code)
+	}
+	assert.Equal(t, http.StatusTooManyRequests, serve(r).Code)
+
+	// the store is not used until the retry interval elapses
+	assert.Equal(t, 1, shared.calls)
+
+	shared.err = nil
+	clock.CurrentTime = clock.CurrentTime.Add(10 * time.Second)
+	assert.Equal(t, http.StatusOK, serve(r).Code)
+	assert.Equal(t, 2, shared.calls)
+}
@@ -0,0 +1,131 @@
+package ratelimit
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/vulcand/oxy/utils"
+)
+
+type sourceFunc func(req *http.Request) string
+
+// NewExtractor creates the extractor of the sources of the requests, from a comma-separated list of variables:
+// client.ip, request.host, request.header.<name>, request.cookie.<name>, request.query.<name>, request.jwt.<claim>.
+// With several variables, the source is the combination of their values.
+func NewExtractor(variables string) (utils.SourceExtractor, error) {
+	var sources []sourceFunc
+
+	for _, variable := range strings.Split(variables, ",") {
+		source, err := newSourceFunc(strings.TrimSpace(variable))
+		if err != nil {
+			return nil, err
+		}
+		sources = append(sources, source)
+	}
+
+	return utils.ExtractorFunc(func(req *http.Request) (string, int64, error) {
+		values := make([]string, len(sources))
+		for i, source := range sources {
+			values[i] = source(req)
+		}
+		return strings.Join(values, ","), 1, nil
+	}), nil
+}
+
+func newSourceFunc(variable string) (sourceFunc, error) {
+	switch {
+	case variable == "client.ip":
+		return clientIP, nil
+	case variable == "request.host":
+		return func(req *http.Request) string { return req.Host }, nil
+	}
+
+	for prefix, newSource := range map[string]func(name string) sourceFunc{
+		"request.header.": headerSource,
+		"request.cookie.": cookieSource,
+		"request.query.":  querySource,
+		"request.jwt.":    jwtClaimSource,
+	} {
+		if strings.HasPrefix(variable, prefix) {
+			name := strings.TrimPrefix(variable, prefix)
+			if len(name) == 0 {
+				return nil, fmt.Errorf("missing name in the limiting variable %q", variable)
+			}
+			return newSource(name), nil
+		}
+	}
+
+	return nil, fmt.Errorf("unsupported limiting variable: %q", variable)
+}
+
+func clientIP(req *http.Request) string {
+	host, _, err := net.SplitHostPort(req.RemoteAddr)
+	if err != nil {
+		return req.RemoteAddr
+	}
+	return host
+}
+
+func headerSource(name string) sourceFunc {
+	return func(req *http.Request) string {
+		return req.Header.Get(name)
+	}
+}
+
+func cookieSource(name string) sourceFunc {
+	return func(req *http.Request) string {
+		cookie, err := req.Cookie(name)
+		if err != nil {
+			return ""
+		}
+		return cookie.Value
+	}
+}
+
+func querySource(name string) sourceFunc {
+	return func(req *http.Request) string {
+		return req.URL.Query().Get(name)
+	}
+}
+
+// jwtClaimSource returns the claim of the bearer token of the request.
+// The signature of the token is not verified: it must be done before (e.g. by a forward authentication).
+func jwtClaimSource(claim string) sourceFunc {
+	return func(req *http.Request) string {
+		token := strings.TrimSpace(strings.TrimPrefix(req.Header.Get("Authorization"), "Bearer "))
+
+		parts := strings.Split(token, ".")
+		if len(parts) != 3 {
+			return ""
+		}
+
+		payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+		if err != nil {
+			return ""
+		}
+
+		claims := make(map[string]interface{})
+		if err := json.Unmarshal(payload, &claims); err != nil {
+			return ""
+		}
+
+		value, ok := claims[claim]
+		if !ok || value == nil {
+			return ""
+		}
+
+		if s, ok := value.(string); ok {
+			return s
+		}
+
+		raw, err := json.Marshal(value)
+		if err != nil {
+			return ""
+		}
+		return string(raw)
+	}
+}
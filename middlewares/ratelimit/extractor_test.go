@@ -0,0 +1,113 @@
+package ratelimit
+
+import (
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewExtractor(t *testing.T) {
+	payload := base64.RawURLEncoding.EncodeToString([]byte(`{"sub":"john","tenant":42}`))
+	token := "eyJhbGciOiJIUzI1NiJ9." + payload + ".signature"
+
+	testCases := []struct {
+		desc           string
+		variables      string
+		expectedSource string
+	}{
+		{
+			desc:           "client IP",
+			variables:      "client.ip",
+			expectedSource: "10.0.0.1",
+		},
+		{
+			desc:           "host",
+			variables:      "request.host",
+			expectedSource: "foo.bar",
+		},
+		{
+			desc:           "header",
+			variables:      "request.header.X-Api-Key",
+			expectedSource: "key",
+		},
+		{
+			desc:           "cookie",
+			variables:      "request.cookie.session",
+			expectedSource: "abc",
+		},
+		{
+			desc:           "missing cookie",
+			variables:      "request.cookie.foo",
+			expectedSource: "",
+		},
+		{
+			desc:           "query parameter",
+			variables:      "request.query.user",
+			expectedSource: "jane",
+		},
+		{
+			desc:           "JWT string claim",
+			variables:      "request.jwt.sub",
+			expectedSource: "john",
+		},
+		{
+			desc:           "JWT number claim",
+			variables:      "request.jwt.tenant",
+			expectedSource: "42",
+		},
+		{
+			desc:           "missing JWT claim",
+			variables:      "request.jwt.foo",
+			expectedSource: "",
+		},
+		{
+			desc:           "combination",
+			variables:      "client.ip, request.jwt.sub",
+			expectedSource: "10.0.0.1,john",
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			extractor, err := NewExtractor(test.variables)
+			require.NoError(t, err)
+
+			req := httptest.NewRequest(http.MethodGet, "http://foo.bar/?user=jane", nil)
+			req.RemoteAddr = "10.0.0.1:1234"
+			req.Header.Set("X-Api-Key", "key")
+			req.Header.Set("Authorization", "Bearer "+token)
+			req.AddCookie(&http.Cookie{Name: "session", Value: "abc"})
+
+			source, amount, err := extractor.Extract(req)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expectedSource, source)
+			assert.Equal(t, int64(1), amount)
+		})
+	}
+}
+
+func TestNewExtractorInvalid(t *testing.T) {
+	for _, variables := range []string{"", "request.foo", "request.header.", "client.ip,request.jwt."} {
+		_, err := NewExtractor(variables)
+		assert.Error(t, err, variables)
+	}
+}
+
+func TestJWTClaimSourceInvalidToken(t *testing.T) {
+	source := jwtClaimSource("sub")
+
+	for _, authorization := range []string{"", "Bearer foo", "Bearer a.!!!.c", "Basic dXNlcjpwYXNz"} {
+		req := httptest.NewRequest(http.MethodGet, "http://foo.bar", nil)
+		req.Header.Set("Authorization", authorization)
+
+		assert.Equal(t, "", source(req), authorization)
+	}
+}
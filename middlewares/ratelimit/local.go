@@ -0,0 +1,139 @@
+package ratelimit
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/containous/traefik/types"
+	"github.com/mailgun/timetools"
+	"github.com/mailgun/ttlmap"
+)
+
+// maxSources is the maximum number of sources tracked by a local rate limiter.
+const maxSources = 65536
+
+// localLimiter limits the requests with token buckets held in memory:
+// the buckets hold up to the burst of the rates, and are refilled at their average.
+type localLimiter struct {
+	rates     []localRate
+	maxPeriod time.Duration
+	clock     timetools.TimeProvider
+
+	mu      sync.Mutex
+	buckets *ttlmap.TtlMap
+}
+
+type localRate struct {
+	period  time.Duration
+	average int64
+	burst   int64
+}
+
+// bucket holds the tokens available for a rate.
+type bucket struct {
+	tokens      float64
+	lastRefresh time.Time
+}
+
+func newLocalLimiter(rateSet map[string]*types.Rate, clock timetools.TimeProvider) (*localLimiter, error) {
+	l := &localLimiter{clock: clock}
+
+	for rateName, r := range rateSet {
+		if r.Period <= 0 || r.Average <= 0 || r.Burst <= 0 {
+			return nil, fmt.Errorf("invalid rate %s: period %s, average %d, burst %d", rateName, time.Duration(r.Period), r.Average, r.Burst)
+		}
+
+		l.rates = append(l.rates, localRate{period: time.Duration(r.Period), average: r.Average, burst: r.Burst})
+
+		if time.Duration(r.Period) > l.maxPeriod {
+			l.maxPeriod = time.Duration(r.Period)
+		}
+	}
+
+	buckets, err := ttlmap.NewMap(maxSources, ttlmap.Clock(clock))
+	if err != nil {
+		return nil, err
+	}
+	l.buckets = buckets
+
+	return l, nil
+}
+
+func (l *localLimiter) consume(source string, amount int64) (*quota, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.clock.UtcNow()
+
+	var buckets []*bucket
+	if value, ok := l.buckets.Get(source); ok {
+		buckets = value.([]*bucket)
+	} else {
+		for _, r := range l.rates {
+			buckets = append(buckets, &bucket{tokens: float64(r.burst), lastRefresh: now})
+		}
+	}
+
+	var delay time.Duration
+	for i, r := range l.rates {
+		if amount > r.burst {
+			return nil, fmt.Errorf("requested tokens larger than max tokens")
+		}
+
+		b := buckets[i]
+		b.refill(now, r)
+
+		if missing := float64(amount) - b.tokens; missing > 0 {
+			delay = maxDuration(delay, r.timeFor(missing))
+		}
+	}
+
+	var q *quota
+	for i, r := range l.rates {
+		b := buckets[i]
+		if delay == 0 {
+			b.tokens -= float64(amount)
+		}
+
+		rateQuota := &quota{
+			limit:     r.burst,
+			remaining: int64(math.Floor(b.tokens)),
+			reset:     r.timeFor(float64(r.burst) - b.tokens),
+		}
+		if q == nil || rateQuota.isLower(q) {
+			q = rateQuota
+		}
+	}
+	q.delay = delay
+
+	// the buckets of a source expire after 10 periods of inactivity
+	if err := l.buckets.Set(source, buckets, int(l.maxPeriod/time.Second)*10+1); err != nil {
+		return nil, err
+	}
+
+	return q, nil
+}
+
+func (b *bucket) refill(now time.Time, r localRate) {
+	elapsed := now.Sub(b.lastRefresh)
+	b.lastRefresh = now
+
+	b.tokens = math.Min(float64(r.burst), b.tokens+elapsed.Seconds()*float64(r.average)/r.period.Seconds())
+}
+
+// timeFor returns the time to refill the tokens.
+func (r localRate) timeFor(tokens float64) time.Duration {
+	if tokens <= 0 {
+		return 0
+	}
+	return time.Duration(math.Ceil(tokens * float64(r.period) / float64(r.average)))
+}
+
+func maxDuration(x, y time.Duration) time.Duration {
+	if x > y {
+		return x
+	}
+	return y
+}
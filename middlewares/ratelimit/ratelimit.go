@@ -0,0 +1,170 @@
+package ratelimit
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/containous/traefik/ip"
+	"github.com/containous/traefik/log"
+	"github.com/containous/traefik/types"
+	"github.com/mailgun/timetools"
+	"github.com/vulcand/oxy/utils"
+)
+
+// DefaultStatusCode is the default status code of the rejected requests.
+const DefaultStatusCode = http.StatusTooManyRequests
+
+// RateLimiter limits the rate of the requests of each source (client IP, header, JWT claim...),
+// and tells the clients the quota they have left with the RateLimit headers.
+type RateLimiter struct {
+	name        string
+	next        http.Handler
+	extractor   utils.SourceExtractor
+	exempt      *ip.Checker
+	local       *localLimiter
+	distributed *distributedLimiter
+	statusCode  int
+	body        string
+	contentType string
+}
+
+// quota is the state of the most restrictive rate for a source.
+type quota struct {
+	limit     int64
+	remaining int64
+	// reset is the time until the quota is fully available again.
+	reset time.Duration
+	// delay is the time until the request is allowed, when it is rejected.
+	delay time.Duration
+}
+
+// New creates a rate limiter for a frontend.
+// With a store, the rates are shared by the Traefik instances, the local rates being used when the store is unreachable.
+func New(name string, next http.Handler, config *types.RateLimit, store Store, batchSize int64, retryInterval time.Duration) (*RateLimiter, error) {
+	return newRateLimiter(name, next, config, store, batchSize, retryInterval, &timetools.RealTime{})
+}
+
+func newRateLimiter(name string, next http.Handler, config *types.RateLimit, store Store, batchSize int64, retryInterval time.Duration,
+	clock timetools.TimeProvider) (*RateLimiter, error) {
+	extractor, err := NewExtractor(config.ExtractorFunc)
+	if err != nil {
+		return nil, err
+	}
+
+	local, err := newLocalLimiter(config.RateSet, clock)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &RateLimiter{
+		name:        name,
+		next:        next,
+		extractor:   extractor,
+		local:       local,
+		statusCode:  config.StatusCode,
+		body:        config.Body,
+		contentType: config.ContentType,
+	}
+
+	if r.statusCode == 0 {
+		r.statusCode = DefaultStatusCode
+	}
+	if r.statusCode < http.StatusBadRequest || r.statusCode > 599 {
+		return nil, fmt.Errorf("invalid status code %d", r.statusCode)
+	}
+
+	if len(r.contentType) == 0 {
+		r.contentType = "text/plain; charset=utf-8"
+	}
+
+	if len(config.ExemptSourceRange) > 0 {
+		r.exempt, err = ip.NewChecker(config.ExemptSourceRange)
+		if err != nil {
+			return nil, fmt.Errorf("invalid exempt source range: %v", err)
+		}
+	}
+
+	if store != nil {
+		r.distributed, err = newDistributedLimiter(name, config.RateSet, store, batchSize, retryInterval, clock)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return r, nil
+}
+
+func (r *RateLimiter) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	if r.exempt != nil && r.exempt.IsAuthorized(req.RemoteAddr) == nil {
+		r.next.ServeHTTP(rw, req)
+		return
+	}
+
+	source, amount, err := r.extractor.Extract(req)
+	if err != nil {
+		utils.DefaultHandler.ServeHTTP(rw, req, err)
+		return
+	}
+
+	q, err := r.consume(source, amount)
+	if err != nil {
+		utils.DefaultHandler.ServeHTTP(rw, req, err)
+		return
+	}
+
+	rw.Header().Set("RateLimit-Limit", strconv.FormatInt(q.limit, 10))
+	rw.Header().Set("RateLimit-Remaining", strconv.FormatInt(q.remaining, 10))
+	rw.Header().Set("RateLimit-Reset", formatSeconds(q.reset))
+
+	if q.delay > 0 {
+		log.Debugf("Limiting request %s %s of %s in frontend %s, retry in %s", req.Method, req.URL, source, r.name, q.delay)
+		r.reject(rw, q.delay)
+		return
+	}
+
+	r.next.ServeHTTP(rw, req)
+}
+
+func (r *RateLimiter) consume(source string, amount int64) (*quota, error) {
+	if r.distributed != nil && r.distributed.isStoreAvailable() {
+		q, err := r.distributed.consume(source, amount)
+		if err == nil {
+			return q, nil
+		}
+
+		log.Warnf("Unable to use the rate limit store for %s, falling back to the local rate limits: %v", r.name, err)
+		r.distributed.setStoreUnavailable()
+	}
+
+	return r.local.consume(source, amount)
+}
+
+func (r *RateLimiter) reject(rw http.ResponseWriter, delay time.Duration) {
+	rw.Header().Set("Retry-After", formatSeconds(delay))
+	rw.Header().Set("X-Retry-In", delay.String())
+	rw.Header().Set("Content-Type", r.contentType)
+	rw.WriteHeader(r.statusCode)
+
+	body := r.body
+	if len(body) == 0 {
+		body = fmt.Sprintf("max rate reached: retry-in %s", delay)
+	}
+	io.WriteString(rw, body)
+}
+
+// isLower returns whether less requests are left in the quota than in the other one.
+func (q *quota) isLower(other *quota) bool {
+	if q.remaining != other.remaining {
+		return q.remaining < other.remaining
+	}
+	return q.reset > other.reset
+}
+
+// formatSeconds formats the duration in seconds, rounded up.
+func formatSeconds(d time.Duration) string {
+	return strconv.FormatInt(int64(math.Ceil(d.Seconds())), 10)
+}
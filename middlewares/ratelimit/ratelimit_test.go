@@ -0,0 +1,225 @@
+package ratelimit
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/containous/flaeg/parse"
+	"github.com/containous/traefik/types"
+	"github.com/mailgun/timetools"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewRateLimiterInvalid(t *testing.T) {
+	rateSet := map[string]*types.Rate{
+		"second": {Period: parse.Duration(time.Second), Average: 10, Burst: 20},
+	}
+
+	testCases := []struct {
+		desc   string
+		config *types.RateLimit
+	}{
+		{
+			desc:   "unsupported extractor",
+			config: &types.RateLimit{ExtractorFunc: "request.foo", RateSet: rateSet},
+		},
+		{
+			desc: "missing burst",
+			config: &types.RateLimit{ExtractorFunc: "client.ip", RateSet: map[string]*types.Rate{
+				"second": {Period: parse.Duration(time.Second), Average: 10},
+			}},
+		},
+		{
+			desc:   "invalid status code",
+			config: &types.RateLimit{ExtractorFunc: "client.ip", RateSet: rateSet, StatusCode: http.StatusOK},
+		},
+		{
+			desc:   "invalid exempt source range",
+			config: &types.RateLimit{ExtractorFunc: "client.ip", RateSet: rateSet, ExemptSourceRange: []string{"foo"}},
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := New("frontend", nil, test.config, nil, 0, 0)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestRateLimiter(t *testing.T) {
+	testCases := []struct {
+		desc                string
+		config              *types.RateLimit
+		remoteAddr          string
+		expectedAccepted    int
+		expectedCode        int
+		expectedBody        string
+		expectedContentType string
+	}{
+		{
+			desc: "default rejection",
+			config: &types.RateLimit{
+				ExtractorFunc: "client.ip",
+				RateSet: map[string]*types.Rate{
+					"minute": {Period: parse.Duration(time.Minute), Average: 60, Burst: 3},
+				},
+			},
+			remoteAddr:          "10.0.0.1:1234",
+			expectedAccepted:    3,
+			expectedCode:        http.StatusTooManyRequests,
+			expectedBody:        "max rate reached: retry-in 1s",
+			expectedContentType: "text/plain; charset=utf-8",
+		},
+		{
+			desc: "custom rejection",
+			config: &types.RateLimit{
+				ExtractorFunc: "client.ip",
+				RateSet: map[string]*types.Rate{
+					"minute": {Period: parse.Duration(time.Minute), Average: 60, Burst: 3},
+				},
+				StatusCode:  http.StatusServiceUnavailable,
+				Body:        `{"error":"slow down"}`,
+				ContentType: "application/json",
+			},
+			remoteAddr:          "10.0.0.1:1234",
+			expectedAccepted:    3,
+			expectedCode:        http.StatusServiceUnavailable,
+			expectedBody:        `{"error":"slow down"}`,
+			expectedContentType: "application/json",
+		},
+		{
+			desc: "exempt client",
+			config: &types.RateLimit{
+				ExtractorFunc: "client.ip",
+				RateSet: map[string]*types.Rate{
+					"minute": {Period: parse.Duration(time.Minute), Average: 60, Burst: 3},
+				},
+				ExemptSourceRange: []string{"10.0.0.0/8"},
+			},
+			remoteAddr:       "10.0.0.1:1234",
+			expectedAccepted: 10,
+		},
+		{
+			desc: "client not exempt",
+			config: &types.RateLimit{
+				ExtractorFunc: "client.ip",
+				RateSet: map[string]*types.Rate{
+					"minute": {Period: parse.Duration(time.Minute), Average: 60, Burst: 3},
+				},
+				ExemptSourceRange: []string{"192.168.0.0/16"},
+			},
+			remoteAddr:          "10.0.0.1:1234",
+			expectedAccepted:    3,
+			expectedCode:        http.StatusTooManyRequests,
+			expectedBody:        "max rate reached: retry-in 1s",
+			expectedContentType: "text/plain; charset=utf-8",
+		},
+		{
+			desc: "most restrictive rate",
+			config: &types.RateLimit{
+				ExtractorFunc: "client.ip",
+				RateSet: map[string]*types.Rate{
+					"second": {Period: parse.Duration(time.Second), Average: 10, Burst: 20},
+					"minute": {Period: parse.Duration(time.Minute), Average: 60, Burst: 5},
+				},
+			},
+			remoteAddr:          "10.0.0.1:1234",
+			expectedAccepted:    5,
+			expectedCode:        http.StatusTooManyRequests,
+			expectedBody:        "max rate reached: retry-in 1s",
+			expectedContentType: "text/plain; charset=utf-8",
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			next := http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				rw.WriteHeader(http.StatusOK)
+			})
+			clock := &timetools.FreezedTime{CurrentTime: time.Date(2018, 7, 1, 10, 0, 0, 0, time.UTC)}
+
+			r, err := newRateLimiter("frontend", next, test.config, nil, 0, 0, clock)
+			require.NoError(t, err)
+
+			var accepted int
+			var recorder *httptest.ResponseRecorder
+			for i := 0; i < 10; i++ {
+				req := httptest.NewRequest(http.MethodGet, "http://localhost", nil)
+				req.RemoteAddr = test.remoteAddr
+
+				recorder = httptest.NewRecorder()
+				r.ServeHTTP(recorder, req)
+
+				if recorder.Code != http.StatusOK {
+					break
+				}
+				accepted++
+			}
+
+			assert.Equal(t, test.expectedAccepted, accepted)
+			if test.expectedCode == 0 {
+				return
+			}
+
+			assert.Equal(t, test.expectedCode, recorder.Code)
+			assert.Equal(t, test.expectedBody, recorder.Body.String())
+			assert.Equal(t, test.expectedContentType, recorder.Header().Get("Content-Type"))
+			assert.Equal(t, "1", recorder.Header().Get("Retry-After"))
+			assert.Equal(t, "0", recorder.Header().Get("RateLimit-Remaining"))
+		})
+	}
+}
+
+func TestRateLimiterHeaders(t *testing.T) {
+	next := http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusOK)
+	})
+	clock := &timetools.FreezedTime{CurrentTime: time.Date(2018, 7, 1, 10, 0, 0, 0, time.UTC)}
+
+	config := &types.RateLimit{
+		ExtractorFunc: "request.header.X-Api-Key",
+		RateSet: map[string]*types.Rate{
+			"minute": {Period: parse.Duration(time.Minute), Average: 6, Burst: 6},
+		},
+	}
+
+	r, err := newRateLimiter("frontend", next, config, nil, 0, 0, clock)
+	require.NoError(t, err)
+
+	serveKey := func(key string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "http://localhost", nil)
+		req.Header.Set("X-Api-Key", key)
+
+		recorder := httptest.NewRecorder()
+		r.ServeHTTP(recorder, req)
+		return recorder
+	}
+
+	recorder := serveKey("foo")
+	assert.Equal(t, "6", recorder.Header().Get("RateLimit-Limit"))
+	assert.Equal(t, "5", recorder.Header().Get("RateLimit-Remaining"))
+	assert.Equal(t, "10", recorder.Header().Get("RateLimit-Reset"))
+
+	recorder = serveKey("foo")
+	assert.Equal(t, "4", recorder.Header().Get("RateLimit-Remaining"))
+	assert.Equal(t, "20", recorder.Header().Get("RateLimit-Reset"))
+
+	// the sources are limited separately
+	recorder = serveKey("bar")
+	assert.Equal(t, "5", recorder.Header().Get("RateLimit-Remaining"))
+
+	// the tokens are refilled at the average rate
+	clock.CurrentTime = clock.CurrentTime.Add(10 * time.Second)
+	recorder = serveKey("foo")
+	assert.Equal(t, "4", recorder.Header().Get("RateLimit-Remaining"))
+}
@@ -0,0 +1,216 @@
+package ratelimit
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/abronan/valkeyrie/store"
+)
+
+const (
+	maxIdleConns   = 8
+	maxCASAttempts = 10
+)
+
+// Store holds the counters shared by the Traefik instances.
+type Store interface {
+	// IncrBy increments the counter of the key by n, and returns its new value.
+	// The counter expires after the ttl.
+	IncrBy(key string, n int64, ttl time.Duration) (int64, error)
+}
+
+// RedisStore is a store using a server speaking the Redis protocol (Redis, KeyDB, Dragonfly...).
+type RedisStore struct {
+	address  string
+	password string
+	db       int
+	timeout  time.Duration
+	prefix   string
+	idle     chan *redisConn
+}
+
+type redisConn struct {
+	net.Conn
+	reader *bufio.Reader
+}
+
+// NewRedisStore creates a store using the Redis server at the address.
+func NewRedisStore(address, password string, db int, timeout time.Duration, prefix string) *RedisStore {
+	return &RedisStore{
+		address:  address,
+		password: password,
+		db:       db,
+		timeout:  timeout,
+		prefix:   prefix,
+		idle:     make(chan *redisConn, maxIdleConns),
+	}
+}
+
+// IncrBy increments the counter of the key by n, and returns its new value.
+func (r *RedisStore) IncrBy(key string, n int64, ttl time.Duration) (int64, error) {
+	conn, err := r.conn()
+	if err != nil {
+		return 0, err
+	}
+
+	key = r.prefix + key
+
+	value, err := conn.pipeline(time.Now().Add(r.timeout),
+		[]string{"INCRBY", key, strconv.FormatInt(n, 10)},
+		[]string{"PEXPIRE", key, strconv.FormatInt(int64(ttl/time.Millisecond), 10)},
+	)
+	if err != nil {
+		conn.Close()
+		return 0, err
+	}
+
+	r.release(conn)
+	return value, nil
+}
+
+func (r *RedisStore) conn() (*redisConn, error) {
+	select {
+	case conn := <-r.idle:
+		return conn, nil
+	default:
+	}
+
+	netConn, err := net.DialTimeout("tcp", r.address, r.timeout)
+	if err != nil {
+		return nil, err
+	}
+
+	conn := &redisConn{Conn: netConn, reader: bufio.NewReader(netConn)}
+
+	var commands [][]string
+	if len(r.password) > 0 {
+		commands = append(commands, []string{"AUTH", r.password})
+	}
+	if r.db != 0 {
+		commands = append(commands, []string{"SELECT", strconv.Itoa(r.db)})
+	}
+
+	if len(commands) > 0 {
+		if _, err := conn.pipeline(time.Now().Add(r.timeout), commands...); err != nil {
+			conn.Close()
+			return nil, err
+		}
+	}
+
+	return conn, nil
+}
+
+func (r *RedisStore) release(conn *redisConn) {
+	select {
+	case r.idle <- conn:
+	default:
+		conn.Close()
+	}
+}
+
+// pipeline sends the commands, and returns the integer reply of the first one.
+func (c *redisConn) pipeline(deadline time.Time, commands ...[]string) (int64, error) {
+	if err := c.SetDeadline(deadline); err != nil {
+		return 0, err
+	}
+
+	var buf []byte
+	for _, command := range commands {
+		buf = append(buf, fmt.Sprintf("*%d\r\n", len(command))...)
+		for _, arg := range command {
+			buf = append(buf, fmt.Sprintf("$%d\r\n%s\r\n", len(arg), arg)...)
+		}
+	}
+
+	if _, err := c.Write(buf); err != nil {
+		return 0, err
+	}
+
+	var value int64
+	for i := range commands {
+		reply, err := c.readReply()
+		if err != nil {
+			return 0, err
+		}
+		if i == 0 {
+			value = reply
+		}
+	}
+
+	return value, nil
+}
+
+// readReply reads a simple string, error or integer reply.
+func (c *redisConn) readReply() (int64, error) {
+	line, err := c.reader.ReadString('\n')
+	if err != nil {
+		return 0, err
+	}
+
+	line = strings.TrimSuffix(line, "\r\n")
+	if len(line) == 0 {
+		return 0, errors.New("empty reply")
+	}
+
+	switch line[0] {
+	case '+':
+		return 0, nil
+	case ':':
+		return strconv.ParseInt(line[1:], 10, 64)
+	case '-':
+		return 0, fmt.Errorf("redis error: %s", line[1:])
+	default:
+		return 0, fmt.Errorf("unexpected reply %q", line)
+	}
+}
+
+// KVStore is a store using the KV store of the cluster (Consul, Etcd, Zookeeper...).
+// The counters are incremented with atomic compare-and-swap operations.
+type KVStore struct {
+	kv     store.Store
+	prefix string
+}
+
+// NewKVStore creates a store using the KV store.
+func NewKVStore(kv store.Store, prefix string) *KVStore {
+	return &KVStore{kv: kv, prefix: prefix}
+}
+
+// IncrBy increments the counter of the key by n, and returns its new value.
+func (k *KVStore) IncrBy(key string, n int64, ttl time.Duration) (int64, error) {
+	key = k.prefix + key
+
+	for i := 0; i < maxCASAttempts; i++ {
+		var value int64
+
+		previous, err := k.kv.Get(key, nil)
+		switch {
+		case err == store.ErrKeyNotFound:
+			previous = nil
+		case err != nil:
+			return 0, err
+		default:
+			value, err = strconv.ParseInt(string(previous.Value), 10, 64)
+			if err != nil {
+				return 0, fmt.Errorf("invalid counter %s: %v", key, err)
+			}
+		}
+
+		value += n
+
+		ok, _, err := k.kv.AtomicPut(key, []byte(strconv.FormatInt(value, 10)), previous, &store.WriteOptions{TTL: ttl})
+		if ok {
+			return value, nil
+		}
+		if err != nil && err != store.ErrKeyModified && err != store.ErrKeyExists {
+			return 0, err
+		}
+	}
+
+	return 0, fmt.Errorf("unable to increment the counter %s: too many concurrent updates", key)
+}
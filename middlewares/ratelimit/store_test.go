@@ -0,0 +1,210 @@
+package ratelimit
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/abronan/valkeyrie/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// redisStandIn is a server speaking the subset of the Redis protocol used by the store.
+type redisStandIn struct {
+	listener net.Listener
+	password string
+
+	mu       sync.Mutex
+	counters map[string]int64
+	ttls     map[string]int64
+}
+
+func newRedisStandIn(t *testing.T, password string) *redisStandIn {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	r := &redisStandIn{
+		listener: listener,
+		password: password,
+		counters: make(map[string]int64),
+		ttls:     make(map[string]int64),
+	}
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go r.serve(conn)
+		}
+	}()
+
+	return r
+}
+
+func (r *redisStandIn) serve(conn net.Conn) {
+	defer conn.Close()
+
+	reader := bufio.NewReader(conn)
+	authenticated := len(r.password) == 0
+
+	for {
+		command, err := readCommand(reader)
+		if err != nil {
+			return
+		}
+
+		var reply string
+		switch {
+		case command[0] == "AUTH":
+			authenticated = command[1] == r.password
+			reply = "+OK\r\n"
+			if !authenticated {
+				reply = "-ERR invalid password\r\n"
+			}
+		case !authenticated:
+			reply = "-NOAUTH Authentication required.\r\n"
+		case command[0] == "SELECT":
+			reply = "+OK\r\n"
+		case command[0] == "INCRBY":
+			n, _ := strconv.ParseInt(command[2], 10, 64)
+			r.mu.Lock()
+			r.counters[command[1]] += n
+			reply = fmt.Sprintf(":%d\r\n", r.counters[command[1]])
+			r.mu.Unlock()
+		case command[0] == "PEXPIRE":
+			ttl, _ := strconv.ParseInt(command[2], 10, 64)
+			r.mu.Lock()
+			r.ttls[command[1]] = ttl
+			r.mu.Unlock()
+			reply = ":1\r\n"
+		default:
+			reply = "-ERR unknown command\r\n"
+		}
+
+		if _, err := io.WriteString(conn, reply); err != nil {
+			return
+		}
+	}
+}
+
+func readCommand(reader *bufio.Reader) ([]string, error) {
+	line, err := reader.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+
+	count, err := strconv.Atoi(strings.TrimSpace(strings.TrimPrefix(line, "*")))
+	if err != nil {
+		return nil, err
+	}
+
+	var command []string
+	for i := 0; i < count; i++ {
+		if _, err := reader.ReadString('\n'); err != nil {
+			return nil, err
+		}
+		arg, err := reader.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		command = append(command, strings.TrimSuffix(arg, "\r\n"))
+	}
+
+	return command, nil
+}
+
+func TestRedisStore(t *testing.T) {
+	standIn := newRedisStandIn(t, "secret")
+	defer standIn.listener.Close()
+
+	redisStore := NewRedisStore(standIn.listener.Addr().String(), "secret", 1, time.Second, "traefik/ratelimit/")
+
+	value, err := redisStore.IncrBy("foo", 10, time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, int64(10), value)
+
+	value, err = redisStore.IncrBy("foo", 5, time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, int64(15), value)
+
+	assert.Equal(t, int64(15), standIn.counters["traefik/ratelimit/foo"])
+	assert.Equal(t, int64(60000), standIn.ttls["traefik/ratelimit/foo"])
+}
+
+func TestRedisStoreInvalidPassword(t *testing.T) {
+	standIn := newRedisStandIn(t, "secret")
+	defer standIn.listener.Close()
+
+	redisStore := NewRedisStore(standIn.listener.Addr().String(), "wrong", 0, time.Second, "")
+
+	_, err := redisStore.IncrBy("foo", 10, time.Minute)
+	assert.Error(t, err)
+}
+
+func TestRedisStoreUnreachable(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	address := listener.Addr().String()
+	listener.Close()
+
+	redisStore := NewRedisStore(address, "", 0, time.Second, "")
+
+	_, err = redisStore.IncrBy("foo", 10, time.Minute)
+	assert.Error(t, err)
+}
+
+// kvMock is a KV store supporting the atomic operations, conflicting on the first put of each key.
+type kvMock struct {
+	store.Store
+	pairs     map[string]*store.KVPair
+	conflicts map[string]bool
+}
+
+func (k *kvMock) Get(key string, options *store.ReadOptions) (*store.KVPair, error) {
+	pair, ok := k.pairs[key]
+	if !ok {
+		return nil, store.ErrKeyNotFound
+	}
+	return pair, nil
+}
+
+func (k *kvMock) AtomicPut(key string, value []byte, previous *store.KVPair, options *store.WriteOptions) (bool, *store.KVPair, error) {
+	if !k.conflicts[key] {
+		k.conflicts[key] = true
+		k.pairs[key] = &store.KVPair{Key: key, Value: []byte("5"), LastIndex: 1}
+		return false, nil, store.ErrKeyExists
+	}
+
+	current := k.pairs[key]
+	if previous == nil || current.LastIndex != previous.LastIndex {
+		return false, nil, store.ErrKeyModified
+	}
+
+	pair := &store.KVPair{Key: key, Value: value, LastIndex: current.LastIndex + 1}
+	k.pairs[key] = pair
+	return true, pair, nil
+}
+
+func TestKVStore(t *testing.T) {
+	kv := &kvMock{pairs: make(map[string]*store.KVPair), conflicts: make(map[string]bool)}
+	kvStore := NewKVStore(kv, "traefik/ratelimit/")
+
+	value, err := kvStore.IncrBy("foo", 10, time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, int64(15), value)
+
+	value, err = kvStore.IncrBy("foo", 10, time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, int64(25), value)
+
+	assert.Equal(t, "25", string(kv.pairs["traefik/ratelimit/foo"].Value))
+}
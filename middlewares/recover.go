@@ -27,6 +27,11 @@ func NegroniRecoverHandler() negroni.Handler {
 
 func recoverFunc(w http.ResponseWriter) {
 	if err := recover(); err != nil {
+		// the aborted handlers are handled by the HTTP server, which closes the connection
+		if err == http.ErrAbortHandler {
+			panic(err)
+		}
+
 		log.Errorf("Recovered from panic in http handler: %+v", err)
 		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
 	}
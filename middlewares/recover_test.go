@@ -43,3 +43,18 @@ func TestNegroniRecoverHandler(t *testing.T) {
 		t.Fatalf("Received non-%d response: %d\n", http.StatusInternalServerError, resp.StatusCode)
 	}
 }
+
+func TestRecoverHandlerAbort(t *testing.T) {
+	fn := func(w http.ResponseWriter, r *http.Request) {
+		panic(http.ErrAbortHandler)
+	}
+	recoverHandler := RecoverHandler(http.HandlerFunc(fn))
+	server := httptest.NewServer(recoverHandler)
+	defer server.Close()
+
+	resp, err := http.Get(server.URL)
+	if err == nil {
+		resp.Body.Close()
+		t.Fatalf("Received a response for an aborted handler: %d\n", resp.StatusCode)
+	}
+}
@@ -2,6 +2,7 @@ package redirect
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -12,6 +13,7 @@ import (
 
 	"github.com/containous/traefik/configuration"
 	"github.com/containous/traefik/middlewares"
+	"github.com/containous/traefik/types"
 	"github.com/urfave/negroni"
 	"github.com/vulcand/oxy/utils"
 )
@@ -20,8 +22,38 @@ const (
 	defaultRedirectRegex = `^(?:https?:\/\/)?([\w\._-]+)(?::\d+)?(.*)$`
 )
 
+// NewHandler creates a new redirection handler from a redirect configuration.
+// dstEntryPoint is the configuration of the entry point to redirect to, if any.
+func NewHandler(config *types.Redirect, dstEntryPoint *configuration.EntryPoint) (negroni.Handler, error) {
+	statusCode, err := getStatusCode(config)
+	if err != nil {
+		return nil, err
+	}
+
+	// an entry point redirect takes precedence over a regex, which takes precedence over URL components
+	switch {
+	case dstEntryPoint != nil:
+		return newEntryPointHandler(dstEntryPoint, statusCode)
+	case len(config.Regex) > 0:
+		return newRegexHandler(config.Regex, config.Replacement, statusCode)
+	case hasURLComponents(config):
+		return newURLHandler(config, statusCode)
+	default:
+		return nil, errors.New("no redirect target provided")
+	}
+}
+
 // NewEntryPointHandler create a new redirection handler base on entry point
 func NewEntryPointHandler(dstEntryPoint *configuration.EntryPoint, permanent bool) (negroni.Handler, error) {
+	return newEntryPointHandler(dstEntryPoint, permanentStatusCode(permanent))
+}
+
+// NewRegexHandler create a new redirection handler base on regex
+func NewRegexHandler(exp string, replacement string, permanent bool) (negroni.Handler, error) {
+	return newRegexHandler(exp, replacement, permanentStatusCode(permanent))
+}
+
+func newEntryPointHandler(dstEntryPoint *configuration.EntryPoint, statusCode int) (negroni.Handler, error) {
 	exp := regexp.MustCompile(`(:\d+)`)
 	match := exp.FindStringSubmatch(dstEntryPoint.Address)
 	if len(match) == 0 {
@@ -35,11 +67,10 @@ func NewEntryPointHandler(dstEntryPoint *configuration.EntryPoint, permanent boo
 
 	replacement := protocol + "://${1}" + match[0] + "${2}"
 
-	return NewRegexHandler(defaultRedirectRegex, replacement, permanent)
+	return newRegexHandler(defaultRedirectRegex, replacement, statusCode)
 }
 
-// NewRegexHandler create a new redirection handler base on regex
-func NewRegexHandler(exp string, replacement string, permanent bool) (negroni.Handler, error) {
+func newRegexHandler(exp string, replacement string, statusCode int) (negroni.Handler, error) {
 	re, err := regexp.Compile(exp)
 	if err != nil {
 		return nil, err
@@ -48,7 +79,7 @@ func NewRegexHandler(exp string, replacement string, permanent bool) (negroni.Ha
 	return &handler{
 		regexp:      re,
 		replacement: replacement,
-		permanent:   permanent,
+		statusCode:  statusCode,
 		errHandler:  utils.DefaultHandler,
 	}, nil
 }
@@ -56,7 +87,7 @@ func NewRegexHandler(exp string, replacement string, permanent bool) (negroni.Ha
 type handler struct {
 	regexp      *regexp.Regexp
 	replacement string
-	permanent   bool
+	statusCode  int
 	errHandler  utils.ErrorHandler
 }
 
@@ -105,7 +136,7 @@ func (h *handler) ServeHTTP(rw http.ResponseWriter, req *http.Request, next http
 	}
 
 	if newURL != oldURL {
-		handler := &moveHandler{location: parsedURL, permanent: h.permanent}
+		handler := &moveHandler{location: parsedURL, statusCode: h.statusCode}
 		handler.ServeHTTP(rw, req)
 		return
 	}
@@ -118,18 +149,35 @@ func (h *handler) ServeHTTP(rw http.ResponseWriter, req *http.Request, next http
 }
 
 type moveHandler struct {
-	location  *url.URL
-	permanent bool
+	location   *url.URL
+	statusCode int
 }
 
 func (m *moveHandler) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
 	rw.Header().Set("Location", m.location.String())
-	status := http.StatusFound
-	if m.permanent {
-		status = http.StatusMovedPermanently
+	rw.WriteHeader(m.statusCode)
+	rw.Write([]byte(http.StatusText(m.statusCode)))
+}
+
+// getStatusCode returns the status code of the redirection,
+// which defaults to 301 or 302 depending on the permanent flag.
+func getStatusCode(config *types.Redirect) (int, error) {
+	switch config.StatusCode {
+	case 0:
+		return permanentStatusCode(config.Permanent), nil
+	case http.StatusMovedPermanently, http.StatusFound, http.StatusSeeOther,
+		http.StatusTemporaryRedirect, http.StatusPermanentRedirect:
+		return config.StatusCode, nil
+	default:
+		return 0, fmt.Errorf("invalid redirect status code %d", config.StatusCode)
+	}
+}
+
+func permanentStatusCode(permanent bool) int {
+	if permanent {
+		return http.StatusMovedPermanently
 	}
-	rw.WriteHeader(status)
-	rw.Write([]byte(http.StatusText(status)))
+	return http.StatusFound
 }
 
 func rawURL(request *http.Request) string {
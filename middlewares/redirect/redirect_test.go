@@ -8,6 +8,7 @@ import (
 	"github.com/containous/traefik/configuration"
 	"github.com/containous/traefik/testhelpers"
 	"github.com/containous/traefik/tls"
+	"github.com/containous/traefik/types"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -180,3 +181,83 @@ func TestNewRegexHandler(t *testing.T) {
 		})
 	}
 }
+
+func TestNewHandler(t *testing.T) {
+	testCases := []struct {
+		desc           string
+		config         *types.Redirect
+		entryPoint     *configuration.EntryPoint
+		url            string
+		expectedURL    string
+		expectedStatus int
+		errorExpected  bool
+	}{
+		{
+			desc:           "entry point with status code",
+			config:         &types.Redirect{EntryPoint: "https", StatusCode: http.StatusPermanentRedirect},
+			entryPoint:     &configuration.EntryPoint{Address: ":443", TLS: &tls.TLS{}},
+			url:            "http://foo:80",
+			expectedURL:    "https://foo:443",
+			expectedStatus: http.StatusPermanentRedirect,
+		},
+		{
+			desc: "regex with status code",
+			config: &types.Redirect{
+				Regex:       `^http://foo\.com(.*)$`,
+				Replacement: "https://bar.com$1",
+				StatusCode:  http.StatusSeeOther,
+			},
+			url:            "http://foo.com",
+			expectedURL:    "https://bar.com",
+			expectedStatus: http.StatusSeeOther,
+		},
+		{
+			desc:           "status code takes precedence over permanent",
+			config:         &types.Redirect{Scheme: "https", Permanent: true, StatusCode: http.StatusTemporaryRedirect},
+			url:            "http://foo.com/bar",
+			expectedURL:    "https://foo.com/bar",
+			expectedStatus: http.StatusTemporaryRedirect,
+		},
+		{
+			desc:           "permanent URL components",
+			config:         &types.Redirect{Scheme: "https", Permanent: true},
+			url:            "http://foo.com/bar",
+			expectedURL:    "https://foo.com/bar",
+			expectedStatus: http.StatusMovedPermanently,
+		},
+		{
+			desc:          "invalid status code",
+			config:        &types.Redirect{Scheme: "https", StatusCode: http.StatusOK},
+			errorExpected: true,
+		},
+		{
+			desc:          "no target",
+			config:        &types.Redirect{Permanent: true},
+			errorExpected: true,
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			handler, err := NewHandler(test.config, test.entryPoint)
+			if test.errorExpected {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			recorder := httptest.NewRecorder()
+			r := testhelpers.MustNewRequest(http.MethodGet, test.url, nil)
+			handler.ServeHTTP(recorder, r, nil)
+
+			location, err := recorder.Result().Location()
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expectedURL, location.String())
+			assert.Equal(t, test.expectedStatus, recorder.Code)
+		})
+	}
+}
@@ -0,0 +1,179 @@
+package redirect
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/containous/traefik/types"
+	"github.com/urfave/negroni"
+	"github.com/vulcand/oxy/utils"
+)
+
+var defaultPorts = map[string]string{
+	"http":  "80",
+	"https": "443",
+}
+
+// urlHandler redirects to the request URL, with some of its components replaced.
+type urlHandler struct {
+	scheme     string
+	host       string
+	port       string
+	path       string
+	pathPrefix string
+	dropQuery  bool
+	rootOnly   bool
+	statusCode int
+	errHandler utils.ErrorHandler
+}
+
+func hasURLComponents(config *types.Redirect) bool {
+	return len(config.Scheme) > 0 || len(config.Host) > 0 || len(config.Port) > 0 ||
+		len(config.Path) > 0 || len(config.PathPrefix) > 0 || config.DropQuery || len(config.AppRoot) > 0
+}
+
+func newURLHandler(config *types.Redirect, statusCode int) (negroni.Handler, error) {
+	if len(config.Scheme) > 0 && config.Scheme != "http" && config.Scheme != "https" {
+		return nil, fmt.Errorf("unsupported redirect scheme %q", config.Scheme)
+	}
+
+	// the host can be an IPv6 address, but can't contain a port
+	host := strings.TrimSuffix(strings.TrimPrefix(config.Host, "["), "]")
+	if strings.Contains(host, "/") || strings.Contains(host, ":") && net.ParseIP(host) == nil {
+		return nil, fmt.Errorf("invalid redirect host %q", config.Host)
+	}
+
+	if len(config.Port) > 0 {
+		if _, err := net.LookupPort("tcp", config.Port); err != nil {
+			return nil, fmt.Errorf("invalid redirect port %q: %v", config.Port, err)
+		}
+	}
+
+	paths := 0
+	for _, path := range []string{config.Path, config.PathPrefix, config.AppRoot} {
+		if len(path) == 0 {
+			continue
+		}
+		if !strings.HasPrefix(path, "/") {
+			return nil, fmt.Errorf("redirect path %q must start with a '/'", path)
+		}
+		paths++
+	}
+	if paths > 1 {
+		return nil, errors.New("only one of path, path prefix or app root can be used in a redirect")
+	}
+
+	h := &urlHandler{
+		scheme:     config.Scheme,
+		host:       host,
+		port:       config.Port,
+		path:       config.Path,
+		pathPrefix: strings.TrimSuffix(config.PathPrefix, "/"),
+		dropQuery:  config.DropQuery,
+		statusCode: statusCode,
+		errHandler: utils.DefaultHandler,
+	}
+
+	if len(config.AppRoot) > 0 {
+		h.path = config.AppRoot
+		h.rootOnly = true
+	}
+
+	return h, nil
+}
+
+func (h *urlHandler) ServeHTTP(rw http.ResponseWriter, req *http.Request, next http.HandlerFunc) {
+	oldURL, err := url.Parse(requestURL(req))
+	if err != nil {
+		h.errHandler.ServeHTTP(rw, req, err)
+		return
+	}
+
+	// the app root only applies to the root of the site
+	if h.rootOnly && oldURL.Path != "/" && len(oldURL.Path) > 0 {
+		next.ServeHTTP(rw, req)
+		return
+	}
+
+	newURL := *oldURL
+
+	if len(h.scheme) > 0 {
+		newURL.Scheme = h.scheme
+	}
+
+	host, port := splitHostPort(oldURL.Host)
+	if len(h.host) > 0 {
+		host = h.host
+	}
+
+	switch {
+	case len(h.port) > 0:
+		port = h.port
+	case newURL.Scheme != oldURL.Scheme:
+		// the port of the request is meaningless with another scheme
+		port = ""
+	}
+
+	if port == defaultPorts[newURL.Scheme] {
+		port = ""
+	}
+
+	newURL.Host = host
+	if len(port) > 0 {
+		newURL.Host = net.JoinHostPort(host, port)
+	} else if strings.Contains(host, ":") {
+		newURL.Host = "[" + host + "]"
+	}
+
+	switch {
+	case len(h.path) > 0:
+		newURL.Path = h.path
+		newURL.RawPath = ""
+	case len(h.pathPrefix) > 0 && !hasPathPrefix(oldURL.Path, h.pathPrefix):
+		newURL.Path = h.pathPrefix + oldURL.Path
+		if len(oldURL.RawPath) > 0 {
+			newURL.RawPath = h.pathPrefix + oldURL.RawPath
+		}
+	}
+
+	if h.dropQuery {
+		newURL.RawQuery = ""
+		newURL.ForceQuery = false
+	}
+
+	// nothing to do when the URL is unchanged, to avoid redirect loops
+	if newURL.String() == oldURL.String() {
+		next.ServeHTTP(rw, req)
+		return
+	}
+
+	handler := &moveHandler{location: &newURL, statusCode: h.statusCode}
+	handler.ServeHTTP(rw, req)
+}
+
+// requestURL returns the URL of the request as sent by the client.
+func requestURL(req *http.Request) string {
+	if len(req.RequestURI) == 0 {
+		clone := *req
+		clone.RequestURI = req.URL.RequestURI()
+		return rawURL(&clone)
+	}
+	return rawURL(req)
+}
+
+// splitHostPort splits the host and the port of an URL host, the port being optional.
+func splitHostPort(hostPort string) (string, string) {
+	host, port, err := net.SplitHostPort(hostPort)
+	if err != nil {
+		return strings.TrimSuffix(strings.TrimPrefix(hostPort, "["), "]"), ""
+	}
+	return host, port
+}
+
+func hasPathPrefix(path, prefix string) bool {
+	return path == prefix || strings.HasPrefix(path, prefix+"/")
+}
@@ -0,0 +1,196 @@
+package redirect
+
+import (
+	"crypto/tls"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/containous/traefik/testhelpers"
+	"github.com/containous/traefik/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewURLHandler(t *testing.T) {
+	testCases := []struct {
+		desc          string
+		config        *types.Redirect
+		errorExpected bool
+	}{
+		{
+			desc:   "valid components",
+			config: &types.Redirect{Scheme: "https", Host: "foo.com", Port: "8443", PathPrefix: "/bar"},
+		},
+		{
+			desc:   "IPv6 host",
+			config: &types.Redirect{Host: "[::1]"},
+		},
+		{
+			desc:          "unsupported scheme",
+			config:        &types.Redirect{Scheme: "ftp"},
+			errorExpected: true,
+		},
+		{
+			desc:          "host with a port",
+			config:        &types.Redirect{Host: "foo.com:80"},
+			errorExpected: true,
+		},
+		{
+			desc:          "invalid port",
+			config:        &types.Redirect{Port: "foo"},
+			errorExpected: true,
+		},
+		{
+			desc:          "relative path",
+			config:        &types.Redirect{Path: "foo"},
+			errorExpected: true,
+		},
+		{
+			desc:          "path and path prefix",
+			config:        &types.Redirect{Path: "/foo", PathPrefix: "/bar"},
+			errorExpected: true,
+		},
+		{
+			desc:          "path prefix and app root",
+			config:        &types.Redirect{PathPrefix: "/bar", AppRoot: "/app"},
+			errorExpected: true,
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			handler, err := newURLHandler(test.config, http.StatusFound)
+			if test.errorExpected {
+				assert.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				assert.NotNil(t, handler)
+			}
+		})
+	}
+}
+
+func TestURLHandler(t *testing.T) {
+	testCases := []struct {
+		desc         string
+		config       *types.Redirect
+		url          string
+		secure       bool
+		expectedURL  string
+		expectedNext bool
+	}{
+		{
+			desc:        "HTTP to HTTPS drops the port",
+			config:      &types.Redirect{Scheme: "https"},
+			url:         "http://foo.com:8080/bar?baz=1",
+			expectedURL: "https://foo.com/bar?baz=1",
+		},
+		{
+			desc:        "HTTP to HTTPS with a port",
+			config:      &types.Redirect{Scheme: "https", Port: "8443"},
+			url:         "http://foo.com/bar",
+			expectedURL: "https://foo.com:8443/bar",
+		},
+		{
+			desc:        "default port is omitted",
+			config:      &types.Redirect{Scheme: "https", Port: "443"},
+			url:         "http://foo.com/bar",
+			expectedURL: "https://foo.com/bar",
+		},
+		{
+			desc:        "HTTPS to HTTP",
+			config:      &types.Redirect{Scheme: "http"},
+			url:         "https://foo.com/bar",
+			secure:      true,
+			expectedURL: "http://foo.com/bar",
+		},
+		{
+			desc:         "already HTTPS",
+			config:       &types.Redirect{Scheme: "https"},
+			url:          "https://foo.com/bar",
+			secure:       true,
+			expectedNext: true,
+		},
+		{
+			desc:        "host keeps the port",
+			config:      &types.Redirect{Host: "www.foo.com"},
+			url:         "http://foo.com:8080/bar",
+			expectedURL: "http://www.foo.com:8080/bar",
+		},
+		{
+			desc:        "IPv6 host",
+			config:      &types.Redirect{Host: "::1", Port: "8080"},
+			url:         "http://foo.com/bar",
+			expectedURL: "http://[::1]:8080/bar",
+		},
+		{
+			desc:        "path",
+			config:      &types.Redirect{Path: "/maintenance"},
+			url:         "http://foo.com/bar?baz=1",
+			expectedURL: "http://foo.com/maintenance?baz=1",
+		},
+		{
+			desc:        "path prefix",
+			config:      &types.Redirect{PathPrefix: "/app/"},
+			url:         "http://foo.com/bar?baz=1",
+			expectedURL: "http://foo.com/app/bar?baz=1",
+		},
+		{
+			desc:         "path prefix already present",
+			config:       &types.Redirect{PathPrefix: "/app"},
+			url:          "http://foo.com/app/bar",
+			expectedNext: true,
+		},
+		{
+			desc:        "drop query",
+			config:      &types.Redirect{DropQuery: true},
+			url:         "http://foo.com/bar?baz=1",
+			expectedURL: "http://foo.com/bar",
+		},
+		{
+			desc:        "app root",
+			config:      &types.Redirect{AppRoot: "/app"},
+			url:         "http://foo.com/?baz=1",
+			expectedURL: "http://foo.com/app?baz=1",
+		},
+		{
+			desc:         "app root ignores other paths",
+			config:       &types.Redirect{AppRoot: "/app"},
+			url:          "http://foo.com/bar",
+			expectedNext: true,
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			handler, err := newURLHandler(test.config, http.StatusFound)
+			require.NoError(t, err)
+
+			req := testhelpers.MustNewRequest(http.MethodGet, test.url, nil)
+			if test.secure {
+				req.TLS = &tls.ConnectionState{}
+			}
+
+			var nextCalled bool
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, req, func(rw http.ResponseWriter, req *http.Request) {
+				nextCalled = true
+			})
+
+			assert.Equal(t, test.expectedNext, nextCalled)
+			if test.expectedNext {
+				return
+			}
+
+			assert.Equal(t, http.StatusFound, recorder.Code)
+			assert.Equal(t, test.expectedURL, recorder.Header().Get("Location"))
+		})
+	}
+}
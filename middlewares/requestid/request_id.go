@@ -0,0 +1,223 @@
+package requestid
+
+import (
+	"bufio"
+	"context"
+	"crypto/rand"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/containous/traefik/ip"
+	"github.com/containous/traefik/middlewares"
+	"github.com/containous/traefik/middlewares/accesslog"
+	"github.com/containous/traefik/middlewares/tracing"
+	"github.com/satori/go.uuid"
+)
+
+// RequestIDKey is the key of the request ID in the context of the request.
+const RequestIDKey = middlewares.RequestIDKey
+
+const (
+	// DefaultHeaderName is the header carrying the request ID when none is configured.
+	DefaultHeaderName = "X-Request-Id"
+
+	formatUUID = "uuid"
+	formatULID = "ulid"
+
+	// maxRequestIDLength bounds the size of the trusted incoming request IDs.
+	maxRequestIDLength = 128
+)
+
+// RequestID is a middleware that makes sure every request carries an ID header,
+// and returns it to the client.
+// It is used by the entry points and the frontends.
+type RequestID struct {
+	headerName string
+	generate   func() (string, error)
+	insecure   bool
+	ipChecker  *ip.Checker
+}
+
+// NewRequestID creates a new RequestID middleware.
+func NewRequestID(headerName string, format string, insecure bool, trustedIPs []string) (*RequestID, error) {
+	if len(headerName) == 0 {
+		headerName = DefaultHeaderName
+	}
+
+	r := &RequestID{
+		headerName: http.CanonicalHeaderKey(headerName),
+		insecure:   insecure,
+	}
+
+	switch strings.ToLower(format) {
+	case "", formatUUID:
+		r.generate = newUUID
+	case formatULID:
+		r.generate = newULID
+	default:
+		return nil, fmt.Errorf("unknown request ID format %q", format)
+	}
+
+	if len(trustedIPs) > 0 {
+		ipChecker, err := ip.NewChecker(trustedIPs)
+		if err != nil {
+			return nil, err
+		}
+		r.ipChecker = ipChecker
+	}
+
+	return r, nil
+}
+
+func (r *RequestID) ServeHTTP(rw http.ResponseWriter, req *http.Request, next http.HandlerFunc) {
+	// the ID given by the entry point is kept by the frontends, under their own header
+	requestID := FromContext(req.Context())
+
+	if len(requestID) == 0 {
+		requestID = req.Header.Get(r.headerName)
+
+		if !r.isTrusted(req) || !isValid(requestID) {
+			var err error
+			requestID, err = r.generate()
+			if err != nil {
+				tracing.SetErrorAndWarnLog(req, "Unable to generate a request ID: %v", err)
+				next(rw, req)
+				return
+			}
+		}
+	}
+
+	req.Header.Set(r.headerName, requestID)
+
+	if table, ok := req.Context().Value(accesslog.DataTableKey).(*accesslog.LogData); ok {
+		table.Core[accesslog.RequestID] = requestID
+	}
+
+	tracing.LogRequestID(req, requestID)
+
+	req = req.WithContext(context.WithValue(req.Context(), RequestIDKey, requestID))
+
+	next(newResponseWriter(rw, r.headerName, requestID), req)
+}
+
+func (r *RequestID) isTrusted(req *http.Request) bool {
+	if r.insecure {
+		return true
+	}
+	return r.ipChecker != nil && r.ipChecker.IsAuthorized(req.RemoteAddr) == nil
+}
+
+// FromContext returns the ID of the request, when set by the middleware.
+func FromContext(ctx context.Context) string {
+	requestID, _ := ctx.Value(RequestIDKey).(string)
+	return requestID
+}
+
+// isValid rejects the empty, oversized or non printable incoming request IDs.
+func isValid(requestID string) bool {
+	if len(requestID) == 0 || len(requestID) > maxRequestIDLength {
+		return false
+	}
+
+	for _, c := range requestID {
+		if c < 0x21 || c > 0x7e {
+			return false
+		}
+	}
+	return true
+}
+
+func newUUID() (string, error) {
+	return uuid.NewV4().String(), nil
+}
+
+// crockfordAlphabet is the base32 alphabet used by ULIDs.
+const crockfordAlphabet = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// newULID generates a ULID: a 48 bits millisecond timestamp followed by 80 random bits,
+// encoded as 26 Crockford's base32 characters.
+func newULID() (string, error) {
+	var raw [16]byte
+
+	ms := uint64(time.Now().UnixNano() / int64(time.Millisecond))
+	for i := 0; i < 6; i++ {
+		raw[i] = byte(ms >> uint(40-8*i))
+	}
+
+	if _, err := rand.Read(raw[6:]); err != nil {
+		return "", err
+	}
+
+	var hi, lo uint64
+	for i := 0; i < 8; i++ {
+		hi = hi<<8 | uint64(raw[i])
+		lo = lo<<8 | uint64(raw[i+8])
+	}
+
+	var encoded [26]byte
+	for i := len(encoded) - 1; i >= 0; i-- {
+		encoded[i] = crockfordAlphabet[lo&0x1f]
+		lo = lo>>5 | hi<<59
+		hi >>= 5
+	}
+
+	return string(encoded[:]), nil
+}
+
+// responseWriter sets the request ID header on the response,
+// replacing the one that may have been returned by the backend.
+type responseWriter struct {
+	http.ResponseWriter
+	headerName  string
+	requestID   string
+	wroteHeader bool
+}
+
+func newResponseWriter(rw http.ResponseWriter, headerName string, requestID string) http.ResponseWriter {
+	w := &responseWriter{ResponseWriter: rw, headerName: headerName, requestID: requestID}
+	if _, ok := rw.(http.CloseNotifier); ok {
+		return &responseWriterWithCloseNotify{w}
+	}
+	return w
+}
+
+func (w *responseWriter) WriteHeader(code int) {
+	if !w.wroteHeader {
+		w.wroteHeader = true
+		w.ResponseWriter.Header().Set(w.headerName, w.requestID)
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *responseWriter) Write(b []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *responseWriter) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+func (w *responseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	if h, ok := w.ResponseWriter.(http.Hijacker); ok {
+		return h.Hijack()
+	}
+	return nil, nil, fmt.Errorf("%T is not a http.Hijacker", w.ResponseWriter)
+}
+
+type responseWriterWithCloseNotify struct {
+	*responseWriter
+}
+
+// CloseNotify returns a channel that receives at most a
+// single value (true) when the client connection has gone away.
+func (w *responseWriterWithCloseNotify) CloseNotify() <-chan bool {
+	return w.ResponseWriter.(http.CloseNotifier).CloseNotify()
+}
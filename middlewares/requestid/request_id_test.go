@@ -0,0 +1,172 @@
+package requestid
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+
+	"github.com/containous/traefik/testhelpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	uuidRegexp = regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+	ulidRegexp = regexp.MustCompile(`^[0-7][0-9A-HJKMNP-TV-Z]{25}$`)
+)
+
+func TestNewRequestID(t *testing.T) {
+	_, err := NewRequestID("", "snowflake", false, nil)
+	assert.Error(t, err)
+
+	_, err = NewRequestID("", "", false, []string{"10.0.0.0/33"})
+	assert.Error(t, err)
+}
+
+func TestRequestID(t *testing.T) {
+	testCases := []struct {
+		desc          string
+		headerName    string
+		format        string
+		insecure      bool
+		trustedIPs    []string
+		remoteAddr    string
+		incomingID    string
+		backendID     string
+		expectedID    string
+		expectedRegex *regexp.Regexp
+	}{
+		{
+			desc:          "generates an UUID by default",
+			expectedRegex: uuidRegexp,
+		},
+		{
+			desc:          "generates an ULID",
+			format:        "ulid",
+			expectedRegex: ulidRegexp,
+		},
+		{
+			desc:          "replaces the incoming ID from untrusted IP",
+			trustedIPs:    []string{"10.0.0.0/8"},
+			remoteAddr:    "192.168.1.1:1234",
+			incomingID:    "foo",
+			expectedRegex: uuidRegexp,
+		},
+		{
+			desc:       "keeps the incoming ID from trusted IP",
+			trustedIPs: []string{"10.0.0.0/8"},
+			remoteAddr: "10.0.0.1:1234",
+			incomingID: "foo",
+			expectedID: "foo",
+		},
+		{
+			desc:       "keeps the incoming ID when insecure",
+			insecure:   true,
+			incomingID: "foo",
+			expectedID: "foo",
+		},
+		{
+			desc:          "replaces an invalid incoming ID",
+			insecure:      true,
+			incomingID:    "foo bar",
+			expectedRegex: uuidRegexp,
+		},
+		{
+			desc:       "custom header name",
+			headerName: "x-correlation-id",
+			insecure:   true,
+			incomingID: "foo",
+			expectedID: "foo",
+		},
+		{
+			desc:       "overrides the ID returned by the backend",
+			insecure:   true,
+			incomingID: "foo",
+			backendID:  "bar",
+			expectedID: "foo",
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			middleware, err := NewRequestID(test.headerName, test.format, test.insecure, test.trustedIPs)
+			require.NoError(t, err)
+
+			headerName := DefaultHeaderName
+			if len(test.headerName) > 0 {
+				headerName = test.headerName
+			}
+
+			req := testhelpers.MustNewRequest(http.MethodGet, "http://foo.bar", nil)
+			if len(test.remoteAddr) > 0 {
+				req.RemoteAddr = test.remoteAddr
+			}
+			if len(test.incomingID) > 0 {
+				req.Header.Set(headerName, test.incomingID)
+			}
+
+			var forwardedID, contextID string
+			recorder := httptest.NewRecorder()
+			middleware.ServeHTTP(recorder, req, func(rw http.ResponseWriter, req *http.Request) {
+				forwardedID = req.Header.Get(headerName)
+				contextID = FromContext(req.Context())
+				if len(test.backendID) > 0 {
+					rw.Header().Add(headerName, test.backendID)
+				}
+				rw.Write([]byte("bar"))
+			})
+
+			if test.expectedRegex != nil {
+				assert.Regexp(t, test.expectedRegex, forwardedID)
+			} else {
+				assert.Equal(t, test.expectedID, forwardedID)
+			}
+
+			assert.Equal(t, forwardedID, contextID)
+			assert.Equal(t, []string{forwardedID}, recorder.Header()[http.CanonicalHeaderKey(headerName)])
+		})
+	}
+}
+
+func TestRequestIDEntryPointAndFrontend(t *testing.T) {
+	entryPoint, err := NewRequestID("", "", false, nil)
+	require.NoError(t, err)
+
+	frontend, err := NewRequestID("X-Correlation-Id", "ulid", false, nil)
+	require.NoError(t, err)
+
+	req := testhelpers.MustNewRequest(http.MethodGet, "http://foo.bar", nil)
+	req.Header.Set("X-Correlation-Id", "forged")
+
+	var forwardedHeader http.Header
+	recorder := httptest.NewRecorder()
+	entryPoint.ServeHTTP(recorder, req, func(rw http.ResponseWriter, req *http.Request) {
+		frontend.ServeHTTP(rw, req, func(rw http.ResponseWriter, req *http.Request) {
+			forwardedHeader = req.Header
+			rw.Write([]byte("bar"))
+		})
+	})
+
+	// the frontend keeps the ID of the entry point, under its own header
+	requestID := forwardedHeader.Get(DefaultHeaderName)
+	assert.Regexp(t, uuidRegexp, requestID)
+	assert.Equal(t, requestID, forwardedHeader.Get("X-Correlation-Id"))
+
+	assert.Equal(t, requestID, recorder.Header().Get(DefaultHeaderName))
+	assert.Equal(t, requestID, recorder.Header().Get("X-Correlation-Id"))
+}
+
+func TestNewULID(t *testing.T) {
+	first, err := newULID()
+	require.NoError(t, err)
+
+	second, err := newULID()
+	require.NoError(t, err)
+
+	assert.Regexp(t, ulidRegexp, first)
+	assert.NotEqual(t, first, second)
+}
@@ -0,0 +1,209 @@
+package sizelimit
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"sync/atomic"
+
+	"github.com/containous/traefik/middlewares/tracing"
+	"github.com/containous/traefik/types"
+	"github.com/go-kit/kit/metrics"
+)
+
+// The reasons of the rejections, used as label of the metrics.
+const (
+	reasonRequestBodySize = "request_body_size"
+	reasonHeaderCount     = "header_count"
+	reasonHeaderSize      = "header_size"
+)
+
+var errBodyTooLarge = errors.New("request body too large")
+
+// SizeLimit is a middleware rejecting the requests exceeding the size limits.
+// The bodies are not buffered: they are counted while they are streamed to the backend.
+type SizeLimit struct {
+	maxBodyBytes    int64
+	maxHeaderCount  int
+	maxHeaderBytes  int64
+	rejectedCounter metrics.Counter
+}
+
+// NewSizeLimit creates a size limit middleware.
+// The rejected requests are counted with the counter (which can be nil), partitioned by reason.
+func NewSizeLimit(config *types.SizeLimits, rejectedCounter metrics.Counter) (*SizeLimit, error) {
+	if config.MaxRequestBodyBytes < 0 || config.MaxHeaderCount < 0 || config.MaxHeaderBytes < 0 {
+		return nil, errors.New("the size limits must be positive")
+	}
+
+	return &SizeLimit{
+		maxBodyBytes:    config.MaxRequestBodyBytes,
+		maxHeaderCount:  config.MaxHeaderCount,
+		maxHeaderBytes:  config.MaxHeaderBytes,
+		rejectedCounter: rejectedCounter,
+	}, nil
+}
+
+func (s *SizeLimit) ServeHTTP(rw http.ResponseWriter, req *http.Request, next http.HandlerFunc) {
+	if s.maxHeaderCount > 0 || s.maxHeaderBytes > 0 {
+		count, size := headerSize(req.Header)
+
+		if s.maxHeaderCount > 0 && count > s.maxHeaderCount {
+			s.reject(rw, req, http.StatusRequestHeaderFieldsTooLarge, reasonHeaderCount)
+			return
+		}
+
+		if s.maxHeaderBytes > 0 && size > s.maxHeaderBytes {
+			s.reject(rw, req, http.StatusRequestHeaderFieldsTooLarge, reasonHeaderSize)
+			return
+		}
+	}
+
+	if s.maxBodyBytes == 0 || req.Body == nil || req.Body == http.NoBody {
+		next.ServeHTTP(rw, req)
+		return
+	}
+
+	// the announced length is checked before the body is read (and before 100-continue is sent)
+	if req.ContentLength > s.maxBodyBytes {
+		s.reject(rw, req, http.StatusRequestEntityTooLarge, reasonRequestBodySize)
+		return
+	}
+
+	body := &limitedBody{ReadCloser: req.Body, remaining: s.maxBodyBytes}
+	req.Body = body
+
+	next.ServeHTTP(&responseWriter{ResponseWriter: rw, req: req, body: body, limit: s}, req)
+}
+
+func (s *SizeLimit) reject(rw http.ResponseWriter, req *http.Request, code int, reason string) {
+	if s.rejectedCounter != nil {
+		s.rejectedCounter.With("reason", reason).Add(1)
+	}
+
+	tracing.SetErrorAndDebugLog(req, "request rejected by the size limits: %s", reason)
+
+	if code == http.StatusRequestEntityTooLarge {
+		// the rest of the body is not read
+		rw.Header().Set("Connection", "close")
+	}
+
+	http.Error(rw, http.StatusText(code), code)
+}
+
+// headerSize returns the number of header fields, and their size.
+func headerSize(header http.Header) (int, int64) {
+	var count int
+	var size int64
+
+	for name, values := range header {
+		for _, value := range values {
+			count++
+			size += int64(len(name) + len(value))
+		}
+	}
+
+	return count, size
+}
+
+// limitedBody fails when more than the remaining bytes are read.
+type limitedBody struct {
+	io.ReadCloser
+	remaining int64
+	exceeded  int32
+}
+
+func (b *limitedBody) Read(p []byte) (int, error) {
+	if b.isExceeded() {
+		return 0, errBodyTooLarge
+	}
+
+	// reads one more byte than allowed, to detect the bodies which are too large
+	if int64(len(p)) > b.remaining+1 {
+		p = p[:b.remaining+1]
+	}
+
+	n, err := b.ReadCloser.Read(p)
+	if int64(n) <= b.remaining {
+		b.remaining -= int64(n)
+		return n, err
+	}
+
+	atomic.StoreInt32(&b.exceeded, 1)
+	n = int(b.remaining)
+	b.remaining = 0
+	return n, errBodyTooLarge
+}
+
+// isExceeded returns whether the body is too large.
+// It is called from the handler, while the body is read by the transport.
+func (b *limitedBody) isExceeded() bool {
+	return atomic.LoadInt32(&b.exceeded) == 1
+}
+
+// responseWriter replaces the response by a 413 when the request body was too large,
+// as the backend (or the forwarder failing to send the body) can't answer properly.
+type responseWriter struct {
+	http.ResponseWriter
+	req         *http.Request
+	body        *limitedBody
+	limit       *SizeLimit
+	wroteHeader bool
+	rejected    bool
+}
+
+func (w *responseWriter) WriteHeader(code int) {
+	if w.wroteHeader {
+		return
+	}
+	w.wroteHeader = true
+
+	if w.body.isExceeded() {
+		w.rejected = true
+
+		for name := range w.Header() {
+			w.Header().Del(name)
+		}
+		w.limit.reject(w.ResponseWriter, w.req, http.StatusRequestEntityTooLarge, reasonRequestBodySize)
+		return
+	}
+
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *responseWriter) Write(b []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+
+	if w.rejected {
+		return len(b), nil
+	}
+
+	return w.ResponseWriter.Write(b)
+}
+
+// Hijack hijacks the connection
+func (w *responseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	return w.ResponseWriter.(http.Hijacker).Hijack()
+}
+
+// CloseNotify returns a channel that receives at most a
+// single value (true) when the client connection has gone
+// away.
+func (w *responseWriter) CloseNotify() <-chan bool {
+	return w.ResponseWriter.(http.CloseNotifier).CloseNotify()
+}
+
+// Flush sends any buffered data to the client.
+func (w *responseWriter) Flush() {
+	if w.rejected {
+		return
+	}
+
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
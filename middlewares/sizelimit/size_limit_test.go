@@ -0,0 +1,141 @@
+package sizelimit
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/containous/traefik/testhelpers"
+	"github.com/containous/traefik/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/negroni"
+)
+
+func TestNewSizeLimitInvalid(t *testing.T) {
+	_, err := NewSizeLimit(&types.SizeLimits{MaxRequestBodyBytes: -1}, nil)
+	assert.Error(t, err)
+}
+
+func TestSizeLimit(t *testing.T) {
+	testCases := []struct {
+		desc               string
+		config             *types.SizeLimits
+		header             http.Header
+		body               string
+		unknownLength      bool
+		expectedStatusCode int
+		expectedReason     string
+	}{
+		{
+			desc:               "no limits",
+			config:             &types.SizeLimits{},
+			header:             http.Header{"X-Foo": {"bar"}},
+			body:               "0123456789",
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			desc:               "body within the limit",
+			config:             &types.SizeLimits{MaxRequestBodyBytes: 10},
+			body:               "0123456789",
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			desc:               "announced body over the limit",
+			config:             &types.SizeLimits{MaxRequestBodyBytes: 5},
+			body:               "0123456789",
+			expectedStatusCode: http.StatusRequestEntityTooLarge,
+			expectedReason:     reasonRequestBodySize,
+		},
+		{
+			desc:               "streamed body within the limit",
+			config:             &types.SizeLimits{MaxRequestBodyBytes: 10},
+			body:               "0123456789",
+			unknownLength:      true,
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			desc:               "streamed body over the limit",
+			config:             &types.SizeLimits{MaxRequestBodyBytes: 5},
+			body:               "0123456789",
+			unknownLength:      true,
+			expectedStatusCode: http.StatusRequestEntityTooLarge,
+			expectedReason:     reasonRequestBodySize,
+		},
+		{
+			desc:               "header count within the limit",
+			config:             &types.SizeLimits{MaxHeaderCount: 2},
+			header:             http.Header{"X-Foo": {"bar", "baz"}},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			desc:               "header count over the limit",
+			config:             &types.SizeLimits{MaxHeaderCount: 2},
+			header:             http.Header{"X-Foo": {"bar", "baz"}, "X-Bar": {"foo"}},
+			expectedStatusCode: http.StatusRequestHeaderFieldsTooLarge,
+			expectedReason:     reasonHeaderCount,
+		},
+		{
+			desc:               "header size within the limit",
+			config:             &types.SizeLimits{MaxHeaderBytes: 8},
+			header:             http.Header{"X-Foo": {"bar"}},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			desc:               "header size over the limit",
+			config:             &types.SizeLimits{MaxHeaderBytes: 8},
+			header:             http.Header{"X-Foo": {"barbaz"}},
+			expectedStatusCode: http.StatusRequestHeaderFieldsTooLarge,
+			expectedReason:     reasonHeaderSize,
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			counter := &testhelpers.CollectingCounter{}
+			sizeLimit, err := NewSizeLimit(test.config, counter)
+			require.NoError(t, err)
+
+			backend := http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				body, err := ioutil.ReadAll(req.Body)
+				if err != nil {
+					http.Error(rw, err.Error(), http.StatusBadGateway)
+					return
+				}
+				assert.Equal(t, test.body, string(body))
+				rw.Write([]byte("backend"))
+			})
+
+			handler := negroni.New(sizeLimit)
+			handler.UseHandler(backend)
+
+			req := httptest.NewRequest(http.MethodPost, "http://localhost", strings.NewReader(test.body))
+			if test.unknownLength {
+				req.ContentLength = -1
+			}
+			for name, values := range test.header {
+				req.Header[name] = values
+			}
+
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, req)
+
+			assert.Equal(t, test.expectedStatusCode, recorder.Code)
+
+			if len(test.expectedReason) == 0 {
+				assert.Equal(t, "backend", recorder.Body.String())
+				assert.Zero(t, counter.CounterValue)
+				return
+			}
+
+			assert.Equal(t, http.StatusText(test.expectedStatusCode)+"\n", recorder.Body.String())
+			assert.Equal(t, float64(1), counter.CounterValue)
+			assert.Equal(t, []string{"reason", test.expectedReason}, counter.LastLabelValues)
+		})
+	}
+}
@@ -0,0 +1,274 @@
+package static
+
+import (
+	"fmt"
+	"html"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/containous/traefik/log"
+	"github.com/containous/traefik/types"
+)
+
+// DefaultIndexFiles are the files served by default for the directories.
+var DefaultIndexFiles = []string{"index.html"}
+
+// precompressedVariants are the extensions of the precompressed files, by order of preference.
+var precompressedVariants = []struct {
+	extension string
+	encoding  string
+}{
+	{extension: ".br", encoding: "br"},
+	{extension: ".gz", encoding: "gzip"},
+}
+
+// Handler serves the static files of a directory.
+type Handler struct {
+	root       http.Dir
+	indexFiles []string
+	spa        bool
+	browse     bool
+}
+
+// NewHandler creates a handler serving the files of the root directory, with the given configuration (which can be nil).
+func NewHandler(root string, config *types.Static) (*Handler, error) {
+	info, err := os.Stat(root)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("%s is not a directory", root)
+	}
+
+	h := &Handler{
+		root:       http.Dir(root),
+		indexFiles: DefaultIndexFiles,
+	}
+
+	if config != nil {
+		if len(config.IndexFiles) > 0 {
+			h.indexFiles = config.IndexFiles
+		}
+		h.spa = config.SPA
+		h.browse = config.Browse
+	}
+
+	for _, index := range h.indexFiles {
+		if len(index) == 0 || strings.Contains(index, "/") {
+			return nil, fmt.Errorf("invalid index file %q", index)
+		}
+	}
+
+	return h, nil
+}
+
+func (h *Handler) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet && req.Method != http.MethodHead {
+		rw.Header().Set("Allow", "GET, HEAD")
+		http.Error(rw, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	name := path.Clean("/" + req.URL.Path)
+
+	file, info, err := h.open(name)
+	if err != nil {
+		h.serveError(rw, req, name, err)
+		return
+	}
+	defer file.Close()
+
+	if !info.IsDir() {
+		h.serveFile(rw, req, name, file, info)
+		return
+	}
+
+	// relative redirect, to keep the prefixes stripped before the backend
+	if !strings.HasSuffix(req.URL.Path, "/") && name != "/" {
+		location := path.Base(name) + "/"
+		if len(req.URL.RawQuery) > 0 {
+			location += "?" + req.URL.RawQuery
+		}
+		rw.Header().Set("Location", location)
+		rw.WriteHeader(http.StatusMovedPermanently)
+		return
+	}
+
+	if served := h.serveIndex(rw, req, name); served {
+		return
+	}
+
+	if h.browse {
+		h.serveDirList(rw, file)
+		return
+	}
+
+	h.serveError(rw, req, name, os.ErrNotExist)
+}
+
+func (h *Handler) open(name string) (http.File, os.FileInfo, error) {
+	file, err := h.root.Open(name)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, nil, err
+	}
+
+	return file, info, nil
+}
+
+// serveIndex serves the first index file found in the directory.
+func (h *Handler) serveIndex(rw http.ResponseWriter, req *http.Request, dir string) bool {
+	for _, index := range h.indexFiles {
+		name := path.Join(dir, index)
+
+		file, info, err := h.open(name)
+		if err != nil {
+			continue
+		}
+
+		if info.IsDir() {
+			file.Close()
+			continue
+		}
+
+		h.serveFile(rw, req, name, file, info)
+		file.Close()
+		return true
+	}
+
+	return false
+}
+
+func (h *Handler) serveError(rw http.ResponseWriter, req *http.Request, name string, err error) {
+	switch {
+	case os.IsNotExist(err):
+		// the routes of the single page applications don't have an extension, unlike their assets
+		if h.spa && len(path.Ext(name)) == 0 && h.serveIndex(rw, req, "/") {
+			return
+		}
+		http.NotFound(rw, req)
+	case os.IsPermission(err):
+		http.Error(rw, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+	default:
+		log.Debugf("Unable to serve the static file %s: %v", name, err)
+		http.Error(rw, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+	}
+}
+
+// serveFile serves a file, or its precompressed variant accepted by the client.
+// The conditional and range requests are handled by http.ServeContent.
+func (h *Handler) serveFile(rw http.ResponseWriter, req *http.Request, name string, file http.File, info os.FileInfo) {
+	contentType := mime.TypeByExtension(path.Ext(name))
+	content, contentInfo, encoding := file, info, ""
+
+	hasVariants := false
+	for _, variant := range precompressedVariants {
+		variantFile, variantInfo, err := h.open(name + variant.extension)
+		if err != nil {
+			continue
+		}
+
+		if variantInfo.IsDir() {
+			variantFile.Close()
+			continue
+		}
+
+		hasVariants = true
+		if len(encoding) == 0 && acceptsEncoding(req, variant.encoding) {
+			defer variantFile.Close()
+			content, contentInfo, encoding = variantFile, variantInfo, variant.encoding
+			continue
+		}
+
+		variantFile.Close()
+	}
+
+	if hasVariants {
+		rw.Header().Add("Vary", "Accept-Encoding")
+	}
+
+	if len(encoding) > 0 {
+		rw.Header().Set("Content-Encoding", encoding)
+		if len(contentType) == 0 {
+			// the content type can't be sniffed from the compressed content
+			contentType = "application/octet-stream"
+		}
+	}
+
+	if len(contentType) > 0 {
+		rw.Header().Set("Content-Type", contentType)
+	}
+
+	rw.Header().Set("Etag", etag(contentInfo, encoding))
+
+	http.ServeContent(rw, req, name, contentInfo.ModTime(), content)
+}
+
+func (h *Handler) serveDirList(rw http.ResponseWriter, dir http.File) {
+	entries, err := dir.Readdir(-1)
+	if err != nil {
+		log.Debugf("Unable to read the directory: %v", err)
+		http.Error(rw, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name() < entries[j].Name() })
+
+	rw.Header().Set("Content-Type", "text/html; charset=utf-8")
+
+	fmt.Fprintf(rw, "<pre>\n")
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() {
+			name += "/"
+		}
+
+		link := url.URL{Path: name}
+		fmt.Fprintf(rw, "<a href=\"%s\">%s</a>\n", html.EscapeString(link.String()), html.EscapeString(name))
+	}
+	fmt.Fprintf(rw, "</pre>\n")
+}
+
+// etag returns a strong validator of the file, specific to its encoding.
+func etag(info os.FileInfo, encoding string) string {
+	tag := strconv.FormatInt(info.ModTime().UnixNano(), 16) + "-" + strconv.FormatInt(info.Size(), 16)
+	if len(encoding) > 0 {
+		tag += "-" + encoding
+	}
+	return `"` + tag + `"`
+}
+
+// acceptsEncoding returns whether the encoding is explicitly accepted by the client.
+func acceptsEncoding(req *http.Request, encoding string) bool {
+	for _, value := range req.Header["Accept-Encoding"] {
+		for _, part := range strings.Split(value, ",") {
+			params := strings.Split(part, ";")
+			if !strings.EqualFold(strings.TrimSpace(params[0]), encoding) {
+				continue
+			}
+
+			for _, param := range params[1:] {
+				param = strings.TrimSpace(param)
+				if strings.HasPrefix(param, "q=") {
+					if q, err := strconv.ParseFloat(strings.TrimPrefix(param, "q="), 64); err == nil && q == 0 {
+						return false
+					}
+				}
+			}
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,263 @@
+package static
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/containous/traefik/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createSite(t *testing.T) string {
+	t.Helper()
+
+	dir, err := ioutil.TempDir("", "static")
+	require.NoError(t, err)
+
+	files := map[string]string{
+		"index.html":          "<h1>home</h1>",
+		"app.js":              "console.log('app')",
+		"app.js.gz":           "gzipped app",
+		"app.js.br":           "brotli app",
+		"style.css":           "body {}",
+		"docs/guide.txt":      "guide",
+		"assets/logo.svg":     "<svg/>",
+		"assets/<script>.txt": "escaped",
+	}
+
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, ioutil.WriteFile(path, []byte(content), 0644))
+	}
+
+	return dir
+}
+
+func TestNewHandlerInvalid(t *testing.T) {
+	dir := createSite(t)
+	defer os.RemoveAll(dir)
+
+	testCases := []struct {
+		desc   string
+		root   string
+		config *types.Static
+	}{
+		{
+			desc: "missing root",
+			root: filepath.Join(dir, "missing"),
+		},
+		{
+			desc: "root is a file",
+			root: filepath.Join(dir, "index.html"),
+		},
+		{
+			desc:   "invalid index file",
+			root:   dir,
+			config: &types.Static{IndexFiles: []string{"../index.html"}},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			_, err := NewHandler(test.root, test.config)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestHandler(t *testing.T) {
+	dir := createSite(t)
+	defer os.RemoveAll(dir)
+
+	testCases := []struct {
+		desc             string
+		config           *types.Static
+		method           string
+		path             string
+		header           http.Header
+		expectedStatus   int
+		expectedBody     string
+		expectedHeader   http.Header
+		expectedLocation string
+	}{
+		{
+			desc:           "file",
+			path:           "/style.css",
+			expectedStatus: http.StatusOK,
+			expectedBody:   "body {}",
+			expectedHeader: http.Header{"Content-Type": {"text/css; charset=utf-8"}},
+		},
+		{
+			desc:           "index file",
+			path:           "/",
+			expectedStatus: http.StatusOK,
+			expectedBody:   "<h1>home</h1>",
+			expectedHeader: http.Header{"Content-Type": {"text/html; charset=utf-8"}},
+		},
+		{
+			desc:           "custom index file",
+			config:         &types.Static{IndexFiles: []string{"guide.txt"}},
+			path:           "/docs/",
+			expectedStatus: http.StatusOK,
+			expectedBody:   "guide",
+		},
+		{
+			desc:             "directory without trailing slash",
+			path:             "/docs",
+			expectedStatus:   http.StatusMovedPermanently,
+			expectedLocation: "docs/",
+		},
+		{
+			desc:           "directory listing disabled",
+			path:           "/docs/",
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   "404 page not found\n",
+		},
+		{
+			desc:           "directory listing",
+			config:         &types.Static{Browse: true},
+			path:           "/assets/",
+			expectedStatus: http.StatusOK,
+			expectedBody:   "<pre>\n<a href=\"%3Cscript%3E.txt\">&lt;script&gt;.txt</a>\n<a href=\"logo.svg\">logo.svg</a>\n</pre>\n",
+		},
+		{
+			desc:           "missing file",
+			path:           "/missing",
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   "404 page not found\n",
+		},
+		{
+			desc:           "SPA fallback",
+			config:         &types.Static{SPA: true},
+			path:           "/users/42",
+			expectedStatus: http.StatusOK,
+			expectedBody:   "<h1>home</h1>",
+		},
+		{
+			desc:           "SPA fallback not used for the assets",
+			config:         &types.Static{SPA: true},
+			path:           "/missing.js",
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   "404 page not found\n",
+		},
+		{
+			desc:           "path traversal",
+			path:           "/../../etc/passwd",
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   "404 page not found\n",
+		},
+		{
+			desc:           "method not allowed",
+			method:         http.MethodPost,
+			path:           "/style.css",
+			expectedStatus: http.StatusMethodNotAllowed,
+			expectedBody:   "Method Not Allowed\n",
+			expectedHeader: http.Header{"Allow": {"GET, HEAD"}},
+		},
+		{
+			desc:           "brotli variant",
+			path:           "/app.js",
+			header:         http.Header{"Accept-Encoding": {"gzip, deflate, br"}},
+			expectedStatus: http.StatusOK,
+			expectedBody:   "brotli app",
+			expectedHeader: http.Header{"Content-Encoding": {"br"}, "Vary": {"Accept-Encoding"}},
+		},
+		{
+			desc:           "gzip variant",
+			path:           "/app.js",
+			header:         http.Header{"Accept-Encoding": {"gzip, br;q=0"}},
+			expectedStatus: http.StatusOK,
+			expectedBody:   "gzipped app",
+			expectedHeader: http.Header{"Content-Encoding": {"gzip"}, "Vary": {"Accept-Encoding"}},
+		},
+		{
+			desc:           "no variant accepted",
+			path:           "/app.js",
+			expectedStatus: http.StatusOK,
+			expectedBody:   "console.log('app')",
+			expectedHeader: http.Header{"Content-Encoding": nil, "Vary": {"Accept-Encoding"}},
+		},
+		{
+			desc:           "range request",
+			path:           "/style.css",
+			header:         http.Header{"Range": {"bytes=0-3"}},
+			expectedStatus: http.StatusPartialContent,
+			expectedBody:   "body",
+			expectedHeader: http.Header{"Content-Range": {"bytes 0-3/7"}},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			handler, err := NewHandler(dir, test.config)
+			require.NoError(t, err)
+
+			method := test.method
+			if len(method) == 0 {
+				method = http.MethodGet
+			}
+
+			req := httptest.NewRequest(method, "http://foo.com"+test.path, nil)
+			for name, values := range test.header {
+				req.Header[name] = values
+			}
+
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, req)
+
+			assert.Equal(t, test.expectedStatus, recorder.Code)
+			if len(test.expectedBody) > 0 {
+				assert.Equal(t, test.expectedBody, recorder.Body.String())
+			}
+			for name, values := range test.expectedHeader {
+				assert.Equal(t, values, recorder.Header()[name], name)
+			}
+			if len(test.expectedLocation) > 0 {
+				assert.Equal(t, test.expectedLocation, recorder.Header().Get("Location"))
+			}
+		})
+	}
+}
+
+func TestHandlerConditionalRequests(t *testing.T) {
+	dir := createSite(t)
+	defer os.RemoveAll(dir)
+
+	handler, err := NewHandler(dir, nil)
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodGet, "http://foo.com/style.css", nil)
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, req)
+
+	require.Equal(t, http.StatusOK, recorder.Code)
+	etag := recorder.Header().Get("Etag")
+	lastModified := recorder.Header().Get("Last-Modified")
+	require.NotEmpty(t, etag)
+	require.NotEmpty(t, lastModified)
+
+	req = httptest.NewRequest(http.MethodGet, "http://foo.com/style.css", nil)
+	req.Header.Set("If-None-Match", etag)
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, req)
+	assert.Equal(t, http.StatusNotModified, recorder.Code)
+
+	req = httptest.NewRequest(http.MethodGet, "http://foo.com/style.css", nil)
+	req.Header.Set("If-Modified-Since", lastModified)
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, req)
+	assert.Equal(t, http.StatusNotModified, recorder.Code)
+
+	req = httptest.NewRequest(http.MethodGet, "http://foo.com/app.js", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, req)
+	assert.NotEqual(t, etag, recorder.Header().Get("Etag"))
+	assert.Contains(t, recorder.Header().Get("Etag"), "-gzip")
+}
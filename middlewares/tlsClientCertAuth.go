@@ -0,0 +1,128 @@
+package middlewares
+
+import (
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/containous/traefik/log"
+	"github.com/containous/traefik/middlewares/tracing"
+	"github.com/containous/traefik/types"
+	"github.com/ryanuber/go-glob"
+)
+
+// TLSClientCertAuth is a middleware that only lets through the requests
+// presenting a TLS client certificate that matches one of the configured rules.
+type TLSClientCertAuth struct {
+	commonNames         []string
+	organizationalUnits []string
+	dnsNames            []string
+	uris                []string
+	issuers             []string
+	fingerprints        map[string]struct{}
+}
+
+// NewTLSClientCertAuth builds a new TLSClientCertAuth given a frontend policy.
+func NewTLSClientCertAuth(config *types.TLSClientCertAuth) (*TLSClientCertAuth, error) {
+	if config == nil {
+		return nil, nil
+	}
+
+	auth := &TLSClientCertAuth{
+		commonNames:         config.CommonNames,
+		organizationalUnits: config.OrganizationalUnits,
+		dnsNames:            config.DNSNames,
+		uris:                config.URIs,
+		issuers:             config.Issuers,
+		fingerprints:        make(map[string]struct{}),
+	}
+
+	for _, fingerprint := range config.Fingerprints {
+		auth.fingerprints[normalizeFingerprint(fingerprint)] = struct{}{}
+	}
+
+	if len(auth.commonNames) == 0 && len(auth.organizationalUnits) == 0 && len(auth.dnsNames) == 0 &&
+		len(auth.uris) == 0 && len(auth.issuers) == 0 && len(auth.fingerprints) == 0 {
+		return nil, errors.New("no TLS client certificate rule provided")
+	}
+
+	return auth, nil
+}
+
+func (a *TLSClientCertAuth) ServeHTTP(w http.ResponseWriter, r *http.Request, next http.HandlerFunc) {
+	if r.TLS == nil || len(r.TLS.PeerCertificates) == 0 {
+		tracing.SetErrorAndDebugLog(r, "request %s - rejecting: no TLS client certificate", r.URL)
+		Reject(w)
+		return
+	}
+
+	// The first peer certificate is the client certificate, the others are intermediates.
+	cert := r.TLS.PeerCertificates[0]
+	if !a.isAuthorized(cert) {
+		tracing.SetErrorAndDebugLog(r, "request %s - rejecting: TLS client certificate %q doesn't match any rule", r.URL, cert.Subject.String())
+		Reject(w)
+		return
+	}
+
+	log.Debugf("Accept TLS client certificate %q", cert.Subject.String())
+	next.ServeHTTP(w, r)
+}
+
+func (a *TLSClientCertAuth) isAuthorized(cert *x509.Certificate) bool {
+	if matchAny(a.commonNames, cert.Subject.CommonName) {
+		return true
+	}
+
+	for _, ou := range cert.Subject.OrganizationalUnit {
+		if matchAny(a.organizationalUnits, ou) {
+			return true
+		}
+	}
+
+	for _, dnsName := range cert.DNSNames {
+		if matchAny(a.dnsNames, dnsName) {
+			return true
+		}
+	}
+
+	// the SAN values are extracted as for the TLS client headers
+	for _, uri := range getURIs(cert) {
+		if matchAny(a.uris, uri) {
+			return true
+		}
+	}
+
+	if matchAny(a.issuers, cert.Issuer.CommonName) || matchAny(a.issuers, cert.Issuer.String()) {
+		return true
+	}
+
+	if len(a.fingerprints) > 0 {
+		sum := sha256.Sum256(cert.Raw)
+		if _, ok := a.fingerprints[hex.EncodeToString(sum[:])]; ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+func matchAny(patterns []string, value string) bool {
+	if len(value) == 0 {
+		return false
+	}
+
+	for _, pattern := range patterns {
+		if glob.Glob(pattern, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeFingerprint accepts the usual fingerprint notations (AB:CD:..., abcd...).
+func normalizeFingerprint(fingerprint string) string {
+	return strings.ToLower(strings.Replace(strings.TrimSpace(fingerprint), ":", "", -1))
+}
@@ -0,0 +1,150 @@
+package middlewares
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/containous/traefik/testhelpers"
+	"github.com/containous/traefik/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func generateClientCert(t *testing.T) *x509.Certificate {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	spiffeID, err := url.Parse("spiffe://example.org/ns/prod/sa/billing")
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject: pkix.Name{
+			CommonName:         "client.example.org",
+			OrganizationalUnit: []string{"payments"},
+		},
+		NotBefore: time.Now().Add(-time.Hour),
+		NotAfter:  time.Now().Add(time.Hour),
+		DNSNames:  []string{"billing.internal.example.org"},
+		URIs:      []*url.URL{spiffeID},
+	}
+
+	raw, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	cert, err := x509.ParseCertificate(raw)
+	require.NoError(t, err)
+
+	return cert
+}
+
+func TestNewTLSClientCertAuth(t *testing.T) {
+	auth, err := NewTLSClientCertAuth(nil)
+	require.NoError(t, err)
+	assert.Nil(t, auth)
+
+	_, err = NewTLSClientCertAuth(&types.TLSClientCertAuth{})
+	assert.Error(t, err)
+}
+
+func TestTLSClientCertAuth(t *testing.T) {
+	cert := generateClientCert(t)
+	sum := sha256.Sum256(cert.Raw)
+	fingerprint := strings.ToUpper(hex.EncodeToString(sum[:]))
+
+	testCases := []struct {
+		desc               string
+		config             *types.TLSClientCertAuth
+		noCert             bool
+		expectedStatusCode int
+	}{
+		{
+			desc:               "no client certificate",
+			config:             &types.TLSClientCertAuth{CommonNames: []string{"*"}},
+			noCert:             true,
+			expectedStatusCode: http.StatusForbidden,
+		},
+		{
+			desc:               "matching common name",
+			config:             &types.TLSClientCertAuth{CommonNames: []string{"*.example.org"}},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			desc:               "non matching common name",
+			config:             &types.TLSClientCertAuth{CommonNames: []string{"admin.example.org"}},
+			expectedStatusCode: http.StatusForbidden,
+		},
+		{
+			desc:               "matching organizational unit",
+			config:             &types.TLSClientCertAuth{OrganizationalUnits: []string{"payments"}},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			desc:               "matching SAN DNS name",
+			config:             &types.TLSClientCertAuth{DNSNames: []string{"billing.internal.example.org"}},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			desc:               "matching SPIFFE ID",
+			config:             &types.TLSClientCertAuth{URIs: []string{"spiffe://example.org/ns/prod/*"}},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			desc:               "non matching SPIFFE ID",
+			config:             &types.TLSClientCertAuth{URIs: []string{"spiffe://example.org/ns/staging/*"}},
+			expectedStatusCode: http.StatusForbidden,
+		},
+		{
+			desc:               "matching issuer",
+			config:             &types.TLSClientCertAuth{Issuers: []string{"client.example.org"}},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			desc:               "matching fingerprint",
+			config:             &types.TLSClientCertAuth{Fingerprints: []string{fingerprint}},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			desc:               "non matching fingerprint",
+			config:             &types.TLSClientCertAuth{Fingerprints: []string{"00:11:22"}},
+			expectedStatusCode: http.StatusForbidden,
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			auth, err := NewTLSClientCertAuth(test.config)
+			require.NoError(t, err)
+
+			req := testhelpers.MustNewRequest(http.MethodGet, "https://foo.bar", nil)
+			if !test.noCert {
+				req.TLS = &tls.ConnectionState{PeerCertificates: []*x509.Certificate{cert}}
+			}
+
+			recorder := httptest.NewRecorder()
+			auth.ServeHTTP(recorder, req, func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			})
+
+			assert.Equal(t, test.expectedStatusCode, recorder.Code)
+		})
+	}
+}
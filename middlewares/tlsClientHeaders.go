@@ -138,19 +138,27 @@ func getSANs(cert *x509.Certificate) []string {
 	}
 
 	sans = append(cert.DNSNames, cert.EmailAddresses...)
+	sans = append(sans, getIPAddresses(cert)...)
 
+	return append(sans, getURIs(cert)...)
+}
+
+// getIPAddresses get the IP address values of the Subject Alternate Name
+func getIPAddresses(cert *x509.Certificate) []string {
 	var ips []string
 	for _, ip := range cert.IPAddresses {
 		ips = append(ips, ip.String())
 	}
-	sans = append(sans, ips...)
+	return ips
+}
 
+// getURIs get the URI values of the Subject Alternate Name
+func getURIs(cert *x509.Certificate) []string {
 	var uris []string
 	for _, uri := range cert.URIs {
 		uris = append(uris, uri.String())
 	}
-
-	return append(sans, uris...)
+	return uris
 }
 
 // getSubjectInfos extract the requested informations from the certificate subject
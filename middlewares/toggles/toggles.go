@@ -0,0 +1,48 @@
+package toggles
+
+import "sync"
+
+type frontendKey struct {
+	provider string
+	frontend string
+}
+
+// Store holds the middlewares enabled or disabled at runtime on the frontends, which override their configuration.
+type Store struct {
+	lock    sync.RWMutex
+	enabled map[frontendKey]bool
+}
+
+// NewStore creates a new store of toggles.
+func NewStore() *Store {
+	return &Store{enabled: make(map[frontendKey]bool)}
+}
+
+// Set enables or disables the middleware of a frontend, whatever its configuration.
+func (s *Store) Set(providerName, frontendName string, enabled bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.enabled[frontendKey{provider: providerName, frontend: frontendName}] = enabled
+}
+
+// Reset restores the middleware of a frontend to its configuration.
+func (s *Store) Reset(providerName, frontendName string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	delete(s.enabled, frontendKey{provider: providerName, frontend: frontendName})
+}
+
+// Get returns whether the middleware of a frontend is enabled, and whether it has been set at runtime.
+func (s *Store) Get(providerName, frontendName string) (enabled bool, ok bool) {
+	if s == nil {
+		return false, false
+	}
+
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	enabled, ok = s.enabled[frontendKey{provider: providerName, frontend: frontendName}]
+	return enabled, ok
+}
@@ -0,0 +1,37 @@
+package toggles
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStore(t *testing.T) {
+	store := NewStore()
+
+	_, ok := store.Get("file", "frontend")
+	assert.False(t, ok)
+
+	store.Set("file", "frontend", true)
+	store.Set("file", "other", false)
+
+	enabled, ok := store.Get("file", "frontend")
+	assert.True(t, enabled)
+	assert.True(t, ok)
+
+	enabled, ok = store.Get("file", "other")
+	assert.False(t, enabled)
+	assert.True(t, ok)
+
+	store.Reset("file", "frontend")
+	_, ok = store.Get("file", "frontend")
+	assert.False(t, ok)
+}
+
+func TestNilStore(t *testing.T) {
+	var store *Store
+
+	enabled, ok := store.Get("file", "frontend")
+	assert.False(t, enabled)
+	assert.False(t, ok)
+}
@@ -104,6 +104,13 @@ func LogRequest(span opentracing.Span, r *http.Request) {
 	}
 }
 
+// LogRequestID used to tag the span associated with this request with the request ID
+func LogRequestID(r *http.Request, requestID string) {
+	if span := GetSpan(r); span != nil {
+		span.SetTag("request.id", requestID)
+	}
+}
+
 // LogResponseCode used to log response code in span
 func LogResponseCode(span opentracing.Span, code int) {
 	if span != nil {
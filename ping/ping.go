@@ -2,16 +2,21 @@ package ping
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 
 	"github.com/containous/mux"
+	"github.com/containous/traefik/log"
+	"github.com/containous/traefik/middlewares/drain"
 )
 
 // Handler expose ping routes
 type Handler struct {
 	EntryPoint  string `description:"Ping entryPoint" export:"true"`
 	terminating bool
+	drain       *drain.State
+	readiness   func() error
 }
 
 // WithContext causes the ping endpoint to serve non 200 responses.
@@ -22,15 +27,51 @@ func (h *Handler) WithContext(ctx context.Context) {
 	}()
 }
 
+// WithDrainState causes the ping endpoint to serve non 200 responses in drain mode.
+func (h *Handler) WithDrainState(state *drain.State) {
+	h.drain = state
+}
+
+// WithReadinessCheck causes the readiness endpoint to serve non 200 responses while the check fails.
+func (h *Handler) WithReadinessCheck(check func() error) {
+	h.readiness = check
+}
+
 // AddRoutes add ping routes on a router
 func (h *Handler) AddRoutes(router *mux.Router) {
 	router.Methods(http.MethodGet, http.MethodHead).Path("/ping").
 		HandlerFunc(func(response http.ResponseWriter, request *http.Request) {
 			statusCode := http.StatusOK
-			if h.terminating {
+			if draining, _ := h.drain.Draining(); h.terminating || draining {
+				statusCode = http.StatusServiceUnavailable
+			}
+			response.WriteHeader(statusCode)
+			fmt.Fprint(response, http.StatusText(statusCode))
+		})
+
+	router.Methods(http.MethodGet, http.MethodHead).Path("/ready").
+		HandlerFunc(func(response http.ResponseWriter, request *http.Request) {
+			statusCode := http.StatusOK
+			if err := h.checkReadiness(); err != nil {
+				log.Debugf("Not ready: %v", err)
 				statusCode = http.StatusServiceUnavailable
 			}
 			response.WriteHeader(statusCode)
 			fmt.Fprint(response, http.StatusText(statusCode))
 		})
 }
+
+func (h *Handler) checkReadiness() error {
+	if h.terminating {
+		return errors.New("terminating")
+	}
+
+	if draining, _ := h.drain.Draining(); draining {
+		return errors.New("draining")
+	}
+
+	if h.readiness != nil {
+		return h.readiness()
+	}
+	return nil
+}
@@ -152,6 +152,23 @@ func (p *Provider) Init(_ types.Constraints) error {
 	return nil
 }
 
+// CheckCertificates returns an error until the certificates of the configured domains are loaded in the certificate store.
+func (p *Provider) CheckCertificates() error {
+	if p.certificateStore == nil {
+		return nil
+	}
+
+	allDomains := p.certificateStore.GetAllDomains()
+	for _, domain := range p.Domains {
+		for _, domainToCheck := range domain.ToStrArray() {
+			if !isDomainAlreadyChecked(domainToCheck, allDomains) {
+				return fmt.Errorf("the ACME certificate of the domain %s is not loaded yet", domainToCheck)
+			}
+		}
+	}
+	return nil
+}
+
 func isAccountMatchingCaServer(accountURI string, serverURI string) bool {
 	aru, err := url.Parse(accountURI)
 	if err != nil {
@@ -175,9 +192,11 @@ func (p *Provider) Provide(configurationChan chan<- types.ConfigMessage, pool *s
 	p.watchNewDomains()
 
 	p.configurationChan = configurationChan
-	p.refreshCertificates()
 
+	// the domains are checked for readiness once the first configuration is delivered
 	p.deleteUnnecessaryDomains()
+	p.refreshCertificates()
+
 	for i := 0; i < len(p.Domains); i++ {
 		domain := p.Domains[i]
 		safe.Go(func() {
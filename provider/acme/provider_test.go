@@ -471,6 +471,62 @@ func TestDeleteUnnecessaryDomains(t *testing.T) {
 	}
 }
 
+func TestCheckCertificates(t *testing.T) {
+	certificates := make(map[string]*tls.Certificate)
+	certificates["traefik.wtf,www.traefik.wtf"] = &tls.Certificate{}
+	certificates["*.containo.us"] = &tls.Certificate{}
+
+	dynamicCerts := &safe.Safe{}
+	dynamicCerts.Set(certificates)
+
+	testCases := []struct {
+		desc          string
+		domains       []types.Domain
+		expectedError bool
+	}{
+		{
+			desc: "no configured domains",
+		},
+		{
+			desc:    "loaded certificate",
+			domains: []types.Domain{{Main: "traefik.wtf", SANs: []string{"www.traefik.wtf"}}},
+		},
+		{
+			desc:    "loaded wildcard certificate",
+			domains: []types.Domain{{Main: "foo.containo.us"}},
+		},
+		{
+			desc:          "missing SAN",
+			domains:       []types.Domain{{Main: "traefik.wtf", SANs: []string{"api.traefik.wtf"}}},
+			expectedError: true,
+		},
+		{
+			desc:          "missing certificate",
+			domains:       []types.Domain{{Main: "traefik.wtf"}, {Main: "acme.wtf"}},
+			expectedError: true,
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			acmeProvider := Provider{
+				Configuration:    &Configuration{Domains: test.domains},
+				certificateStore: &traefiktls.CertificateStore{DynamicCerts: dynamicCerts},
+			}
+
+			err := acmeProvider.CheckCertificates()
+			if test.expectedError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
 func TestIsAccountMatchingCaServer(t *testing.T) {
 	testCases := []struct {
 		desc       string
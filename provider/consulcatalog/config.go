@@ -46,29 +46,47 @@ func (p *Provider) buildConfiguration(catalog []catalogUpdate) *types.Configurat
 		"getPassTLSCert":         label.GetFuncBool(label.TraefikFrontendPassTLSCert, label.DefaultPassTLSCert),
 		"getPassTLSClientCert":   label.GetTLSClientCert,
 		"getWhiteList":           label.GetWhiteList,
+		"getTLSClientCertAuth":   label.GetTLSClientCertAuth,
+		"getGeoIP":               label.GetGeoIP,
+		"getBodyRewrite":         label.GetBodyRewrite,
+		"getMaintenance":         label.GetMaintenance,
+		"getFaultInjection":      label.GetFaultInjection,
+		"getSizeLimits":          label.GetSizeLimits,
+		"getRequestID":           label.GetRequestID,
 		"getRedirect":            label.GetRedirect,
 		"getErrorPages":          label.GetErrorPages,
 		"getRateLimit":           label.GetRateLimit,
 		"getHeaders":             label.GetHeaders,
+
+		// UDP functions
+		"getUDPEntryPoints": label.GetFuncSliceString(label.TraefikUDPEntryPoints),
+		"getUDPServers":     p.getUDPServers,
 	}
 
 	var allNodes []*api.ServiceEntry
 	var services []*serviceUpdate
+	udpServices := make(map[string]catalogUpdate)
 	for _, info := range catalog {
 		if len(info.Nodes) > 0 {
 			services = append(services, p.generateFrontends(info.Service)...)
 			allNodes = append(allNodes, info.Nodes...)
+
+			if label.Has(info.Service.TraefikLabels, label.TraefikUDPEntryPoints) {
+				udpServices[provider.Normalize(info.Service.ServiceName)] = info
+			}
 		}
 	}
 	// Ensure a stable ordering of nodes so that identical configurations may be detected
 	sort.Sort(nodeSorter(allNodes))
 
 	templateObjects := struct {
-		Services []*serviceUpdate
-		Nodes    []*api.ServiceEntry
+		Services    []*serviceUpdate
+		Nodes       []*api.ServiceEntry
+		UDPServices map[string]catalogUpdate
 	}{
-		Services: services,
-		Nodes:    allNodes,
+		Services:    services,
+		Nodes:       allNodes,
+		UDPServices: udpServices,
 	}
 
 	configuration, err := p.GetConfiguration("templates/consul_catalog.tmpl", funcMap, templateObjects)
@@ -124,6 +142,27 @@ func (p *Provider) getServer(node *api.ServiceEntry) types.Server {
 	}
 }
 
+// getUDPServers returns the UDP servers of the nodes, listening on the port of the udp.serverPort tag,
+// or on the port of the service.
+func (p *Provider) getUDPServers(nodes []*api.ServiceEntry) map[string]types.UDPServer {
+	var servers map[string]types.UDPServer
+
+	for i, node := range nodes {
+		if servers == nil {
+			servers = make(map[string]types.UDPServer)
+		}
+
+		port := label.GetStringValue(tagsToNeutralLabels(node.Service.Tags, p.Prefix), label.TraefikUDPServerPort, strconv.Itoa(node.Service.Port))
+
+		servers[getServerName(node, i)] = types.UDPServer{
+			Address: net.JoinHostPort(getBackendAddress(node), port),
+			Weight:  p.getWeight(node.Service.Tags),
+		}
+	}
+
+	return servers
+}
+
 func (p *Provider) setupFrontEndRuleTemplate() {
 	var FuncMap = template.FuncMap{
 		"getAttribute": p.getAttribute,
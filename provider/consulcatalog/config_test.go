@@ -10,6 +10,7 @@ import (
 	"github.com/containous/traefik/types"
 	"github.com/hashicorp/consul/api"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestProviderBuildConfiguration(t *testing.T) {
@@ -822,6 +823,73 @@ func TestProviderBuildConfiguration(t *testing.T) {
 	}
 }
 
+func TestProviderBuildUDPConfiguration(t *testing.T) {
+	p := &Provider{
+		Domain:               "localhost",
+		Prefix:               "traefik",
+		FrontEndRule:         "Host:{{.ServiceName}}.{{.Domain}}",
+		frontEndRuleTemplate: template.New("consul catalog frontend rule"),
+	}
+
+	dnsNode := &api.ServiceEntry{
+		Service: &api.AgentService{
+			Service: "dns",
+			Address: "10.0.0.1",
+			Port:    53,
+			Tags:    []string{label.TraefikUDPEntryPoints + "=dns", label.TraefikWeight + "=2"},
+		},
+		Node: &api.Node{Node: "node1", Address: "10.0.0.1"},
+	}
+	syslogNode := &api.ServiceEntry{
+		Service: &api.AgentService{
+			Service: "syslog",
+			Port:    8080,
+			Tags:    []string{label.TraefikUDPEntryPoints + "=syslog,logs", label.TraefikUDPServerPort + "=514"},
+		},
+		Node: &api.Node{Node: "node2", Address: "10.0.0.2"},
+	}
+
+	nodes := fakeLoadTraefikLabelsSlice([]catalogUpdate{
+		{
+			Service: &serviceUpdate{ServiceName: "dns", Attributes: dnsNode.Service.Tags},
+			Nodes:   []*api.ServiceEntry{dnsNode},
+		},
+		{
+			Service: &serviceUpdate{ServiceName: "syslog", Attributes: syslogNode.Service.Tags},
+			Nodes:   []*api.ServiceEntry{syslogNode},
+		},
+	}, p.Prefix)
+
+	actualConfig := p.buildConfiguration(nodes)
+	require.NotNil(t, actualConfig)
+
+	expectedFrontends := map[string]*types.UDPFrontend{
+		"udp-frontend-dns": {
+			EntryPoints: []string{"dns"},
+			Backend:     "udp-backend-dns",
+		},
+		"udp-frontend-syslog": {
+			EntryPoints: []string{"syslog", "logs"},
+			Backend:     "udp-backend-syslog",
+		},
+	}
+	assert.Equal(t, expectedFrontends, actualConfig.UDPFrontends)
+
+	expectedBackends := map[string]*types.UDPBackend{
+		"udp-backend-dns": {
+			Servers: map[string]types.UDPServer{
+				getServerName(dnsNode, 0): {Address: "10.0.0.1:53", Weight: 2},
+			},
+		},
+		"udp-backend-syslog": {
+			Servers: map[string]types.UDPServer{
+				getServerName(syslogNode, 0): {Address: "10.0.0.2:514", Weight: 1},
+			},
+		},
+	}
+	assert.Equal(t, expectedBackends, actualConfig.UDPBackends)
+}
+
 func TestGetTag(t *testing.T) {
 	testCases := []struct {
 		desc         string
@@ -39,6 +39,7 @@ func (p *Provider) buildConfiguration(containersInspected []dockerData) *types.C
 		"getHealthCheck":    label.GetHealthCheck,
 		"getBuffering":      label.GetBuffering,
 		"getCircuitBreaker": label.GetCircuitBreaker,
+		"getProxyProtocol":  label.GetProxyProtocol,
 		"getLoadBalancer":   label.GetLoadBalancer,
 
 		// Frontend functions
@@ -56,6 +57,17 @@ func (p *Provider) buildConfiguration(containersInspected []dockerData) *types.C
 		"getRateLimit":         label.GetRateLimit,
 		"getHeaders":           label.GetHeaders,
 		"getWhiteList":         label.GetWhiteList,
+		"getTLSClientCertAuth": label.GetTLSClientCertAuth,
+		"getGeoIP":             label.GetGeoIP,
+		"getBodyRewrite":       label.GetBodyRewrite,
+		"getMaintenance":       label.GetMaintenance,
+		"getFaultInjection":    label.GetFaultInjection,
+		"getSizeLimits":        label.GetSizeLimits,
+		"getRequestID":         label.GetRequestID,
+
+		// UDP functions
+		"getUDPEntryPoints": label.GetFuncSliceString(label.TraefikUDPEntryPoints),
+		"getUDPServers":     p.getUDPServers,
 	}
 
 	// filter containers
@@ -90,16 +102,24 @@ func (p *Provider) buildConfiguration(containersInspected []dockerData) *types.C
 		}
 	}
 
+	udpServices := map[string][]dockerData{}
+	for _, container := range fun.Filter(p.udpContainerFilter, containersInspected).([]dockerData) {
+		udpServiceName := provider.Normalize(getServiceNameKey(container, p.SwarmMode, ""))
+		udpServices[udpServiceName] = append(udpServices[udpServiceName], container)
+	}
+
 	templateObjects := struct {
-		Containers []dockerData
-		Frontends  map[string][]dockerData
-		Servers    map[string][]dockerData
-		Domain     string
+		Containers  []dockerData
+		Frontends   map[string][]dockerData
+		Servers     map[string][]dockerData
+		UDPServices map[string][]dockerData
+		Domain      string
 	}{
-		Containers: filteredContainers,
-		Frontends:  frontends,
-		Servers:    servers,
-		Domain:     p.Domain,
+		Containers:  filteredContainers,
+		Frontends:   frontends,
+		Servers:     servers,
+		UDPServices: udpServices,
+		Domain:      p.Domain,
 	}
 
 	configuration, err := p.GetConfiguration("templates/docker.tmpl", dockerFuncMap, templateObjects)
@@ -141,6 +161,24 @@ func (p *Provider) containerFilter(container dockerData) bool {
 		return false
 	}
 
+	return p.constraintsAndHealthFilter(container)
+}
+
+// udpContainerFilter keeps the containers declaring a UDP service, which do not need any HTTP port or frontend rule.
+func (p *Provider) udpContainerFilter(container dockerData) bool {
+	if _, ok := container.Labels[label.TraefikUDPEntryPoints]; !ok {
+		return false
+	}
+
+	if !label.IsEnabled(container.Labels, p.ExposedByDefault) {
+		log.Debugf("Filtering disabled container %s", container.Name)
+		return false
+	}
+
+	return p.constraintsAndHealthFilter(container)
+}
+
+func (p *Provider) constraintsAndHealthFilter(container dockerData) bool {
 	constraintTags := label.SplitAndTrimString(container.Labels[label.TraefikTags], ",")
 	if ok, failingConstraint := p.MatchConstraints(constraintTags); !ok {
 		if failingConstraint != nil {
@@ -395,6 +433,69 @@ func (p *Provider) getServers(containers []dockerData) map[string]types.Server {
 	return servers
 }
 
+// getUDPServers returns the UDP servers of the containers, listening on the port of the traefik.udp.serverPort label,
+// or on their lowest exposed UDP port.
+func (p *Provider) getUDPServers(containers []dockerData) map[string]types.UDPServer {
+	var servers map[string]types.UDPServer
+
+	for _, container := range containers {
+		ip := p.getIPAddress(container)
+		if len(ip) == 0 {
+			log.Warnf("Unable to find the IP address for the container %q: the UDP server is ignored", container.Name)
+			continue
+		}
+
+		port := getUDPPort(container)
+		if len(port) == 0 {
+			log.Warnf("Unable to find the UDP port for the container %q: the UDP server is ignored", container.Name)
+			continue
+		}
+
+		if servers == nil {
+			servers = make(map[string]types.UDPServer)
+		}
+
+		address := net.JoinHostPort(ip, port)
+
+		serverName := getServerName(container.Name, "udp://"+address)
+		if _, exist := servers[serverName]; exist {
+			log.Debugf("Skipping UDP server %q with the same address.", serverName)
+			continue
+		}
+
+		servers[serverName] = types.UDPServer{
+			Address: address,
+			Weight:  label.GetIntValue(container.Labels, label.TraefikWeight, label.DefaultWeight),
+		}
+	}
+
+	return servers
+}
+
+func getUDPPort(container dockerData) string {
+	if value := label.GetStringValue(container.Labels, label.TraefikUDPServerPort, ""); len(value) != 0 {
+		return value
+	}
+
+	var ports []nat.Port
+	for port := range container.NetworkSettings.Ports {
+		if port.Proto() == "udp" {
+			ports = append(ports, port)
+		}
+	}
+
+	less := func(i, j nat.Port) bool {
+		return i.Int() < j.Int()
+	}
+	nat.Sort(ports, less)
+
+	if len(ports) > 0 {
+		return ports[0].Port()
+	}
+
+	return ""
+}
+
 func getServerName(containerName, url string) string {
 	hash := md5.New()
 	_, err := hash.Write([]byte(url))
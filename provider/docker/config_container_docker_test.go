@@ -325,6 +325,550 @@ func TestDockerBuildConfiguration(t *testing.T) {
 				},
 			},
 		},
+		{
+			desc: "when frontend header operations",
+			containers: []docker.ContainerJSON{
+				containerJSON(
+					name("test"),
+					labels(map[string]string{
+						label.Prefix + label.BaseFrontendRequestOperation + "1." + label.SuffixHeaderOperationAction:       "set",
+						label.Prefix + label.BaseFrontendRequestOperation + "1." + label.SuffixHeaderOperationName:         "X-User-Id",
+						label.Prefix + label.BaseFrontendRequestOperation + "1." + label.SuffixHeaderOperationValue:        "{{ .Vars.id }}",
+						label.Prefix + label.BaseFrontendResponseOperation + "1." + label.SuffixHeaderOperationAction:      "replace",
+						label.Prefix + label.BaseFrontendResponseOperation + "1." + label.SuffixHeaderOperationName:        "Location",
+						label.Prefix + label.BaseFrontendResponseOperation + "1." + label.SuffixHeaderOperationRegex:       "^http://(.*)",
+						label.Prefix + label.BaseFrontendResponseOperation + "1." + label.SuffixHeaderOperationValue:       "https://$1",
+						label.Prefix + label.BaseFrontendResponseOperation + "1." + label.SuffixHeaderOperationStatusCodes: "301,302",
+					}),
+					ports(nat.PortMap{
+						"80/tcp": {},
+					}),
+					withNetwork("bridge", ipv4("127.0.0.1")),
+				),
+			},
+			expectedFrontends: map[string]*types.Frontend{
+				"frontend-Host-test-docker-localhost-0": {
+					Backend:        "backend-test",
+					PassHostHeader: true,
+					EntryPoints:    []string{},
+					Headers: &types.Headers{
+						RequestOperations: []types.HeaderOperation{
+							{Action: "set", Name: "X-User-Id", Value: "{{ .Vars.id }}"},
+						},
+						ResponseOperations: []types.HeaderOperation{
+							{Action: "replace", Name: "Location", Regex: "^http://(.*)", Value: "https://$1", StatusCodes: []string{"301", "302"}},
+						},
+					},
+					Routes: map[string]types.Route{
+						"route-frontend-Host-test-docker-localhost-0": {
+							Rule: "Host:test.docker.localhost",
+						},
+					},
+				},
+			},
+			expectedBackends: map[string]*types.Backend{
+				"backend-test": {
+					Servers: map[string]types.Server{
+						"server-test-842895ca2aca17f6ee36ddb2f621194d": {
+							URL:    "http://127.0.0.1:80",
+							Weight: label.DefaultWeight,
+						},
+					},
+					CircuitBreaker: nil,
+				},
+			},
+		},
+		{
+			desc: "when frontend TLS client certificate authorization",
+			containers: []docker.ContainerJSON{
+				containerJSON(
+					name("test"),
+					labels(map[string]string{
+						label.TraefikFrontendTLSClientCertAuthCommonNames: "client1.example.org,*.example.com",
+						label.TraefikFrontendTLSClientCertAuthURIs:        "spiffe://example.org/ns/prod/*",
+					}),
+					ports(nat.PortMap{
+						"80/tcp": {},
+					}),
+					withNetwork("bridge", ipv4("127.0.0.1")),
+				),
+			},
+			expectedFrontends: map[string]*types.Frontend{
+				"frontend-Host-test-docker-localhost-0": {
+					Backend:        "backend-test",
+					PassHostHeader: true,
+					EntryPoints:    []string{},
+					TLSClientCertAuth: &types.TLSClientCertAuth{
+						CommonNames: []string{"client1.example.org", "*.example.com"},
+						URIs:        []string{"spiffe://example.org/ns/prod/*"},
+					},
+					Routes: map[string]types.Route{
+						"route-frontend-Host-test-docker-localhost-0": {
+							Rule: "Host:test.docker.localhost",
+						},
+					},
+				},
+			},
+			expectedBackends: map[string]*types.Backend{
+				"backend-test": {
+					Servers: map[string]types.Server{
+						"server-test-842895ca2aca17f6ee36ddb2f621194d": {
+							URL:    "http://127.0.0.1:80",
+							Weight: label.DefaultWeight,
+						},
+					},
+					CircuitBreaker: nil,
+				},
+			},
+		},
+		{
+			desc: "when frontend GeoIP",
+			containers: []docker.ContainerJSON{
+				containerJSON(
+					name("test"),
+					labels(map[string]string{
+						label.TraefikFrontendGeoIPCountryDatabase: "/foo/country.mmdb",
+						label.TraefikFrontendGeoIPDeniedCountries: "KP,IR",
+						label.TraefikFrontendGeoIPAddHeaders:      "true",
+					}),
+					ports(nat.PortMap{
+						"80/tcp": {},
+					}),
+					withNetwork("bridge", ipv4("127.0.0.1")),
+				),
+			},
+			expectedFrontends: map[string]*types.Frontend{
+				"frontend-Host-test-docker-localhost-0": {
+					Backend:        "backend-test",
+					PassHostHeader: true,
+					EntryPoints:    []string{},
+					GeoIP: &types.GeoIP{
+						CountryDatabase: "/foo/country.mmdb",
+						DeniedCountries: []string{"KP", "IR"},
+						AddHeaders:      true,
+					},
+					Routes: map[string]types.Route{
+						"route-frontend-Host-test-docker-localhost-0": {
+							Rule: "Host:test.docker.localhost",
+						},
+					},
+				},
+			},
+			expectedBackends: map[string]*types.Backend{
+				"backend-test": {
+					Servers: map[string]types.Server{
+						"server-test-842895ca2aca17f6ee36ddb2f621194d": {
+							URL:    "http://127.0.0.1:80",
+							Weight: label.DefaultWeight,
+						},
+					},
+					CircuitBreaker: nil,
+				},
+			},
+		},
+		{
+			desc: "when frontend maintenance",
+			containers: []docker.ContainerJSON{
+				containerJSON(
+					name("test"),
+					labels(map[string]string{
+						label.TraefikFrontendMaintenanceEnabled:           "true",
+						label.TraefikFrontendMaintenanceRetryAfter:        "10m",
+						label.TraefikFrontendMaintenanceSourceRange:       "10.0.0.0/8",
+						label.TraefikFrontendMaintenanceBypassHeaderName:  "X-Bypass",
+						label.TraefikFrontendMaintenanceBypassHeaderValue: `"secret"`,
+					}),
+					ports(nat.PortMap{
+						"80/tcp": {},
+					}),
+					withNetwork("bridge", ipv4("127.0.0.1")),
+				),
+			},
+			expectedFrontends: map[string]*types.Frontend{
+				"frontend-Host-test-docker-localhost-0": {
+					Backend:        "backend-test",
+					PassHostHeader: true,
+					EntryPoints:    []string{},
+					Maintenance: &types.Maintenance{
+						Enabled:           true,
+						RetryAfter:        parse.Duration(10 * time.Minute),
+						SourceRange:       []string{"10.0.0.0/8"},
+						BypassHeaderName:  "X-Bypass",
+						BypassHeaderValue: `"secret"`,
+					},
+					Routes: map[string]types.Route{
+						"route-frontend-Host-test-docker-localhost-0": {
+							Rule: "Host:test.docker.localhost",
+						},
+					},
+				},
+			},
+			expectedBackends: map[string]*types.Backend{
+				"backend-test": {
+					Servers: map[string]types.Server{
+						"server-test-842895ca2aca17f6ee36ddb2f621194d": {
+							URL:    "http://127.0.0.1:80",
+							Weight: label.DefaultWeight,
+						},
+					},
+					CircuitBreaker: nil,
+				},
+			},
+		},
+		{
+			desc: "when error page from a template",
+			containers: []docker.ContainerJSON{
+				containerJSON(
+					name("test"),
+					labels(map[string]string{
+						label.Prefix + label.BaseFrontendErrorPage + "foo." + label.SuffixErrorPageStatus:      "500-599",
+						label.Prefix + label.BaseFrontendErrorPage + "foo." + label.SuffixErrorPageBody:        `{"status": {{ .StatusCode }}, "id": "{{ .RequestID }}"}`,
+						label.Prefix + label.BaseFrontendErrorPage + "foo." + label.SuffixErrorPageContentType: "application/json",
+					}),
+					ports(nat.PortMap{
+						"80/tcp": {},
+					}),
+					withNetwork("bridge", ipv4("127.0.0.1")),
+				),
+			},
+			expectedFrontends: map[string]*types.Frontend{
+				"frontend-Host-test-docker-localhost-0": {
+					Backend:        "backend-test",
+					PassHostHeader: true,
+					EntryPoints:    []string{},
+					Errors: map[string]*types.ErrorPage{
+						"foo": {
+							Status:      []string{"500-599"},
+							Body:        `{"status": {{ .StatusCode }}, "id": "{{ .RequestID }}"}`,
+							ContentType: "application/json",
+						},
+					},
+					Routes: map[string]types.Route{
+						"route-frontend-Host-test-docker-localhost-0": {
+							Rule: "Host:test.docker.localhost",
+						},
+					},
+				},
+			},
+			expectedBackends: map[string]*types.Backend{
+				"backend-test": {
+					Servers: map[string]types.Server{
+						"server-test-842895ca2aca17f6ee36ddb2f621194d": {
+							URL:    "http://127.0.0.1:80",
+							Weight: label.DefaultWeight,
+						},
+					},
+					CircuitBreaker: nil,
+				},
+			},
+		},
+		{
+			desc: "when frontend fault injection",
+			containers: []docker.ContainerJSON{
+				containerJSON(
+					name("test"),
+					labels(map[string]string{
+						label.TraefikFrontendFaultInjectionEnabled:           "true",
+						label.TraefikFrontendFaultInjectionHeaderName:        "X-Chaos",
+						label.TraefikFrontendFaultInjectionDelayPercentage:   "12.5",
+						label.TraefikFrontendFaultInjectionDelay:             "100ms",
+						label.TraefikFrontendFaultInjectionDelayDistribution: "exponential",
+						label.TraefikFrontendFaultInjectionAbortPercentage:   "5",
+						label.TraefikFrontendFaultInjectionAbortStatusCode:   "502",
+					}),
+					ports(nat.PortMap{
+						"80/tcp": {},
+					}),
+					withNetwork("bridge", ipv4("127.0.0.1")),
+				),
+			},
+			expectedFrontends: map[string]*types.Frontend{
+				"frontend-Host-test-docker-localhost-0": {
+					Backend:        "backend-test",
+					PassHostHeader: true,
+					EntryPoints:    []string{},
+					FaultInjection: &types.FaultInjection{
+						Enabled:           true,
+						HeaderName:        "X-Chaos",
+						DelayPercentage:   12.5,
+						Delay:             parse.Duration(100 * time.Millisecond),
+						DelayDistribution: "exponential",
+						AbortPercentage:   5,
+						AbortStatusCode:   502,
+					},
+					Routes: map[string]types.Route{
+						"route-frontend-Host-test-docker-localhost-0": {
+							Rule: "Host:test.docker.localhost",
+						},
+					},
+				},
+			},
+			expectedBackends: map[string]*types.Backend{
+				"backend-test": {
+					Servers: map[string]types.Server{
+						"server-test-842895ca2aca17f6ee36ddb2f621194d": {
+							URL:    "http://127.0.0.1:80",
+							Weight: label.DefaultWeight,
+						},
+					},
+					CircuitBreaker: nil,
+				},
+			},
+		},
+		{
+			desc: "when frontend request ID",
+			containers: []docker.ContainerJSON{
+				containerJSON(
+					name("test"),
+					labels(map[string]string{
+						label.TraefikFrontendRequestIDFormat:     "ulid",
+						label.TraefikFrontendRequestIDTrustedIPs: "10.0.0.0/8",
+					}),
+					ports(nat.PortMap{
+						"80/tcp": {},
+					}),
+					withNetwork("bridge", ipv4("127.0.0.1")),
+				),
+			},
+			expectedFrontends: map[string]*types.Frontend{
+				"frontend-Host-test-docker-localhost-0": {
+					Backend:        "backend-test",
+					PassHostHeader: true,
+					EntryPoints:    []string{},
+					RequestID: &types.RequestID{
+						Format:     "ulid",
+						TrustedIPs: []string{"10.0.0.0/8"},
+					},
+					Routes: map[string]types.Route{
+						"route-frontend-Host-test-docker-localhost-0": {
+							Rule: "Host:test.docker.localhost",
+						},
+					},
+				},
+			},
+			expectedBackends: map[string]*types.Backend{
+				"backend-test": {
+					Servers: map[string]types.Server{
+						"server-test-842895ca2aca17f6ee36ddb2f621194d": {
+							URL:    "http://127.0.0.1:80",
+							Weight: label.DefaultWeight,
+						},
+					},
+					CircuitBreaker: nil,
+				},
+			},
+		},
+		{
+			desc: "when frontend size limits",
+			containers: []docker.ContainerJSON{
+				containerJSON(
+					name("test"),
+					labels(map[string]string{
+						label.TraefikFrontendSizeLimitsMaxRequestBodyBytes: "10485760",
+						label.TraefikFrontendSizeLimitsMaxHeaderCount:      "100",
+					}),
+					ports(nat.PortMap{
+						"80/tcp": {},
+					}),
+					withNetwork("bridge", ipv4("127.0.0.1")),
+				),
+			},
+			expectedFrontends: map[string]*types.Frontend{
+				"frontend-Host-test-docker-localhost-0": {
+					Backend:        "backend-test",
+					PassHostHeader: true,
+					EntryPoints:    []string{},
+					SizeLimits: &types.SizeLimits{
+						MaxRequestBodyBytes: 10485760,
+						MaxHeaderCount:      100,
+					},
+					Routes: map[string]types.Route{
+						"route-frontend-Host-test-docker-localhost-0": {
+							Rule: "Host:test.docker.localhost",
+						},
+					},
+				},
+			},
+			expectedBackends: map[string]*types.Backend{
+				"backend-test": {
+					Servers: map[string]types.Server{
+						"server-test-842895ca2aca17f6ee36ddb2f621194d": {
+							URL:    "http://127.0.0.1:80",
+							Weight: label.DefaultWeight,
+						},
+					},
+					CircuitBreaker: nil,
+				},
+			},
+		},
+		{
+			desc: "when frontend URL components redirect",
+			containers: []docker.ContainerJSON{
+				containerJSON(
+					name("test"),
+					labels(map[string]string{
+						label.TraefikFrontendRedirectScheme:     "https",
+						label.TraefikFrontendRedirectPort:       "8443",
+						label.TraefikFrontendRedirectPathPrefix: "/app",
+						label.TraefikFrontendRedirectDropQuery:  "true",
+						label.TraefikFrontendRedirectStatusCode: "308",
+					}),
+					ports(nat.PortMap{
+						"80/tcp": {},
+					}),
+					withNetwork("bridge", ipv4("127.0.0.1")),
+				),
+			},
+			expectedFrontends: map[string]*types.Frontend{
+				"frontend-Host-test-docker-localhost-0": {
+					Backend:        "backend-test",
+					PassHostHeader: true,
+					EntryPoints:    []string{},
+					Redirect: &types.Redirect{
+						Scheme:     "https",
+						Port:       "8443",
+						PathPrefix: "/app",
+						DropQuery:  true,
+						StatusCode: 308,
+					},
+					Routes: map[string]types.Route{
+						"route-frontend-Host-test-docker-localhost-0": {
+							Rule: "Host:test.docker.localhost",
+						},
+					},
+				},
+			},
+			expectedBackends: map[string]*types.Backend{
+				"backend-test": {
+					Servers: map[string]types.Server{
+						"server-test-842895ca2aca17f6ee36ddb2f621194d": {
+							URL:    "http://127.0.0.1:80",
+							Weight: label.DefaultWeight,
+						},
+					},
+					CircuitBreaker: nil,
+				},
+			},
+		},
+		{
+			desc: "when frontend body rewrite",
+			containers: []docker.ContainerJSON{
+				containerJSON(
+					name("test"),
+					labels(map[string]string{
+						label.TraefikFrontendBodyRewriteContentTypes:                                                         "text/html",
+						label.Prefix + label.BaseFrontendBodyReplacement + "links." + label.SuffixBodyReplacementRegex:       `(href|src)="/`,
+						label.Prefix + label.BaseFrontendBodyReplacement + "links." + label.SuffixBodyReplacementReplacement: `$1="{{ .Prefix }}/`,
+					}),
+					ports(nat.PortMap{
+						"80/tcp": {},
+					}),
+					withNetwork("bridge", ipv4("127.0.0.1")),
+				),
+			},
+			expectedFrontends: map[string]*types.Frontend{
+				"frontend-Host-test-docker-localhost-0": {
+					Backend:        "backend-test",
+					PassHostHeader: true,
+					EntryPoints:    []string{},
+					BodyRewrite: &types.BodyRewrite{
+						ContentTypes: []string{"text/html"},
+						Replacements: []types.BodyReplacement{
+							{Regex: `(href|src)="/`, Replacement: `$1="{{ .Prefix }}/`},
+						},
+					},
+					Routes: map[string]types.Route{
+						"route-frontend-Host-test-docker-localhost-0": {
+							Rule: "Host:test.docker.localhost",
+						},
+					},
+				},
+			},
+			expectedBackends: map[string]*types.Backend{
+				"backend-test": {
+					Servers: map[string]types.Server{
+						"server-test-842895ca2aca17f6ee36ddb2f621194d": {
+							URL:    "http://127.0.0.1:80",
+							Weight: label.DefaultWeight,
+						},
+					},
+					CircuitBreaker: nil,
+				},
+			},
+		},
+		{
+			desc: "when frontend forward auth with body and cache",
+			containers: []docker.ContainerJSON{
+				containerJSON(
+					name("test"),
+					labels(map[string]string{
+						label.TraefikFrontendAuthForwardTrustForwardHeader:    "true",
+						label.TraefikFrontendAuthForwardAddress:               "auth.server",
+						label.TraefikFrontendAuthForwardTLSCa:                 "ca.crt",
+						label.TraefikFrontendAuthForwardTLSCaOptional:         "true",
+						label.TraefikFrontendAuthForwardTLSCert:               "server.crt",
+						label.TraefikFrontendAuthForwardTLSKey:                "server.key",
+						label.TraefikFrontendAuthForwardTLSInsecureSkipVerify: "true",
+						label.TraefikFrontendAuthForwardAuthRequestHeaders:    "Authorization,X-Signature",
+						label.TraefikFrontendAuthForwardMethod:                "POST",
+						label.TraefikFrontendAuthForwardForwardBody:           "true",
+						label.TraefikFrontendAuthForwardMaxBodySize:           "2048",
+						label.TraefikFrontendAuthForwardCacheKeyHeaders:       "Authorization",
+						label.TraefikFrontendAuthForwardCacheTTL:              "30s",
+						label.TraefikFrontendAuthForwardCacheNegativeTTL:      "5s",
+					}),
+					ports(nat.PortMap{
+						"80/tcp": {},
+					}),
+					withNetwork("bridge", ipv4("127.0.0.1")),
+				),
+			},
+			expectedFrontends: map[string]*types.Frontend{
+				"frontend-Host-test-docker-localhost-0": {
+					Backend:        "backend-test",
+					PassHostHeader: true,
+					EntryPoints:    []string{},
+					Auth: &types.Auth{
+						Forward: &types.Forward{
+							Address:            "auth.server",
+							TrustForwardHeader: true,
+							AuthRequestHeaders: []string{"Authorization", "X-Signature"},
+							Method:             "POST",
+							ForwardBody:        true,
+							MaxBodySize:        2048,
+							Cache: &types.ForwardCache{
+								KeyHeaders:  []string{"Authorization"},
+								TTL:         parse.Duration(30 * time.Second),
+								NegativeTTL: parse.Duration(5 * time.Second),
+							},
+							TLS: &types.ClientTLS{
+								CA:                 "ca.crt",
+								CAOptional:         true,
+								InsecureSkipVerify: true,
+								Cert:               "server.crt",
+								Key:                "server.key",
+							},
+						},
+					},
+					Routes: map[string]types.Route{
+						"route-frontend-Host-test-docker-localhost-0": {
+							Rule: "Host:test.docker.localhost",
+						},
+					},
+				},
+			},
+			expectedBackends: map[string]*types.Backend{
+				"backend-test": {
+					Servers: map[string]types.Server{
+						"server-test-842895ca2aca17f6ee36ddb2f621194d": {
+							URL:    "http://127.0.0.1:80",
+							Weight: label.DefaultWeight,
+						},
+					},
+					CircuitBreaker: nil,
+				},
+			},
+		},
 		{
 			desc: "when basic container configuration with multiple network",
 			containers: []docker.ContainerJSON{
@@ -525,6 +1069,10 @@ func TestDockerBuildConfiguration(t *testing.T) {
 						label.Prefix + label.BaseFrontendErrorPage + "bar." + label.SuffixErrorPageQuery:   "bar_query",
 
 						label.TraefikFrontendRateLimitExtractorFunc:                                        "client.ip",
+						label.TraefikFrontendRateLimitStatusCode:                                           "503",
+						label.TraefikFrontendRateLimitBody:                                                 `{"error":"slow down"}`,
+						label.TraefikFrontendRateLimitContentType:                                          "application/json",
+						label.TraefikFrontendRateLimitExemptSourceRange:                                    "10.0.0.0/8",
 						label.Prefix + label.BaseFrontendRateLimit + "foo." + label.SuffixRateLimitPeriod:  "6",
 						label.Prefix + label.BaseFrontendRateLimit + "foo." + label.SuffixRateLimitAverage: "12",
 						label.Prefix + label.BaseFrontendRateLimit + "foo." + label.SuffixRateLimitBurst:   "18",
@@ -640,7 +1188,11 @@ func TestDockerBuildConfiguration(t *testing.T) {
 						},
 					},
 					RateLimit: &types.RateLimit{
-						ExtractorFunc: "client.ip",
+						ExtractorFunc:     "client.ip",
+						StatusCode:        503,
+						Body:              `{"error":"slow down"}`,
+						ContentType:       "application/json",
+						ExemptSourceRange: []string{"10.0.0.0/8"},
 						RateSet: map[string]*types.Rate{
 							"foo": {
 								Period:  parse.Duration(6 * time.Second),
@@ -1732,3 +2284,139 @@ func TestDockerGetServers(t *testing.T) {
 		})
 	}
 }
+
+func TestDockerGetUDPPort(t *testing.T) {
+	testCases := []struct {
+		desc      string
+		container docker.ContainerJSON
+		expected  string
+	}{
+		{
+			desc:      "no port",
+			container: containerJSON(name("foo")),
+			expected:  "",
+		},
+		{
+			desc: "only TCP ports",
+			container: containerJSON(ports(nat.PortMap{
+				"80/tcp": {},
+			})),
+			expected: "",
+		},
+		{
+			desc: "lowest UDP port",
+			container: containerJSON(ports(nat.PortMap{
+				"80/tcp":   {},
+				"5353/udp": {},
+				"53/udp":   {},
+			})),
+			expected: "53",
+		},
+		{
+			desc: "port label",
+			container: containerJSON(labels(map[string]string{
+				label.TraefikUDPServerPort: "514",
+			}), ports(nat.PortMap{
+				"53/udp": {},
+			})),
+			expected: "514",
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			dData := parseContainer(test.container)
+
+			actual := getUDPPort(dData)
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
+
+func TestDockerBuildUDPConfiguration(t *testing.T) {
+	containers := []docker.ContainerJSON{
+		containerJSON(
+			name("dns1"),
+			labels(map[string]string{
+				label.TraefikUDPEntryPoints:      "dns",
+				labelDockerComposeProject:        "infra",
+				labelDockerComposeService:        "dns",
+				label.TraefikWeight:              "2",
+				label.TraefikFrontendEntryPoints: "http",
+			}),
+			ports(nat.PortMap{
+				"53/udp": {},
+			}),
+			withNetwork("bridge", ipv4("10.0.0.1")),
+		),
+		containerJSON(
+			name("dns2"),
+			labels(map[string]string{
+				label.TraefikUDPEntryPoints: "dns",
+				labelDockerComposeProject:   "infra",
+				labelDockerComposeService:   "dns",
+			}),
+			ports(nat.PortMap{
+				"53/udp": {},
+			}),
+			withNetwork("bridge", ipv4("10.0.0.2")),
+		),
+		containerJSON(
+			name("syslog"),
+			labels(map[string]string{
+				label.TraefikUDPEntryPoints: "syslog,logs",
+				label.TraefikUDPServerPort:  "514",
+			}),
+			withNetwork("bridge", ipv4("10.0.0.3")),
+		),
+		containerJSON(
+			name("web"),
+			ports(nat.PortMap{
+				"80/tcp": {},
+			}),
+			withNetwork("bridge", ipv4("10.0.0.4")),
+		),
+	}
+
+	var dockerDataList []dockerData
+	for _, cont := range containers {
+		dockerDataList = append(dockerDataList, parseContainer(cont))
+	}
+
+	provider := &Provider{
+		Domain:           "docker.localhost",
+		ExposedByDefault: true,
+	}
+	actualConfig := provider.buildConfiguration(dockerDataList)
+	require.NotNil(t, actualConfig, "actualConfig")
+
+	expectedFrontends := map[string]*types.UDPFrontend{
+		"udp-frontend-dns-infra": {
+			EntryPoints: []string{"dns"},
+			Backend:     "udp-backend-dns-infra",
+		},
+		"udp-frontend-syslog": {
+			EntryPoints: []string{"syslog", "logs"},
+			Backend:     "udp-backend-syslog",
+		},
+	}
+	assert.Equal(t, expectedFrontends, actualConfig.UDPFrontends)
+
+	expectedBackends := map[string]*types.UDPBackend{
+		"udp-backend-dns-infra": {
+			Servers: map[string]types.UDPServer{
+				"server-dns1-cb664dbf88152164dd593a0db3a3ba7f": {Address: "10.0.0.1:53", Weight: 2},
+				"server-dns2-7d4929a80156010b4b10065e9112c7d3": {Address: "10.0.0.2:53", Weight: 1},
+			},
+		},
+		"udp-backend-syslog": {
+			Servers: map[string]types.UDPServer{
+				"server-syslog-4e0aeb5c150dec8da174dcedd5c078b2": {Address: "10.0.0.3:514", Weight: 1},
+			},
+		},
+	}
+	assert.Equal(t, expectedBackends, actualConfig.UDPBackends)
+}
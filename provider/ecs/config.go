@@ -46,6 +46,17 @@ func (p *Provider) buildConfiguration(instances []ecsInstance) (*types.Configura
 		"getRateLimit":         label.GetRateLimit,
 		"getHeaders":           label.GetHeaders,
 		"getWhiteList":         label.GetWhiteList,
+		"getTLSClientCertAuth": label.GetTLSClientCertAuth,
+		"getGeoIP":             label.GetGeoIP,
+		"getBodyRewrite":       label.GetBodyRewrite,
+		"getMaintenance":       label.GetMaintenance,
+		"getFaultInjection":    label.GetFaultInjection,
+		"getSizeLimits":        label.GetSizeLimits,
+		"getRequestID":         label.GetRequestID,
+
+		// UDP functions
+		"getUDPEntryPoints": label.GetFuncSliceString(label.TraefikUDPEntryPoints),
+		"getUDPServers":     getUDPServers,
 	}
 
 	services := make(map[string][]ecsInstance)
@@ -67,10 +78,20 @@ func (p *Provider) buildConfiguration(instances []ecsInstance) (*types.Configura
 		}
 	}
 
+	udpServices := make(map[string][]ecsInstance)
+	for _, instance := range instances {
+		if p.filterUDPInstance(instance) {
+			udpServiceName := provider.Normalize(instance.Name)
+			udpServices[udpServiceName] = append(udpServices[udpServiceName], instance)
+		}
+	}
+
 	return p.GetConfiguration("templates/ecs.tmpl", ecsFuncMap, struct {
-		Services map[string][]ecsInstance
+		Services    map[string][]ecsInstance
+		UDPServices map[string][]ecsInstance
 	}{
-		Services: services,
+		Services:    services,
+		UDPServices: udpServices,
 	})
 }
 
@@ -85,6 +106,29 @@ func (p *Provider) filterInstance(i ecsInstance) bool {
 		return false
 	}
 
+	return p.filterRunningInstance(i)
+}
+
+// filterUDPInstance keeps the instances declaring a UDP service, which do not need any HTTP port or frontend rule.
+func (p *Provider) filterUDPInstance(i ecsInstance) bool {
+	if !label.Has(i.TraefikLabels, label.TraefikUDPEntryPoints) {
+		return false
+	}
+
+	if i.machine == nil {
+		log.Debug("Filtering ecs instance with nil machine")
+		return false
+	}
+
+	if !label.Has(i.TraefikLabels, label.TraefikUDPServerPort) {
+		log.Debugf("Filtering ecs instance without %s label %s (%s)", label.TraefikUDPServerPort, i.Name, i.ID)
+		return false
+	}
+
+	return p.filterRunningInstance(i)
+}
+
+func (p *Provider) filterRunningInstance(i ecsInstance) bool {
 	if strings.ToLower(i.machine.state) != ec2.InstanceStateNameRunning {
 		log.Debugf("Filtering ecs instance with an incorrect state %s (%s) (state = %s)", i.Name, i.ID, i.machine.state)
 		return false
@@ -226,6 +270,34 @@ func getServers(instances []ecsInstance) map[string]types.Server {
 	return servers
 }
 
+// getUDPServers returns the UDP servers of the instances, listening on the host port mapped to the port of the traefik.udp.serverPort label.
+func getUDPServers(instances []ecsInstance) map[string]types.UDPServer {
+	var servers map[string]types.UDPServer
+
+	for _, instance := range instances {
+		if servers == nil {
+			servers = make(map[string]types.UDPServer)
+		}
+
+		port := label.GetStringValue(instance.TraefikLabels, label.TraefikUDPServerPort, "")
+		if value, err := strconv.ParseInt(port, 10, 64); err == nil {
+			for _, mapping := range instance.machine.ports {
+				if value == mapping.hostPort || value == mapping.containerPort {
+					port = strconv.FormatInt(mapping.hostPort, 10)
+					break
+				}
+			}
+		}
+
+		servers[provider.Normalize("server-"+instance.Name+"-"+instance.ID)] = types.UDPServer{
+			Address: net.JoinHostPort(getHost(instance), port),
+			Weight:  label.GetIntValue(instance.TraefikLabels, label.TraefikWeight, label.DefaultWeight),
+		}
+	}
+
+	return servers
+}
+
 func isEnabled(i ecsInstance, exposedByDefault bool) bool {
 	return label.GetBoolValue(i.TraefikLabels, label.TraefikEnable, exposedByDefault)
 }
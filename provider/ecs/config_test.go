@@ -11,6 +11,7 @@ import (
 	"github.com/containous/traefik/provider/label"
 	"github.com/containous/traefik/types"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestBuildConfiguration(t *testing.T) {
@@ -969,6 +970,99 @@ func TestBuildConfiguration(t *testing.T) {
 	}
 }
 
+func TestBuildUDPConfiguration(t *testing.T) {
+	instances := fakeLoadTraefikLabels([]ecsInstance{
+		instance(
+			name("dns"),
+			ID("1"),
+			dockerLabels(map[string]*string{
+				label.TraefikUDPEntryPoints: aws.String("dns"),
+				label.TraefikUDPServerPort:  aws.String("53"),
+				label.TraefikWeight:         aws.String("2"),
+			}),
+			iMachine(
+				mState(ec2.InstanceStateNameRunning),
+				mPrivateIP("10.0.0.1"),
+				mPorts(
+					mPort(53, 32053),
+				),
+			),
+		),
+		instance(
+			name("dns"),
+			ID("2"),
+			dockerLabels(map[string]*string{
+				label.TraefikUDPEntryPoints: aws.String("dns"),
+				label.TraefikUDPServerPort:  aws.String("53"),
+				label.TraefikWeight:         aws.String("2"),
+			}),
+			iMachine(
+				mState(ec2.InstanceStateNameRunning),
+				mPrivateIP("10.0.0.2"),
+				mPorts(
+					mPort(53, 32054),
+				),
+			),
+		),
+		instance(
+			name("syslog"),
+			ID("3"),
+			dockerLabels(map[string]*string{
+				label.TraefikUDPEntryPoints: aws.String("syslog,logs"),
+				label.TraefikUDPServerPort:  aws.String("514"),
+			}),
+			iMachine(
+				mState(ec2.InstanceStateNameRunning),
+				mPrivateIP("10.0.0.3"),
+			),
+		),
+		instance(
+			name("stopped"),
+			ID("4"),
+			dockerLabels(map[string]*string{
+				label.TraefikUDPEntryPoints: aws.String("dns"),
+				label.TraefikUDPServerPort:  aws.String("53"),
+			}),
+			iMachine(
+				mState(ec2.InstanceStateNameStopped),
+				mPrivateIP("10.0.0.4"),
+			),
+		),
+	})
+
+	p := &Provider{ExposedByDefault: true}
+
+	actualConfig, err := p.buildConfiguration(instances)
+	require.NoError(t, err)
+
+	expectedFrontends := map[string]*types.UDPFrontend{
+		"udp-frontend-dns": {
+			EntryPoints: []string{"dns"},
+			Backend:     "udp-backend-dns",
+		},
+		"udp-frontend-syslog": {
+			EntryPoints: []string{"syslog", "logs"},
+			Backend:     "udp-backend-syslog",
+		},
+	}
+	assert.Equal(t, expectedFrontends, actualConfig.UDPFrontends)
+
+	expectedBackends := map[string]*types.UDPBackend{
+		"udp-backend-dns": {
+			Servers: map[string]types.UDPServer{
+				"server-dns-1": {Address: "10.0.0.1:32053", Weight: 2},
+				"server-dns-2": {Address: "10.0.0.2:32054", Weight: 2},
+			},
+		},
+		"udp-backend-syslog": {
+			Servers: map[string]types.UDPServer{
+				"server-syslog-3": {Address: "10.0.0.3:514", Weight: 1},
+			},
+		},
+	}
+	assert.Equal(t, expectedBackends, actualConfig.UDPBackends)
+}
+
 func TestFilterInstance(t *testing.T) {
 	testCases := []struct {
 		desc             string
@@ -179,7 +179,9 @@ func (p *Provider) loadFileConfig(filename string, parseTemplate bool) (*types.C
 	if err != nil {
 		return nil, err
 	}
-	if configuration == nil || configuration.Backends == nil && configuration.Frontends == nil && configuration.TLS == nil {
+	if configuration == nil || configuration.Backends == nil && configuration.Frontends == nil &&
+		configuration.TCPBackends == nil && configuration.TCPFrontends == nil &&
+		configuration.UDPBackends == nil && configuration.UDPFrontends == nil && configuration.TLS == nil {
 		configuration = &types.Configuration{
 			Frontends: make(map[string]*types.Frontend),
 			Backends:  make(map[string]*types.Backend),
@@ -197,8 +199,12 @@ func (p *Provider) loadFileConfigFromDirectory(directory string, configuration *
 
 	if configuration == nil {
 		configuration = &types.Configuration{
-			Frontends: make(map[string]*types.Frontend),
-			Backends:  make(map[string]*types.Backend),
+			Frontends:    make(map[string]*types.Frontend),
+			Backends:     make(map[string]*types.Backend),
+			TCPFrontends: make(map[string]*types.TCPFrontend),
+			TCPBackends:  make(map[string]*types.TCPBackend),
+			UDPFrontends: make(map[string]*types.UDPFrontend),
+			UDPBackends:  make(map[string]*types.UDPBackend),
 		}
 	}
 
@@ -238,6 +244,38 @@ func (p *Provider) loadFileConfigFromDirectory(directory string, configuration *
 			}
 		}
 
+		for backendName, backend := range c.TCPBackends {
+			if _, exists := configuration.TCPBackends[backendName]; exists {
+				log.Warnf("TCP backend %s already configured, skipping", backendName)
+			} else {
+				configuration.TCPBackends[backendName] = backend
+			}
+		}
+
+		for frontendName, frontend := range c.TCPFrontends {
+			if _, exists := configuration.TCPFrontends[frontendName]; exists {
+				log.Warnf("TCP frontend %s already configured, skipping", frontendName)
+			} else {
+				configuration.TCPFrontends[frontendName] = frontend
+			}
+		}
+
+		for backendName, backend := range c.UDPBackends {
+			if _, exists := configuration.UDPBackends[backendName]; exists {
+				log.Warnf("UDP backend %s already configured, skipping", backendName)
+			} else {
+				configuration.UDPBackends[backendName] = backend
+			}
+		}
+
+		for frontendName, frontend := range c.UDPFrontends {
+			if _, exists := configuration.UDPFrontends[frontendName]; exists {
+				log.Warnf("UDP frontend %s already configured, skipping", frontendName)
+			} else {
+				configuration.UDPFrontends[frontendName] = frontend
+			}
+		}
+
 		for _, conf := range c.TLS {
 			if _, exists := configTLSMaps[conf]; exists {
 				log.Warnf("TLS Configuration %v already configured, skipping", conf)
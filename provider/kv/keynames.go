@@ -24,6 +24,10 @@ const (
 	pathBackendBufferingMaxRequestBodyBytes     = pathBackendBuffering + "maxrequestbodybytes"
 	pathBackendBufferingMemRequestBodyBytes     = pathBackendBuffering + "memrequestbodybytes"
 	pathBackendBufferingRetryExpression         = pathBackendBuffering + "retryexpression"
+	pathBackendStatic                           = "/static/"
+	pathBackendStaticBrowse                     = pathBackendStatic + "browse"
+	pathBackendStaticIndexFiles                 = pathBackendStatic + "indexfiles"
+	pathBackendStaticSPA                        = pathBackendStatic + "spa"
 
 	pathFrontends                                         = "/frontends/"
 	pathFrontendBackend                                   = "/backend"
@@ -44,6 +48,9 @@ const (
 	pathFrontendPassTLSClientCertInfosSubjectSerialNumber = pathFrontendPassTLSClientCertInfosSubject + "/serialNumber"
 	pathFrontendPassTLSCert                               = "/passtlscert"
 	pathFrontendWhiteListSourceRange                      = "/whitelist/sourcerange"
+	pathFrontendWhiteListSourceSets                       = "/whitelist/sourcesets"
+	pathFrontendWhiteListDenyRange                        = "/whitelist/denyrange"
+	pathFrontendWhiteListDenySets                         = "/whitelist/denysets"
 	pathFrontendWhiteListIPStrategy                       = "/whitelist/ipstrategy"
 	pathFrontendWhiteListIPStrategyDepth                  = pathFrontendWhiteListIPStrategy + "/depth"
 	pathFrontendWhiteListIPStrategyExcludedIPs            = pathFrontendWhiteListIPStrategy + "/excludedips"
@@ -59,6 +66,14 @@ const (
 	pathFrontendAuthDigestUsersFile              = pathFrontendAuthDigest + "usersfile"
 	pathFrontendAuthForward                      = pathFrontendAuth + "forward/"
 	pathFrontendAuthForwardAddress               = pathFrontendAuthForward + "address"
+	pathFrontendAuthForwardAuthRequestHeaders    = pathFrontendAuthForward + "authrequestheaders"
+	pathFrontendAuthForwardCache                 = pathFrontendAuthForward + "cache/"
+	pathFrontendAuthForwardCacheKeyHeaders       = pathFrontendAuthForwardCache + "keyheaders"
+	pathFrontendAuthForwardCacheNegativeTTL      = pathFrontendAuthForwardCache + "negativettl"
+	pathFrontendAuthForwardCacheTTL              = pathFrontendAuthForwardCache + "ttl"
+	pathFrontendAuthForwardForwardBody           = pathFrontendAuthForward + "forwardbody"
+	pathFrontendAuthForwardMaxBodySize           = pathFrontendAuthForward + "maxbodysize"
+	pathFrontendAuthForwardMethod                = pathFrontendAuthForward + "method"
 	pathFrontendAuthForwardTLS                   = pathFrontendAuthForward + "tls/"
 	pathFrontendAuthForwardTLSCa                 = pathFrontendAuthForwardTLS + "ca"
 	pathFrontendAuthForwardTLSCaOptional         = pathFrontendAuthForwardTLS + "caoptional"
@@ -68,21 +83,91 @@ const (
 	pathFrontendAuthForwardTrustForwardHeader    = pathFrontendAuthForward + "trustforwardheader"
 	pathFrontendAuthHeaderField                  = pathFrontendAuth + "headerfield"
 
-	pathFrontendEntryPoints            = "/entrypoints"
-	pathFrontendRedirectEntryPoint     = "/redirect/entrypoint"
-	pathFrontendRedirectRegex          = "/redirect/regex"
-	pathFrontendRedirectReplacement    = "/redirect/replacement"
-	pathFrontendRedirectPermanent      = "/redirect/permanent"
-	pathFrontendErrorPages             = "/errors/"
-	pathFrontendErrorPagesBackend      = "/backend"
-	pathFrontendErrorPagesQuery        = "/query"
-	pathFrontendErrorPagesStatus       = "/status"
-	pathFrontendRateLimit              = "/ratelimit/"
-	pathFrontendRateLimitRateSet       = pathFrontendRateLimit + "rateset/"
-	pathFrontendRateLimitExtractorFunc = pathFrontendRateLimit + "extractorfunc"
-	pathFrontendRateLimitPeriod        = "/period"
-	pathFrontendRateLimitAverage       = "/average"
-	pathFrontendRateLimitBurst         = "/burst"
+	pathFrontendTLSClientCertAuth                    = "/tlsclientcertauth/"
+	pathFrontendTLSClientCertAuthCommonNames         = pathFrontendTLSClientCertAuth + "commonnames"
+	pathFrontendTLSClientCertAuthDNSNames            = pathFrontendTLSClientCertAuth + "dnsnames"
+	pathFrontendTLSClientCertAuthFingerprints        = pathFrontendTLSClientCertAuth + "fingerprints"
+	pathFrontendTLSClientCertAuthIssuers             = pathFrontendTLSClientCertAuth + "issuers"
+	pathFrontendTLSClientCertAuthOrganizationalUnits = pathFrontendTLSClientCertAuth + "organizationalunits"
+	pathFrontendTLSClientCertAuthURIs                = pathFrontendTLSClientCertAuth + "uris"
+
+	pathFrontendBodyRewrite                = "/bodyrewrite/"
+	pathFrontendBodyRewriteContentTypes    = pathFrontendBodyRewrite + "contenttypes"
+	pathFrontendBodyRewriteMaxBodySize     = pathFrontendBodyRewrite + "maxbodysize"
+	pathFrontendBodyRewriteReplacements    = pathFrontendBodyRewrite + "replacements/"
+	pathFrontendBodyReplacementSearch      = "/search"
+	pathFrontendBodyReplacementRegex       = "/regex"
+	pathFrontendBodyReplacementReplacement = "/replacement"
+
+	pathFrontendGeoIP                 = "/geoip/"
+	pathFrontendGeoIPAddHeaders       = pathFrontendGeoIP + "addheaders"
+	pathFrontendGeoIPAllowedASNs      = pathFrontendGeoIP + "allowedasns"
+	pathFrontendGeoIPAllowedCountries = pathFrontendGeoIP + "allowedcountries"
+	pathFrontendGeoIPASNDatabase      = pathFrontendGeoIP + "asndatabase"
+	pathFrontendGeoIPCountryDatabase  = pathFrontendGeoIP + "countrydatabase"
+	pathFrontendGeoIPDeniedASNs       = pathFrontendGeoIP + "deniedasns"
+	pathFrontendGeoIPDeniedCountries  = pathFrontendGeoIP + "deniedcountries"
+
+	pathFrontendMaintenance                  = "/maintenance/"
+	pathFrontendMaintenanceBypassHeaderName  = pathFrontendMaintenance + "bypassheadername"
+	pathFrontendMaintenanceBypassHeaderValue = pathFrontendMaintenance + "bypassheadervalue"
+	pathFrontendMaintenanceEnabled           = pathFrontendMaintenance + "enabled"
+	pathFrontendMaintenancePage              = pathFrontendMaintenance + "page"
+	pathFrontendMaintenanceRetryAfter        = pathFrontendMaintenance + "retryafter"
+	pathFrontendMaintenanceSourceRange       = pathFrontendMaintenance + "sourcerange"
+	pathFrontendMaintenanceStatusCode        = pathFrontendMaintenance + "statuscode"
+
+	pathFrontendEntryPoints                     = "/entrypoints"
+	pathFrontendFaultInjection                  = "/faultinjection/"
+	pathFrontendFaultInjectionAbortPercentage   = pathFrontendFaultInjection + "abortpercentage"
+	pathFrontendFaultInjectionAbortReset        = pathFrontendFaultInjection + "abortreset"
+	pathFrontendFaultInjectionAbortStatusCode   = pathFrontendFaultInjection + "abortstatuscode"
+	pathFrontendFaultInjectionDelay             = pathFrontendFaultInjection + "delay"
+	pathFrontendFaultInjectionDelayDistribution = pathFrontendFaultInjection + "delaydistribution"
+	pathFrontendFaultInjectionDelayMax          = pathFrontendFaultInjection + "delaymax"
+	pathFrontendFaultInjectionDelayPercentage   = pathFrontendFaultInjection + "delaypercentage"
+	pathFrontendFaultInjectionEnabled           = pathFrontendFaultInjection + "enabled"
+	pathFrontendFaultInjectionHeaderName        = pathFrontendFaultInjection + "headername"
+	pathFrontendFaultInjectionHeaderValue       = pathFrontendFaultInjection + "headervalue"
+	pathFrontendRequestID                       = "/requestid/"
+	pathFrontendRequestIDFormat                 = pathFrontendRequestID + "format"
+	pathFrontendRequestIDHeaderName             = pathFrontendRequestID + "headername"
+	pathFrontendRequestIDInsecure               = pathFrontendRequestID + "insecure"
+	pathFrontendRequestIDTrustedIPs             = pathFrontendRequestID + "trustedips"
+	pathFrontendSizeLimits                      = "/sizelimits/"
+	pathFrontendSizeLimitsMaxHeaderBytes        = pathFrontendSizeLimits + "maxheaderbytes"
+	pathFrontendSizeLimitsMaxHeaderCount        = pathFrontendSizeLimits + "maxheadercount"
+	pathFrontendSizeLimitsMaxRequestBodyBytes   = pathFrontendSizeLimits + "maxrequestbodybytes"
+	pathFrontendRedirectEntryPoint              = "/redirect/entrypoint"
+	pathFrontendRedirectRegex                   = "/redirect/regex"
+	pathFrontendRedirectReplacement             = "/redirect/replacement"
+	pathFrontendRedirectPermanent               = "/redirect/permanent"
+	pathFrontendRedirectStatusCode              = "/redirect/statuscode"
+	pathFrontendRedirectScheme                  = "/redirect/scheme"
+	pathFrontendRedirectHost                    = "/redirect/host"
+	pathFrontendRedirectPort                    = "/redirect/port"
+	pathFrontendRedirectPath                    = "/redirect/path"
+	pathFrontendRedirectPathPrefix              = "/redirect/pathprefix"
+	pathFrontendRedirectDropQuery               = "/redirect/dropquery"
+	pathFrontendRedirectAppRoot                 = "/redirect/approot"
+	pathFrontendErrorPages                      = "/errors/"
+	pathFrontendErrorPagesBackend               = "/backend"
+	pathFrontendErrorPagesQuery                 = "/query"
+	pathFrontendErrorPagesStatus                = "/status"
+	pathFrontendErrorPagesFile                  = "/file"
+	pathFrontendErrorPagesBody                  = "/body"
+	pathFrontendErrorPagesContentType           = "/contenttype"
+	pathFrontendRateLimit                       = "/ratelimit/"
+	pathFrontendRateLimitRateSet                = pathFrontendRateLimit + "rateset/"
+	pathFrontendRateLimitExtractorFunc          = pathFrontendRateLimit + "extractorfunc"
+	pathFrontendRateLimitDistributed            = pathFrontendRateLimit + "distributed"
+	pathFrontendRateLimitStatusCode             = pathFrontendRateLimit + "statuscode"
+	pathFrontendRateLimitBody                   = pathFrontendRateLimit + "body"
+	pathFrontendRateLimitContentType            = pathFrontendRateLimit + "contenttype"
+	pathFrontendRateLimitExemptSourceRange      = pathFrontendRateLimit + "exemptsourcerange"
+	pathFrontendRateLimitPeriod                 = "/period"
+	pathFrontendRateLimitAverage                = "/average"
+	pathFrontendRateLimitBurst                  = "/burst"
 
 	pathFrontendCustomRequestHeaders    = "/headers/customrequestheaders/"
 	pathFrontendCustomResponseHeaders   = "/headers/customresponseheaders/"
@@ -107,6 +192,14 @@ const (
 	pathFrontendReferrerPolicy          = "/headers/referrerpolicy"
 	pathFrontendIsDevelopment           = "/headers/isdevelopment"
 
+	pathFrontendRequestOperations    = "/headers/requestoperations/"
+	pathFrontendResponseOperations   = "/headers/responseoperations/"
+	pathFrontendOperationAction      = "/action"
+	pathFrontendOperationName        = "/name"
+	pathFrontendOperationValue       = "/value"
+	pathFrontendOperationRegex       = "/regex"
+	pathFrontendOperationStatusCodes = "/statuscodes"
+
 	pathFrontendRoutes = "/routes/"
 	pathFrontendRule   = "/rule"
 
@@ -54,6 +54,13 @@ func (p *Provider) buildConfiguration() *types.Configuration {
 		"getRateLimit":         p.getRateLimit,
 		"getHeaders":           p.getHeaders,
 		"getWhiteList":         p.getWhiteList,
+		"getTLSClientCertAuth": p.getTLSClientCertAuth,
+		"getGeoIP":             p.getGeoIP,
+		"getBodyRewrite":       p.getBodyRewrite,
+		"getMaintenance":       p.getMaintenance,
+		"getFaultInjection":    p.getFaultInjection,
+		"getSizeLimits":        p.getSizeLimits,
+		"getRequestID":         p.getRequestID,
 
 		// Backend functions
 		"getServers":        p.getServers,
@@ -62,6 +69,7 @@ func (p *Provider) buildConfiguration() *types.Configuration {
 		"getMaxConn":        p.getMaxConn,
 		"getHealthCheck":    p.getHealthCheck,
 		"getBuffering":      p.getBuffering,
+		"getStatic":         p.getStatic,
 	}
 
 	configuration, err := p.GetConfiguration("templates/kv.tmpl", KvFuncMap, templateObjects)
@@ -79,16 +87,20 @@ func (p *Provider) buildConfiguration() *types.Configuration {
 }
 
 func (p *Provider) getWhiteList(rootPath string) *types.WhiteList {
-	ranges := p.getList(rootPath, pathFrontendWhiteListSourceRange)
+	whiteList := &types.WhiteList{
+		SourceRange: p.getList(rootPath, pathFrontendWhiteListSourceRange),
+		SourceSets:  p.getList(rootPath, pathFrontendWhiteListSourceSets),
+		DenyRange:   p.getList(rootPath, pathFrontendWhiteListDenyRange),
+		DenySets:    p.getList(rootPath, pathFrontendWhiteListDenySets),
+	}
 
-	if len(ranges) == 0 {
+	if len(whiteList.SourceRange) == 0 && len(whiteList.SourceSets) == 0 &&
+		len(whiteList.DenyRange) == 0 && len(whiteList.DenySets) == 0 {
 		return nil
 	}
 
-	return &types.WhiteList{
-		SourceRange: ranges,
-		IPStrategy:  p.getIPStrategy(rootPath),
-	}
+	whiteList.IPStrategy = p.getIPStrategy(rootPath)
+	return whiteList
 }
 
 func (p *Provider) getIPStrategy(rootPath string) *types.IPStrategy {
@@ -108,11 +120,13 @@ func (p *Provider) getIPStrategy(rootPath string) *types.IPStrategy {
 
 func (p *Provider) getRedirect(rootPath string) *types.Redirect {
 	permanent := p.getBool(false, rootPath, pathFrontendRedirectPermanent)
+	statusCode := p.getInt(0, rootPath, pathFrontendRedirectStatusCode)
 
 	if p.has(rootPath, pathFrontendRedirectEntryPoint) {
 		return &types.Redirect{
 			EntryPoint: p.get("", rootPath, pathFrontendRedirectEntryPoint),
 			Permanent:  permanent,
+			StatusCode: statusCode,
 		}
 	}
 
@@ -121,9 +135,27 @@ func (p *Provider) getRedirect(rootPath string) *types.Redirect {
 			Regex:       p.get("", rootPath, pathFrontendRedirectRegex),
 			Replacement: p.get("", rootPath, pathFrontendRedirectReplacement),
 			Permanent:   permanent,
+			StatusCode:  statusCode,
 		}
 	}
 
+	redirect := &types.Redirect{
+		Permanent:  permanent,
+		StatusCode: statusCode,
+		Scheme:     p.get("", rootPath, pathFrontendRedirectScheme),
+		Host:       p.get("", rootPath, pathFrontendRedirectHost),
+		Port:       p.get("", rootPath, pathFrontendRedirectPort),
+		Path:       p.get("", rootPath, pathFrontendRedirectPath),
+		PathPrefix: p.get("", rootPath, pathFrontendRedirectPathPrefix),
+		DropQuery:  p.getBool(false, rootPath, pathFrontendRedirectDropQuery),
+		AppRoot:    p.get("", rootPath, pathFrontendRedirectAppRoot),
+	}
+
+	if len(redirect.Scheme) > 0 || len(redirect.Host) > 0 || len(redirect.Port) > 0 ||
+		len(redirect.Path) > 0 || len(redirect.PathPrefix) > 0 || redirect.DropQuery || len(redirect.AppRoot) > 0 {
+		return redirect
+	}
+
 	return nil
 }
 
@@ -140,9 +172,12 @@ func (p *Provider) getErrorPages(rootPath string) map[string]*types.ErrorPage {
 		pageName := p.last(pathPage)
 
 		errorPages[pageName] = &types.ErrorPage{
-			Backend: p.get("", pathPage, pathFrontendErrorPagesBackend),
-			Query:   p.get("", pathPage, pathFrontendErrorPagesQuery),
-			Status:  p.getList(pathPage, pathFrontendErrorPagesStatus),
+			Backend:     p.get("", pathPage, pathFrontendErrorPagesBackend),
+			Query:       p.get("", pathPage, pathFrontendErrorPagesQuery),
+			Status:      p.getList(pathPage, pathFrontendErrorPagesStatus),
+			File:        p.get("", pathPage, pathFrontendErrorPagesFile),
+			Body:        p.get("", pathPage, pathFrontendErrorPagesBody),
+			ContentType: p.get("", pathPage, pathFrontendErrorPagesContentType),
 		}
 	}
 
@@ -182,8 +217,13 @@ func (p *Provider) getRateLimit(rootPath string) *types.RateLimit {
 	}
 
 	return &types.RateLimit{
-		ExtractorFunc: extractorFunc,
-		RateSet:       limits,
+		ExtractorFunc:     extractorFunc,
+		RateSet:           limits,
+		Distributed:       p.getBool(false, rootPath, pathFrontendRateLimitDistributed),
+		StatusCode:        p.getInt(0, rootPath, pathFrontendRateLimitStatusCode),
+		Body:              p.get("", rootPath, pathFrontendRateLimitBody),
+		ContentType:       p.get("", rootPath, pathFrontendRateLimitContentType),
+		ExemptSourceRange: p.getList(rootPath, pathFrontendRateLimitExemptSourceRange),
 	}
 }
 
@@ -191,6 +231,8 @@ func (p *Provider) getHeaders(rootPath string) *types.Headers {
 	headers := &types.Headers{
 		CustomRequestHeaders:    p.getMap(rootPath, pathFrontendCustomRequestHeaders),
 		CustomResponseHeaders:   p.getMap(rootPath, pathFrontendCustomResponseHeaders),
+		RequestOperations:       p.getHeaderOperations(rootPath, pathFrontendRequestOperations),
+		ResponseOperations:      p.getHeaderOperations(rootPath, pathFrontendResponseOperations),
 		SSLProxyHeaders:         p.getMap(rootPath, pathFrontendSSLProxyHeaders),
 		AllowedHosts:            p.getList("", rootPath, pathFrontendAllowedHosts),
 		HostsProxyHeaders:       p.getList(rootPath, pathFrontendHostsProxyHeaders),
@@ -220,6 +262,22 @@ func (p *Provider) getHeaders(rootPath string) *types.Headers {
 	return headers
 }
 
+func (p *Provider) getHeaderOperations(rootPath string, pathOperations string) []types.HeaderOperation {
+	var operations []types.HeaderOperation
+
+	for _, pathOperation := range p.list(rootPath, pathOperations) {
+		operations = append(operations, types.HeaderOperation{
+			Action:      p.get("", pathOperation, pathFrontendOperationAction),
+			Name:        p.get("", pathOperation, pathFrontendOperationName),
+			Value:       p.get("", pathOperation, pathFrontendOperationValue),
+			Regex:       p.get("", pathOperation, pathFrontendOperationRegex),
+			StatusCodes: p.getList(pathOperation, pathFrontendOperationStatusCodes),
+		})
+	}
+
+	return operations
+}
+
 func (p *Provider) getLoadBalancer(rootPath string) *types.LoadBalancer {
 	lb := &types.LoadBalancer{
 		Method: p.get(label.DefaultBackendLoadBalancerMethod, rootPath, pathBackendLoadBalancerMethod),
@@ -305,6 +363,18 @@ func (p *Provider) getBuffering(rootPath string) *types.Buffering {
 	return buffering
 }
 
+func (p *Provider) getStatic(rootPath string) *types.Static {
+	if !p.hasPrefix(rootPath, pathBackendStatic) {
+		return nil
+	}
+
+	return &types.Static{
+		IndexFiles: p.getList(rootPath, pathBackendStaticIndexFiles),
+		SPA:        p.getBool(false, rootPath, pathBackendStaticSPA),
+		Browse:     p.getBool(false, rootPath, pathBackendStaticBrowse),
+	}
+}
+
 func (p *Provider) getTLSSection(prefix string) []*tls.Configuration {
 	var tlsSection []*tls.Configuration
 
@@ -370,6 +440,118 @@ func (p *Provider) getTLSClientCert(rootPath string) *types.TLSClientHeaders {
 	return tlsClientHeaders
 }
 
+func (p *Provider) getTLSClientCertAuth(rootPath string) *types.TLSClientCertAuth {
+	if !p.hasPrefix(rootPath, pathFrontendTLSClientCertAuth) {
+		return nil
+	}
+
+	return &types.TLSClientCertAuth{
+		CommonNames:         p.getList(rootPath, pathFrontendTLSClientCertAuthCommonNames),
+		OrganizationalUnits: p.getList(rootPath, pathFrontendTLSClientCertAuthOrganizationalUnits),
+		DNSNames:            p.getList(rootPath, pathFrontendTLSClientCertAuthDNSNames),
+		URIs:                p.getList(rootPath, pathFrontendTLSClientCertAuthURIs),
+		Issuers:             p.getList(rootPath, pathFrontendTLSClientCertAuthIssuers),
+		Fingerprints:        p.getList(rootPath, pathFrontendTLSClientCertAuthFingerprints),
+	}
+}
+
+func (p *Provider) getGeoIP(rootPath string) *types.GeoIP {
+	if !p.hasPrefix(rootPath, pathFrontendGeoIP) {
+		return nil
+	}
+
+	return &types.GeoIP{
+		CountryDatabase:  p.get("", rootPath, pathFrontendGeoIPCountryDatabase),
+		ASNDatabase:      p.get("", rootPath, pathFrontendGeoIPASNDatabase),
+		AllowedCountries: p.getList(rootPath, pathFrontendGeoIPAllowedCountries),
+		DeniedCountries:  p.getList(rootPath, pathFrontendGeoIPDeniedCountries),
+		AllowedASNs:      p.getList(rootPath, pathFrontendGeoIPAllowedASNs),
+		DeniedASNs:       p.getList(rootPath, pathFrontendGeoIPDeniedASNs),
+		AddHeaders:       p.getBool(false, rootPath, pathFrontendGeoIPAddHeaders),
+	}
+}
+
+func (p *Provider) getMaintenance(rootPath string) *types.Maintenance {
+	if !p.hasPrefix(rootPath, pathFrontendMaintenance) {
+		return nil
+	}
+
+	return &types.Maintenance{
+		Enabled:           p.getBool(false, rootPath, pathFrontendMaintenanceEnabled),
+		StatusCode:        p.getInt(0, rootPath, pathFrontendMaintenanceStatusCode),
+		RetryAfter:        p.getDuration(0, rootPath, pathFrontendMaintenanceRetryAfter),
+		Page:              p.get("", rootPath, pathFrontendMaintenancePage),
+		SourceRange:       p.getList(rootPath, pathFrontendMaintenanceSourceRange),
+		BypassHeaderName:  p.get("", rootPath, pathFrontendMaintenanceBypassHeaderName),
+		BypassHeaderValue: p.get("", rootPath, pathFrontendMaintenanceBypassHeaderValue),
+	}
+}
+
+func (p *Provider) getFaultInjection(rootPath string) *types.FaultInjection {
+	if !p.hasPrefix(rootPath, pathFrontendFaultInjection) {
+		return nil
+	}
+
+	return &types.FaultInjection{
+		Enabled:           p.getBool(false, rootPath, pathFrontendFaultInjectionEnabled),
+		HeaderName:        p.get("", rootPath, pathFrontendFaultInjectionHeaderName),
+		HeaderValue:       p.get("", rootPath, pathFrontendFaultInjectionHeaderValue),
+		DelayPercentage:   p.getFloat64(0, rootPath, pathFrontendFaultInjectionDelayPercentage),
+		Delay:             p.getDuration(0, rootPath, pathFrontendFaultInjectionDelay),
+		DelayMax:          p.getDuration(0, rootPath, pathFrontendFaultInjectionDelayMax),
+		DelayDistribution: p.get("", rootPath, pathFrontendFaultInjectionDelayDistribution),
+		AbortPercentage:   p.getFloat64(0, rootPath, pathFrontendFaultInjectionAbortPercentage),
+		AbortStatusCode:   p.getInt(0, rootPath, pathFrontendFaultInjectionAbortStatusCode),
+		AbortReset:        p.getBool(false, rootPath, pathFrontendFaultInjectionAbortReset),
+	}
+}
+
+func (p *Provider) getRequestID(rootPath string) *types.RequestID {
+	if !p.hasPrefix(rootPath, pathFrontendRequestID) {
+		return nil
+	}
+
+	return &types.RequestID{
+		HeaderName: p.get("", rootPath, pathFrontendRequestIDHeaderName),
+		Format:     p.get("", rootPath, pathFrontendRequestIDFormat),
+		Insecure:   p.getBool(false, rootPath, pathFrontendRequestIDInsecure),
+		TrustedIPs: p.getList(rootPath, pathFrontendRequestIDTrustedIPs),
+	}
+}
+
+func (p *Provider) getSizeLimits(rootPath string) *types.SizeLimits {
+	if !p.hasPrefix(rootPath, pathFrontendSizeLimits) {
+		return nil
+	}
+
+	return &types.SizeLimits{
+		MaxRequestBodyBytes: p.getInt64(0, rootPath, pathFrontendSizeLimitsMaxRequestBodyBytes),
+		MaxHeaderCount:      p.getInt(0, rootPath, pathFrontendSizeLimitsMaxHeaderCount),
+		MaxHeaderBytes:      p.getInt64(0, rootPath, pathFrontendSizeLimitsMaxHeaderBytes),
+	}
+}
+
+func (p *Provider) getBodyRewrite(rootPath string) *types.BodyRewrite {
+	if !p.hasPrefix(rootPath, pathFrontendBodyRewrite) {
+		return nil
+	}
+
+	bodyRewrite := &types.BodyRewrite{
+		ContentTypes: p.getList(rootPath, pathFrontendBodyRewriteContentTypes),
+		MaxBodySize:  p.getInt64(0, rootPath, pathFrontendBodyRewriteMaxBodySize),
+	}
+
+	for _, pathReplacement := range p.list(rootPath, pathFrontendBodyRewriteReplacements) {
+		bodyRewrite.Replacements = append(bodyRewrite.Replacements, types.BodyReplacement{
+			Search:      p.get("", pathReplacement, pathFrontendBodyReplacementSearch),
+			Regex:       p.get("", pathReplacement, pathFrontendBodyReplacementRegex),
+			Replacement: p.get("", pathReplacement, pathFrontendBodyReplacementReplacement),
+		})
+	}
+
+	return bodyRewrite
+}
+
 // GetAuth Create auth from path
 func (p *Provider) getAuth(rootPath string) *types.Auth {
 	if p.hasPrefix(rootPath, pathFrontendAuth) {
@@ -413,6 +595,19 @@ func (p *Provider) getAuthForward(rootPath string) *types.Forward {
 	forwardAuth := &types.Forward{
 		Address:            p.get("", rootPath, pathFrontendAuthForwardAddress),
 		TrustForwardHeader: p.getBool(false, rootPath, pathFrontendAuthForwardTrustForwardHeader),
+		AuthRequestHeaders: p.getList(rootPath, pathFrontendAuthForwardAuthRequestHeaders),
+		Method:             p.get("", rootPath, pathFrontendAuthForwardMethod),
+		ForwardBody:        p.getBool(false, rootPath, pathFrontendAuthForwardForwardBody),
+		MaxBodySize:        p.getInt64(0, rootPath, pathFrontendAuthForwardMaxBodySize),
+	}
+
+	// Cache configuration
+	if p.hasPrefix(rootPath, pathFrontendAuthForwardCache) {
+		forwardAuth.Cache = &types.ForwardCache{
+			KeyHeaders:  p.getList(rootPath, pathFrontendAuthForwardCacheKeyHeaders),
+			TTL:         p.getDuration(0, rootPath, pathFrontendAuthForwardCacheTTL),
+			NegativeTTL: p.getDuration(0, rootPath, pathFrontendAuthForwardCacheNegativeTTL),
+		}
 	}
 
 	// TLS configuration
@@ -621,6 +816,36 @@ func (p *Provider) getInt64(defaultValue int64, keyParts ...string) int64 {
 	return value
 }
 
+func (p *Provider) getFloat64(defaultValue float64, keyParts ...string) float64 {
+	rawValue := p.get("", keyParts...)
+
+	if len(rawValue) == 0 {
+		return defaultValue
+	}
+
+	value, err := strconv.ParseFloat(rawValue, 64)
+	if err != nil {
+		log.Errorf("Invalid value for %v: %s", keyParts, rawValue)
+		return defaultValue
+	}
+	return value
+}
+
+func (p *Provider) getDuration(defaultValue parse.Duration, keyParts ...string) parse.Duration {
+	rawValue := p.get("", keyParts...)
+
+	if len(rawValue) == 0 {
+		return defaultValue
+	}
+
+	var value parse.Duration
+	if err := value.Set(rawValue); err != nil {
+		log.Errorf("Invalid value for %v: %s", keyParts, rawValue)
+		return defaultValue
+	}
+	return value
+}
+
 func (p *Provider) list(keyParts ...string) []string {
 	rootKey := strings.Join(keyParts, "")
 
@@ -1309,6 +1309,18 @@ func TestWhiteList(t *testing.T) {
 				},
 			},
 		},
+		{
+			desc:     "should return a struct when only deny list and IP sets",
+			rootPath: "traefik/frontends/foo",
+			kvPairs: filler("traefik",
+				frontend("foo",
+					withPair(pathFrontendWhiteListDenyRange, "10.10.10.10"),
+					withList(pathFrontendWhiteListDenySets, "abuse", "tor"))),
+			expected: &types.WhiteList{
+				DenyRange: []string{"10.10.10.10"},
+				DenySets:  []string{"abuse", "tor"},
+			},
+		},
 	}
 
 	for _, test := range testCases {
@@ -1391,6 +1403,38 @@ func TestProviderGetRedirect(t *testing.T) {
 				EntryPoint: "https",
 			},
 		},
+		{
+			desc:     "should use URL components when component keys are valued in the store",
+			rootPath: "traefik/frontends/foo",
+			kvPairs: filler("traefik",
+				frontend("foo",
+					withPair(pathFrontendRedirectScheme, "https"),
+					withPair(pathFrontendRedirectHost, "foo.com"),
+					withPair(pathFrontendRedirectPort, "8443"),
+					withPair(pathFrontendRedirectPath, "/bar"),
+					withPair(pathFrontendRedirectDropQuery, "true"),
+					withPair(pathFrontendRedirectStatusCode, "307"))),
+			expected: &types.Redirect{
+				Scheme:     "https",
+				Host:       "foo.com",
+				Port:       "8443",
+				Path:       "/bar",
+				DropQuery:  true,
+				StatusCode: 307,
+			},
+		},
+		{
+			desc:     "should use app root when app root key is valued in the store",
+			rootPath: "traefik/frontends/foo",
+			kvPairs: filler("traefik",
+				frontend("foo",
+					withPair(pathFrontendRedirectAppRoot, "/app"),
+					withPair(pathFrontendRedirectPermanent, "true"))),
+			expected: &types.Redirect{
+				AppRoot:   "/app",
+				Permanent: true,
+			},
+		},
 		{
 			desc:     "should return when redirect keys are not valued in the store",
 			rootPath: "traefik/frontends/foo",
@@ -1440,6 +1484,28 @@ func TestProviderGetErrorPages(t *testing.T) {
 				},
 			},
 		},
+		{
+			desc:     "error page from a template",
+			rootPath: "traefik/frontends/foo",
+			kvPairs: filler("traefik",
+				frontend("foo",
+					withList(pathFrontendErrorPages+"foo"+pathFrontendErrorPagesStatus, "500-599"),
+					withPair(pathFrontendErrorPages+"foo"+pathFrontendErrorPagesBody, `{"status": {{ .StatusCode }}}`),
+					withPair(pathFrontendErrorPages+"foo"+pathFrontendErrorPagesContentType, "application/json"),
+					withList(pathFrontendErrorPages+"bar"+pathFrontendErrorPagesStatus, "404"),
+					withPair(pathFrontendErrorPages+"bar"+pathFrontendErrorPagesFile, "/pages/404.html"))),
+			expected: map[string]*types.ErrorPage{
+				"foo": {
+					Status:      []string{"500-599"},
+					Body:        `{"status": {{ .StatusCode }}}`,
+					ContentType: "application/json",
+				},
+				"bar": {
+					Status: []string{"404"},
+					File:   "/pages/404.html",
+				},
+			},
+		},
 		{
 			desc:     "return nil when no errors pages",
 			rootPath: "traefik/frontends/foo",
@@ -1493,6 +1559,52 @@ func TestProviderGetRateLimit(t *testing.T) {
 				},
 			},
 		},
+		{
+			desc:     "with a distributed limit",
+			rootPath: "traefik/frontends/foo",
+			kvPairs: filler("traefik",
+				frontend("foo",
+					withPair(pathFrontendRateLimitDistributed, "true"),
+					withRateLimit("client.ip",
+						withLimit("foo", "6", "12", "18")))),
+			expected: &types.RateLimit{
+				ExtractorFunc: "client.ip",
+				Distributed:   true,
+				RateSet: map[string]*types.Rate{
+					"foo": {
+						Average: 6,
+						Burst:   12,
+						Period:  parse.Duration(18 * time.Second),
+					},
+				},
+			},
+		},
+		{
+			desc:     "with a custom rejection and exemptions",
+			rootPath: "traefik/frontends/foo",
+			kvPairs: filler("traefik",
+				frontend("foo",
+					withPair(pathFrontendRateLimitStatusCode, "503"),
+					withPair(pathFrontendRateLimitBody, `{"error":"slow down"}`),
+					withPair(pathFrontendRateLimitContentType, "application/json"),
+					withList(pathFrontendRateLimitExemptSourceRange, "10.0.0.0/8", "192.168.0.1"),
+					withRateLimit("client.ip,request.jwt.sub",
+						withLimit("foo", "6", "12", "18")))),
+			expected: &types.RateLimit{
+				ExtractorFunc:     "client.ip,request.jwt.sub",
+				StatusCode:        503,
+				Body:              `{"error":"slow down"}`,
+				ContentType:       "application/json",
+				ExemptSourceRange: []string{"10.0.0.0/8", "192.168.0.1"},
+				RateSet: map[string]*types.Rate{
+					"foo": {
+						Average: 6,
+						Burst:   12,
+						Period:  parse.Duration(18 * time.Second),
+					},
+				},
+			},
+		},
 		{
 			desc:     "return nil when no extractor func",
 			rootPath: "traefik/frontends/foo",
@@ -1564,6 +1676,31 @@ func TestProviderGetHeaders(t *testing.T) {
 				},
 			},
 		},
+		{
+			desc:     "Header Operations",
+			rootPath: "traefik/frontends/foo",
+			kvPairs: filler("traefik",
+				frontend("foo",
+					withPair(pathFrontendRequestOperations+"1"+pathFrontendOperationAction, "set"),
+					withPair(pathFrontendRequestOperations+"1"+pathFrontendOperationName, "X-Client-Ip"),
+					withPair(pathFrontendRequestOperations+"1"+pathFrontendOperationValue, "{{ .ClientIP }}"),
+					withPair(pathFrontendRequestOperations+"2"+pathFrontendOperationAction, "remove"),
+					withPair(pathFrontendRequestOperations+"2"+pathFrontendOperationName, "Cookie"),
+					withPair(pathFrontendResponseOperations+"1"+pathFrontendOperationAction, "replace"),
+					withPair(pathFrontendResponseOperations+"1"+pathFrontendOperationName, "Location"),
+					withPair(pathFrontendResponseOperations+"1"+pathFrontendOperationRegex, "^http://(.*)"),
+					withPair(pathFrontendResponseOperations+"1"+pathFrontendOperationValue, "https://$1"),
+					withList(pathFrontendResponseOperations+"1"+pathFrontendOperationStatusCodes, "301", "302"))),
+			expected: &types.Headers{
+				RequestOperations: []types.HeaderOperation{
+					{Action: "set", Name: "X-Client-Ip", Value: "{{ .ClientIP }}"},
+					{Action: "remove", Name: "Cookie"},
+				},
+				ResponseOperations: []types.HeaderOperation{
+					{Action: "replace", Name: "Location", Regex: "^http://(.*)", Value: "https://$1", StatusCodes: []string{"301", "302"}},
+				},
+			},
+		},
 		{
 			desc:     "SSL Proxy Headers",
 			rootPath: "traefik/frontends/foo",
@@ -2149,6 +2286,371 @@ func TestProviderGetTLSes(t *testing.T) {
 	}
 }
 
+func TestProviderGetTLSClientCertAuth(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		rootPath string
+		kvPairs  []*store.KVPair
+		expected *types.TLSClientCertAuth
+	}{
+		{
+			desc:     "should return nil when no data",
+			expected: nil,
+		},
+		{
+			desc:     "should return a TLS client certificate authorization",
+			rootPath: "traefik/frontends/foo",
+			kvPairs: filler("traefik",
+				frontend("foo",
+					withList(pathFrontendTLSClientCertAuthCommonNames, "client1.example.org", "*.example.com"),
+					withPair(pathFrontendTLSClientCertAuthURIs, "spiffe://example.org/ns/prod/*"),
+					withPair(pathFrontendTLSClientCertAuthFingerprints, "ab:cd:ef"),
+				)),
+			expected: &types.TLSClientCertAuth{
+				CommonNames:  []string{"client1.example.org", "*.example.com"},
+				URIs:         []string{"spiffe://example.org/ns/prod/*"},
+				Fingerprints: []string{"ab:cd:ef"},
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			p := newProviderMock(test.kvPairs)
+
+			result := p.getTLSClientCertAuth(test.rootPath)
+
+			assert.Equal(t, test.expected, result)
+		})
+	}
+}
+
+func TestProviderGetGeoIP(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		rootPath string
+		kvPairs  []*store.KVPair
+		expected *types.GeoIP
+	}{
+		{
+			desc:     "should return nil when no data",
+			expected: nil,
+		},
+		{
+			desc:     "should return a GeoIP",
+			rootPath: "traefik/frontends/foo",
+			kvPairs: filler("traefik",
+				frontend("foo",
+					withPair(pathFrontendGeoIPCountryDatabase, "/foo/country.mmdb"),
+					withList(pathFrontendGeoIPDeniedCountries, "KP", "IR"),
+					withPair(pathFrontendGeoIPAddHeaders, "true"),
+				)),
+			expected: &types.GeoIP{
+				CountryDatabase: "/foo/country.mmdb",
+				DeniedCountries: []string{"KP", "IR"},
+				AddHeaders:      true,
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			p := newProviderMock(test.kvPairs)
+
+			result := p.getGeoIP(test.rootPath)
+
+			assert.Equal(t, test.expected, result)
+		})
+	}
+}
+
+func TestProviderGetStatic(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		rootPath string
+		kvPairs  []*store.KVPair
+		expected *types.Static
+	}{
+		{
+			desc:     "should return nil when no data",
+			expected: nil,
+		},
+		{
+			desc:     "should return a static configuration",
+			rootPath: "traefik/backends/foo",
+			kvPairs: filler("traefik",
+				backend("foo",
+					withList(pathBackendStaticIndexFiles, "index.html", "index.htm"),
+					withPair(pathBackendStaticSPA, "true"),
+					withPair(pathBackendStaticBrowse, "false"),
+				)),
+			expected: &types.Static{
+				IndexFiles: []string{"index.html", "index.htm"},
+				SPA:        true,
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			p := newProviderMock(test.kvPairs)
+
+			result := p.getStatic(test.rootPath)
+
+			assert.Equal(t, test.expected, result)
+		})
+	}
+}
+
+func TestProviderGetMaintenance(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		rootPath string
+		kvPairs  []*store.KVPair
+		expected *types.Maintenance
+	}{
+		{
+			desc:     "should return nil when no data",
+			expected: nil,
+		},
+		{
+			desc:     "should return a maintenance",
+			rootPath: "traefik/frontends/foo",
+			kvPairs: filler("traefik",
+				frontend("foo",
+					withPair(pathFrontendMaintenanceEnabled, "true"),
+					withPair(pathFrontendMaintenanceStatusCode, "200"),
+					withPair(pathFrontendMaintenanceRetryAfter, "10m"),
+					withPair(pathFrontendMaintenancePage, "/foo/maintenance.html"),
+					withList(pathFrontendMaintenanceSourceRange, "10.0.0.0/8", "192.168.1.1"),
+					withPair(pathFrontendMaintenanceBypassHeaderName, "X-Bypass"),
+					withPair(pathFrontendMaintenanceBypassHeaderValue, "secret"),
+				)),
+			expected: &types.Maintenance{
+				Enabled:           true,
+				StatusCode:        200,
+				RetryAfter:        parse.Duration(10 * time.Minute),
+				Page:              "/foo/maintenance.html",
+				SourceRange:       []string{"10.0.0.0/8", "192.168.1.1"},
+				BypassHeaderName:  "X-Bypass",
+				BypassHeaderValue: "secret",
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			p := newProviderMock(test.kvPairs)
+
+			result := p.getMaintenance(test.rootPath)
+
+			assert.Equal(t, test.expected, result)
+		})
+	}
+}
+
+func TestProviderGetFaultInjection(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		rootPath string
+		kvPairs  []*store.KVPair
+		expected *types.FaultInjection
+	}{
+		{
+			desc:     "should return nil when no data",
+			expected: nil,
+		},
+		{
+			desc:     "should return a fault injection",
+			rootPath: "traefik/frontends/foo",
+			kvPairs: filler("traefik",
+				frontend("foo",
+					withPair(pathFrontendFaultInjectionEnabled, "true"),
+					withPair(pathFrontendFaultInjectionHeaderName, "X-Chaos"),
+					withPair(pathFrontendFaultInjectionHeaderValue, "on"),
+					withPair(pathFrontendFaultInjectionDelayPercentage, "12.5"),
+					withPair(pathFrontendFaultInjectionDelay, "100ms"),
+					withPair(pathFrontendFaultInjectionDelayMax, "2s"),
+					withPair(pathFrontendFaultInjectionDelayDistribution, "exponential"),
+					withPair(pathFrontendFaultInjectionAbortPercentage, "5"),
+					withPair(pathFrontendFaultInjectionAbortStatusCode, "502"),
+					withPair(pathFrontendFaultInjectionAbortReset, "true"),
+				)),
+			expected: &types.FaultInjection{
+				Enabled:           true,
+				HeaderName:        "X-Chaos",
+				HeaderValue:       "on",
+				DelayPercentage:   12.5,
+				Delay:             parse.Duration(100 * time.Millisecond),
+				DelayMax:          parse.Duration(2 * time.Second),
+				DelayDistribution: "exponential",
+				AbortPercentage:   5,
+				AbortStatusCode:   502,
+				AbortReset:        true,
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			p := newProviderMock(test.kvPairs)
+
+			result := p.getFaultInjection(test.rootPath)
+
+			assert.Equal(t, test.expected, result)
+		})
+	}
+}
+
+func TestProviderGetRequestID(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		rootPath string
+		kvPairs  []*store.KVPair
+		expected *types.RequestID
+	}{
+		{
+			desc:     "should return nil when no data",
+			expected: nil,
+		},
+		{
+			desc:     "should return request ID",
+			rootPath: "traefik/frontends/foo",
+			kvPairs: filler("traefik",
+				frontend("foo",
+					withPair(pathFrontendRequestIDHeaderName, "X-Correlation-Id"),
+					withPair(pathFrontendRequestIDFormat, "ulid"),
+					withPair(pathFrontendRequestIDInsecure, "true"),
+					withList(pathFrontendRequestIDTrustedIPs, "10.0.0.0/8", "192.168.0.1"),
+				)),
+			expected: &types.RequestID{
+				HeaderName: "X-Correlation-Id",
+				Format:     "ulid",
+				Insecure:   true,
+				TrustedIPs: []string{"10.0.0.0/8", "192.168.0.1"},
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			p := newProviderMock(test.kvPairs)
+
+			result := p.getRequestID(test.rootPath)
+
+			assert.Equal(t, test.expected, result)
+		})
+	}
+}
+
+func TestProviderGetSizeLimits(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		rootPath string
+		kvPairs  []*store.KVPair
+		expected *types.SizeLimits
+	}{
+		{
+			desc:     "should return nil when no data",
+			expected: nil,
+		},
+		{
+			desc:     "should return size limits",
+			rootPath: "traefik/frontends/foo",
+			kvPairs: filler("traefik",
+				frontend("foo",
+					withPair(pathFrontendSizeLimitsMaxRequestBodyBytes, "10485760"),
+					withPair(pathFrontendSizeLimitsMaxHeaderCount, "100"),
+					withPair(pathFrontendSizeLimitsMaxHeaderBytes, "8192"),
+				)),
+			expected: &types.SizeLimits{
+				MaxRequestBodyBytes: 10485760,
+				MaxHeaderCount:      100,
+				MaxHeaderBytes:      8192,
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			p := newProviderMock(test.kvPairs)
+
+			result := p.getSizeLimits(test.rootPath)
+
+			assert.Equal(t, test.expected, result)
+		})
+	}
+}
+
+func TestProviderGetBodyRewrite(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		rootPath string
+		kvPairs  []*store.KVPair
+		expected *types.BodyRewrite
+	}{
+		{
+			desc:     "should return nil when no data",
+			expected: nil,
+		},
+		{
+			desc:     "should return a body rewrite",
+			rootPath: "traefik/frontends/foo",
+			kvPairs: filler("traefik",
+				frontend("foo",
+					withList(pathFrontendBodyRewriteContentTypes, "text/html"),
+					withPair(pathFrontendBodyRewriteMaxBodySize, "2048"),
+					withPair(pathFrontendBodyRewriteReplacements+"1"+pathFrontendBodyReplacementRegex, `href="/`),
+					withPair(pathFrontendBodyRewriteReplacements+"1"+pathFrontendBodyReplacementReplacement, `href="{{ .Prefix }}/`),
+					withPair(pathFrontendBodyRewriteReplacements+"2"+pathFrontendBodyReplacementSearch, "foo"),
+					withPair(pathFrontendBodyRewriteReplacements+"2"+pathFrontendBodyReplacementReplacement, "bar"),
+				)),
+			expected: &types.BodyRewrite{
+				ContentTypes: []string{"text/html"},
+				MaxBodySize:  2048,
+				Replacements: []types.BodyReplacement{
+					{Regex: `href="/`, Replacement: `href="{{ .Prefix }}/`},
+					{Search: "foo", Replacement: "bar"},
+				},
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			p := newProviderMock(test.kvPairs)
+
+			result := p.getBodyRewrite(test.rootPath)
+
+			assert.Equal(t, test.expected, result)
+		})
+	}
+}
+
 func TestProviderGetAuth(t *testing.T) {
 	testCases := []struct {
 		desc     string
@@ -2226,6 +2728,35 @@ func TestProviderGetAuth(t *testing.T) {
 				},
 			},
 		},
+		{
+			desc:     "should return a valid forward auth with body and cache",
+			rootPath: "traefik/frontends/foo",
+			kvPairs: filler("traefik",
+				frontend("foo",
+					withPair(pathFrontendAuthForwardAddress, "auth.server"),
+					withList(pathFrontendAuthForwardAuthRequestHeaders, "Authorization", "X-Signature"),
+					withPair(pathFrontendAuthForwardMethod, "POST"),
+					withPair(pathFrontendAuthForwardForwardBody, "true"),
+					withPair(pathFrontendAuthForwardMaxBodySize, "2048"),
+					withList(pathFrontendAuthForwardCacheKeyHeaders, "Authorization"),
+					withPair(pathFrontendAuthForwardCacheTTL, "30s"),
+					withPair(pathFrontendAuthForwardCacheNegativeTTL, "5s"),
+				)),
+			expected: &types.Auth{
+				Forward: &types.Forward{
+					Address:            "auth.server",
+					AuthRequestHeaders: []string{"Authorization", "X-Signature"},
+					Method:             "POST",
+					ForwardBody:        true,
+					MaxBodySize:        2048,
+					Cache: &types.ForwardCache{
+						KeyHeaders:  []string{"Authorization"},
+						TTL:         parse.Duration(30 * time.Second),
+						NegativeTTL: parse.Duration(5 * time.Second),
+					},
+				},
+			},
+		},
 	}
 
 	for _, test := range testCases {
@@ -7,6 +7,7 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/containous/flaeg/parse"
 	"github.com/containous/traefik/log"
 )
 
@@ -35,6 +36,15 @@ var (
 
 	// RegexpFrontendRateLimit used to extract rate limits from label
 	RegexpFrontendRateLimit = regexp.MustCompile(`^traefik\.frontend\.rateLimit\.rateSet\.(?P<name>[^ .]+)\.(?P<field>[^ .]+)$`)
+
+	// RegexpFrontendRequestOperation used to extract request header operations from label
+	RegexpFrontendRequestOperation = regexp.MustCompile(`^traefik\.frontend\.headers\.requestOperations\.(?P<name>[^ .]+)\.(?P<field>[^ .]+)$`)
+
+	// RegexpFrontendResponseOperation used to extract response header operations from label
+	RegexpFrontendResponseOperation = regexp.MustCompile(`^traefik\.frontend\.headers\.responseOperations\.(?P<name>[^ .]+)\.(?P<field>[^ .]+)$`)
+
+	// RegexpFrontendBodyReplacement used to extract body replacements from label
+	RegexpFrontendBodyReplacement = regexp.MustCompile(`^traefik\.frontend\.bodyRewrite\.replacements\.(?P<name>[^ .]+)\.(?P<field>[^ .]+)$`)
 )
 
 // GetStringValue get string value associated to a label
@@ -82,6 +92,31 @@ func GetInt64Value(labels map[string]string, labelName string, defaultValue int6
 	return defaultValue
 }
 
+// GetFloat64Value get float64 value associated to a label
+func GetFloat64Value(labels map[string]string, labelName string, defaultValue float64) float64 {
+	if rawValue, ok := labels[labelName]; ok {
+		value, err := strconv.ParseFloat(rawValue, 64)
+		if err == nil {
+			return value
+		}
+		log.Errorf("Unable to parse %q: %q, falling back to %v. %v", labelName, rawValue, defaultValue, err)
+	}
+	return defaultValue
+}
+
+// GetDurationValue get duration value associated to a label
+func GetDurationValue(labels map[string]string, labelName string, defaultValue parse.Duration) parse.Duration {
+	if rawValue, ok := labels[labelName]; ok {
+		var value parse.Duration
+		err := value.Set(rawValue)
+		if err == nil {
+			return value
+		}
+		log.Errorf("Unable to parse %q: %q, falling back to %v. %v", labelName, rawValue, defaultValue, err)
+	}
+	return defaultValue
+}
+
 // GetSliceStringValue get a slice of string associated to a label
 func GetSliceStringValue(labels map[string]string, labelName string) []string {
 	var value []string
@@ -3,7 +3,9 @@ package label
 import (
 	"strconv"
 	"testing"
+	"time"
 
+	"github.com/containous/flaeg/parse"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -259,6 +261,107 @@ func TestGetInt64Value(t *testing.T) {
 	}
 }
 
+func TestGetFloat64Value(t *testing.T) {
+	testCases := []struct {
+		desc         string
+		labels       map[string]string
+		labelName    string
+		defaultValue float64
+		expected     float64
+	}{
+		{
+			desc:      "empty map",
+			labelName: "foo",
+		},
+		{
+			desc:      "invalid float value",
+			labelName: "foo",
+			labels: map[string]string{
+				"foo": "bar",
+			},
+			defaultValue: 666,
+			expected:     666,
+		},
+		{
+			desc:      "float value",
+			labelName: "foo",
+			labels: map[string]string{
+				"foo": "0.5",
+			},
+			defaultValue: 666,
+			expected:     0.5,
+		},
+		{
+			desc:      "int value",
+			labelName: "foo",
+			labels: map[string]string{
+				"foo": "10",
+			},
+			defaultValue: 666,
+			expected:     10,
+		},
+	}
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			got := GetFloat64Value(test.labels, test.labelName, test.defaultValue)
+			assert.Equal(t, test.expected, got)
+		})
+	}
+}
+
+func TestGetDurationValue(t *testing.T) {
+	testCases := []struct {
+		desc         string
+		labels       map[string]string
+		labelName    string
+		defaultValue parse.Duration
+		expected     parse.Duration
+	}{
+		{
+			desc:      "empty map",
+			labelName: "foo",
+		},
+		{
+			desc:      "invalid duration value",
+			labelName: "foo",
+			labels: map[string]string{
+				"foo": "bar",
+			},
+			defaultValue: parse.Duration(time.Second),
+			expected:     parse.Duration(time.Second),
+		},
+		{
+			desc:      "duration value",
+			labelName: "foo",
+			labels: map[string]string{
+				"foo": "1m",
+			},
+			defaultValue: parse.Duration(time.Second),
+			expected:     parse.Duration(time.Minute),
+		},
+		{
+			desc:      "seconds value",
+			labelName: "foo",
+			labels: map[string]string{
+				"foo": "10",
+			},
+			expected: parse.Duration(10 * time.Second),
+		},
+	}
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			got := GetDurationValue(test.labels, test.labelName, test.defaultValue)
+			assert.Equal(t, test.expected, got)
+		})
+	}
+}
+
 func TestGetSliceStringValue(t *testing.T) {
 	testCases := []struct {
 		desc      string
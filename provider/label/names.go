@@ -12,6 +12,8 @@ const (
 	SuffixProtocol                                           = "protocol"
 	SuffixTags                                               = "tags"
 	SuffixWeight                                             = "weight"
+	SuffixUDPEntryPoints                                     = "udp.entryPoints"
+	SuffixUDPServerPort                                      = "udp.serverPort"
 	SuffixBackendID                                          = "backend.id"
 	SuffixBackendCircuitBreaker                              = "backend.circuitbreaker"
 	SuffixBackendCircuitBreakerExpression                    = "backend.circuitbreaker.expression"
@@ -34,6 +36,7 @@ const (
 	SuffixBackendBufferingMaxResponseBodyBytes               = SuffixBackendBuffering + ".maxResponseBodyBytes"
 	SuffixBackendBufferingMemResponseBodyBytes               = SuffixBackendBuffering + ".memResponseBodyBytes"
 	SuffixBackendBufferingRetryExpression                    = SuffixBackendBuffering + ".retryExpression"
+	SuffixBackendProxyProtocolVersion                        = "backend.proxyProtocol.version"
 	SuffixFrontend                                           = "frontend"
 	SuffixFrontendAuth                                       = SuffixFrontend + ".auth"
 	SuffixFrontendAuthBasic                                  = SuffixFrontendAuth + ".basic"
@@ -47,6 +50,14 @@ const (
 	SuffixFrontendAuthDigestUsersFile                        = SuffixFrontendAuthDigest + ".usersFile"
 	SuffixFrontendAuthForward                                = SuffixFrontendAuth + ".forward"
 	SuffixFrontendAuthForwardAddress                         = SuffixFrontendAuthForward + ".address"
+	SuffixFrontendAuthForwardAuthRequestHeaders              = SuffixFrontendAuthForward + ".authRequestHeaders"
+	SuffixFrontendAuthForwardCache                           = SuffixFrontendAuthForward + ".cache"
+	SuffixFrontendAuthForwardCacheKeyHeaders                 = SuffixFrontendAuthForwardCache + ".keyHeaders"
+	SuffixFrontendAuthForwardCacheNegativeTTL                = SuffixFrontendAuthForwardCache + ".negativeTTL"
+	SuffixFrontendAuthForwardCacheTTL                        = SuffixFrontendAuthForwardCache + ".ttl"
+	SuffixFrontendAuthForwardForwardBody                     = SuffixFrontendAuthForward + ".forwardBody"
+	SuffixFrontendAuthForwardMaxBodySize                     = SuffixFrontendAuthForward + ".maxBodySize"
+	SuffixFrontendAuthForwardMethod                          = SuffixFrontendAuthForward + ".method"
 	SuffixFrontendAuthForwardTLS                             = SuffixFrontendAuthForward + ".tls"
 	SuffixFrontendAuthForwardTLSCa                           = SuffixFrontendAuthForwardTLS + ".ca"
 	SuffixFrontendAuthForwardTLSCaOptional                   = SuffixFrontendAuthForwardTLS + ".caOptional"
@@ -55,7 +66,37 @@ const (
 	SuffixFrontendAuthForwardTLSKey                          = SuffixFrontendAuthForwardTLS + ".key"
 	SuffixFrontendAuthForwardTrustForwardHeader              = SuffixFrontendAuthForward + ".trustForwardHeader"
 	SuffixFrontendAuthHeaderField                            = SuffixFrontendAuth + ".headerField"
+	SuffixFrontendBodyRewrite                                = "frontend.bodyRewrite"
+	SuffixFrontendBodyRewriteContentTypes                    = SuffixFrontendBodyRewrite + ".contentTypes"
+	SuffixFrontendBodyRewriteMaxBodySize                     = SuffixFrontendBodyRewrite + ".maxBodySize"
 	SuffixFrontendEntryPoints                                = "frontend.entryPoints"
+	SuffixFrontendFaultInjection                             = "frontend.faultInjection"
+	SuffixFrontendFaultInjectionAbortPercentage              = SuffixFrontendFaultInjection + ".abortPercentage"
+	SuffixFrontendFaultInjectionAbortReset                   = SuffixFrontendFaultInjection + ".abortReset"
+	SuffixFrontendFaultInjectionAbortStatusCode              = SuffixFrontendFaultInjection + ".abortStatusCode"
+	SuffixFrontendFaultInjectionDelay                        = SuffixFrontendFaultInjection + ".delay"
+	SuffixFrontendFaultInjectionDelayDistribution            = SuffixFrontendFaultInjection + ".delayDistribution"
+	SuffixFrontendFaultInjectionDelayMax                     = SuffixFrontendFaultInjection + ".delayMax"
+	SuffixFrontendFaultInjectionDelayPercentage              = SuffixFrontendFaultInjection + ".delayPercentage"
+	SuffixFrontendFaultInjectionEnabled                      = SuffixFrontendFaultInjection + ".enabled"
+	SuffixFrontendFaultInjectionHeaderName                   = SuffixFrontendFaultInjection + ".headerName"
+	SuffixFrontendFaultInjectionHeaderValue                  = SuffixFrontendFaultInjection + ".headerValue"
+	SuffixFrontendGeoIP                                      = "frontend.geoIP"
+	SuffixFrontendGeoIPAddHeaders                            = SuffixFrontendGeoIP + ".addHeaders"
+	SuffixFrontendGeoIPAllowedASNs                           = SuffixFrontendGeoIP + ".allowedASNs"
+	SuffixFrontendGeoIPAllowedCountries                      = SuffixFrontendGeoIP + ".allowedCountries"
+	SuffixFrontendGeoIPASNDatabase                           = SuffixFrontendGeoIP + ".asnDatabase"
+	SuffixFrontendGeoIPCountryDatabase                       = SuffixFrontendGeoIP + ".countryDatabase"
+	SuffixFrontendGeoIPDeniedASNs                            = SuffixFrontendGeoIP + ".deniedASNs"
+	SuffixFrontendGeoIPDeniedCountries                       = SuffixFrontendGeoIP + ".deniedCountries"
+	SuffixFrontendMaintenance                                = "frontend.maintenance"
+	SuffixFrontendMaintenanceBypassHeaderName                = SuffixFrontendMaintenance + ".bypassHeaderName"
+	SuffixFrontendMaintenanceBypassHeaderValue               = SuffixFrontendMaintenance + ".bypassHeaderValue"
+	SuffixFrontendMaintenanceEnabled                         = SuffixFrontendMaintenance + ".enabled"
+	SuffixFrontendMaintenancePage                            = SuffixFrontendMaintenance + ".page"
+	SuffixFrontendMaintenanceRetryAfter                      = SuffixFrontendMaintenance + ".retryAfter"
+	SuffixFrontendMaintenanceSourceRange                     = SuffixFrontendMaintenance + ".sourceRange"
+	SuffixFrontendMaintenanceStatusCode                      = SuffixFrontendMaintenance + ".statusCode"
 	SuffixFrontendHeaders                                    = "frontend.headers."
 	SuffixFrontendRequestHeaders                             = SuffixFrontendHeaders + "customRequestHeaders"
 	SuffixFrontendResponseHeaders                            = SuffixFrontendHeaders + "customResponseHeaders"
@@ -96,13 +137,45 @@ const (
 	SuffixFrontendPassTLSCert                                = "frontend.passTLSCert" // Deprecated
 	SuffixFrontendPriority                                   = "frontend.priority"
 	SuffixFrontendRateLimitExtractorFunc                     = "frontend.rateLimit.extractorFunc"
+	SuffixFrontendRateLimitDistributed                       = "frontend.rateLimit.distributed"
+	SuffixFrontendRateLimitStatusCode                        = "frontend.rateLimit.statusCode"
+	SuffixFrontendRateLimitBody                              = "frontend.rateLimit.body"
+	SuffixFrontendRateLimitContentType                       = "frontend.rateLimit.contentType"
+	SuffixFrontendRateLimitExemptSourceRange                 = "frontend.rateLimit.exemptSourceRange"
 	SuffixFrontendRedirectEntryPoint                         = "frontend.redirect.entryPoint"
 	SuffixFrontendRedirectRegex                              = "frontend.redirect.regex"
 	SuffixFrontendRedirectReplacement                        = "frontend.redirect.replacement"
 	SuffixFrontendRedirectPermanent                          = "frontend.redirect.permanent"
+	SuffixFrontendRedirectStatusCode                         = "frontend.redirect.statusCode"
+	SuffixFrontendRedirectScheme                             = "frontend.redirect.scheme"
+	SuffixFrontendRedirectHost                               = "frontend.redirect.host"
+	SuffixFrontendRedirectPort                               = "frontend.redirect.port"
+	SuffixFrontendRedirectPath                               = "frontend.redirect.path"
+	SuffixFrontendRedirectPathPrefix                         = "frontend.redirect.pathPrefix"
+	SuffixFrontendRedirectDropQuery                          = "frontend.redirect.dropQuery"
+	SuffixFrontendRedirectAppRoot                            = "frontend.redirect.appRoot"
 	SuffixFrontendRule                                       = "frontend.rule"
+	SuffixFrontendRequestID                                  = "frontend.requestID"
+	SuffixFrontendRequestIDFormat                            = SuffixFrontendRequestID + ".format"
+	SuffixFrontendRequestIDHeaderName                        = SuffixFrontendRequestID + ".headerName"
+	SuffixFrontendRequestIDInsecure                          = SuffixFrontendRequestID + ".insecure"
+	SuffixFrontendRequestIDTrustedIPs                        = SuffixFrontendRequestID + ".trustedIPs"
+	SuffixFrontendSizeLimits                                 = "frontend.sizeLimits"
+	SuffixFrontendSizeLimitsMaxHeaderBytes                   = SuffixFrontendSizeLimits + ".maxHeaderBytes"
+	SuffixFrontendSizeLimitsMaxHeaderCount                   = SuffixFrontendSizeLimits + ".maxHeaderCount"
+	SuffixFrontendSizeLimitsMaxRequestBodyBytes              = SuffixFrontendSizeLimits + ".maxRequestBodyBytes"
+	SuffixFrontendTLSClientCertAuth                          = "frontend.tlsClientCertAuth"
+	SuffixFrontendTLSClientCertAuthCommonNames               = SuffixFrontendTLSClientCertAuth + ".commonNames"
+	SuffixFrontendTLSClientCertAuthDNSNames                  = SuffixFrontendTLSClientCertAuth + ".dnsNames"
+	SuffixFrontendTLSClientCertAuthFingerprints              = SuffixFrontendTLSClientCertAuth + ".fingerprints"
+	SuffixFrontendTLSClientCertAuthIssuers                   = SuffixFrontendTLSClientCertAuth + ".issuers"
+	SuffixFrontendTLSClientCertAuthOrganizationalUnits       = SuffixFrontendTLSClientCertAuth + ".organizationalUnits"
+	SuffixFrontendTLSClientCertAuthURIs                      = SuffixFrontendTLSClientCertAuth + ".uris"
 	SuffixFrontendWhiteList                                  = "frontend.whiteList."
 	SuffixFrontendWhiteListSourceRange                       = SuffixFrontendWhiteList + "sourceRange"
+	SuffixFrontendWhiteListSourceSets                        = SuffixFrontendWhiteList + "sourceSets"
+	SuffixFrontendWhiteListDenyRange                         = SuffixFrontendWhiteList + "denyRange"
+	SuffixFrontendWhiteListDenySets                          = SuffixFrontendWhiteList + "denySets"
 	SuffixFrontendWhiteListIPStrategy                        = SuffixFrontendWhiteList + "ipStrategy"
 	SuffixFrontendWhiteListIPStrategyDepth                   = SuffixFrontendWhiteListIPStrategy + ".depth"
 	SuffixFrontendWhiteListIPStrategyExcludedIPS             = SuffixFrontendWhiteListIPStrategy + ".excludedIPs"
@@ -114,6 +187,8 @@ const (
 	TraefikProtocol                                          = Prefix + SuffixProtocol
 	TraefikTags                                              = Prefix + SuffixTags
 	TraefikWeight                                            = Prefix + SuffixWeight
+	TraefikUDPEntryPoints                                    = Prefix + SuffixUDPEntryPoints
+	TraefikUDPServerPort                                     = Prefix + SuffixUDPServerPort
 	TraefikBackend                                           = Prefix + SuffixBackend
 	TraefikBackendID                                         = Prefix + SuffixBackendID
 	TraefikBackendCircuitBreaker                             = Prefix + SuffixBackendCircuitBreaker
@@ -137,6 +212,7 @@ const (
 	TraefikBackendBufferingMaxResponseBodyBytes              = Prefix + SuffixBackendBufferingMaxResponseBodyBytes
 	TraefikBackendBufferingMemResponseBodyBytes              = Prefix + SuffixBackendBufferingMemResponseBodyBytes
 	TraefikBackendBufferingRetryExpression                   = Prefix + SuffixBackendBufferingRetryExpression
+	TraefikBackendProxyProtocolVersion                       = Prefix + SuffixBackendProxyProtocolVersion
 	TraefikFrontend                                          = Prefix + SuffixFrontend
 	TraefikFrontendAuth                                      = Prefix + SuffixFrontendAuth
 	TraefikFrontendAuthBasic                                 = Prefix + SuffixFrontendAuthBasic
@@ -150,6 +226,14 @@ const (
 	TraefikFrontendAuthDigestUsersFile                       = Prefix + SuffixFrontendAuthDigestUsersFile
 	TraefikFrontendAuthForward                               = Prefix + SuffixFrontendAuthForward
 	TraefikFrontendAuthForwardAddress                        = Prefix + SuffixFrontendAuthForwardAddress
+	TraefikFrontendAuthForwardAuthRequestHeaders             = Prefix + SuffixFrontendAuthForwardAuthRequestHeaders
+	TraefikFrontendAuthForwardCache                          = Prefix + SuffixFrontendAuthForwardCache
+	TraefikFrontendAuthForwardCacheKeyHeaders                = Prefix + SuffixFrontendAuthForwardCacheKeyHeaders
+	TraefikFrontendAuthForwardCacheNegativeTTL               = Prefix + SuffixFrontendAuthForwardCacheNegativeTTL
+	TraefikFrontendAuthForwardCacheTTL                       = Prefix + SuffixFrontendAuthForwardCacheTTL
+	TraefikFrontendAuthForwardForwardBody                    = Prefix + SuffixFrontendAuthForwardForwardBody
+	TraefikFrontendAuthForwardMaxBodySize                    = Prefix + SuffixFrontendAuthForwardMaxBodySize
+	TraefikFrontendAuthForwardMethod                         = Prefix + SuffixFrontendAuthForwardMethod
 	TraefikFrontendAuthForwardTLS                            = Prefix + SuffixFrontendAuthForwardTLS
 	TraefikFrontendAuthForwardTLSCa                          = Prefix + SuffixFrontendAuthForwardTLSCa
 	TraefikFrontendAuthForwardTLSCaOptional                  = Prefix + SuffixFrontendAuthForwardTLSCaOptional
@@ -158,7 +242,37 @@ const (
 	TraefikFrontendAuthForwardTLSKey                         = Prefix + SuffixFrontendAuthForwardTLSKey
 	TraefikFrontendAuthForwardTrustForwardHeader             = Prefix + SuffixFrontendAuthForwardTrustForwardHeader
 	TraefikFrontendAuthHeaderField                           = Prefix + SuffixFrontendAuthHeaderField
+	TraefikFrontendBodyRewrite                               = Prefix + SuffixFrontendBodyRewrite
+	TraefikFrontendBodyRewriteContentTypes                   = Prefix + SuffixFrontendBodyRewriteContentTypes
+	TraefikFrontendBodyRewriteMaxBodySize                    = Prefix + SuffixFrontendBodyRewriteMaxBodySize
 	TraefikFrontendEntryPoints                               = Prefix + SuffixFrontendEntryPoints
+	TraefikFrontendFaultInjection                            = Prefix + SuffixFrontendFaultInjection
+	TraefikFrontendFaultInjectionAbortPercentage             = Prefix + SuffixFrontendFaultInjectionAbortPercentage
+	TraefikFrontendFaultInjectionAbortReset                  = Prefix + SuffixFrontendFaultInjectionAbortReset
+	TraefikFrontendFaultInjectionAbortStatusCode             = Prefix + SuffixFrontendFaultInjectionAbortStatusCode
+	TraefikFrontendFaultInjectionDelay                       = Prefix + SuffixFrontendFaultInjectionDelay
+	TraefikFrontendFaultInjectionDelayDistribution           = Prefix + SuffixFrontendFaultInjectionDelayDistribution
+	TraefikFrontendFaultInjectionDelayMax                    = Prefix + SuffixFrontendFaultInjectionDelayMax
+	TraefikFrontendFaultInjectionDelayPercentage             = Prefix + SuffixFrontendFaultInjectionDelayPercentage
+	TraefikFrontendFaultInjectionEnabled                     = Prefix + SuffixFrontendFaultInjectionEnabled
+	TraefikFrontendFaultInjectionHeaderName                  = Prefix + SuffixFrontendFaultInjectionHeaderName
+	TraefikFrontendFaultInjectionHeaderValue                 = Prefix + SuffixFrontendFaultInjectionHeaderValue
+	TraefikFrontendGeoIP                                     = Prefix + SuffixFrontendGeoIP
+	TraefikFrontendGeoIPAddHeaders                           = Prefix + SuffixFrontendGeoIPAddHeaders
+	TraefikFrontendGeoIPAllowedASNs                          = Prefix + SuffixFrontendGeoIPAllowedASNs
+	TraefikFrontendGeoIPAllowedCountries                     = Prefix + SuffixFrontendGeoIPAllowedCountries
+	TraefikFrontendGeoIPASNDatabase                          = Prefix + SuffixFrontendGeoIPASNDatabase
+	TraefikFrontendGeoIPCountryDatabase                      = Prefix + SuffixFrontendGeoIPCountryDatabase
+	TraefikFrontendGeoIPDeniedASNs                           = Prefix + SuffixFrontendGeoIPDeniedASNs
+	TraefikFrontendGeoIPDeniedCountries                      = Prefix + SuffixFrontendGeoIPDeniedCountries
+	TraefikFrontendMaintenance                               = Prefix + SuffixFrontendMaintenance
+	TraefikFrontendMaintenanceBypassHeaderName               = Prefix + SuffixFrontendMaintenanceBypassHeaderName
+	TraefikFrontendMaintenanceBypassHeaderValue              = Prefix + SuffixFrontendMaintenanceBypassHeaderValue
+	TraefikFrontendMaintenanceEnabled                        = Prefix + SuffixFrontendMaintenanceEnabled
+	TraefikFrontendMaintenancePage                           = Prefix + SuffixFrontendMaintenancePage
+	TraefikFrontendMaintenanceRetryAfter                     = Prefix + SuffixFrontendMaintenanceRetryAfter
+	TraefikFrontendMaintenanceSourceRange                    = Prefix + SuffixFrontendMaintenanceSourceRange
+	TraefikFrontendMaintenanceStatusCode                     = Prefix + SuffixFrontendMaintenanceStatusCode
 	TraefikFrontendPassHostHeader                            = Prefix + SuffixFrontendPassHostHeader
 	TraefikFrontendPassTLSClientCert                         = Prefix + SuffixFrontendPassTLSClientCert
 	TraefikFrontendPassTLSClientCertPem                      = Prefix + SuffixFrontendPassTLSClientCertPem
@@ -176,12 +290,44 @@ const (
 	TraefikFrontendPassTLSCert                               = Prefix + SuffixFrontendPassTLSCert // Deprecated
 	TraefikFrontendPriority                                  = Prefix + SuffixFrontendPriority
 	TraefikFrontendRateLimitExtractorFunc                    = Prefix + SuffixFrontendRateLimitExtractorFunc
+	TraefikFrontendRateLimitDistributed                      = Prefix + SuffixFrontendRateLimitDistributed
+	TraefikFrontendRateLimitStatusCode                       = Prefix + SuffixFrontendRateLimitStatusCode
+	TraefikFrontendRateLimitBody                             = Prefix + SuffixFrontendRateLimitBody
+	TraefikFrontendRateLimitContentType                      = Prefix + SuffixFrontendRateLimitContentType
+	TraefikFrontendRateLimitExemptSourceRange                = Prefix + SuffixFrontendRateLimitExemptSourceRange
 	TraefikFrontendRedirectEntryPoint                        = Prefix + SuffixFrontendRedirectEntryPoint
 	TraefikFrontendRedirectRegex                             = Prefix + SuffixFrontendRedirectRegex
 	TraefikFrontendRedirectReplacement                       = Prefix + SuffixFrontendRedirectReplacement
 	TraefikFrontendRedirectPermanent                         = Prefix + SuffixFrontendRedirectPermanent
+	TraefikFrontendRedirectStatusCode                        = Prefix + SuffixFrontendRedirectStatusCode
+	TraefikFrontendRedirectScheme                            = Prefix + SuffixFrontendRedirectScheme
+	TraefikFrontendRedirectHost                              = Prefix + SuffixFrontendRedirectHost
+	TraefikFrontendRedirectPort                              = Prefix + SuffixFrontendRedirectPort
+	TraefikFrontendRedirectPath                              = Prefix + SuffixFrontendRedirectPath
+	TraefikFrontendRedirectPathPrefix                        = Prefix + SuffixFrontendRedirectPathPrefix
+	TraefikFrontendRedirectDropQuery                         = Prefix + SuffixFrontendRedirectDropQuery
+	TraefikFrontendRedirectAppRoot                           = Prefix + SuffixFrontendRedirectAppRoot
 	TraefikFrontendRule                                      = Prefix + SuffixFrontendRule
+	TraefikFrontendRequestID                                 = Prefix + SuffixFrontendRequestID
+	TraefikFrontendRequestIDFormat                           = Prefix + SuffixFrontendRequestIDFormat
+	TraefikFrontendRequestIDHeaderName                       = Prefix + SuffixFrontendRequestIDHeaderName
+	TraefikFrontendRequestIDInsecure                         = Prefix + SuffixFrontendRequestIDInsecure
+	TraefikFrontendRequestIDTrustedIPs                       = Prefix + SuffixFrontendRequestIDTrustedIPs
+	TraefikFrontendSizeLimits                                = Prefix + SuffixFrontendSizeLimits
+	TraefikFrontendSizeLimitsMaxHeaderBytes                  = Prefix + SuffixFrontendSizeLimitsMaxHeaderBytes
+	TraefikFrontendSizeLimitsMaxHeaderCount                  = Prefix + SuffixFrontendSizeLimitsMaxHeaderCount
+	TraefikFrontendSizeLimitsMaxRequestBodyBytes             = Prefix + SuffixFrontendSizeLimitsMaxRequestBodyBytes
+	TraefikFrontendTLSClientCertAuth                         = Prefix + SuffixFrontendTLSClientCertAuth
+	TraefikFrontendTLSClientCertAuthCommonNames              = Prefix + SuffixFrontendTLSClientCertAuthCommonNames
+	TraefikFrontendTLSClientCertAuthDNSNames                 = Prefix + SuffixFrontendTLSClientCertAuthDNSNames
+	TraefikFrontendTLSClientCertAuthFingerprints             = Prefix + SuffixFrontendTLSClientCertAuthFingerprints
+	TraefikFrontendTLSClientCertAuthIssuers                  = Prefix + SuffixFrontendTLSClientCertAuthIssuers
+	TraefikFrontendTLSClientCertAuthOrganizationalUnits      = Prefix + SuffixFrontendTLSClientCertAuthOrganizationalUnits
+	TraefikFrontendTLSClientCertAuthURIs                     = Prefix + SuffixFrontendTLSClientCertAuthURIs
 	TraefikFrontendWhiteListSourceRange                      = Prefix + SuffixFrontendWhiteListSourceRange
+	TraefikFrontendWhiteListSourceSets                       = Prefix + SuffixFrontendWhiteListSourceSets
+	TraefikFrontendWhiteListDenyRange                        = Prefix + SuffixFrontendWhiteListDenyRange
+	TraefikFrontendWhiteListDenySets                         = Prefix + SuffixFrontendWhiteListDenySets
 	TraefikFrontendWhiteListIPStrategy                       = Prefix + SuffixFrontendWhiteListIPStrategy
 	TraefikFrontendWhiteListIPStrategyDepth                  = Prefix + SuffixFrontendWhiteListIPStrategyDepth
 	TraefikFrontendWhiteListIPStrategyExcludedIPS            = Prefix + SuffixFrontendWhiteListIPStrategyExcludedIPS
@@ -211,8 +357,22 @@ const (
 	SuffixErrorPageBackend                                   = "backend"
 	SuffixErrorPageQuery                                     = "query"
 	SuffixErrorPageStatus                                    = "status"
+	SuffixErrorPageFile                                      = "file"
+	SuffixErrorPageBody                                      = "body"
+	SuffixErrorPageContentType                               = "contentType"
 	BaseFrontendRateLimit                                    = "frontend.rateLimit.rateSet."
 	SuffixRateLimitPeriod                                    = "period"
 	SuffixRateLimitAverage                                   = "average"
 	SuffixRateLimitBurst                                     = "burst"
+	BaseFrontendRequestOperation                             = "frontend.headers.requestOperations."
+	BaseFrontendResponseOperation                            = "frontend.headers.responseOperations."
+	SuffixHeaderOperationAction                              = "action"
+	SuffixHeaderOperationName                                = "name"
+	SuffixHeaderOperationValue                               = "value"
+	SuffixHeaderOperationRegex                               = "regex"
+	SuffixHeaderOperationStatusCodes                         = "statusCodes"
+	BaseFrontendBodyReplacement                              = "frontend.bodyRewrite.replacements."
+	SuffixBodyReplacementSearch                              = "search"
+	SuffixBodyReplacementRegex                               = "regex"
+	SuffixBodyReplacementReplacement                         = "replacement"
 )
@@ -3,6 +3,7 @@ package label
 import (
 	"math"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -13,15 +14,20 @@ import (
 
 // GetWhiteList Create white list from labels
 func GetWhiteList(labels map[string]string) *types.WhiteList {
-	ranges := GetSliceStringValue(labels, TraefikFrontendWhiteListSourceRange)
-	if len(ranges) == 0 {
-		return nil
+	whiteList := &types.WhiteList{
+		SourceRange: GetSliceStringValue(labels, TraefikFrontendWhiteListSourceRange),
+		SourceSets:  GetSliceStringValue(labels, TraefikFrontendWhiteListSourceSets),
+		DenyRange:   GetSliceStringValue(labels, TraefikFrontendWhiteListDenyRange),
+		DenySets:    GetSliceStringValue(labels, TraefikFrontendWhiteListDenySets),
 	}
 
-	return &types.WhiteList{
-		SourceRange: ranges,
-		IPStrategy:  getIPStrategy(labels),
+	if len(whiteList.SourceRange) == 0 && len(whiteList.SourceSets) == 0 &&
+		len(whiteList.DenyRange) == 0 && len(whiteList.DenySets) == 0 {
+		return nil
 	}
+
+	whiteList.IPStrategy = getIPStrategy(labels)
+	return whiteList
 }
 
 func getIPStrategy(labels map[string]string) *types.IPStrategy {
@@ -42,11 +48,13 @@ func getIPStrategy(labels map[string]string) *types.IPStrategy {
 // GetRedirect Create redirect from labels
 func GetRedirect(labels map[string]string) *types.Redirect {
 	permanent := GetBoolValue(labels, TraefikFrontendRedirectPermanent, false)
+	statusCode := GetIntValue(labels, TraefikFrontendRedirectStatusCode, 0)
 
 	if Has(labels, TraefikFrontendRedirectEntryPoint) {
 		return &types.Redirect{
 			EntryPoint: GetStringValue(labels, TraefikFrontendRedirectEntryPoint, ""),
 			Permanent:  permanent,
+			StatusCode: statusCode,
 		}
 	}
 
@@ -56,9 +64,27 @@ func GetRedirect(labels map[string]string) *types.Redirect {
 			Regex:       GetStringValue(labels, TraefikFrontendRedirectRegex, ""),
 			Replacement: GetStringValue(labels, TraefikFrontendRedirectReplacement, ""),
 			Permanent:   permanent,
+			StatusCode:  statusCode,
 		}
 	}
 
+	redirect := &types.Redirect{
+		Permanent:  permanent,
+		StatusCode: statusCode,
+		Scheme:     GetStringValue(labels, TraefikFrontendRedirectScheme, ""),
+		Host:       GetStringValue(labels, TraefikFrontendRedirectHost, ""),
+		Port:       GetStringValue(labels, TraefikFrontendRedirectPort, ""),
+		Path:       GetStringValue(labels, TraefikFrontendRedirectPath, ""),
+		PathPrefix: GetStringValue(labels, TraefikFrontendRedirectPathPrefix, ""),
+		DropQuery:  GetBoolValue(labels, TraefikFrontendRedirectDropQuery, false),
+		AppRoot:    GetStringValue(labels, TraefikFrontendRedirectAppRoot, ""),
+	}
+
+	if len(redirect.Scheme) > 0 || len(redirect.Host) > 0 || len(redirect.Port) > 0 ||
+		len(redirect.Path) > 0 || len(redirect.PathPrefix) > 0 || redirect.DropQuery || len(redirect.AppRoot) > 0 {
+		return redirect
+	}
+
 	return nil
 }
 
@@ -95,6 +121,168 @@ func GetTLSClientCert(labels map[string]string) *types.TLSClientHeaders {
 	return tlsClientHeaders
 }
 
+// GetTLSClientCertAuth Create TLS client certificate authorization from labels
+func GetTLSClientCertAuth(labels map[string]string) *types.TLSClientCertAuth {
+	if !HasPrefix(labels, TraefikFrontendTLSClientCertAuth) {
+		return nil
+	}
+
+	return &types.TLSClientCertAuth{
+		CommonNames:         GetSliceStringValue(labels, TraefikFrontendTLSClientCertAuthCommonNames),
+		OrganizationalUnits: GetSliceStringValue(labels, TraefikFrontendTLSClientCertAuthOrganizationalUnits),
+		DNSNames:            GetSliceStringValue(labels, TraefikFrontendTLSClientCertAuthDNSNames),
+		URIs:                GetSliceStringValue(labels, TraefikFrontendTLSClientCertAuthURIs),
+		Issuers:             GetSliceStringValue(labels, TraefikFrontendTLSClientCertAuthIssuers),
+		Fingerprints:        GetSliceStringValue(labels, TraefikFrontendTLSClientCertAuthFingerprints),
+	}
+}
+
+// GetGeoIP Create GeoIP from labels
+func GetGeoIP(labels map[string]string) *types.GeoIP {
+	if !HasPrefix(labels, TraefikFrontendGeoIP) {
+		return nil
+	}
+
+	return &types.GeoIP{
+		CountryDatabase:  GetStringValue(labels, TraefikFrontendGeoIPCountryDatabase, ""),
+		ASNDatabase:      GetStringValue(labels, TraefikFrontendGeoIPASNDatabase, ""),
+		AllowedCountries: GetSliceStringValue(labels, TraefikFrontendGeoIPAllowedCountries),
+		DeniedCountries:  GetSliceStringValue(labels, TraefikFrontendGeoIPDeniedCountries),
+		AllowedASNs:      GetSliceStringValue(labels, TraefikFrontendGeoIPAllowedASNs),
+		DeniedASNs:       GetSliceStringValue(labels, TraefikFrontendGeoIPDeniedASNs),
+		AddHeaders:       GetBoolValue(labels, TraefikFrontendGeoIPAddHeaders, false),
+	}
+}
+
+// GetMaintenance Create maintenance from labels
+func GetMaintenance(labels map[string]string) *types.Maintenance {
+	if !HasPrefix(labels, TraefikFrontendMaintenance) {
+		return nil
+	}
+
+	return &types.Maintenance{
+		Enabled:           GetBoolValue(labels, TraefikFrontendMaintenanceEnabled, false),
+		StatusCode:        GetIntValue(labels, TraefikFrontendMaintenanceStatusCode, 0),
+		RetryAfter:        GetDurationValue(labels, TraefikFrontendMaintenanceRetryAfter, 0),
+		Page:              GetStringValue(labels, TraefikFrontendMaintenancePage, ""),
+		SourceRange:       GetSliceStringValue(labels, TraefikFrontendMaintenanceSourceRange),
+		BypassHeaderName:  GetStringValue(labels, TraefikFrontendMaintenanceBypassHeaderName, ""),
+		BypassHeaderValue: GetStringValue(labels, TraefikFrontendMaintenanceBypassHeaderValue, ""),
+	}
+}
+
+// GetFaultInjection Create fault injection from labels
+func GetFaultInjection(labels map[string]string) *types.FaultInjection {
+	if !HasPrefix(labels, TraefikFrontendFaultInjection) {
+		return nil
+	}
+
+	return &types.FaultInjection{
+		Enabled:           GetBoolValue(labels, TraefikFrontendFaultInjectionEnabled, false),
+		HeaderName:        GetStringValue(labels, TraefikFrontendFaultInjectionHeaderName, ""),
+		HeaderValue:       GetStringValue(labels, TraefikFrontendFaultInjectionHeaderValue, ""),
+		DelayPercentage:   GetFloat64Value(labels, TraefikFrontendFaultInjectionDelayPercentage, 0),
+		Delay:             GetDurationValue(labels, TraefikFrontendFaultInjectionDelay, 0),
+		DelayMax:          GetDurationValue(labels, TraefikFrontendFaultInjectionDelayMax, 0),
+		DelayDistribution: GetStringValue(labels, TraefikFrontendFaultInjectionDelayDistribution, ""),
+		AbortPercentage:   GetFloat64Value(labels, TraefikFrontendFaultInjectionAbortPercentage, 0),
+		AbortStatusCode:   GetIntValue(labels, TraefikFrontendFaultInjectionAbortStatusCode, 0),
+		AbortReset:        GetBoolValue(labels, TraefikFrontendFaultInjectionAbortReset, false),
+	}
+}
+
+// GetRequestID Create request ID from labels
+func GetRequestID(labels map[string]string) *types.RequestID {
+	if !HasPrefix(labels, TraefikFrontendRequestID) {
+		return nil
+	}
+
+	return &types.RequestID{
+		HeaderName: GetStringValue(labels, TraefikFrontendRequestIDHeaderName, ""),
+		Format:     GetStringValue(labels, TraefikFrontendRequestIDFormat, ""),
+		Insecure:   GetBoolValue(labels, TraefikFrontendRequestIDInsecure, false),
+		TrustedIPs: GetSliceStringValue(labels, TraefikFrontendRequestIDTrustedIPs),
+	}
+}
+
+// GetSizeLimits Create size limits from labels
+func GetSizeLimits(labels map[string]string) *types.SizeLimits {
+	if !HasPrefix(labels, TraefikFrontendSizeLimits) {
+		return nil
+	}
+
+	return &types.SizeLimits{
+		MaxRequestBodyBytes: GetInt64Value(labels, TraefikFrontendSizeLimitsMaxRequestBodyBytes, 0),
+		MaxHeaderCount:      GetIntValue(labels, TraefikFrontendSizeLimitsMaxHeaderCount, 0),
+		MaxHeaderBytes:      GetInt64Value(labels, TraefikFrontendSizeLimitsMaxHeaderBytes, 0),
+	}
+}
+
+// GetBodyRewrite Create body rewrite from labels
+func GetBodyRewrite(labels map[string]string) *types.BodyRewrite {
+	if !HasPrefix(labels, TraefikFrontendBodyRewrite) {
+		return nil
+	}
+
+	return &types.BodyRewrite{
+		ContentTypes: GetSliceStringValue(labels, TraefikFrontendBodyRewriteContentTypes),
+		MaxBodySize:  GetInt64Value(labels, TraefikFrontendBodyRewriteMaxBodySize, 0),
+		Replacements: ParseBodyReplacements(labels, Prefix+BaseFrontendBodyReplacement, RegexpFrontendBodyReplacement),
+	}
+}
+
+// ParseBodyReplacements parse body replacements to create BodyReplacement structs, ordered by replacement name
+func ParseBodyReplacements(labels map[string]string, labelPrefix string, labelRegex *regexp.Regexp) []types.BodyReplacement {
+	replacements := make(map[string]*types.BodyReplacement)
+
+	for lblName, value := range labels {
+		if strings.HasPrefix(lblName, labelPrefix) && len(value) > 0 {
+			submatch := labelRegex.FindStringSubmatch(lblName)
+			if len(submatch) != 3 {
+				log.Errorf("Invalid body replacement label: %s, sub-match: %v", lblName, submatch)
+				continue
+			}
+
+			replacementName := submatch[1]
+
+			replacement, ok := replacements[replacementName]
+			if !ok {
+				replacement = &types.BodyReplacement{}
+				replacements[replacementName] = replacement
+			}
+
+			switch submatch[2] {
+			case SuffixBodyReplacementSearch:
+				replacement.Search = value
+			case SuffixBodyReplacementRegex:
+				replacement.Regex = value
+			case SuffixBodyReplacementReplacement:
+				replacement.Replacement = value
+			default:
+				log.Errorf("Invalid syntax for body replacement: %s", lblName)
+				continue
+			}
+		}
+	}
+
+	if len(replacements) == 0 {
+		return nil
+	}
+
+	var names []string
+	for name := range replacements {
+		names = append(names, name)
+	}
+	sortNames(names)
+
+	var result []types.BodyReplacement
+	for _, name := range names {
+		result = append(result, *replacements[name])
+	}
+
+	return result
+}
+
 // GetAuth Create auth from labels
 func GetAuth(labels map[string]string) *types.Auth {
 	if !HasPrefix(labels, TraefikFrontendAuth) {
@@ -149,6 +337,19 @@ func getAuthForward(labels map[string]string) *types.Forward {
 	forwardAuth := &types.Forward{
 		Address:            GetStringValue(labels, TraefikFrontendAuthForwardAddress, ""),
 		TrustForwardHeader: GetBoolValue(labels, TraefikFrontendAuthForwardTrustForwardHeader, false),
+		AuthRequestHeaders: GetSliceStringValue(labels, TraefikFrontendAuthForwardAuthRequestHeaders),
+		Method:             GetStringValue(labels, TraefikFrontendAuthForwardMethod, ""),
+		ForwardBody:        GetBoolValue(labels, TraefikFrontendAuthForwardForwardBody, false),
+		MaxBodySize:        GetInt64Value(labels, TraefikFrontendAuthForwardMaxBodySize, 0),
+	}
+
+	// Cache configuration
+	if HasPrefix(labels, TraefikFrontendAuthForwardCache) {
+		forwardAuth.Cache = &types.ForwardCache{
+			KeyHeaders:  GetSliceStringValue(labels, TraefikFrontendAuthForwardCacheKeyHeaders),
+			TTL:         GetDurationValue(labels, TraefikFrontendAuthForwardCacheTTL, 0),
+			NegativeTTL: GetDurationValue(labels, TraefikFrontendAuthForwardCacheNegativeTTL, 0),
+		}
 	}
 
 	// TLS configuration
@@ -202,6 +403,12 @@ func ParseErrorPages(labels map[string]string, labelPrefix string, labelRegex *r
 				ep.Query = value
 			case SuffixErrorPageBackend:
 				ep.Backend = value
+			case SuffixErrorPageFile:
+				ep.File = value
+			case SuffixErrorPageBody:
+				ep.Body = value
+			case SuffixErrorPageContentType:
+				ep.ContentType = value
 			default:
 				log.Errorf("Invalid page error label: %s", lblName)
 				continue
@@ -223,8 +430,13 @@ func GetRateLimit(labels map[string]string) *types.RateLimit {
 	limits := ParseRateSets(labels, prefix, RegexpFrontendRateLimit)
 
 	return &types.RateLimit{
-		ExtractorFunc: extractorFunc,
-		RateSet:       limits,
+		ExtractorFunc:     extractorFunc,
+		RateSet:           limits,
+		Distributed:       GetBoolValue(labels, TraefikFrontendRateLimitDistributed, false),
+		StatusCode:        GetIntValue(labels, TraefikFrontendRateLimitStatusCode, 0),
+		Body:              GetStringValue(labels, TraefikFrontendRateLimitBody, ""),
+		ContentType:       GetStringValue(labels, TraefikFrontendRateLimitContentType, ""),
+		ExemptSourceRange: GetSliceStringValue(labels, TraefikFrontendRateLimitExemptSourceRange),
 	}
 }
 
@@ -289,6 +501,8 @@ func GetHeaders(labels map[string]string) *types.Headers {
 	headers := &types.Headers{
 		CustomRequestHeaders:    GetMapValue(labels, TraefikFrontendRequestHeaders),
 		CustomResponseHeaders:   GetMapValue(labels, TraefikFrontendResponseHeaders),
+		RequestOperations:       ParseHeaderOperations(labels, Prefix+BaseFrontendRequestOperation, RegexpFrontendRequestOperation),
+		ResponseOperations:      ParseHeaderOperations(labels, Prefix+BaseFrontendResponseOperation, RegexpFrontendResponseOperation),
 		SSLProxyHeaders:         GetMapValue(labels, TraefikFrontendSSLProxyHeaders),
 		AllowedHosts:            GetSliceStringValue(labels, TraefikFrontendAllowedHosts),
 		HostsProxyHeaders:       GetSliceStringValue(labels, TraefikFrontendHostsProxyHeaders),
@@ -318,6 +532,62 @@ func GetHeaders(labels map[string]string) *types.Headers {
 	return headers
 }
 
+// ParseHeaderOperations parse header operations to create HeaderOperation structs, ordered by operation name
+func ParseHeaderOperations(labels map[string]string, labelPrefix string, labelRegex *regexp.Regexp) []types.HeaderOperation {
+	operations := make(map[string]*types.HeaderOperation)
+
+	for lblName, value := range labels {
+		if strings.HasPrefix(lblName, labelPrefix) && len(value) > 0 {
+			submatch := labelRegex.FindStringSubmatch(lblName)
+			if len(submatch) != 3 {
+				log.Errorf("Invalid header operation label: %s, sub-match: %v", lblName, submatch)
+				continue
+			}
+
+			operationName := submatch[1]
+
+			op, ok := operations[operationName]
+			if !ok {
+				op = &types.HeaderOperation{}
+				operations[operationName] = op
+			}
+
+			switch submatch[2] {
+			case SuffixHeaderOperationAction:
+				op.Action = value
+			case SuffixHeaderOperationName:
+				op.Name = value
+			case SuffixHeaderOperationValue:
+				op.Value = value
+			case SuffixHeaderOperationRegex:
+				op.Regex = value
+			case SuffixHeaderOperationStatusCodes:
+				op.StatusCodes = SplitAndTrimString(value, ",")
+			default:
+				log.Errorf("Invalid syntax for header operation: %s", lblName)
+				continue
+			}
+		}
+	}
+
+	if len(operations) == 0 {
+		return nil
+	}
+
+	var names []string
+	for name := range operations {
+		names = append(names, name)
+	}
+	sortNames(names)
+
+	var result []types.HeaderOperation
+	for _, name := range names {
+		result = append(result, *operations[name])
+	}
+
+	return result
+}
+
 // GetMaxConn Create max connection from labels
 func GetMaxConn(labels map[string]string) *types.MaxConn {
 	amount := GetInt64Value(labels, TraefikBackendMaxConnAmount, math.MinInt64)
@@ -373,6 +643,17 @@ func GetBuffering(labels map[string]string) *types.Buffering {
 	}
 }
 
+// GetProxyProtocol Create PROXY protocol from labels
+func GetProxyProtocol(labels map[string]string) *types.ProxyProtocol {
+	if !Has(labels, TraefikBackendProxyProtocolVersion) {
+		return nil
+	}
+
+	return &types.ProxyProtocol{
+		Version: GetIntValue(labels, TraefikBackendProxyProtocolVersion, 1),
+	}
+}
+
 // GetCircuitBreaker Create circuit breaker from labels
 func GetCircuitBreaker(labels map[string]string) *types.CircuitBreaker {
 	circuitBreaker := GetStringValue(labels, TraefikBackendCircuitBreakerExpression, "")
@@ -401,3 +682,23 @@ func GetLoadBalancer(labels map[string]string) *types.LoadBalancer {
 
 	return lb
 }
+
+// sortNames sorts the names of ordered labels: the numeric names by value (2 before 10),
+// before the other names in lexicographic order.
+func sortNames(names []string) {
+	sort.Slice(names, func(i, j int) bool {
+		a, errA := strconv.Atoi(names[i])
+		b, errB := strconv.Atoi(names[j])
+
+		switch {
+		case errA == nil && errB == nil && a != b:
+			return a < b
+		case errA == nil && errB != nil:
+			return true
+		case errA != nil && errB == nil:
+			return false
+		default:
+			return names[i] < names[j]
+		}
+	})
+}
@@ -38,6 +38,27 @@ func TestParseErrorPages(t *testing.T) {
 				},
 			},
 		},
+		{
+			desc: "template error page",
+			labels: map[string]string{
+				Prefix + BaseFrontendErrorPage + "foo." + SuffixErrorPageStatus:      "500-599",
+				Prefix + BaseFrontendErrorPage + "foo." + SuffixErrorPageBody:        `{"status": {{ .StatusCode }}}`,
+				Prefix + BaseFrontendErrorPage + "foo." + SuffixErrorPageContentType: "application/json",
+				Prefix + BaseFrontendErrorPage + "bar." + SuffixErrorPageStatus:      "404",
+				Prefix + BaseFrontendErrorPage + "bar." + SuffixErrorPageFile:        "/pages/404.html",
+			},
+			expected: map[string]*types.ErrorPage{
+				"foo": {
+					Status:      []string{"500-599"},
+					Body:        `{"status": {{ .StatusCode }}}`,
+					ContentType: "application/json",
+				},
+				"bar": {
+					Status: []string{"404"},
+					File:   "/pages/404.html",
+				},
+			},
+		},
 		{
 			desc: "only status field",
 			labels: map[string]string{
@@ -123,6 +144,84 @@ func TestParseRateSets(t *testing.T) {
 	}
 }
 
+func TestParseHeaderOperations(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		labels   map[string]string
+		expected []types.HeaderOperation
+	}{
+		{
+			desc: "2 operations ordered by name",
+			labels: map[string]string{
+				Prefix + BaseFrontendResponseOperation + "2." + SuffixHeaderOperationAction:      "set",
+				Prefix + BaseFrontendResponseOperation + "2." + SuffixHeaderOperationName:        "Cache-Control",
+				Prefix + BaseFrontendResponseOperation + "2." + SuffixHeaderOperationValue:       "no-store",
+				Prefix + BaseFrontendResponseOperation + "2." + SuffixHeaderOperationStatusCodes: "500-599, 404",
+				Prefix + BaseFrontendResponseOperation + "1." + SuffixHeaderOperationAction:      "replace",
+				Prefix + BaseFrontendResponseOperation + "1." + SuffixHeaderOperationName:        "Location",
+				Prefix + BaseFrontendResponseOperation + "1." + SuffixHeaderOperationRegex:       "^http://(.*)",
+				Prefix + BaseFrontendResponseOperation + "1." + SuffixHeaderOperationValue:       "https://$1",
+			},
+			expected: []types.HeaderOperation{
+				{
+					Action: "replace",
+					Name:   "Location",
+					Regex:  "^http://(.*)",
+					Value:  "https://$1",
+				},
+				{
+					Action:      "set",
+					Name:        "Cache-Control",
+					Value:       "no-store",
+					StatusCodes: []string{"500-599", "404"},
+				},
+			},
+		},
+		{
+			desc: "numeric names ordered by value, before the other names",
+			labels: map[string]string{
+				Prefix + BaseFrontendResponseOperation + "10." + SuffixHeaderOperationAction:    "remove",
+				Prefix + BaseFrontendResponseOperation + "10." + SuffixHeaderOperationName:      "X-Ten",
+				Prefix + BaseFrontendResponseOperation + "2." + SuffixHeaderOperationAction:     "remove",
+				Prefix + BaseFrontendResponseOperation + "2." + SuffixHeaderOperationName:       "X-Two",
+				Prefix + BaseFrontendResponseOperation + "last." + SuffixHeaderOperationAction:  "remove",
+				Prefix + BaseFrontendResponseOperation + "last." + SuffixHeaderOperationName:    "X-Last",
+				Prefix + BaseFrontendResponseOperation + "cache." + SuffixHeaderOperationAction: "remove",
+				Prefix + BaseFrontendResponseOperation + "cache." + SuffixHeaderOperationName:   "X-Cache",
+			},
+			expected: []types.HeaderOperation{
+				{Action: "remove", Name: "X-Two"},
+				{Action: "remove", Name: "X-Ten"},
+				{Action: "remove", Name: "X-Cache"},
+				{Action: "remove", Name: "X-Last"},
+			},
+		},
+		{
+			desc: "invalid field",
+			labels: map[string]string{
+				Prefix + BaseFrontendResponseOperation + "foo." + "courgette": "404",
+			},
+			expected: []types.HeaderOperation{{}},
+		},
+		{
+			desc:     "no header operations labels",
+			labels:   map[string]string{},
+			expected: nil,
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			operations := ParseHeaderOperations(test.labels, Prefix+BaseFrontendResponseOperation, RegexpFrontendResponseOperation)
+
+			assert.EqualValues(t, test.expected, operations)
+		})
+	}
+}
+
 func TestWhiteList(t *testing.T) {
 	testCases := []struct {
 		desc     string
@@ -213,6 +312,19 @@ func TestWhiteList(t *testing.T) {
 				},
 			},
 		},
+		{
+			desc: "should return a struct when only deny list and IP sets",
+			labels: map[string]string{
+				TraefikFrontendWhiteListSourceSets: "offices",
+				TraefikFrontendWhiteListDenyRange:  "10.10.10.10",
+				TraefikFrontendWhiteListDenySets:   "abuse,tor",
+			},
+			expected: &types.WhiteList{
+				SourceSets: []string{"offices"},
+				DenyRange:  []string{"10.10.10.10"},
+				DenySets:   []string{"abuse", "tor"},
+			},
+		},
 	}
 
 	for _, test := range testCases {
@@ -260,6 +372,49 @@ func TestGetCircuitBreaker(t *testing.T) {
 	}
 }
 
+func TestGetProxyProtocol(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		labels   map[string]string
+		expected *types.ProxyProtocol
+	}{
+		{
+			desc:     "should return nil when no PROXY protocol label",
+			labels:   map[string]string{},
+			expected: nil,
+		},
+		{
+			desc: "should return a struct when PROXY protocol label is set",
+			labels: map[string]string{
+				TraefikBackendProxyProtocolVersion: "2",
+			},
+			expected: &types.ProxyProtocol{
+				Version: 2,
+			},
+		},
+		{
+			desc: "should use the version 1 when the label is invalid",
+			labels: map[string]string{
+				TraefikBackendProxyProtocolVersion: "v2",
+			},
+			expected: &types.ProxyProtocol{
+				Version: 1,
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			actual := GetProxyProtocol(test.labels)
+
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
+
 func TestGetLoadBalancer(t *testing.T) {
 	testCases := []struct {
 		desc     string
@@ -530,6 +685,52 @@ func TestGetRedirect(t *testing.T) {
 				Permanent:   true,
 			},
 		},
+		{
+			desc: "should return a struct when entry point redirect label (status code)",
+			labels: map[string]string{
+				TraefikFrontendRedirectEntryPoint: "https",
+				TraefikFrontendRedirectStatusCode: "308",
+			},
+			expected: &types.Redirect{
+				EntryPoint: "https",
+				StatusCode: 308,
+			},
+		},
+		{
+			desc: "should return a struct when URL components redirect labels",
+			labels: map[string]string{
+				TraefikFrontendRedirectScheme:     "https",
+				TraefikFrontendRedirectHost:       "foo.com",
+				TraefikFrontendRedirectPort:       "8443",
+				TraefikFrontendRedirectPathPrefix: "/app",
+				TraefikFrontendRedirectDropQuery:  "true",
+				TraefikFrontendRedirectStatusCode: "307",
+			},
+			expected: &types.Redirect{
+				Scheme:     "https",
+				Host:       "foo.com",
+				Port:       "8443",
+				PathPrefix: "/app",
+				DropQuery:  true,
+				StatusCode: 307,
+			},
+		},
+		{
+			desc: "should return a struct when app root redirect label",
+			labels: map[string]string{
+				TraefikFrontendRedirectAppRoot: "/app",
+			},
+			expected: &types.Redirect{
+				AppRoot: "/app",
+			},
+		},
+		{
+			desc: "should return nil when only permanent label",
+			labels: map[string]string{
+				TraefikFrontendRedirectPermanent: "true",
+			},
+			expected: nil,
+		},
 	}
 
 	for _, test := range testCases {
@@ -582,6 +783,52 @@ func TestGetRateLimit(t *testing.T) {
 				},
 			},
 		},
+		{
+			desc: "should return a distributed rate limit",
+			labels: map[string]string{
+				TraefikFrontendRateLimitExtractorFunc:                            "client.ip",
+				TraefikFrontendRateLimitDistributed:                              "true",
+				Prefix + BaseFrontendRateLimit + "foo." + SuffixRateLimitPeriod:  "6",
+				Prefix + BaseFrontendRateLimit + "foo." + SuffixRateLimitAverage: "12",
+			},
+			expected: &types.RateLimit{
+				ExtractorFunc: "client.ip",
+				Distributed:   true,
+				RateSet: map[string]*types.Rate{
+					"foo": {
+						Period:  parse.Duration(6 * time.Second),
+						Average: 12,
+					},
+				},
+			},
+		},
+		{
+			desc: "should return a rate limit with a custom rejection and exemptions",
+			labels: map[string]string{
+				TraefikFrontendRateLimitExtractorFunc:                            "client.ip,request.jwt.sub",
+				TraefikFrontendRateLimitStatusCode:                               "503",
+				TraefikFrontendRateLimitBody:                                     `{"error":"slow down"}`,
+				TraefikFrontendRateLimitContentType:                              "application/json",
+				TraefikFrontendRateLimitExemptSourceRange:                        "10.0.0.0/8, 192.168.0.1",
+				Prefix + BaseFrontendRateLimit + "foo." + SuffixRateLimitPeriod:  "6",
+				Prefix + BaseFrontendRateLimit + "foo." + SuffixRateLimitAverage: "12",
+				Prefix + BaseFrontendRateLimit + "foo." + SuffixRateLimitBurst:   "18",
+			},
+			expected: &types.RateLimit{
+				ExtractorFunc:     "client.ip,request.jwt.sub",
+				StatusCode:        503,
+				Body:              `{"error":"slow down"}`,
+				ContentType:       "application/json",
+				ExemptSourceRange: []string{"10.0.0.0/8", "192.168.0.1"},
+				RateSet: map[string]*types.Rate{
+					"foo": {
+						Period:  parse.Duration(6 * time.Second),
+						Average: 12,
+						Burst:   18,
+					},
+				},
+			},
+		},
 		{
 			desc: "should return nil when ExtractorFunc is missing",
 			labels: map[string]string{
@@ -806,6 +1053,33 @@ func TestGetAuth(t *testing.T) {
 				},
 			},
 		},
+		{
+			desc: "should return a forward auth with body and cache",
+			labels: map[string]string{
+				TraefikFrontendAuthForwardAddress:            "myAddress",
+				TraefikFrontendAuthForwardAuthRequestHeaders: "Authorization, X-Signature",
+				TraefikFrontendAuthForwardMethod:             "POST",
+				TraefikFrontendAuthForwardForwardBody:        "true",
+				TraefikFrontendAuthForwardMaxBodySize:        "2048",
+				TraefikFrontendAuthForwardCacheKeyHeaders:    "Authorization",
+				TraefikFrontendAuthForwardCacheTTL:           "30s",
+				TraefikFrontendAuthForwardCacheNegativeTTL:   "5s",
+			},
+			expected: &types.Auth{
+				Forward: &types.Forward{
+					Address:            "myAddress",
+					AuthRequestHeaders: []string{"Authorization", "X-Signature"},
+					Method:             "POST",
+					ForwardBody:        true,
+					MaxBodySize:        2048,
+					Cache: &types.ForwardCache{
+						KeyHeaders:  []string{"Authorization"},
+						TTL:         parse.Duration(30 * time.Second),
+						NegativeTTL: parse.Duration(5 * time.Second),
+					},
+				},
+			},
+		},
 	}
 
 	for _, test := range testCases {
@@ -819,6 +1093,318 @@ func TestGetAuth(t *testing.T) {
 		})
 	}
 }
+
+func TestGetTLSClientCertAuth(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		labels   map[string]string
+		expected *types.TLSClientCertAuth
+	}{
+		{
+			desc:     "should return nil when no tags",
+			labels:   map[string]string{},
+			expected: nil,
+		},
+		{
+			desc: "should return a TLS client certificate authorization",
+			labels: map[string]string{
+				TraefikFrontendTLSClientCertAuthCommonNames:         "client1.example.org,*.example.com",
+				TraefikFrontendTLSClientCertAuthOrganizationalUnits: "payments",
+				TraefikFrontendTLSClientCertAuthDNSNames:            "billing.example.org",
+				TraefikFrontendTLSClientCertAuthURIs:                "spiffe://example.org/ns/prod/*",
+				TraefikFrontendTLSClientCertAuthIssuers:             "Internal CA",
+				TraefikFrontendTLSClientCertAuthFingerprints:        "ab:cd:ef",
+			},
+			expected: &types.TLSClientCertAuth{
+				CommonNames:         []string{"client1.example.org", "*.example.com"},
+				OrganizationalUnits: []string{"payments"},
+				DNSNames:            []string{"billing.example.org"},
+				URIs:                []string{"spiffe://example.org/ns/prod/*"},
+				Issuers:             []string{"Internal CA"},
+				Fingerprints:        []string{"ab:cd:ef"},
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			result := GetTLSClientCertAuth(test.labels)
+
+			assert.Equal(t, test.expected, result)
+		})
+	}
+}
+
+func TestGetGeoIP(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		labels   map[string]string
+		expected *types.GeoIP
+	}{
+		{
+			desc:     "should return nil when no tags",
+			labels:   map[string]string{},
+			expected: nil,
+		},
+		{
+			desc: "should return a GeoIP",
+			labels: map[string]string{
+				TraefikFrontendGeoIPCountryDatabase:  "/foo/country.mmdb",
+				TraefikFrontendGeoIPASNDatabase:      "/foo/asn.mmdb",
+				TraefikFrontendGeoIPAllowedCountries: "FR,DE",
+				TraefikFrontendGeoIPDeniedCountries:  "KP",
+				TraefikFrontendGeoIPAllowedASNs:      "AS3215",
+				TraefikFrontendGeoIPDeniedASNs:       "AS64496,64497",
+				TraefikFrontendGeoIPAddHeaders:       "true",
+			},
+			expected: &types.GeoIP{
+				CountryDatabase:  "/foo/country.mmdb",
+				ASNDatabase:      "/foo/asn.mmdb",
+				AllowedCountries: []string{"FR", "DE"},
+				DeniedCountries:  []string{"KP"},
+				AllowedASNs:      []string{"AS3215"},
+				DeniedASNs:       []string{"AS64496", "64497"},
+				AddHeaders:       true,
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			result := GetGeoIP(test.labels)
+
+			assert.Equal(t, test.expected, result)
+		})
+	}
+}
+
+func TestGetMaintenance(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		labels   map[string]string
+		expected *types.Maintenance
+	}{
+		{
+			desc:     "should return nil when no tags",
+			labels:   map[string]string{},
+			expected: nil,
+		},
+		{
+			desc: "should return a maintenance",
+			labels: map[string]string{
+				TraefikFrontendMaintenanceEnabled:           "true",
+				TraefikFrontendMaintenanceStatusCode:        "200",
+				TraefikFrontendMaintenanceRetryAfter:        "10m",
+				TraefikFrontendMaintenancePage:              "/foo/maintenance.html",
+				TraefikFrontendMaintenanceSourceRange:       "10.0.0.0/8,192.168.1.1",
+				TraefikFrontendMaintenanceBypassHeaderName:  "X-Bypass",
+				TraefikFrontendMaintenanceBypassHeaderValue: "secret",
+			},
+			expected: &types.Maintenance{
+				Enabled:           true,
+				StatusCode:        200,
+				RetryAfter:        parse.Duration(10 * time.Minute),
+				Page:              "/foo/maintenance.html",
+				SourceRange:       []string{"10.0.0.0/8", "192.168.1.1"},
+				BypassHeaderName:  "X-Bypass",
+				BypassHeaderValue: "secret",
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			result := GetMaintenance(test.labels)
+
+			assert.Equal(t, test.expected, result)
+		})
+	}
+}
+
+func TestGetFaultInjection(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		labels   map[string]string
+		expected *types.FaultInjection
+	}{
+		{
+			desc:     "should return nil when no tags",
+			labels:   map[string]string{},
+			expected: nil,
+		},
+		{
+			desc: "should return a fault injection",
+			labels: map[string]string{
+				TraefikFrontendFaultInjectionEnabled:           "true",
+				TraefikFrontendFaultInjectionHeaderName:        "X-Chaos",
+				TraefikFrontendFaultInjectionHeaderValue:       "on",
+				TraefikFrontendFaultInjectionDelayPercentage:   "12.5",
+				TraefikFrontendFaultInjectionDelay:             "100ms",
+				TraefikFrontendFaultInjectionDelayMax:          "2s",
+				TraefikFrontendFaultInjectionDelayDistribution: "exponential",
+				TraefikFrontendFaultInjectionAbortPercentage:   "5",
+				TraefikFrontendFaultInjectionAbortStatusCode:   "502",
+				TraefikFrontendFaultInjectionAbortReset:        "true",
+			},
+			expected: &types.FaultInjection{
+				Enabled:           true,
+				HeaderName:        "X-Chaos",
+				HeaderValue:       "on",
+				DelayPercentage:   12.5,
+				Delay:             parse.Duration(100 * time.Millisecond),
+				DelayMax:          parse.Duration(2 * time.Second),
+				DelayDistribution: "exponential",
+				AbortPercentage:   5,
+				AbortStatusCode:   502,
+				AbortReset:        true,
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			result := GetFaultInjection(test.labels)
+
+			assert.Equal(t, test.expected, result)
+		})
+	}
+}
+
+func TestGetRequestID(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		labels   map[string]string
+		expected *types.RequestID
+	}{
+		{
+			desc:     "should return nil when no tags",
+			labels:   map[string]string{},
+			expected: nil,
+		},
+		{
+			desc: "should return request ID",
+			labels: map[string]string{
+				TraefikFrontendRequestIDHeaderName: "X-Correlation-Id",
+				TraefikFrontendRequestIDFormat:     "ulid",
+				TraefikFrontendRequestIDInsecure:   "true",
+				TraefikFrontendRequestIDTrustedIPs: "10.0.0.0/8,192.168.0.1",
+			},
+			expected: &types.RequestID{
+				HeaderName: "X-Correlation-Id",
+				Format:     "ulid",
+				Insecure:   true,
+				TrustedIPs: []string{"10.0.0.0/8", "192.168.0.1"},
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			result := GetRequestID(test.labels)
+
+			assert.Equal(t, test.expected, result)
+		})
+	}
+}
+
+func TestGetSizeLimits(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		labels   map[string]string
+		expected *types.SizeLimits
+	}{
+		{
+			desc:     "should return nil when no tags",
+			labels:   map[string]string{},
+			expected: nil,
+		},
+		{
+			desc: "should return size limits",
+			labels: map[string]string{
+				TraefikFrontendSizeLimitsMaxRequestBodyBytes: "10485760",
+				TraefikFrontendSizeLimitsMaxHeaderCount:      "100",
+				TraefikFrontendSizeLimitsMaxHeaderBytes:      "8192",
+			},
+			expected: &types.SizeLimits{
+				MaxRequestBodyBytes: 10485760,
+				MaxHeaderCount:      100,
+				MaxHeaderBytes:      8192,
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			result := GetSizeLimits(test.labels)
+
+			assert.Equal(t, test.expected, result)
+		})
+	}
+}
+
+func TestGetBodyRewrite(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		labels   map[string]string
+		expected *types.BodyRewrite
+	}{
+		{
+			desc:     "should return nil when no tags",
+			labels:   map[string]string{},
+			expected: nil,
+		},
+		{
+			desc: "should return a body rewrite with ordered replacements",
+			labels: map[string]string{
+				TraefikFrontendBodyRewriteContentTypes:                                          "text/html,application/json",
+				TraefikFrontendBodyRewriteMaxBodySize:                                           "2048",
+				Prefix + BaseFrontendBodyReplacement + "10." + SuffixBodyReplacementSearch:      "foo",
+				Prefix + BaseFrontendBodyReplacement + "10." + SuffixBodyReplacementReplacement: "bar",
+				Prefix + BaseFrontendBodyReplacement + "9." + SuffixBodyReplacementRegex:        `href="/`,
+				Prefix + BaseFrontendBodyReplacement + "9." + SuffixBodyReplacementReplacement:  `href="{{ .Prefix }}/`,
+				Prefix + BaseFrontendBodyReplacement + "9.foo":                                  "bar",
+			},
+			expected: &types.BodyRewrite{
+				ContentTypes: []string{"text/html", "application/json"},
+				MaxBodySize:  2048,
+				Replacements: []types.BodyReplacement{
+					{Regex: `href="/`, Replacement: `href="{{ .Prefix }}/`},
+					{Search: "foo", Replacement: "bar"},
+				},
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			result := GetBodyRewrite(test.labels)
+
+			assert.Equal(t, test.expected, result)
+		})
+	}
+}
+
 func TestGetPassTLSClientCert(t *testing.T) {
 	testCases := []struct {
 		desc     string
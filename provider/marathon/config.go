@@ -54,9 +54,21 @@ func (p *Provider) buildConfiguration(applications *marathon.Applications) *type
 		"getRateLimit":         label.GetRateLimit,
 		"getHeaders":           label.GetHeaders,
 		"getWhiteList":         label.GetWhiteList,
+		"getTLSClientCertAuth": label.GetTLSClientCertAuth,
+		"getGeoIP":             label.GetGeoIP,
+		"getBodyRewrite":       label.GetBodyRewrite,
+		"getMaintenance":       label.GetMaintenance,
+		"getFaultInjection":    label.GetFaultInjection,
+		"getSizeLimits":        label.GetSizeLimits,
+		"getRequestID":         label.GetRequestID,
+
+		// UDP functions
+		"getUDPEntryPoints": getUDPEntryPoints,
+		"getUDPServers":     p.getUDPServers,
 	}
 
 	apps := make(map[string]*appData)
+	udpApps := make(map[string]marathon.Application)
 	for _, app := range applications.Apps {
 		if p.applicationFilter(app) {
 			// Tasks
@@ -70,6 +82,10 @@ func (p *Provider) buildConfiguration(applications *marathon.Applications) *type
 
 			app.Tasks = filteredTasks
 
+			if label.Has(stringValueMap(app.Labels), label.TraefikUDPEntryPoints) {
+				udpApps[provider.Normalize(app.ID)] = app
+			}
+
 			// segments
 			segmentProperties := label.ExtractTraefikLabels(stringValueMap(app.Labels))
 			for segmentName, labels := range segmentProperties {
@@ -90,11 +106,13 @@ func (p *Provider) buildConfiguration(applications *marathon.Applications) *type
 	}
 
 	templateObjects := struct {
-		Applications map[string]*appData
-		Domain       string
+		Applications    map[string]*appData
+		UDPApplications map[string]marathon.Application
+		Domain          string
 	}{
-		Applications: apps,
-		Domain:       p.Domain,
+		Applications:    apps,
+		UDPApplications: udpApps,
+		Domain:          p.Domain,
 	}
 
 	configuration, err := p.GetConfiguration("templates/marathon.tmpl", MarathonFuncMap, templateObjects)
@@ -346,6 +364,46 @@ func (p *Provider) getServer(app appData, task marathon.Task) (string, *types.Se
 	}, nil
 }
 
+func getUDPEntryPoints(app marathon.Application) []string {
+	return label.GetSliceStringValue(stringValueMap(app.Labels), label.TraefikUDPEntryPoints)
+}
+
+// getUDPServers returns the UDP servers of the tasks of the application, listening on the port of the traefik.udp.serverPort label,
+// or on the port selected for the HTTP servers.
+func (p *Provider) getUDPServers(app marathon.Application) map[string]types.UDPServer {
+	appLabels := stringValueMap(app.Labels)
+
+	var servers map[string]types.UDPServer
+	for _, task := range app.Tasks {
+		host, err := p.getServerHost(*task, appData{Application: app})
+		if len(host) == 0 {
+			log.Errorf("Unable to find the host of the UDP server of %s: %v", identifier(app, *task, ""), err)
+			continue
+		}
+
+		port := label.GetStringValue(appLabels, label.TraefikUDPServerPort, "")
+		if len(port) == 0 {
+			taskPort, err := processPorts(app, *task, appLabels)
+			if err != nil {
+				log.Errorf("Unable to find the UDP port of %s: %v", identifier(app, *task, ""), err)
+				continue
+			}
+			port = strconv.Itoa(taskPort)
+		}
+
+		if servers == nil {
+			servers = make(map[string]types.UDPServer)
+		}
+
+		servers[provider.Normalize("server-"+app.ID+"-"+task.ID)] = types.UDPServer{
+			Address: net.JoinHostPort(host, port),
+			Weight:  label.GetIntValue(appLabels, label.TraefikWeight, label.DefaultWeight),
+		}
+	}
+
+	return servers
+}
+
 func (p *Provider) getServerHost(task marathon.Task, app appData) (string, error) {
 	networks := app.Networks
 	var hostFlag bool
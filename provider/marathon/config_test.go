@@ -10,6 +10,7 @@ import (
 	"github.com/containous/traefik/types"
 	"github.com/gambol99/go-marathon"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestGetConfigurationAPIErrors(t *testing.T) {
@@ -1053,6 +1054,67 @@ func TestBuildConfigurationSegments(t *testing.T) {
 	}
 }
 
+func TestBuildConfigurationUDP(t *testing.T) {
+	applications := withApplications(
+		application(
+			appID("/dns"),
+			appPorts(53),
+			withLabel(label.TraefikUDPEntryPoints, "dns"),
+			withLabel(label.TraefikWeight, "2"),
+			withTasks(
+				task(withTaskID("task1"), host("10.0.0.1"), taskPorts(31053)),
+				task(withTaskID("task2"), host("10.0.0.2"), taskPorts(32053)),
+			),
+		),
+		application(
+			appID("/infra/syslog"),
+			withLabel(label.TraefikUDPEntryPoints, "syslog,logs"),
+			withLabel(label.TraefikUDPServerPort, "514"),
+			withTasks(task(host("10.0.0.3"))),
+		),
+		application(
+			appID("/web"),
+			appPorts(80),
+			withTasks(localhostTask(taskPorts(80))),
+		),
+	)
+
+	p := &Provider{
+		Domain:           "marathon.localhost",
+		ExposedByDefault: true,
+	}
+
+	actualConfig := p.buildConfiguration(applications)
+	require.NotNil(t, actualConfig)
+
+	expectedFrontends := map[string]*types.UDPFrontend{
+		"udp-frontend-dns": {
+			EntryPoints: []string{"dns"},
+			Backend:     "udp-backend-dns",
+		},
+		"udp-frontend-infra-syslog": {
+			EntryPoints: []string{"syslog", "logs"},
+			Backend:     "udp-backend-infra-syslog",
+		},
+	}
+	assert.Equal(t, expectedFrontends, actualConfig.UDPFrontends)
+
+	expectedBackends := map[string]*types.UDPBackend{
+		"udp-backend-dns": {
+			Servers: map[string]types.UDPServer{
+				"server-dns-task1": {Address: "10.0.0.1:31053", Weight: 2},
+				"server-dns-task2": {Address: "10.0.0.2:32053", Weight: 2},
+			},
+		},
+		"udp-backend-infra-syslog": {
+			Servers: map[string]types.UDPServer{
+				"server-infra-syslog-taskID": {Address: "10.0.0.3:514", Weight: 1},
+			},
+		},
+	}
+	assert.Equal(t, expectedBackends, actualConfig.UDPBackends)
+}
+
 func TestApplicationFilterConstraints(t *testing.T) {
 	testCases := []struct {
 		desc                      string
@@ -55,6 +55,13 @@ func (p *Provider) buildConfiguration(tasks []state.Task) *types.Configuration {
 		"getRateLimit":         label.GetRateLimit,
 		"getHeaders":           label.GetHeaders,
 		"getWhiteList":         label.GetWhiteList,
+		"getTLSClientCertAuth": label.GetTLSClientCertAuth,
+		"getGeoIP":             label.GetGeoIP,
+		"getBodyRewrite":       label.GetBodyRewrite,
+		"getMaintenance":       label.GetMaintenance,
+		"getFaultInjection":    label.GetFaultInjection,
+		"getSizeLimits":        label.GetSizeLimits,
+		"getRequestID":         label.GetRequestID,
 	}
 
 	appsTasks := p.filterTasks(tasks)
@@ -42,6 +42,17 @@ func (p *Provider) buildConfiguration(services []rancherData) *types.Configurati
 		"getRedirect":          label.GetRedirect,
 		"getHeaders":           label.GetHeaders,
 		"getWhiteList":         label.GetWhiteList,
+		"getTLSClientCertAuth": label.GetTLSClientCertAuth,
+		"getGeoIP":             label.GetGeoIP,
+		"getBodyRewrite":       label.GetBodyRewrite,
+		"getMaintenance":       label.GetMaintenance,
+		"getFaultInjection":    label.GetFaultInjection,
+		"getSizeLimits":        label.GetSizeLimits,
+		"getRequestID":         label.GetRequestID,
+
+		// UDP functions
+		"getUDPEntryPoints": label.GetFuncSliceString(label.TraefikUDPEntryPoints),
+		"getUDPServers":     getUDPServers,
 	}
 
 	// filter services
@@ -63,14 +74,21 @@ func (p *Provider) buildConfiguration(services []rancherData) *types.Configurati
 		}
 	}
 
+	udpServices := map[string]rancherData{}
+	for _, service := range fun.Filter(p.udpServiceFilter, services).([]rancherData) {
+		udpServices[provider.Normalize(service.Name)] = service
+	}
+
 	templateObjects := struct {
-		Frontends map[string]rancherData
-		Backends  map[string]rancherData
-		Domain    string
+		Frontends   map[string]rancherData
+		Backends    map[string]rancherData
+		UDPServices map[string]rancherData
+		Domain      string
 	}{
-		Frontends: frontends,
-		Backends:  backends,
-		Domain:    p.Domain,
+		Frontends:   frontends,
+		Backends:    backends,
+		UDPServices: udpServices,
+		Domain:      p.Domain,
 	}
 
 	configuration, err := p.GetConfiguration("templates/rancher.tmpl", RancherFuncMap, templateObjects)
@@ -102,6 +120,29 @@ func (p *Provider) serviceFilter(service rancherData) bool {
 		return false
 	}
 
+	return p.constraintsAndHealthFilter(service)
+}
+
+// udpServiceFilter keeps the services declaring a UDP service, which do not need any HTTP port or frontend rule.
+func (p *Provider) udpServiceFilter(service rancherData) bool {
+	if !label.Has(service.Labels, label.TraefikUDPEntryPoints) {
+		return false
+	}
+
+	if !label.Has(service.Labels, label.TraefikUDPServerPort) {
+		log.Debugf("Filtering UDP service %s without %s label", service.Name, label.TraefikUDPServerPort)
+		return false
+	}
+
+	if !label.IsEnabled(service.Labels, p.ExposedByDefault) {
+		log.Debugf("Filtering disabled service %s", service.Name)
+		return false
+	}
+
+	return p.constraintsAndHealthFilter(service)
+}
+
+func (p *Provider) constraintsAndHealthFilter(service rancherData) bool {
 	constraintTags := label.GetSliceStringValue(service.Labels, label.TraefikTags)
 	if ok, failingConstraint := p.MatchConstraints(constraintTags); !ok {
 		if failingConstraint != nil {
@@ -192,6 +233,32 @@ func getServers(service rancherData) map[string]types.Server {
 	return servers
 }
 
+// getUDPServers returns the UDP servers of the containers of the service, listening on the port of the traefik.udp.serverPort label.
+func getUDPServers(service rancherData) map[string]types.UDPServer {
+	var servers map[string]types.UDPServer
+
+	port := label.GetStringValue(service.Labels, label.TraefikUDPServerPort, "")
+	weight := label.GetIntValue(service.Labels, label.TraefikWeight, label.DefaultWeight)
+
+	for index, ip := range service.Containers {
+		if len(ip) == 0 {
+			log.Warnf("Unable to find the IP address for a container in the service %q: the UDP server is ignored.", service.Name)
+			continue
+		}
+
+		if servers == nil {
+			servers = make(map[string]types.UDPServer)
+		}
+
+		servers["server-"+strconv.Itoa(index)] = types.UDPServer{
+			Address: net.JoinHostPort(ip, port),
+			Weight:  weight,
+		}
+	}
+
+	return servers
+}
+
 func checkSegmentPort(labels map[string]string, segmentName string) (int, error) {
 	if rawPort, ok := labels[label.TraefikPort]; ok {
 		port, err := strconv.Atoi(rawPort)
@@ -743,6 +743,80 @@ func TestProviderBuildConfiguration(t *testing.T) {
 	}
 }
 
+func TestProviderBuildUDPConfiguration(t *testing.T) {
+	provider := &Provider{
+		Domain:           "rancher.localhost",
+		ExposedByDefault: true,
+	}
+
+	services := []rancherData{
+		{
+			Name: "infra/dns",
+			Labels: map[string]string{
+				label.TraefikUDPEntryPoints: "dns",
+				label.TraefikUDPServerPort:  "53",
+				label.TraefikWeight:         "2",
+			},
+			Containers: []string{"10.0.0.1", "10.0.0.2"},
+			Health:     "healthy",
+			State:      "active",
+		},
+		{
+			Name: "syslog",
+			Labels: map[string]string{
+				label.TraefikUDPEntryPoints: "syslog,logs",
+				label.TraefikUDPServerPort:  "514",
+				label.TraefikPort:           "80",
+			},
+			Containers: []string{"10.0.0.3"},
+			Health:     "healthy",
+			State:      "active",
+		},
+		{
+			Name: "no-port",
+			Labels: map[string]string{
+				label.TraefikUDPEntryPoints: "dns",
+			},
+			Containers: []string{"10.0.0.4"},
+			Health:     "healthy",
+			State:      "active",
+		},
+	}
+
+	actualConfig := provider.buildConfiguration(services)
+	require.NotNil(t, actualConfig)
+
+	expectedFrontends := map[string]*types.UDPFrontend{
+		"udp-frontend-infra-dns": {
+			EntryPoints: []string{"dns"},
+			Backend:     "udp-backend-infra-dns",
+		},
+		"udp-frontend-syslog": {
+			EntryPoints: []string{"syslog", "logs"},
+			Backend:     "udp-backend-syslog",
+		},
+	}
+	assert.Equal(t, expectedFrontends, actualConfig.UDPFrontends)
+
+	expectedBackends := map[string]*types.UDPBackend{
+		"udp-backend-infra-dns": {
+			Servers: map[string]types.UDPServer{
+				"server-0": {Address: "10.0.0.1:53", Weight: 2},
+				"server-1": {Address: "10.0.0.2:53", Weight: 2},
+			},
+		},
+		"udp-backend-syslog": {
+			Servers: map[string]types.UDPServer{
+				"server-0": {Address: "10.0.0.3:514", Weight: 1},
+			},
+		},
+	}
+	assert.Equal(t, expectedBackends, actualConfig.UDPBackends)
+
+	// the service with both a UDP service and an HTTP port is also served by HTTP
+	assert.Contains(t, actualConfig.Backends, "backend-syslog")
+}
+
 func TestProviderServiceFilter(t *testing.T) {
 	provider := &Provider{
 		Domain:                    "rancher.localhost",
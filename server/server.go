@@ -17,6 +17,7 @@ import (
 	"time"
 
 	"github.com/armon/go-proxyproto"
+	"github.com/containous/flaeg/parse"
 	"github.com/containous/mux"
 	"github.com/containous/traefik/cluster"
 	"github.com/containous/traefik/configuration"
@@ -26,11 +27,17 @@ import (
 	"github.com/containous/traefik/metrics"
 	"github.com/containous/traefik/middlewares"
 	"github.com/containous/traefik/middlewares/accesslog"
+	"github.com/containous/traefik/middlewares/bodyrate"
+	"github.com/containous/traefik/middlewares/drain"
+	mratelimit "github.com/containous/traefik/middlewares/ratelimit"
+	"github.com/containous/traefik/middlewares/toggles"
 	"github.com/containous/traefik/middlewares/tracing"
 	"github.com/containous/traefik/provider"
 	"github.com/containous/traefik/safe"
+	"github.com/containous/traefik/tcp"
 	traefiktls "github.com/containous/traefik/tls"
 	"github.com/containous/traefik/types"
+	"github.com/containous/traefik/udp"
 	"github.com/sirupsen/logrus"
 	"github.com/urfave/negroni"
 	"github.com/xenolf/lego/acme"
@@ -111,6 +118,16 @@ type Server struct {
 	configurationListeners        []func(types.Configuration)
 	entryPoints                   map[string]EntryPoint
 	bufferPool                    httputil.BufferPool
+	ipSets                        map[string]*ip.Set
+	maintenanceToggles            *toggles.Store
+	faultInjectionToggles         *toggles.Store
+	rateLimitStore                mratelimit.Store
+	tcpHealthChecks               tcp.HealthChecks
+	sockets                       map[string]socket
+	drainState                    *drain.State
+	inheritedSockets              *inheritedSockets
+	readiness                     readiness
+	readinessChecks               []func() error
 }
 
 // EntryPoint entryPoint information (configuration + internalRouter)
@@ -126,8 +143,12 @@ type serverEntryPoints map[string]*serverEntryPoint
 
 type serverEntryPoint struct {
 	httpServer              *h2c.Server
-	listener                net.Listener
+	listener                *tcp.Listener
 	httpRouter              *middlewares.HandlerSwitcher
+	tcpRouter               *tcp.RouterSwitcher
+	udpServer               *udp.Server
+	udpFrontendName         string
+	udpLoadBalancer         *udp.LoadBalancer
 	certs                   *traefiktls.CertificateStore
 	onDemandListener        func(string) (*tls.Certificate, error)
 	tlsALPNGetter           func(string) (*tls.Certificate, error)
@@ -152,6 +173,25 @@ func (s serverEntryPoint) Shutdown(ctx context.Context) {
 		}()
 	}
 
+	if s.udpServer != nil {
+		if err := s.udpServer.Close(); err != nil {
+			log.Error(err)
+		}
+	}
+
+	if s.listener != nil {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := s.listener.Shutdown(ctx); err != nil {
+				if ctx.Err() == context.DeadlineExceeded {
+					log.Debugf("Wait TCP connections is over due to: %s", err)
+					s.listener.CloseConnections()
+				}
+			}
+		}()
+	}
+
 	if s.hijackConnectionTracker != nil {
 		wg.Add(1)
 		go func() {
@@ -200,13 +240,38 @@ func NewServer(globalConfiguration configuration.GlobalConfiguration, provider p
 	currentConfigurations := make(types.Configurations)
 	server.currentConfigurations.Set(currentConfigurations)
 	server.providerConfigUpdateMap = make(map[string]chan types.ConfigMessage)
+	server.inheritedSockets = loadInheritedSockets()
+
+	var drainGracePeriod time.Duration
+	if globalConfiguration.LifeCycle != nil {
+		drainGracePeriod = time.Duration(globalConfiguration.LifeCycle.DrainGracePeriod)
+	}
+	server.drainState = drain.NewState(drainGracePeriod)
+
+	if server.globalConfiguration.Ping != nil {
+		server.globalConfiguration.Ping.WithDrainState(server.drainState)
+		server.globalConfiguration.Ping.WithReadinessCheck(server.CheckReadiness)
+	}
 
 	if server.globalConfiguration.API != nil {
 		server.globalConfiguration.API.CurrentConfigurations = &server.currentConfigurations
+
+		// the API only changes the state of Traefik when explicitly allowed
+		if server.globalConfiguration.API.RuntimeToggles {
+			server.maintenanceToggles = toggles.NewStore()
+			server.globalConfiguration.API.MaintenanceToggles = server.maintenanceToggles
+
+			server.faultInjectionToggles = toggles.NewStore()
+			server.globalConfiguration.API.FaultInjectionToggles = server.faultInjectionToggles
+		}
+
+		server.globalConfiguration.API.Drain = server.drainState
 	}
 
 	server.bufferPool = newBufferPool()
 
+	server.ipSets = buildIPSets(globalConfiguration.IPSets)
+
 	server.routinesPool = safe.NewPool(context.Background())
 
 	transport, err := createHTTPTransport(globalConfiguration)
@@ -228,6 +293,13 @@ func NewServer(globalConfiguration configuration.GlobalConfiguration, provider p
 		server.leadership = cluster.NewLeadership(server.routinesPool.Ctx(), globalConfiguration.Cluster)
 	}
 
+	if globalConfiguration.RateLimitStore != nil {
+		server.rateLimitStore, err = buildRateLimitStore(globalConfiguration.RateLimitStore, globalConfiguration.Cluster)
+		if err != nil {
+			log.Errorf("Unable to create the rate limit store, the rate limits will not be distributed: %v", err)
+		}
+	}
+
 	if globalConfiguration.AccessLog != nil {
 		var err error
 		server.accessLoggerMiddleware, err = accesslog.NewLogHandler(globalConfiguration.AccessLog)
@@ -252,6 +324,7 @@ func (s *Server) Start() {
 	s.routinesPool.Go(func(stop chan bool) {
 		s.listenSignals(stop)
 	})
+	s.startIPSetsRefresh()
 }
 
 // StartWithContext starts the server and Stop/Close it when context is Done
@@ -271,6 +344,12 @@ func (s *Server) StartWithContext(ctx context.Context) {
 	s.Start()
 }
 
+// Draining returns whether the server is in drain mode.
+func (s *Server) Draining() bool {
+	draining, _ := s.drainState.Draining()
+	return draining
+}
+
 // Wait blocks until server is shutted down.
 func (s *Server) Wait() {
 	<-s.stopChan
@@ -310,6 +389,7 @@ func (s *Server) Close() {
 	stopMetricsClients()
 	s.stopLeadership()
 	s.routinesPool.Cleanup()
+	closeUnusedGeoIPDatabases(nil)
 	close(s.configurationChan)
 	close(s.configurationValidatedChan)
 	signal.Stop(s.signals)
@@ -335,13 +415,54 @@ func (s *Server) stopLeadership() {
 	}
 }
 
+func (s *Server) startIPSetsRefresh() {
+	for _, set := range s.ipSets {
+		set := set
+		s.routinesPool.Go(func(stop chan bool) {
+			set.Refresh(stop)
+		})
+	}
+}
+
+// buildIPSets creates the IP sets and loads their initial content.
+// A set which cannot be loaded is kept empty, and loaded again by its refresh after a short backoff.
+func buildIPSets(ipSetsConfig map[string]*types.IPSet) map[string]*ip.Set {
+	ipSets := make(map[string]*ip.Set)
+	for name, config := range ipSetsConfig {
+		if config == nil {
+			continue
+		}
+
+		set, err := ip.NewSet(name, config.File, config.URL, time.Duration(config.RefreshInterval))
+		if err != nil {
+			log.Errorf("Unable to create the IP set %s: %v", name, err)
+			continue
+		}
+
+		if err := set.Load(); err != nil {
+			log.Errorf("Unable to load the IP set %s: %v", name, err)
+		}
+
+		ipSets[name] = set
+	}
+	return ipSets
+}
+
 func (s *Server) startHTTPServers() {
 	s.serverEntryPoints = s.buildServerEntryPoints()
 
 	for newServerEntryPointName, newServerEntryPoint := range s.serverEntryPoints {
+		if s.entryPoints[newServerEntryPointName].Configuration.UDP != nil {
+			serverEntryPoint := s.setupUDPServerEntryPoint(newServerEntryPointName, newServerEntryPoint)
+			go s.startUDPServer(serverEntryPoint)
+			continue
+		}
+
 		serverEntryPoint := s.setupServerEntryPoint(newServerEntryPointName, newServerEntryPoint)
 		go s.startServer(serverEntryPoint)
 	}
+
+	s.inheritedSockets.closeUnused()
 }
 
 func (s *Server) listenProviders(stop chan bool) {
@@ -518,7 +639,15 @@ func (s *Server) startServer(serverEntryPoint *serverEntryPoint) {
 }
 
 func (s *Server) setupServerEntryPoint(newServerEntryPointName string, newServerEntryPoint *serverEntryPoint) *serverEntryPoint {
-	serverMiddlewares, err := s.buildServerEntryPointMiddlewares(newServerEntryPointName)
+	timeouts := buildServerTimeouts(s.globalConfiguration, s.entryPoints[newServerEntryPointName].Configuration)
+
+	var bodyRateConns *bodyrate.Conns
+	if timeouts.MinRequestBodyRate > 0 {
+		// the minimum rate middleware sets the read deadline of the connections
+		bodyRateConns = bodyrate.NewConns()
+	}
+
+	serverMiddlewares, err := s.buildServerEntryPointMiddlewares(newServerEntryPointName, bodyRateConns)
 	if err != nil {
 		log.Fatal("Error preparing server: ", err)
 	}
@@ -530,10 +659,18 @@ func (s *Server) setupServerEntryPoint(newServerEntryPointName string, newServer
 
 	serverEntryPoint := s.serverEntryPoints[newServerEntryPointName]
 	serverEntryPoint.httpServer = newSrv
-	serverEntryPoint.listener = listener
+	sniffTimeout := time.Duration(timeouts.ReadHeaderTimeout)
+	if sniffTimeout <= 0 {
+		sniffTimeout = time.Duration(timeouts.ReadTimeout)
+	}
+	serverEntryPoint.listener = tcp.NewListener(listener, newServerEntryPoint.tcpRouter, newSrv.TLSConfig, sniffTimeout, time.Duration(timeouts.IdleTimeout))
 
 	serverEntryPoint.hijackConnectionTracker = newHijackConnectionTracker()
 	serverEntryPoint.httpServer.ConnState = func(conn net.Conn, state http.ConnState) {
+		if bodyRateConns != nil {
+			bodyRateConns.ConnState(conn, state)
+		}
+
 		switch state {
 		case http.StateHijacked:
 			serverEntryPoint.hijackConnectionTracker.AddHijackedConnection(conn)
@@ -546,8 +683,9 @@ func (s *Server) setupServerEntryPoint(newServerEntryPointName string, newServer
 }
 
 func (s *Server) prepareServer(entryPointName string, entryPoint *configuration.EntryPoint, router *middlewares.HandlerSwitcher, middlewares []negroni.Handler) (*h2c.Server, net.Listener, error) {
-	readTimeout, writeTimeout, idleTimeout := buildServerTimeouts(s.globalConfiguration)
-	log.Infof("Preparing server %s %+v with readTimeout=%s writeTimeout=%s idleTimeout=%s", entryPointName, entryPoint, readTimeout, writeTimeout, idleTimeout)
+	timeouts := buildServerTimeouts(s.globalConfiguration, entryPoint)
+	log.Infof("Preparing server %s %+v with readTimeout=%s readHeaderTimeout=%s writeTimeout=%s idleTimeout=%s", entryPointName, entryPoint,
+		timeouts.ReadTimeout, timeouts.ReadHeaderTimeout, timeouts.WriteTimeout, timeouts.IdleTimeout)
 
 	// middlewares
 	n := negroni.New()
@@ -564,13 +702,11 @@ func (s *Server) prepareServer(entryPointName string, entryPoint *configuration.
 		return nil, nil, fmt.Errorf("error creating TLS config: %v", err)
 	}
 
-	listener, err := net.Listen("tcp", entryPoint.Address)
+	listener, err := s.listen(entryPointName, entryPoint)
 	if err != nil {
 		return nil, nil, fmt.Errorf("error opening listener: %v", err)
 	}
 
-	listener = tcpKeepAliveListener{listener.(*net.TCPListener)}
-
 	if entryPoint.ProxyProtocol != nil {
 		listener, err = buildProxyProtocolListener(entryPoint, listener)
 		if err != nil {
@@ -578,19 +714,56 @@ func (s *Server) prepareServer(entryPointName string, entryPoint *configuration.
 		}
 	}
 
-	return &h2c.Server{
-			Server: &http.Server{
-				Addr:         entryPoint.Address,
-				Handler:      internalMuxRouter,
-				TLSConfig:    tlsConfig,
-				ReadTimeout:  readTimeout,
-				WriteTimeout: writeTimeout,
-				IdleTimeout:  idleTimeout,
-				ErrorLog:     httpServerLogger,
-			},
-		},
-		listener,
-		nil
+	if entryPoint.MaxConnections > 0 || entryPoint.MaxConnectionsPerIP > 0 {
+		if entryPoint.MaxConnectionsPerIP > 0 && entryPoint.ClientIPStrategy != nil {
+			log.Warnf("The connections per IP of the entry point %s are limited by the address of the connection: the ClientIPStrategy relies on HTTP headers, not available when the connection is accepted", entryPointName)
+		}
+
+		listener = tcp.NewLimitListener(listener, entryPoint.MaxConnections, entryPoint.MaxConnectionsPerIP,
+			s.metricsRegistry.EntrypointRejectedConnsCounter().With("entrypoint", entryPointName))
+	}
+
+	httpServer := &http.Server{
+		Addr:              entryPoint.Address,
+		Handler:           internalMuxRouter,
+		TLSConfig:         tlsConfig,
+		ReadTimeout:       time.Duration(timeouts.ReadTimeout),
+		ReadHeaderTimeout: time.Duration(timeouts.ReadHeaderTimeout),
+		WriteTimeout:      time.Duration(timeouts.WriteTimeout),
+		IdleTimeout:       time.Duration(timeouts.IdleTimeout),
+		ErrorLog:          httpServerLogger,
+	}
+
+	return &h2c.Server{Server: httpServer}, listener, nil
+}
+
+// listen opens the listener of the entry point, or takes over the one passed by the previous process.
+func (s *Server) listen(entryPointName string, entryPoint *configuration.EntryPoint) (net.Listener, error) {
+	if path := entryPoint.UnixSocketPath(); len(path) > 0 {
+		listener := s.inheritedSockets.listener(entryPointName, "unix", path)
+		if listener == nil {
+			var err error
+			listener, err = listenUnixSocket(path, entryPoint.UnixSocket)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		s.addSocket(entryPointName, listener.(*net.UnixListener))
+		return listener, nil
+	}
+
+	listener := s.inheritedSockets.listener(entryPointName, "tcp", entryPoint.Address)
+	if listener == nil {
+		var err error
+		listener, err = net.Listen("tcp", entryPoint.Address)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	s.addSocket(entryPointName, listener.(*net.TCPListener))
+	return tcpKeepAliveListener{listener.(*net.TCPListener)}, nil
 }
 
 func buildProxyProtocolListener(entryPoint *configuration.EntryPoint, listener net.Listener) (net.Listener, error) {
@@ -640,21 +813,44 @@ func (s *Server) buildInternalRouter(entryPointName string) *mux.Router {
 	return internalMuxRouter
 }
 
-func buildServerTimeouts(globalConfig configuration.GlobalConfiguration) (readTimeout, writeTimeout, idleTimeout time.Duration) {
-	readTimeout = time.Duration(0)
-	writeTimeout = time.Duration(0)
+// buildServerTimeouts returns the responding timeouts of the entry point, the options it doesn't set being the global ones.
+// A negative option of the entry point disables the global one.
+func buildServerTimeouts(globalConfig configuration.GlobalConfiguration, entryPoint *configuration.EntryPoint) configuration.RespondingTimeouts {
+	timeouts := configuration.RespondingTimeouts{IdleTimeout: parse.Duration(configuration.DefaultIdleTimeout)}
 	if globalConfig.RespondingTimeouts != nil {
-		readTimeout = time.Duration(globalConfig.RespondingTimeouts.ReadTimeout)
-		writeTimeout = time.Duration(globalConfig.RespondingTimeouts.WriteTimeout)
+		timeouts = *globalConfig.RespondingTimeouts
 	}
 
-	if globalConfig.RespondingTimeouts != nil {
-		idleTimeout = time.Duration(globalConfig.RespondingTimeouts.IdleTimeout)
-	} else {
-		idleTimeout = configuration.DefaultIdleTimeout
+	if entryPoint == nil || entryPoint.RespondingTimeouts == nil {
+		return timeouts
+	}
+
+	overrides := entryPoint.RespondingTimeouts
+	timeouts.ReadTimeout = overrideDuration(timeouts.ReadTimeout, overrides.ReadTimeout)
+	timeouts.WriteTimeout = overrideDuration(timeouts.WriteTimeout, overrides.WriteTimeout)
+	timeouts.IdleTimeout = overrideDuration(timeouts.IdleTimeout, overrides.IdleTimeout)
+	timeouts.ReadHeaderTimeout = overrideDuration(timeouts.ReadHeaderTimeout, overrides.ReadHeaderTimeout)
+	timeouts.MinRequestBodyRateGracePeriod = overrideDuration(timeouts.MinRequestBodyRateGracePeriod, overrides.MinRequestBodyRateGracePeriod)
+
+	if overrides.MinRequestBodyRate < 0 {
+		timeouts.MinRequestBodyRate = 0
+	} else if overrides.MinRequestBodyRate > 0 {
+		timeouts.MinRequestBodyRate = overrides.MinRequestBodyRate
 	}
 
-	return readTimeout, writeTimeout, idleTimeout
+	return timeouts
+}
+
+// overrideDuration returns the value of the entry point when set, zero when it is negative, and the global value otherwise.
+func overrideDuration(global, entryPoint parse.Duration) parse.Duration {
+	switch {
+	case entryPoint < 0:
+		return 0
+	case entryPoint > 0:
+		return entryPoint
+	default:
+		return global
+	}
 }
 
 func registerMetricClients(metricsConfig *types.Metrics) metrics.Registry {
@@ -20,6 +20,7 @@ import (
 	"github.com/containous/traefik/middlewares"
 	"github.com/containous/traefik/middlewares/pipelining"
 	"github.com/containous/traefik/rules"
+	"github.com/containous/traefik/tcp"
 	traefiktls "github.com/containous/traefik/tls"
 	"github.com/containous/traefik/tls/generate"
 	"github.com/containous/traefik/types"
@@ -53,7 +54,14 @@ func (s *Server) loadConfiguration(configMsg types.ConfigMessage) {
 	s.metricsRegistry.LastConfigReloadSuccessGauge().Set(float64(time.Now().Unix()))
 
 	for newServerEntryPointName, newServerEntryPoint := range newServerEntryPoints {
+		if s.entryPoints[newServerEntryPointName].Configuration.UDP != nil {
+			s.serverEntryPoints[newServerEntryPointName].udpServer.UpdateLoadBalancer(newServerEntryPoint.udpLoadBalancer)
+			log.Infof("Server configuration reloaded on UDP %s", s.serverEntryPoints[newServerEntryPointName].udpServer.Addr())
+			continue
+		}
+
 		s.serverEntryPoints[newServerEntryPointName].httpRouter.UpdateHandler(newServerEntryPoint.httpRouter.GetHandler())
+		s.serverEntryPoints[newServerEntryPointName].tcpRouter.UpdateRouter(newServerEntryPoint.tcpRouter.GetRouter())
 
 		if s.entryPoints[newServerEntryPointName].Configuration.TLS == nil {
 			if newServerEntryPoint.certs.ContainsCertificates() {
@@ -67,6 +75,12 @@ func (s *Server) loadConfiguration(configMsg types.ConfigMessage) {
 	}
 
 	s.currentConfigurations.Set(newConfigurations)
+	s.readiness.applied(configMsg.ProviderName)
+
+	closeUnusedGeoIPDatabases(newConfigurations)
+
+	// the previous process hands off its listeners once a configuration is applied
+	s.inheritedSockets.notifyReady()
 
 	for _, listener := range s.configurationListeners {
 		listener(*configMsg.Configuration)
@@ -116,6 +130,11 @@ func (s *Server) loadConfig(configurations types.Configurations, globalConfigura
 
 	healthcheck.GetHealthCheck(s.metricsRegistry).SetBackendsConfiguration(s.routinesPool.Ctx(), backendsHealthCheck)
 
+	tcpHealthChecks := s.loadTCPConfig(configurations, serverEntryPoints)
+	s.tcpHealthChecks.SetHealthChecks(s.routinesPool.Ctx(), tcpHealthChecks)
+
+	s.loadUDPConfig(configurations, serverEntryPoints)
+
 	// Get new certificates list sorted per entrypoints
 	// Update certificates
 	entryPointsCertificates, err := s.loadHTTPSConfiguration(configurations, globalConfiguration.DefaultEntryPoints)
@@ -158,9 +177,13 @@ func (s *Server) loadFrontendConfig(
 	var postConfigs []handlerPostConfig
 
 	for _, entryPointName := range frontend.EntryPoints {
-		log.Debugf("Wiring frontend %s to entryPoint %s", frontendName, entryPointName)
-
 		entryPoint := s.entryPoints[entryPointName].Configuration
+		if entryPoint.UDP != nil {
+			log.Errorf("Entry point %s is a UDP entry point, skipping it for frontend %s", entryPointName, frontendName)
+			continue
+		}
+
+		log.Debugf("Wiring frontend %s to entryPoint %s", frontendName, entryPointName)
 
 		if backendsHandlers[entryPointName+providerName+frontendHash] == nil {
 			log.Debugf("Creating backend %s", frontend.Backend)
@@ -174,7 +197,7 @@ func (s *Server) loadFrontendConfig(
 				postConfigs = append(postConfigs, postConfig)
 			}
 
-			fwd, err := s.buildForwarder(entryPointName, entryPoint, frontendName, frontend, responseModifier)
+			fwd, err := s.buildForwarder(entryPointName, entryPoint, frontendName, frontend, backend, responseModifier)
 			if err != nil {
 				return nil, fmt.Errorf("failed to create the forwarder for frontend %s: %v", frontendName, err)
 			}
@@ -230,10 +253,10 @@ func (s *Server) loadFrontendConfig(
 }
 
 func (s *Server) buildForwarder(entryPointName string, entryPoint *configuration.EntryPoint,
-	frontendName string, frontend *types.Frontend,
+	frontendName string, frontend *types.Frontend, backend *types.Backend,
 	responseModifier modifyResponse) (http.Handler, error) {
 
-	roundTripper, err := s.getRoundTripper(entryPointName, frontend.PassTLSCert, entryPoint.TLS)
+	roundTripper, err := s.getRoundTripper(entryPointName, frontend.PassTLSCert, entryPoint.TLS, backend.ProxyProtocol)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create RoundTripper for frontend %s: %v", frontendName, err)
 	}
@@ -245,20 +268,16 @@ func (s *Server) buildForwarder(entryPointName string, entryPoint *configuration
 		forward.RoundTripper(roundTripper),
 		forward.ResponseModifier(responseModifier),
 		forward.BufferPool(s.bufferPool),
-		forward.WebsocketConnectionClosedHook(func(req *http.Request, conn net.Conn) {
-			server := req.Context().Value(http.ServerContextKey).(*http.Server)
-			if server != nil {
-				connState := server.ConnState
-				if connState != nil {
-					connState(conn, http.StateClosed)
-				}
-			}
-		}),
+		forward.WebsocketConnectionClosedHook(notifyHijackedConnectionClosed),
 	)
 	if err != nil {
 		return nil, fmt.Errorf("error creating forwarder for frontend %s: %v", frontendName, err)
 	}
 
+	if backend.ProxyProtocol != nil {
+		fwd = newProxyProtocolForwarder(fwd, roundTripper, frontend.PassHostHeader, s.bufferPool, notifyHijackedConnectionClosed)
+	}
+
 	if s.tracingMiddleware.IsEnabled() {
 		tm := s.tracingMiddleware.NewForwarderMiddleware(frontendName, frontend.Backend)
 
@@ -273,6 +292,17 @@ func (s *Server) buildForwarder(entryPointName string, entryPoint *configuration
 	return fwd, nil
 }
 
+// notifyHijackedConnectionClosed tells the server of the request that its hijacked connection is closed.
+func notifyHijackedConnectionClosed(req *http.Request, conn net.Conn) {
+	server := req.Context().Value(http.ServerContextKey).(*http.Server)
+	if server != nil {
+		connState := server.ConnState
+		if connState != nil {
+			connState(conn, http.StateClosed)
+		}
+	}
+}
+
 func buildServerRoute(serverEntryPoint *serverEntryPoint, frontendName string, frontend *types.Frontend, hostResolver *hostresolver.Resolver) (*types.ServerRoute, error) {
 	serverRoute := &types.ServerRoute{Route: serverEntryPoint.httpRouter.GetHandler().NewRoute().Name(frontendName)}
 
@@ -309,16 +339,21 @@ func (s *Server) preLoadConfiguration(configMsg types.ConfigMessage) {
 		log.Debugf("Configuration received from provider %s: %s", configMsg.ProviderName, string(jsonConf))
 	}
 
-	if configMsg.Configuration == nil || configMsg.Configuration.Backends == nil && configMsg.Configuration.Frontends == nil && configMsg.Configuration.TLS == nil {
+	if configMsg.Configuration == nil || configMsg.Configuration.Backends == nil && configMsg.Configuration.Frontends == nil &&
+		configMsg.Configuration.TCPFrontends == nil && configMsg.Configuration.UDPFrontends == nil && configMsg.Configuration.TLS == nil {
 		log.Infof("Skipping empty Configuration for provider %s", configMsg.ProviderName)
+		s.readiness.skipped(configMsg.ProviderName)
 		return
 	}
 
 	if reflect.DeepEqual(currentConfigurations[configMsg.ProviderName], configMsg.Configuration) {
 		log.Infof("Skipping same configuration for provider %s", configMsg.ProviderName)
+		s.readiness.skipped(configMsg.ProviderName)
 		return
 	}
 
+	s.readiness.queued(configMsg.ProviderName)
+
 	providerConfigUpdateCh, ok := s.providerConfigUpdateMap[configMsg.ProviderName]
 	if !ok {
 		providerConfigUpdateCh = make(chan types.ConfigMessage)
@@ -332,7 +367,14 @@ func (s *Server) preLoadConfiguration(configMsg types.ConfigMessage) {
 }
 
 func (s *Server) defaultConfigurationValues(configuration *types.Configuration) {
-	if configuration == nil || configuration.Frontends == nil {
+	if configuration == nil {
+		return
+	}
+
+	s.configureTCPFrontends(configuration.TCPFrontends)
+	s.configureUDPFrontends(configuration.UDPFrontends)
+
+	if configuration.Frontends == nil {
 		return
 	}
 	s.configureFrontends(configuration.Frontends)
@@ -553,6 +595,7 @@ func (s *Server) buildServerEntryPoints() map[string]*serverEntryPoint {
 	for entryPointName, entryPoint := range s.entryPoints {
 		serverEntryPoints[entryPointName] = &serverEntryPoint{
 			httpRouter:       middlewares.NewHandlerSwitcher(s.buildDefaultHTTPRouter()),
+			tcpRouter:        tcp.NewRouterSwitcher(tcp.NewRouter()),
 			onDemandListener: entryPoint.OnDemandListener,
 			tlsALPNGetter:    entryPoint.TLSALPNGetter,
 		}
@@ -2,9 +2,12 @@ package server
 
 import (
 	"fmt"
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -151,6 +154,53 @@ func TestServerLoadConfigHealthCheckOptions(t *testing.T) {
 	}
 }
 
+func TestServerLoadConfigHealthCheckStaticFiles(t *testing.T) {
+	dir, err := ioutil.TempDir("", "traefik-static")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	globalConfig := configuration.GlobalConfiguration{
+		HealthCheck: &configuration.HealthCheckConfig{
+			Interval: parse.Duration(5 * time.Second),
+			Timeout:  parse.Duration(3 * time.Second),
+		},
+	}
+	entryPoints := map[string]EntryPoint{
+		"http": {
+			Configuration: &configuration.EntryPoint{
+				ForwardedHeaders: &configuration.ForwardedHeaders{Insecure: true},
+			},
+		},
+	}
+
+	dynamicConfigs := types.Configurations{
+		"config": &types.Configuration{
+			Frontends: map[string]*types.Frontend{
+				"frontend": {
+					EntryPoints: []string{"http"},
+					Backend:     "backend",
+				},
+			},
+			Backends: map[string]*types.Backend{
+				"backend": {
+					Servers: map[string]types.Server{
+						"server": {URL: "file://" + dir},
+					},
+					LoadBalancer: &types.LoadBalancer{Method: "Wrr"},
+					HealthCheck:  &types.HealthCheck{Path: "/health"},
+				},
+			},
+		},
+	}
+
+	srv := NewServer(globalConfig, nil, entryPoints)
+
+	_, err = srv.loadConfig(dynamicConfigs, globalConfig)
+	require.NoError(t, err)
+
+	assert.Empty(t, healthcheck.GetHealthCheck(th.NewCollectingHealthCheckMetrics()).Backends, "health check backends")
+}
+
 func TestServerLoadConfigEmptyBasicAuth(t *testing.T) {
 	globalConfig := configuration.GlobalConfiguration{
 		EntryPoints: configuration.EntryPoints{
@@ -286,6 +336,85 @@ func TestReuseBackend(t *testing.T) {
 	assert.Equal(t, http.StatusUnauthorized, responseRecorderUnauthorized.Result().StatusCode, "status code")
 }
 
+func TestServerStaticFileBackend(t *testing.T) {
+	dir, err := ioutil.TempDir("", "static")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	err = ioutil.WriteFile(filepath.Join(dir, "index.html"), []byte("<h1>home</h1>"), 0644)
+	require.NoError(t, err)
+
+	globalConfig := configuration.GlobalConfiguration{
+		DefaultEntryPoints: []string{"http"},
+	}
+
+	entryPoints := map[string]EntryPoint{
+		"http": {Configuration: &configuration.EntryPoint{
+			ForwardedHeaders: &configuration.ForwardedHeaders{Insecure: true},
+		}},
+	}
+
+	dynamicConfigs := types.Configurations{
+		"config": th.BuildConfiguration(
+			th.WithFrontends(
+				th.WithFrontend("backend",
+					th.WithFrontendName("frontend0"),
+					th.WithEntryPoints("http"),
+					th.WithRoutes(th.WithRoute("/app", "PathPrefixStrip: /app"))),
+			),
+			th.WithBackends(th.WithBackendNew("backend",
+				th.WithLBMethod("wrr"),
+				th.WithServersNew(th.WithServerNew("file://"+filepath.ToSlash(dir))),
+				func(b *types.Backend) {
+					b.Static = &types.Static{SPA: true}
+				}),
+			),
+		),
+	}
+
+	srv := NewServer(globalConfig, nil, entryPoints)
+
+	serverEntryPoints, err := srv.loadConfig(dynamicConfigs, globalConfig)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		desc           string
+		path           string
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			desc:           "index file",
+			path:           "/app/",
+			expectedStatus: http.StatusOK,
+			expectedBody:   "<h1>home</h1>",
+		},
+		{
+			desc:           "SPA fallback",
+			path:           "/app/users/42",
+			expectedStatus: http.StatusOK,
+			expectedBody:   "<h1>home</h1>",
+		},
+		{
+			desc:           "missing asset",
+			path:           "/app/missing.js",
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   "404 page not found\n",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			request := httptest.NewRequest(http.MethodGet, "http://localhost"+test.path, nil)
+			serverEntryPoints["http"].httpRouter.ServeHTTP(recorder, request)
+
+			assert.Equal(t, test.expectedStatus, recorder.Code)
+			assert.Equal(t, test.expectedBody, recorder.Body.String())
+		})
+	}
+}
+
 func TestThrottleProviderConfigReload(t *testing.T) {
 	throttleDuration := 30 * time.Millisecond
 	publishConfig := make(chan types.ConfigMessage)
@@ -8,6 +8,7 @@ import (
 	"net"
 	"net/http"
 	"net/url"
+	"strings"
 	"time"
 
 	"github.com/containous/traefik/configuration"
@@ -15,12 +16,13 @@ import (
 	"github.com/containous/traefik/log"
 	"github.com/containous/traefik/middlewares"
 	"github.com/containous/traefik/middlewares/accesslog"
+	mratelimit "github.com/containous/traefik/middlewares/ratelimit"
+	"github.com/containous/traefik/middlewares/static"
 	"github.com/containous/traefik/server/cookie"
 	traefiktls "github.com/containous/traefik/tls"
 	"github.com/containous/traefik/types"
 	"github.com/vulcand/oxy/buffer"
 	"github.com/vulcand/oxy/connlimit"
-	"github.com/vulcand/oxy/ratelimit"
 	"github.com/vulcand/oxy/roundrobin"
 	"github.com/vulcand/oxy/utils"
 	"golang.org/x/net/http2"
@@ -35,6 +37,44 @@ func (t *h2cTransportWrapper) RoundTrip(req *http.Request) (*http.Response, erro
 	return t.Transport.RoundTrip(req)
 }
 
+// staticForwarder serves the requests balanced to the file:// servers with their static files,
+// and forwards the other ones.
+type staticForwarder struct {
+	next     http.Handler
+	handlers map[string]http.Handler
+}
+
+func newStaticForwarder(next http.Handler) *staticForwarder {
+	return &staticForwarder{next: next, handlers: make(map[string]http.Handler)}
+}
+
+func (f *staticForwarder) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	if req.URL.Scheme != "file" {
+		f.next.ServeHTTP(rw, req)
+		return
+	}
+
+	handler, ok := f.handlers[req.URL.String()]
+	if !ok {
+		log.Errorf("No static file server for %s", req.URL)
+		http.Error(rw, http.StatusText(http.StatusBadGateway), http.StatusBadGateway)
+		return
+	}
+
+	// the balancer replaces the URL of the request by the one of the server
+	requestURL, err := url.ParseRequestURI(req.RequestURI)
+	if err != nil {
+		http.Error(rw, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		return
+	}
+
+	outReq := new(http.Request)
+	*outReq = *req
+	outReq.URL = &url.URL{Path: requestURL.Path, RawPath: requestURL.RawPath, RawQuery: requestURL.RawQuery}
+
+	handler.ServeHTTP(rw, outReq)
+}
+
 func (s *Server) buildBalancerMiddlewares(frontendName string, frontend *types.Frontend, backend *types.Backend, fwd http.Handler) (http.Handler, *healthcheck.BackendConfig, error) {
 	balancer, err := s.buildLoadBalancer(frontendName, frontend.Backend, backend, fwd)
 	if err != nil {
@@ -43,10 +83,21 @@ func (s *Server) buildBalancerMiddlewares(frontendName string, frontend *types.F
 
 	// Health Check
 	var backendHealthCheck *healthcheck.BackendConfig
-	if hcOpts := buildHealthCheckOptions(balancer, frontend.Backend, backend.HealthCheck, s.globalConfiguration.HealthCheck); hcOpts != nil {
+	if hasOnlyStaticServers(backend) {
+		if backend.HealthCheck != nil {
+			log.Debugf("Skipping the health check of backend %s: its static files are served by Traefik", frontend.Backend)
+		}
+	} else if hcOpts := buildHealthCheckOptions(balancer, frontend.Backend, backend.HealthCheck, s.globalConfiguration.HealthCheck); hcOpts != nil {
 		log.Debugf("Setting up backend health check %s", *hcOpts)
 
 		hcOpts.Transport = s.defaultForwardingRoundTripper
+		if backend.ProxyProtocol != nil {
+			// the servers expect a PROXY protocol header on every connection
+			hcOpts.Transport, err = s.getRoundTripper("", false, nil, backend.ProxyProtocol)
+			if err != nil {
+				return nil, nil, err
+			}
+		}
 		backendHealthCheck = healthcheck.NewBackendConfig(*hcOpts, frontend.Backend)
 	}
 
@@ -55,7 +106,7 @@ func (s *Server) buildBalancerMiddlewares(frontendName string, frontend *types.F
 
 	// Rate Limit
 	if frontend.RateLimit != nil && len(frontend.RateLimit.RateSet) > 0 {
-		handler, err := buildRateLimiter(lb, frontend.RateLimit)
+		handler, err := s.buildRateLimiter(frontendName, lb, frontend.RateLimit)
 		if err != nil {
 			return nil, nil, fmt.Errorf("error creating rate limiter: %v", err)
 		}
@@ -114,6 +165,9 @@ func (s *Server) buildLoadBalancer(frontendName string, backendName string, back
 	var rr *roundrobin.RoundRobin
 	var saveFrontend http.Handler
 
+	staticFwd := newStaticForwarder(fwd)
+	fwd = staticFwd
+
 	if s.accessLoggerMiddleware != nil {
 		saveBackend := accesslog.NewSaveBackend(fwd, backendName)
 		saveFrontend = accesslog.NewSaveFrontend(saveBackend, frontendName)
@@ -177,21 +231,33 @@ func (s *Server) buildLoadBalancer(frontendName string, backendName string, back
 		return nil, fmt.Errorf("invalid load-balancing method %q", lbMethod)
 	}
 
-	if err := s.configureLBServers(lb, backend, backendName); err != nil {
+	if err := s.configureLBServers(lb, backend, backendName, staticFwd); err != nil {
 		return nil, fmt.Errorf("error configuring load balancer for frontend %s: %v", frontendName, err)
 	}
 
 	return lb, nil
 }
 
-func (s *Server) configureLBServers(lb healthcheck.BalancerHandler, backend *types.Backend, backendName string) error {
+func (s *Server) configureLBServers(lb healthcheck.BalancerHandler, backend *types.Backend, backendName string, staticFwd *staticForwarder) error {
 	for name, srv := range backend.Servers {
-		u, err := url.Parse(srv.URL)
+		u, err := parseServerURL(srv.URL)
 		if err != nil {
 			return fmt.Errorf("error parsing server URL %s: %v", srv.URL, err)
 		}
 
-		log.Debugf("Creating server %s at %s with weight %d", name, u, srv.Weight)
+		if u.Scheme == "file" {
+			if len(u.Host) > 0 && u.Host != "localhost" {
+				return fmt.Errorf("error creating static file server %s: only local files can be served", srv.URL)
+			}
+
+			handler, err := static.NewHandler(u.Path, backend.Static)
+			if err != nil {
+				return fmt.Errorf("error creating static file server %s: %v", srv.URL, err)
+			}
+			staticFwd.handlers[u.String()] = handler
+		}
+
+		log.Debugf("Creating server %s at %s with weight %d", name, srv.URL, srv.Weight)
 
 		if err := lb.UpsertServer(u, roundrobin.Weight(srv.Weight)); err != nil {
 			return fmt.Errorf("error adding server %s to load balancer: %v", srv.URL, err)
@@ -203,24 +269,37 @@ func (s *Server) configureLBServers(lb healthcheck.BalancerHandler, backend *typ
 }
 
 // getRoundTripper will either use server.defaultForwardingRoundTripper or create a new one
-// given a custom TLS configuration is passed and the passTLSCert option is set to true.
-func (s *Server) getRoundTripper(entryPointName string, passTLSCert bool, tls *traefiktls.TLS) (http.RoundTripper, error) {
+// given a custom TLS configuration is passed and the passTLSCert option is set to true,
+// or the PROXY protocol header has to be sent to the servers.
+func (s *Server) getRoundTripper(entryPointName string, passTLSCert bool, tls *traefiktls.TLS, proxyProtocol *types.ProxyProtocol) (http.RoundTripper, error) {
+	if !passTLSCert && proxyProtocol == nil {
+		return s.defaultForwardingRoundTripper, nil
+	}
+
+	transport, err := createHTTPTransport(s.globalConfiguration)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create HTTP transport: %v", err)
+	}
+
 	if passTLSCert {
 		tlsConfig, err := createClientTLSConfig(entryPointName, tls)
 		if err != nil {
 			return nil, fmt.Errorf("failed to create TLSClientConfig: %v", err)
 		}
 
-		transport, err := createHTTPTransport(s.globalConfiguration)
+		transport.TLSClientConfig = tlsConfig
+	}
+
+	if proxyProtocol != nil {
+		version, err := getProxyProtocolVersion(proxyProtocol)
 		if err != nil {
-			return nil, fmt.Errorf("failed to create HTTP transport: %v", err)
+			return nil, err
 		}
 
-		transport.TLSClientConfig = tlsConfig
-		return transport, nil
+		configureProxyProtocol(transport, version)
 	}
 
-	return s.defaultForwardingRoundTripper, nil
+	return transport, nil
 }
 
 // createHTTPTransport creates an http.Transport configured with the GlobalConfiguration settings.
@@ -261,6 +340,8 @@ func createHTTPTransport(globalConfiguration configuration.GlobalConfiguration)
 		transport.ResponseHeaderTimeout = time.Duration(globalConfiguration.ForwardingTimeouts.ResponseHeaderTimeout)
 	}
 
+	transport.RegisterProtocol(unixSocketScheme, newUnixSocketTransport(dialer, transport))
+
 	if globalConfiguration.InsecureSkipVerify {
 		transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
 	}
@@ -343,22 +424,34 @@ func (s *Server) buildRetryMiddleware(handler http.Handler, retry *configuration
 	return middlewares.NewRetry(retryAttempts, handler, retryListeners)
 }
 
-func buildRateLimiter(handler http.Handler, rlConfig *types.RateLimit) (http.Handler, error) {
-	extractFunc, err := utils.NewExtractor(rlConfig.ExtractorFunc)
-	if err != nil {
-		return nil, err
+func (s *Server) buildRateLimiter(frontendName string, handler http.Handler, rlConfig *types.RateLimit) (http.Handler, error) {
+	log.Debugf("Creating load-balancer rate limiter")
+
+	if !rlConfig.Distributed {
+		return mratelimit.New(frontendName, handler, rlConfig, nil, 0, 0)
 	}
 
-	log.Debugf("Creating load-balancer rate limiter")
+	if s.rateLimitStore == nil {
+		log.Warnf("No rate limit store, the rate limits of the frontend %s are not distributed", frontendName)
+		return mratelimit.New(frontendName, handler, rlConfig, nil, 0, 0)
+	}
 
-	rateSet := ratelimit.NewRateSet()
-	for _, rate := range rlConfig.RateSet {
-		if err := rateSet.Add(time.Duration(rate.Period), rate.Average, rate.Burst); err != nil {
-			return nil, err
+	storeConfig := s.globalConfiguration.RateLimitStore
+	return mratelimit.New(frontendName, handler, rlConfig, s.rateLimitStore, storeConfig.BatchSize, time.Duration(storeConfig.RetryInterval))
+}
+
+func buildRateLimitStore(config *configuration.RateLimitStore, cluster *types.Cluster) (mratelimit.Store, error) {
+	switch config.Backend {
+	case "redis":
+		return mratelimit.NewRedisStore(config.Address, config.Password, config.DB, time.Duration(config.Timeout), config.Prefix), nil
+	case "cluster":
+		if cluster == nil || cluster.Store == nil {
+			return nil, errors.New("the cluster store is only available in cluster mode")
 		}
+		return mratelimit.NewKVStore(cluster.Store.Store, config.Prefix), nil
+	default:
+		return nil, fmt.Errorf("unknown rate limit store backend %q", config.Backend)
 	}
-
-	return ratelimit.New(handler, extractFunc, rateSet)
 }
 
 func buildBufferingMiddleware(handler http.Handler, config *types.Buffering) (http.Handler, error) {
@@ -397,29 +490,8 @@ func buildHealthCheckOptions(lb healthcheck.BalancerHandler, backend string, hc
 		return nil
 	}
 
-	interval := time.Duration(hcConfig.Interval)
-	if hc.Interval != "" {
-		intervalOverride, err := time.ParseDuration(hc.Interval)
-		if err != nil {
-			log.Errorf("Illegal health check interval for backend '%s': %s", backend, err)
-		} else if intervalOverride <= 0 {
-			log.Errorf("Health check interval smaller than zero for backend '%s', backend", backend)
-		} else {
-			interval = intervalOverride
-		}
-	}
-
-	timeout := time.Duration(hcConfig.Timeout)
-	if hc.Timeout != "" {
-		timeoutOverride, err := time.ParseDuration(hc.Timeout)
-		if err != nil {
-			log.Errorf("Illegal health check timeout for backend '%s': %s", backend, err)
-		} else if timeoutOverride <= 0 {
-			log.Errorf("Health check timeout smaller than zero for backend '%s', backend", backend)
-		} else {
-			timeout = timeoutOverride
-		}
-	}
+	interval := parseHealthCheckDuration(hc.Interval, time.Duration(hcConfig.Interval), "interval", backend)
+	timeout := parseHealthCheckDuration(hc.Timeout, time.Duration(hcConfig.Timeout), "timeout", backend)
 
 	if timeout >= interval {
 		log.Warnf("Health check timeout for backend '%s' should be lower than the health check interval. Interval set to timeout + 1 second (%s).", backend)
@@ -436,3 +508,37 @@ func buildHealthCheckOptions(lb healthcheck.BalancerHandler, backend string, hc
 		Headers:  hc.Headers,
 	}
 }
+
+// hasOnlyStaticServers returns whether all the servers of the backend are file:// ones, which need no health check.
+func hasOnlyStaticServers(backend *types.Backend) bool {
+	if len(backend.Servers) == 0 {
+		return false
+	}
+
+	for _, server := range backend.Servers {
+		if !strings.HasPrefix(server.URL, "file://") {
+			return false
+		}
+	}
+	return true
+}
+
+// parseHealthCheckDuration returns the duration overriding the default health check interval or timeout of a backend.
+func parseHealthCheckDuration(raw string, defaultValue time.Duration, name string, backend string) time.Duration {
+	if raw == "" {
+		return defaultValue
+	}
+
+	value, err := time.ParseDuration(raw)
+	if err != nil {
+		log.Errorf("Illegal health check %s for backend '%s': %s", name, backend, err)
+		return defaultValue
+	}
+
+	if value <= 0 {
+		log.Errorf("Health check %s smaller than zero for backend '%s'", name, backend)
+		return defaultValue
+	}
+
+	return value
+}
@@ -3,6 +3,7 @@ package server
 import (
 	"testing"
 
+	"github.com/containous/traefik/configuration"
 	"github.com/containous/traefik/types"
 	"github.com/stretchr/testify/assert"
 )
@@ -79,3 +80,48 @@ func TestConfigureBackends(t *testing.T) {
 		})
 	}
 }
+
+func TestBuildRateLimitStore(t *testing.T) {
+	testCases := []struct {
+		desc        string
+		config      *configuration.RateLimitStore
+		cluster     *types.Cluster
+		expectedErr bool
+	}{
+		{
+			desc:   "redis",
+			config: &configuration.RateLimitStore{Backend: "redis", Address: "localhost:6379"},
+		},
+		{
+			desc:    "cluster",
+			config:  &configuration.RateLimitStore{Backend: "cluster"},
+			cluster: &types.Cluster{Store: &types.Store{Prefix: "traefik"}},
+		},
+		{
+			desc:        "cluster without cluster mode",
+			config:      &configuration.RateLimitStore{Backend: "cluster"},
+			expectedErr: true,
+		},
+		{
+			desc:        "unknown backend",
+			config:      &configuration.RateLimitStore{Backend: "memcached"},
+			expectedErr: true,
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			store, err := buildRateLimitStore(test.config, test.cluster)
+
+			if test.expectedErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, store)
+			}
+		})
+	}
+}
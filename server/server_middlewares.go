@@ -3,14 +3,23 @@ package server
 import (
 	"fmt"
 	"net/http"
+	"time"
 
+	"github.com/containous/traefik/ip"
 	"github.com/containous/traefik/log"
 	"github.com/containous/traefik/middlewares"
 	"github.com/containous/traefik/middlewares/accesslog"
 	mauth "github.com/containous/traefik/middlewares/auth"
+	"github.com/containous/traefik/middlewares/bodyrate"
+	"github.com/containous/traefik/middlewares/drain"
 	"github.com/containous/traefik/middlewares/errorpages"
+	"github.com/containous/traefik/middlewares/faultinjection"
 	"github.com/containous/traefik/middlewares/forwardedheaders"
+	"github.com/containous/traefik/middlewares/geoip"
+	"github.com/containous/traefik/middlewares/maintenance"
 	"github.com/containous/traefik/middlewares/redirect"
+	"github.com/containous/traefik/middlewares/requestid"
+	"github.com/containous/traefik/middlewares/sizelimit"
 	"github.com/containous/traefik/types"
 	thoas_stats "github.com/thoas/stats"
 	"github.com/unrolled/secure"
@@ -27,6 +36,19 @@ func (s *Server) buildMiddlewares(frontendName string, frontend *types.Frontend,
 	var middle []negroni.Handler
 	var postConfig handlerPostConfig
 
+	// Request ID
+	if frontend.RequestID != nil {
+		requestIDMiddleware, err := buildRequestID(frontend.RequestID)
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("error creating request ID middleware: %v", err)
+		}
+
+		log.Debugf("Adding request ID for frontend %s", frontendName)
+
+		handler := s.tracingMiddleware.NewNegroniHandlerWrapper("Request ID", requestIDMiddleware, false)
+		middle = append(middle, handler)
+	}
+
 	// Error pages
 	if len(frontend.Errors) > 0 {
 		handlers, err := buildErrorPagesMiddleware(frontendName, frontend, backends, entryPointName, providerName)
@@ -48,7 +70,7 @@ func (s *Server) buildMiddlewares(frontendName string, frontend *types.Frontend,
 	}
 
 	// Whitelist
-	ipWhitelistMiddleware, err := buildIPWhiteLister(frontend.WhiteList, s.entryPoints[entryPointName].Configuration.ClientIPStrategy)
+	ipWhitelistMiddleware, err := buildIPWhiteLister(frontend.WhiteList, s.entryPoints[entryPointName].Configuration.ClientIPStrategy, s.ipSets)
 	if err != nil {
 		return nil, nil, nil, fmt.Errorf("error creating IP Whitelister: %s", err)
 	}
@@ -62,6 +84,52 @@ func (s *Server) buildMiddlewares(frontendName string, frontend *types.Frontend,
 		middle = append(middle, handler)
 	}
 
+	// Size limits
+	if frontend.SizeLimits != nil {
+		sizeLimitMiddleware, err := sizelimit.NewSizeLimit(frontend.SizeLimits, s.metricsRegistry.EntrypointRejectedReqsCounter().With("entrypoint", entryPointName))
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("error creating size limit middleware: %v", err)
+		}
+
+		log.Debugf("Adding size limits for frontend %s", frontendName)
+
+		handler := s.tracingMiddleware.NewNegroniHandlerWrapper(
+			"Size limits",
+			s.wrapNegroniHandlerWithAccessLog(sizeLimitMiddleware, fmt.Sprintf("size limits for %s", frontendName)),
+			false)
+		middle = append(middle, handler)
+	}
+
+	// TLS client certificate authorization
+	tlsClientCertAuthMiddleware, err := middlewares.NewTLSClientCertAuth(frontend.TLSClientCertAuth)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("error creating TLS client certificate authorization: %v", err)
+	}
+	if tlsClientCertAuthMiddleware != nil {
+		log.Debugf("Adding TLS client certificate authorization for frontend %s", frontendName)
+
+		handler := s.tracingMiddleware.NewNegroniHandlerWrapper(
+			"TLS client certificate authorization",
+			s.wrapNegroniHandlerWithAccessLog(tlsClientCertAuthMiddleware, fmt.Sprintf("TLS client certificate authorization for %s", frontendName)),
+			false)
+		middle = append(middle, handler)
+	}
+
+	// GeoIP
+	geoIPMiddleware, err := buildGeoIP(frontend.GeoIP, s.entryPoints[entryPointName].Configuration.ClientIPStrategy)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("error creating GeoIP middleware: %v", err)
+	}
+	if geoIPMiddleware != nil {
+		log.Debugf("Adding GeoIP for frontend %s", frontendName)
+
+		handler := s.tracingMiddleware.NewNegroniHandlerWrapper(
+			"GeoIP",
+			s.wrapNegroniHandlerWithAccessLog(geoIPMiddleware, fmt.Sprintf("GeoIP for %s", frontendName)),
+			false)
+		middle = append(middle, handler)
+	}
+
 	// Redirect
 	if frontend.Redirect != nil && entryPointName != frontend.Redirect.EntryPoint {
 		rewrite, err := s.buildRedirectHandler(entryPointName, frontend.Redirect)
@@ -75,8 +143,27 @@ func (s *Server) buildMiddlewares(frontendName string, frontend *types.Frontend,
 		log.Debugf("Frontend %s redirect created", frontendName)
 	}
 
+	// Maintenance
+	maintenanceMiddleware, err := s.buildMaintenance(providerName, frontendName, frontend.Maintenance, s.entryPoints[entryPointName].Configuration.ClientIPStrategy)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("error creating maintenance middleware: %v", err)
+	}
+	if maintenanceMiddleware != nil {
+		log.Debugf("Adding maintenance for frontend %s", frontendName)
+
+		handler := s.tracingMiddleware.NewNegroniHandlerWrapper(
+			"Maintenance",
+			s.wrapNegroniHandlerWithAccessLog(maintenanceMiddleware, fmt.Sprintf("maintenance for %s", frontendName)),
+			false)
+		middle = append(middle, handler)
+	}
+
 	// Header
-	headerMiddleware := middlewares.NewHeaderFromStruct(frontend.Headers)
+	headerMiddleware, err := buildHeaders(frontend.Headers, s.entryPoints[entryPointName].Configuration.ClientIPStrategy)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("error creating Header middleware: %v", err)
+	}
+
 	if headerMiddleware != nil {
 		log.Debugf("Adding header middleware for frontend %s", frontendName)
 
@@ -84,6 +171,16 @@ func (s *Server) buildMiddlewares(frontendName string, frontend *types.Frontend,
 		middle = append(middle, handler)
 	}
 
+	// Body rewrite
+	bodyRewriter, err := middlewares.NewBodyRewriter(frontend.BodyRewrite)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("error creating body rewriter: %v", err)
+	}
+
+	if bodyRewriter != nil {
+		log.Debugf("Adding body rewriter for frontend %s", frontendName)
+	}
+
 	// Secure
 	secureMiddleware := middlewares.NewSecure(frontend.Headers)
 	if secureMiddleware != nil {
@@ -113,11 +210,33 @@ func (s *Server) buildMiddlewares(frontendName string, frontend *types.Frontend,
 		middle = append(middle, handler)
 	}
 
-	return middle, buildModifyResponse(secureMiddleware, headerMiddleware), postConfig, nil
+	// Fault injection
+	if frontend.FaultInjection != nil {
+		faultInjectionMiddleware, err := faultinjection.NewFaultInjection(providerName, frontendName, frontend.FaultInjection, s.faultInjectionToggles)
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("error creating fault injection middleware: %v", err)
+		}
+
+		log.Debugf("Adding fault injection for frontend %s", frontendName)
+
+		handler := s.tracingMiddleware.NewNegroniHandlerWrapper(
+			"Fault injection",
+			s.wrapNegroniHandlerWithAccessLog(faultInjectionMiddleware, fmt.Sprintf("fault injection for %s", frontendName)),
+			false)
+		middle = append(middle, handler)
+	}
+
+	return middle, buildModifyResponse(secureMiddleware, headerMiddleware, bodyRewriter), postConfig, nil
 }
 
-func (s *Server) buildServerEntryPointMiddlewares(serverEntryPointName string) ([]negroni.Handler, error) {
-	serverMiddlewares := []negroni.Handler{middlewares.NegroniRecoverHandler()}
+func (s *Server) buildServerEntryPointMiddlewares(serverEntryPointName string, bodyRateConns *bodyrate.Conns) ([]negroni.Handler, error) {
+	serverMiddlewares := []negroni.Handler{middlewares.NegroniRecoverHandler(), drain.NewHandler(s.drainState)}
+
+	timeouts := buildServerTimeouts(s.globalConfiguration, s.entryPoints[serverEntryPointName].Configuration)
+	if bodyRateConns != nil && timeouts.MinRequestBodyRate > 0 {
+		serverMiddlewares = append(serverMiddlewares, bodyrate.NewMinRate(bodyRateConns, timeouts.MinRequestBodyRate, time.Duration(timeouts.MinRequestBodyRateGracePeriod),
+			time.Duration(timeouts.ReadTimeout), s.metricsRegistry.EntrypointRejectedReqsCounter().With("entrypoint", serverEntryPointName)))
+	}
 
 	if s.tracingMiddleware.IsEnabled() {
 		serverMiddlewares = append(serverMiddlewares, s.tracingMiddleware.NewEntryPoint(serverEntryPointName))
@@ -127,10 +246,27 @@ func (s *Server) buildServerEntryPointMiddlewares(serverEntryPointName string) (
 		serverMiddlewares = append(serverMiddlewares, s.accessLoggerMiddleware)
 	}
 
+	if s.entryPoints[serverEntryPointName].Configuration.RequestID != nil {
+		requestIDMiddleware, err := buildRequestID(s.entryPoints[serverEntryPointName].Configuration.RequestID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create request ID middleware: %v", err)
+		}
+		serverMiddlewares = append(serverMiddlewares, requestIDMiddleware)
+	}
+
 	if s.metricsRegistry.IsEnabled() {
 		serverMiddlewares = append(serverMiddlewares, middlewares.NewEntryPointMetricsMiddleware(s.metricsRegistry, serverEntryPointName))
 	}
 
+	if s.entryPoints[serverEntryPointName].Configuration.SizeLimits != nil {
+		sizeLimitMiddleware, err := sizelimit.NewSizeLimit(s.entryPoints[serverEntryPointName].Configuration.SizeLimits,
+			s.metricsRegistry.EntrypointRejectedReqsCounter().With("entrypoint", serverEntryPointName))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create size limit middleware: %v", err)
+		}
+		serverMiddlewares = append(serverMiddlewares, s.wrapNegroniHandlerWithAccessLog(sizeLimitMiddleware, fmt.Sprintf("size limits for entrypoint %s", serverEntryPointName)))
+	}
+
 	if s.globalConfiguration.API != nil {
 		if s.globalConfiguration.API.Stats == nil {
 			s.globalConfiguration.API.Stats = thoas_stats.New()
@@ -167,7 +303,7 @@ func (s *Server) buildServerEntryPointMiddlewares(serverEntryPointName string) (
 		serverMiddlewares = append(serverMiddlewares, xForwardedMiddleware)
 	}
 
-	ipWhitelistMiddleware, err := buildIPWhiteLister(s.entryPoints[serverEntryPointName].Configuration.WhiteList, s.entryPoints[serverEntryPointName].Configuration.ClientIPStrategy)
+	ipWhitelistMiddleware, err := buildIPWhiteLister(s.entryPoints[serverEntryPointName].Configuration.WhiteList, s.entryPoints[serverEntryPointName].Configuration.ClientIPStrategy, s.ipSets)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create ip whitelist middleware: %v", err)
 	}
@@ -184,6 +320,11 @@ func (s *Server) buildServerEntryPointMiddlewares(serverEntryPointName string) (
 func errorPagesPostConfig(epHandlers []*errorpages.Handler) handlerPostConfig {
 	return func(backendsHandlers map[string]http.Handler) error {
 		for _, errorPageHandler := range epHandlers {
+			// the error page is only rendered from its template
+			if len(errorPageHandler.BackendName) == 0 {
+				continue
+			}
+
 			if handler, ok := backendsHandlers[errorPageHandler.BackendName]; ok {
 				err := errorPageHandler.PostLoad(handler)
 				if err != nil {
@@ -204,7 +345,14 @@ func buildErrorPagesMiddleware(frontendName string, frontend *types.Frontend, ba
 	var errorPageHandlers []*errorpages.Handler
 
 	for errorPageName, errorPage := range frontend.Errors {
-		if frontend.Backend == errorPage.Backend {
+		if len(errorPage.Backend) == 0 && errorPage.HasTemplate() {
+			errorPagesHandler, err := errorpages.NewHandler(errorPage, "")
+			if err != nil {
+				return nil, fmt.Errorf("error creating error pages: %v", err)
+			}
+
+			errorPageHandlers = append(errorPageHandlers, errorPagesHandler)
+		} else if frontend.Backend == errorPage.Backend {
 			log.Errorf("Error when creating error page %q for frontend %q: error pages backend %q is the same as backend for the frontend (infinite call risk).",
 				errorPageName, frontendName, errorPage.Backend)
 		} else if backends[errorPage.Backend] == nil {
@@ -274,20 +422,25 @@ func (s *Server) buildRedirectHandler(srcEntryPointName string, opt *types.Redir
 			return nil, fmt.Errorf("unknown target entrypoint %q", srcEntryPointName)
 		}
 		log.Debugf("Creating entry point redirect %s -> %s", srcEntryPointName, opt.EntryPoint)
-		return redirect.NewEntryPointHandler(entryPoint, opt.Permanent)
+		return redirect.NewHandler(opt, entryPoint)
 	}
 
-	// regex redirect
-	redirection, err := redirect.NewRegexHandler(opt.Regex, opt.Replacement, opt.Permanent)
+	// regex or URL components redirect
+	redirection, err := redirect.NewHandler(opt, nil)
 	if err != nil {
 		return nil, err
 	}
-	log.Debugf("Creating regex redirect %s -> %s -> %s", srcEntryPointName, opt.Regex, opt.Replacement)
+
+	if len(opt.Regex) > 0 {
+		log.Debugf("Creating regex redirect %s -> %s -> %s", srcEntryPointName, opt.Regex, opt.Replacement)
+	} else {
+		log.Debugf("Creating URL redirect %s -> %+v", srcEntryPointName, opt)
+	}
 
 	return redirection, nil
 }
 
-func buildIPWhiteLister(whiteList *types.WhiteList, ipStrategy *types.IPStrategy) (*middlewares.IPWhiteLister, error) {
+func buildIPWhiteLister(whiteList *types.WhiteList, ipStrategy *types.IPStrategy, ipSets map[string]*ip.Set) (*middlewares.IPWhiteLister, error) {
 	if whiteList == nil {
 		return nil, nil
 	}
@@ -301,7 +454,78 @@ func buildIPWhiteLister(whiteList *types.WhiteList, ipStrategy *types.IPStrategy
 		return nil, err
 	}
 
-	return middlewares.NewIPWhiteLister(whiteList.SourceRange, strategy)
+	return middlewares.NewIPFilter(whiteList, ipSets, strategy)
+}
+
+func buildHeaders(headers *types.Headers, ipStrategy *types.IPStrategy) (*middlewares.HeaderStruct, error) {
+	if headers == nil || !headers.HasCustomHeadersDefined() {
+		return nil, nil
+	}
+
+	strategy, err := ipStrategy.Get()
+	if err != nil {
+		return nil, err
+	}
+
+	return middlewares.NewHeaderFromStruct(headers, strategy)
+}
+
+func buildGeoIP(config *types.GeoIP, ipStrategy *types.IPStrategy) (*geoip.GeoIP, error) {
+	if config == nil {
+		return nil, nil
+	}
+
+	if config.IPStrategy != nil {
+		ipStrategy = config.IPStrategy
+	}
+
+	strategy, err := ipStrategy.Get()
+	if err != nil {
+		return nil, err
+	}
+
+	return geoip.NewGeoIP(config, strategy)
+}
+
+// closeUnusedGeoIPDatabases closes the GeoIP databases no frontend of the configurations uses anymore.
+func closeUnusedGeoIPDatabases(configurations types.Configurations) {
+	var paths []string
+	for _, config := range configurations {
+		if config == nil {
+			continue
+		}
+
+		for _, frontend := range config.Frontends {
+			if frontend != nil && frontend.GeoIP != nil {
+				paths = append(paths, frontend.GeoIP.CountryDatabase, frontend.GeoIP.ASNDatabase)
+			}
+		}
+	}
+
+	geoip.CloseUnusedDatabases(paths)
+}
+
+func buildRequestID(config *types.RequestID) (*requestid.RequestID, error) {
+	return requestid.NewRequestID(config.HeaderName, config.Format, config.Insecure, config.TrustedIPs)
+}
+
+// buildMaintenance builds the maintenance middleware of a frontend.
+// It is also built without configuration when the API runtime toggles are enabled, to allow to toggle the maintenance at runtime.
+func (s *Server) buildMaintenance(providerName, frontendName string, config *types.Maintenance, ipStrategy *types.IPStrategy) (*maintenance.Maintenance, error) {
+	if config == nil && s.maintenanceToggles == nil {
+		return nil, nil
+	}
+
+	if config != nil && config.IPStrategy != nil {
+		ipStrategy = config.IPStrategy
+	}
+
+	strategy, err := ipStrategy.Get()
+	if err != nil {
+		return nil, err
+	}
+
+	return maintenance.NewMaintenance(providerName, frontendName, config, s.maintenanceToggles, strategy)
 }
 
 func (s *Server) wrapNegroniHandlerWithAccessLog(handler negroni.Handler, frontendName string) negroni.Handler {
@@ -322,7 +546,7 @@ func (s *Server) wrapHTTPHandlerWithAccessLog(handler http.Handler, frontendName
 	return handler
 }
 
-func buildModifyResponse(secure *secure.Secure, header *middlewares.HeaderStruct) func(res *http.Response) error {
+func buildModifyResponse(secure *secure.Secure, header *middlewares.HeaderStruct, bodyRewriter *middlewares.BodyRewriter) func(res *http.Response) error {
 	return func(res *http.Response) error {
 		if secure != nil {
 			if err := secure.ModifyResponseHeaders(res); err != nil {
@@ -335,6 +559,12 @@ func buildModifyResponse(secure *secure.Secure, header *middlewares.HeaderStruct
 				return err
 			}
 		}
+
+		if bodyRewriter != nil {
+			if err := bodyRewriter.ModifyResponseBody(res); err != nil {
+				return err
+			}
+		}
 		return nil
 	}
 }
@@ -109,6 +109,13 @@ func TestBuildIPWhiteLister(t *testing.T) {
 			middlewareConfigured: true,
 			errMessage:           "",
 		},
+		{
+			desc: "unknown IP set",
+			whiteList: &types.WhiteList{
+				DenySets: []string{"abuse"},
+			},
+			errMessage: `unknown IP set "abuse"`,
+		},
 	}
 
 	for _, test := range testCases {
@@ -116,7 +123,7 @@ func TestBuildIPWhiteLister(t *testing.T) {
 		t.Run(test.desc, func(t *testing.T) {
 			t.Parallel()
 
-			middleware, err := buildIPWhiteLister(test.whiteList, nil)
+			middleware, err := buildIPWhiteLister(test.whiteList, nil, nil)
 
 			if test.errMessage != "" {
 				require.EqualError(t, err, test.errMessage)
@@ -201,6 +208,28 @@ func TestBuildRedirectHandler(t *testing.T) {
 			},
 			expectedURL: "https://foo.com:443",
 		},
+		{
+			desc:              "redirect URL components",
+			srcEntryPointName: "http",
+			url:               "http://foo.com:8080/bar?baz=1",
+			redirect: &types.Redirect{
+				Scheme:     "https",
+				Host:       "www.foo.com",
+				PathPrefix: "/app",
+				DropQuery:  true,
+				StatusCode: http.StatusPermanentRedirect,
+			},
+			expectedURL: "https://www.foo.com/app/bar",
+		},
+		{
+			desc:              "redirect app root",
+			srcEntryPointName: "http",
+			url:               "http://foo.com/",
+			redirect: &types.Redirect{
+				AppRoot: "/app",
+			},
+			expectedURL: "http://foo.com/app",
+		},
 	}
 
 	for _, test := range testCases {
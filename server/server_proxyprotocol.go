@@ -0,0 +1,213 @@
+package server
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"os"
+	"strconv"
+
+	"github.com/containous/traefik/log"
+	"github.com/containous/traefik/tcp"
+	"github.com/containous/traefik/types"
+	"github.com/vulcand/oxy/forward"
+	"github.com/vulcand/oxy/utils"
+)
+
+type proxyProtocolAddrsKey struct{}
+
+// proxyProtocolAddrs holds the addresses of the client connection announced to the backend servers.
+type proxyProtocolAddrs struct {
+	src net.Addr
+	dst net.Addr
+}
+
+func getProxyProtocolVersion(proxyProtocol *types.ProxyProtocol) (int, error) {
+	switch proxyProtocol.Version {
+	case 0, 1:
+		return 1, nil
+	case 2:
+		return 2, nil
+	default:
+		return 0, fmt.Errorf("invalid PROXY protocol version %d", proxyProtocol.Version)
+	}
+}
+
+// configureProxyProtocol makes the transport send the PROXY protocol header on the connections to the servers,
+// announcing the client connection of the forwarded request, or an unknown connection for the health checks.
+// As a header is sent once per connection, the connections are not reused.
+func configureProxyProtocol(transport *http.Transport, version int) {
+	dial := transport.DialContext
+	transport.DialContext = func(ctx context.Context, network, addr string) (net.Conn, error) {
+		conn, err := dial(ctx, network, addr)
+		if err != nil {
+			return nil, err
+		}
+
+		var src, dst net.Addr
+		if addrs, ok := ctx.Value(proxyProtocolAddrsKey{}).(proxyProtocolAddrs); ok {
+			src, dst = addrs.src, addrs.dst
+		}
+
+		if err := tcp.WriteProxyProtocolHeader(conn, version, src, dst); err != nil {
+			conn.Close()
+			return nil, err
+		}
+
+		return conn, nil
+	}
+
+	transport.DisableKeepAlives = true
+
+	// HTTP/2 would multiplex the requests of several clients on a connection
+	transport.TLSNextProto = make(map[string]func(string, *tls.Conn) http.RoundTripper)
+	if transport.TLSClientConfig != nil {
+		transport.TLSClientConfig = transport.TLSClientConfig.Clone()
+		transport.TLSClientConfig.NextProtos = nil
+	}
+}
+
+// proxyProtocolForwarder passes the addresses of the client connection to the transport sending the PROXY protocol header.
+// The WebSocket connections are forwarded through this transport too, instead of the WebSocket dialer of the forwarder.
+type proxyProtocolForwarder struct {
+	next           http.Handler
+	transport      http.RoundTripper
+	passHostHeader bool
+	bufferPool     httputil.BufferPool
+	rewriter       *forward.HeaderRewriter
+	onClosed       func(req *http.Request, conn net.Conn)
+}
+
+func newProxyProtocolForwarder(next http.Handler, transport http.RoundTripper, passHostHeader bool, bufferPool httputil.BufferPool,
+	onClosed func(req *http.Request, conn net.Conn)) *proxyProtocolForwarder {
+	return &proxyProtocolForwarder{
+		next:           next,
+		transport:      transport,
+		passHostHeader: passHostHeader,
+		bufferPool:     bufferPool,
+		rewriter:       &forward.HeaderRewriter{TrustForwardHeader: true, Hostname: getHostname()},
+		onClosed:       onClosed,
+	}
+}
+
+func (f *proxyProtocolForwarder) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	addrs := proxyProtocolAddrs{src: parseTCPAddr(req.RemoteAddr)}
+	if localAddr, ok := req.Context().Value(http.LocalAddrContextKey).(net.Addr); ok {
+		addrs.dst = localAddr
+	}
+
+	req = req.WithContext(context.WithValue(req.Context(), proxyProtocolAddrsKey{}, addrs))
+
+	if !forward.IsWebsocketRequest(req) {
+		f.next.ServeHTTP(rw, req)
+		return
+	}
+
+	f.serveWebSocket(rw, req)
+}
+
+func (f *proxyProtocolForwarder) serveWebSocket(rw http.ResponseWriter, req *http.Request) {
+	// the balancer replaces the URL of the request by the one of the server
+	target := req.URL
+
+	proxy := &httputil.ReverseProxy{
+		Director: func(outReq *http.Request) {
+			u := target
+			if requestURL, err := url.ParseRequestURI(req.RequestURI); err == nil {
+				u = requestURL
+			}
+
+			outReq.URL = &url.URL{Scheme: target.Scheme, Host: target.Host, Path: u.Path, RawPath: u.RawPath, RawQuery: u.RawQuery}
+			outReq.RequestURI = ""
+
+			// the reverse proxy appends the client IP to X-Forwarded-For itself
+			xForwardedFor, hasXForwardedFor := outReq.Header[forward.XForwardedFor]
+			f.rewriter.Rewrite(outReq)
+			if hasXForwardedFor {
+				outReq.Header[forward.XForwardedFor] = xForwardedFor
+			} else {
+				outReq.Header.Del(forward.XForwardedFor)
+			}
+
+			if !f.passHostHeader {
+				outReq.Host = target.Host
+			}
+		},
+		Transport:  f.transport,
+		BufferPool: f.bufferPool,
+		ErrorHandler: func(rw http.ResponseWriter, req *http.Request, err error) {
+			utils.DefaultHandler.ServeHTTP(rw, req, err)
+		},
+	}
+
+	recorder := &hijackRecorder{ResponseWriter: rw}
+	proxy.ServeHTTP(recorder, req)
+
+	if recorder.conn != nil && f.onClosed != nil {
+		f.onClosed(req, recorder.conn)
+	}
+}
+
+// hijackRecorder keeps the connection hijacked to forward an upgraded connection, to notify its closing.
+type hijackRecorder struct {
+	http.ResponseWriter
+	conn net.Conn
+}
+
+func (r *hijackRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hijacker, ok := r.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, errors.New("the response writer does not support hijacking")
+	}
+
+	conn, brw, err := hijacker.Hijack()
+	if err == nil {
+		r.conn = conn
+	}
+	return conn, brw, err
+}
+
+func (r *hijackRecorder) Flush() {
+	if flusher, ok := r.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+func (r *hijackRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
+
+// parseTCPAddr returns the TCP address of a host:port, or nil if it is not one (e.g. the clients of a Unix domain socket).
+func parseTCPAddr(hostPort string) net.Addr {
+	host, port, err := net.SplitHostPort(hostPort)
+	if err != nil {
+		return nil
+	}
+
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return nil
+	}
+
+	portNum, err := strconv.Atoi(port)
+	if err != nil {
+		return nil
+	}
+
+	return &net.TCPAddr{IP: ip, Port: portNum}
+}
+
+func getHostname() string {
+	hostname, err := os.Hostname()
+	if err != nil {
+		log.Debugf("Unable to get the hostname: %v", err)
+		return "localhost"
+	}
+	return hostname
+}
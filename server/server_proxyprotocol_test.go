@@ -0,0 +1,141 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/armon/go-proxyproto"
+	"github.com/containous/traefik/types"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vulcand/oxy/forward"
+	"github.com/vulcand/oxy/roundrobin"
+)
+
+func TestGetProxyProtocolVersion(t *testing.T) {
+	testCases := []struct {
+		desc          string
+		version       int
+		expected      int
+		expectedError bool
+	}{
+		{
+			desc:     "default version",
+			expected: 1,
+		},
+		{
+			desc:     "version 1",
+			version:  1,
+			expected: 1,
+		},
+		{
+			desc:     "version 2",
+			version:  2,
+			expected: 2,
+		},
+		{
+			desc:          "invalid version",
+			version:       3,
+			expectedError: true,
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			version, err := getProxyProtocolVersion(&types.ProxyProtocol{Version: test.version})
+			if test.expectedError {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, version)
+		})
+	}
+}
+
+func TestProxyProtocolForwarder(t *testing.T) {
+	upgrader := websocket.Upgrader{}
+
+	backend := httptest.NewUnstartedServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if !websocket.IsWebSocketUpgrade(req) {
+			fmt.Fprint(rw, req.RemoteAddr)
+			return
+		}
+
+		conn, err := upgrader.Upgrade(rw, req, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		conn.WriteMessage(websocket.TextMessage, []byte(req.RemoteAddr))
+	}))
+	backend.Listener = &proxyproto.Listener{Listener: backend.Listener}
+	backend.Start()
+	defer backend.Close()
+
+	transport := &http.Transport{DialContext: (&net.Dialer{}).DialContext}
+	configureProxyProtocol(transport, 1)
+
+	fwd, err := forward.New(forward.RoundTripper(transport))
+	require.NoError(t, err)
+
+	lb, err := roundrobin.New(newProxyProtocolForwarder(fwd, transport, false, nil, nil))
+	require.NoError(t, err)
+
+	backendURL, err := url.Parse(backend.URL)
+	require.NoError(t, err)
+	require.NoError(t, lb.UpsertServer(backendURL))
+
+	frontend := httptest.NewServer(lb)
+	defer frontend.Close()
+
+	t.Run("HTTP", func(t *testing.T) {
+		var clientAddr net.Addr
+		client := http.Client{Transport: &http.Transport{
+			DialContext: func(ctx context.Context, network, addr string) (net.Conn, error) {
+				conn, err := (&net.Dialer{}).DialContext(ctx, network, addr)
+				if err == nil {
+					clientAddr = conn.LocalAddr()
+				}
+				return conn, err
+			},
+		}}
+
+		resp, err := client.Get(frontend.URL)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		body, err := ioutil.ReadAll(resp.Body)
+		require.NoError(t, err)
+
+		// the backend sees the address of the client connection, not the one of the proxy
+		assert.Equal(t, clientAddr.String(), string(body))
+	})
+
+	t.Run("WebSocket", func(t *testing.T) {
+		frontendURL, err := url.Parse(frontend.URL)
+		require.NoError(t, err)
+		frontendURL.Scheme = "ws"
+
+		conn, _, err := websocket.DefaultDialer.Dial(frontendURL.String(), nil)
+		require.NoError(t, err)
+		defer conn.Close()
+
+		_, message, err := conn.ReadMessage()
+		require.NoError(t, err)
+
+		assert.Equal(t, conn.LocalAddr().String(), string(message))
+	})
+}
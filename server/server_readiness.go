@@ -0,0 +1,98 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// deliveredProviders is implemented by the providers telling which of them have delivered their first configuration.
+type deliveredProviders interface {
+	DeliveredProviders() ([]string, bool)
+}
+
+// readiness tracks whether the first configuration of the providers is applied, by provider name.
+// A provider is known once its first configuration has been handled, pending until it is applied.
+type readiness struct {
+	lock    sync.Mutex
+	pending map[string]bool
+}
+
+// queued records a configuration of the provider waiting to be applied.
+func (r *readiness) queued(providerName string) {
+	r.first(providerName, true)
+}
+
+// skipped records a configuration of the provider with nothing to apply.
+func (r *readiness) skipped(providerName string) {
+	r.first(providerName, false)
+}
+
+// applied records the application of a configuration of the provider.
+func (r *readiness) applied(providerName string) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if r.pending == nil {
+		r.pending = make(map[string]bool)
+	}
+	r.pending[providerName] = false
+}
+
+// first records the first configuration of the provider, the next ones are reloads.
+func (r *readiness) first(providerName string, pending bool) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if r.pending == nil {
+		r.pending = make(map[string]bool)
+	}
+
+	if _, ok := r.pending[providerName]; !ok {
+		r.pending[providerName] = pending
+	}
+}
+
+// check returns an error until the first configuration of every provider has been applied.
+func (r *readiness) check(providerNames []string) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	sort.Strings(providerNames)
+	for _, providerName := range providerNames {
+		if pending, ok := r.pending[providerName]; !ok || pending {
+			return fmt.Errorf("the configuration of the provider %s is not applied yet", providerName)
+		}
+	}
+	return nil
+}
+
+// AddReadinessCheck adds a check which fails while the server is not ready to serve requests.
+func (s *Server) AddReadinessCheck(check func() error) {
+	s.readinessChecks = append(s.readinessChecks, check)
+}
+
+// CheckReadiness returns an error until every provider has delivered its first configuration,
+// the configurations have been applied and the readiness checks pass.
+func (s *Server) CheckReadiness() error {
+	var providerNames []string
+	if provider, ok := s.provider.(deliveredProviders); ok {
+		var delivered bool
+		providerNames, delivered = provider.DeliveredProviders()
+		if !delivered {
+			return errors.New("the providers have not delivered their first configuration yet")
+		}
+	}
+
+	if err := s.readiness.check(providerNames); err != nil {
+		return err
+	}
+
+	for _, check := range s.readinessChecks {
+		if err := check(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
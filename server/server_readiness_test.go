@@ -0,0 +1,127 @@
+package server
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/containous/traefik/safe"
+	"github.com/containous/traefik/types"
+	"github.com/stretchr/testify/assert"
+)
+
+type deliveredProvidersMock struct {
+	names     []string
+	delivered bool
+}
+
+func (p deliveredProvidersMock) Provide(configurationChan chan<- types.ConfigMessage, pool *safe.Pool) error {
+	return nil
+}
+
+func (p deliveredProvidersMock) Init(constraints types.Constraints) error {
+	return nil
+}
+
+func (p deliveredProvidersMock) DeliveredProviders() ([]string, bool) {
+	return p.names, p.delivered
+}
+
+func TestCheckReadiness(t *testing.T) {
+	testCases := []struct {
+		desc          string
+		provider      deliveredProvidersMock
+		records       func(r *readiness)
+		checks        []func() error
+		expectedError bool
+	}{
+		{
+			desc:     "no providers",
+			provider: deliveredProvidersMock{delivered: true},
+		},
+		{
+			desc:          "first configurations not delivered",
+			provider:      deliveredProvidersMock{names: []string{"file"}},
+			expectedError: true,
+		},
+		{
+			desc:          "first configuration not handled",
+			provider:      deliveredProvidersMock{names: []string{"file"}, delivered: true},
+			expectedError: true,
+		},
+		{
+			desc:     "first configuration queued",
+			provider: deliveredProvidersMock{names: []string{"file"}, delivered: true},
+			records: func(r *readiness) {
+				r.queued("file")
+			},
+			expectedError: true,
+		},
+		{
+			desc:     "first configuration applied",
+			provider: deliveredProvidersMock{names: []string{"file"}, delivered: true},
+			records: func(r *readiness) {
+				r.queued("file")
+				r.applied("file")
+			},
+		},
+		{
+			desc:     "empty first configuration",
+			provider: deliveredProvidersMock{names: []string{"file"}, delivered: true},
+			records: func(r *readiness) {
+				r.skipped("file")
+			},
+		},
+		{
+			desc:     "configuration reloading",
+			provider: deliveredProvidersMock{names: []string{"file"}, delivered: true},
+			records: func(r *readiness) {
+				r.queued("file")
+				r.applied("file")
+				r.queued("file")
+			},
+		},
+		{
+			desc:     "one of the configurations applied",
+			provider: deliveredProvidersMock{names: []string{"docker", "file"}, delivered: true},
+			records: func(r *readiness) {
+				r.applied("file")
+				r.queued("docker")
+			},
+			expectedError: true,
+		},
+		{
+			desc:     "failing check",
+			provider: deliveredProvidersMock{names: []string{"file"}, delivered: true},
+			records: func(r *readiness) {
+				r.applied("file")
+			},
+			checks: []func() error{
+				func() error { return nil },
+				func() error { return errors.New("not ready") },
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			server := &Server{provider: test.provider}
+			if test.records != nil {
+				test.records(&server.readiness)
+			}
+			for _, check := range test.checks {
+				server.AddReadinessCheck(check)
+			}
+
+			err := server.CheckReadiness()
+			if test.expectedError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
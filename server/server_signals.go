@@ -10,7 +10,7 @@ import (
 )
 
 func (s *Server) configureSignals() {
-	signal.Notify(s.signals, syscall.SIGUSR1)
+	signal.Notify(s.signals, syscall.SIGUSR1, syscall.SIGUSR2, syscall.SIGTTOU, syscall.SIGTTIN)
 }
 
 func (s *Server) listenSignals(stop chan bool) {
@@ -32,6 +32,24 @@ func (s *Server) listenSignals(stop chan bool) {
 				if err := log.RotateFile(); err != nil {
 					log.Errorf("Error rotating traefik log: %v", err)
 				}
+			case syscall.SIGTTOU:
+				if s.drainState.Drain() {
+					log.Infof("Entering drain mode: %+v", sig)
+				}
+			case syscall.SIGTTIN:
+				if s.drainState.Undrain() {
+					log.Infof("Leaving drain mode: %+v", sig)
+				}
+			case syscall.SIGUSR2:
+				log.Infof("Starting a new process to take over the entry points: %+v", sig)
+
+				if err := s.upgrade(); err != nil {
+					log.Errorf("Error starting the new process: %v", err)
+					continue
+				}
+
+				s.handOff()
+				return
 			}
 		}
 	}
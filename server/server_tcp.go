@@ -0,0 +1,164 @@
+package server
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/containous/traefik/configuration"
+	"github.com/containous/traefik/log"
+	"github.com/containous/traefik/tcp"
+	"github.com/containous/traefik/types"
+)
+
+// loadTCPConfig adds the routes of the TCP frontends to the entry points, and returns the health checks of their backends.
+func (s *Server) loadTCPConfig(configurations types.Configurations, serverEntryPoints map[string]*serverEntryPoint) []*tcp.HealthCheck {
+	var providerNames []string
+	for providerName := range configurations {
+		providerNames = append(providerNames, providerName)
+	}
+	sort.Strings(providerNames)
+
+	var healthChecks []*tcp.HealthCheck
+
+	for _, providerName := range providerNames {
+		config := configurations[providerName]
+
+		var frontendNames []string
+		for frontendName := range config.TCPFrontends {
+			frontendNames = append(frontendNames, frontendName)
+		}
+		sort.Strings(frontendNames)
+
+		balancers := make(map[string]*tcp.LoadBalancer)
+
+		for _, frontendName := range frontendNames {
+			frontend := config.TCPFrontends[frontendName]
+
+			lb, ok := balancers[frontend.Backend]
+			if !ok {
+				backend := config.TCPBackends[frontend.Backend]
+				if backend == nil {
+					log.Errorf("Undefined TCP backend '%s' for TCP frontend %s. Skipping TCP frontend %s...", frontend.Backend, frontendName, frontendName)
+					continue
+				}
+
+				var healthCheck *tcp.HealthCheck
+				var err error
+				lb, healthCheck, err = s.buildTCPLoadBalancer(frontend.Backend, backend)
+				if err != nil {
+					log.Errorf("%v. Skipping TCP frontend %s...", err, frontendName)
+					continue
+				}
+
+				balancers[frontend.Backend] = lb
+				if healthCheck != nil {
+					healthChecks = append(healthChecks, healthCheck)
+				}
+			}
+
+			if err := s.addTCPRoutes(frontendName, frontend, lb, serverEntryPoints); err != nil {
+				log.Errorf("%v. Skipping TCP frontend %s...", err, frontendName)
+			}
+		}
+	}
+
+	return healthChecks
+}
+
+func (s *Server) configureTCPFrontends(frontends map[string]*types.TCPFrontend) {
+	for frontendName, frontend := range frontends {
+		// default endpoints if not defined in frontends
+		if len(frontend.EntryPoints) == 0 {
+			frontend.EntryPoints = s.globalConfiguration.DefaultEntryPoints
+		}
+
+		frontendEntryPoints, undefinedEntryPoints := s.filterEntryPoints(frontend.EntryPoints)
+		if len(undefinedEntryPoints) > 0 {
+			log.Errorf("Undefined entry point(s) '%s' for TCP frontend %s", strings.Join(undefinedEntryPoints, ","), frontendName)
+		}
+
+		frontend.EntryPoints = frontendEntryPoints
+	}
+}
+
+func (s *Server) addTCPRoutes(frontendName string, frontend *types.TCPFrontend, handler tcp.Handler, serverEntryPoints map[string]*serverEntryPoint) error {
+	hosts, err := tcp.ParseRule(frontend.Rule)
+	if err != nil {
+		return err
+	}
+
+	if len(frontend.EntryPoints) == 0 {
+		return fmt.Errorf("no entrypoint defined for TCP frontend %s", frontendName)
+	}
+
+	for _, entryPointName := range frontend.EntryPoints {
+		serverEntryPoint, ok := serverEntryPoints[entryPointName]
+		if !ok {
+			continue
+		}
+
+		if s.entryPoints[entryPointName].Configuration.UDP != nil {
+			log.Errorf("Entry point %s is a UDP entry point, skipping it for TCP frontend %s", entryPointName, frontendName)
+			continue
+		}
+
+		if !frontend.Passthrough && !tcp.IsCatchAll(hosts) && s.entryPoints[entryPointName].Configuration.TLS == nil {
+			log.Errorf("TLS is not enabled on entry point %s: the TLS connections of TCP frontend %s can only be passed through", entryPointName, frontendName)
+			continue
+		}
+
+		log.Debugf("Wiring TCP frontend %s to entryPoint %s", frontendName, entryPointName)
+
+		if err := serverEntryPoint.tcpRouter.GetRouter().AddRoute(frontendName, hosts, frontend.Passthrough, handler); err != nil {
+			log.Errorf("Unable to add TCP frontend %s to entry point %s: %v", frontendName, entryPointName, err)
+		}
+	}
+
+	return nil
+}
+
+func (s *Server) buildTCPLoadBalancer(backendName string, backend *types.TCPBackend) (*tcp.LoadBalancer, *tcp.HealthCheck, error) {
+	if len(backend.Servers) == 0 {
+		return nil, nil, fmt.Errorf("no server in TCP backend %s", backendName)
+	}
+
+	dialTimeout := configuration.DefaultDialTimeout
+	if s.globalConfiguration.ForwardingTimeouts != nil {
+		dialTimeout = time.Duration(s.globalConfiguration.ForwardingTimeouts.DialTimeout)
+	}
+
+	var serverNames []string
+	for serverName := range backend.Servers {
+		serverNames = append(serverNames, serverName)
+	}
+	sort.Strings(serverNames)
+
+	lb := tcp.NewLoadBalancer(backendName)
+	for _, serverName := range serverNames {
+		srv := backend.Servers[serverName]
+		if len(srv.Address) == 0 {
+			return nil, nil, fmt.Errorf("no address for server %s of TCP backend %s", serverName, backendName)
+		}
+
+		log.Debugf("Creating TCP server %s at %s with weight %d", serverName, srv.Address, srv.Weight)
+		lb.AddServer(srv.Address, srv.Weight, tcp.NewProxy(srv.Address, dialTimeout))
+	}
+
+	if backend.HealthCheck == nil {
+		return lb, nil, nil
+	}
+
+	interval := configuration.DefaultHealthCheckInterval
+	timeout := configuration.DefaultHealthCheckTimeout
+	if s.globalConfiguration.HealthCheck != nil {
+		interval = time.Duration(s.globalConfiguration.HealthCheck.Interval)
+		timeout = time.Duration(s.globalConfiguration.HealthCheck.Timeout)
+	}
+
+	interval = parseHealthCheckDuration(backend.HealthCheck.Interval, interval, "interval", backendName)
+	timeout = parseHealthCheckDuration(backend.HealthCheck.Timeout, timeout, "timeout", backendName)
+
+	return lb, tcp.NewHealthCheck(lb, interval, timeout), nil
+}
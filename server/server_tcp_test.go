@@ -0,0 +1,80 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/containous/traefik/configuration"
+	"github.com/containous/traefik/tls"
+	"github.com/containous/traefik/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServerLoadTCPConfig(t *testing.T) {
+	entryPoints := map[string]EntryPoint{
+		"https": {Configuration: &configuration.EntryPoint{TLS: &tls.TLS{}}},
+		"http":  {Configuration: &configuration.EntryPoint{}},
+	}
+
+	srv := NewServer(configuration.GlobalConfiguration{}, nil, entryPoints)
+
+	dynamicConfigs := types.Configurations{
+		"config": &types.Configuration{
+			TCPFrontends: map[string]*types.TCPFrontend{
+				"postgres": {
+					EntryPoints: []string{"https", "http"},
+					Backend:     "postgres",
+					Rule:        "HostSNI:db.example.com",
+				},
+				"mqtt": {
+					EntryPoints: []string{"https"},
+					Backend:     "mqtt",
+					Rule:        "HostSNI:mqtt.example.com",
+					Passthrough: true,
+				},
+				"plain": {
+					EntryPoints: []string{"http"},
+					Backend:     "mqtt",
+					Rule:        "HostSNI:*",
+				},
+				"undefined": {
+					EntryPoints: []string{"https"},
+					Backend:     "undefined",
+					Rule:        "HostSNI:undefined.example.com",
+				},
+				"invalid": {
+					EntryPoints: []string{"https"},
+					Backend:     "mqtt",
+					Rule:        "Host:invalid.example.com",
+				},
+			},
+			TCPBackends: map[string]*types.TCPBackend{
+				"postgres": {
+					Servers: map[string]types.TCPServer{
+						"server1": {Address: "10.0.0.1:5432"},
+					},
+					HealthCheck: &types.TCPHealthCheck{Interval: "10s"},
+				},
+				"mqtt": {
+					Servers: map[string]types.TCPServer{
+						"server1": {Address: "10.0.0.2:8883", Weight: 2},
+						"server2": {Address: "10.0.0.3:8883"},
+					},
+				},
+			},
+		},
+	}
+
+	serverEntryPoints := srv.buildServerEntryPoints()
+	healthChecks := srv.loadTCPConfig(dynamicConfigs, serverEntryPoints)
+	assert.Len(t, healthChecks, 1)
+
+	httpsRouter := serverEntryPoints["https"].tcpRouter.GetRouter()
+	assert.Equal(t, "postgres", httpsRouter.Match("db.example.com"))
+	assert.Equal(t, "mqtt", httpsRouter.Match("mqtt.example.com"))
+	assert.Empty(t, httpsRouter.Match("undefined.example.com"))
+	assert.Empty(t, httpsRouter.Match("invalid.example.com"))
+
+	// TLS cannot be terminated on the http entry point
+	httpRouter := serverEntryPoints["http"].tcpRouter.GetRouter()
+	assert.Equal(t, "plain", httpRouter.Match("db.example.com"))
+}
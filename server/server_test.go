@@ -9,6 +9,7 @@ import (
 
 	"github.com/containous/flaeg/parse"
 	"github.com/containous/mux"
+	"github.com/containous/traefik/api"
 	"github.com/containous/traefik/configuration"
 	"github.com/containous/traefik/middlewares"
 	th "github.com/containous/traefik/testhelpers"
@@ -20,24 +21,28 @@ import (
 
 func TestPrepareServerTimeouts(t *testing.T) {
 	testCases := []struct {
-		desc                 string
-		globalConfig         configuration.GlobalConfiguration
-		expectedIdleTimeout  time.Duration
-		expectedReadTimeout  time.Duration
-		expectedWriteTimeout time.Duration
+		desc                      string
+		globalConfig              configuration.GlobalConfiguration
+		entryPointTimeouts        *configuration.RespondingTimeouts
+		expectedIdleTimeout       time.Duration
+		expectedReadTimeout       time.Duration
+		expectedReadHeaderTimeout time.Duration
+		expectedWriteTimeout      time.Duration
 	}{
 		{
 			desc: "full configuration",
 			globalConfig: configuration.GlobalConfiguration{
 				RespondingTimeouts: &configuration.RespondingTimeouts{
-					IdleTimeout:  parse.Duration(10 * time.Second),
-					ReadTimeout:  parse.Duration(12 * time.Second),
-					WriteTimeout: parse.Duration(14 * time.Second),
+					IdleTimeout:       parse.Duration(10 * time.Second),
+					ReadTimeout:       parse.Duration(12 * time.Second),
+					WriteTimeout:      parse.Duration(14 * time.Second),
+					ReadHeaderTimeout: parse.Duration(5 * time.Second),
 				},
 			},
-			expectedIdleTimeout:  10 * time.Second,
-			expectedReadTimeout:  12 * time.Second,
-			expectedWriteTimeout: 14 * time.Second,
+			expectedIdleTimeout:       10 * time.Second,
+			expectedReadTimeout:       12 * time.Second,
+			expectedReadHeaderTimeout: 5 * time.Second,
+			expectedWriteTimeout:      14 * time.Second,
 		},
 		{
 			desc:                 "using defaults",
@@ -46,6 +51,70 @@ func TestPrepareServerTimeouts(t *testing.T) {
 			expectedReadTimeout:  0 * time.Second,
 			expectedWriteTimeout: 0 * time.Second,
 		},
+		{
+			desc: "entry point configuration",
+			globalConfig: configuration.GlobalConfiguration{
+				RespondingTimeouts: &configuration.RespondingTimeouts{
+					IdleTimeout:  parse.Duration(10 * time.Second),
+					ReadTimeout:  parse.Duration(12 * time.Second),
+					WriteTimeout: parse.Duration(14 * time.Second),
+				},
+			},
+			entryPointTimeouts: &configuration.RespondingTimeouts{
+				IdleTimeout:        parse.Duration(20 * time.Second),
+				ReadTimeout:        parse.Duration(22 * time.Second),
+				WriteTimeout:       parse.Duration(24 * time.Second),
+				ReadHeaderTimeout:  parse.Duration(5 * time.Second),
+				MinRequestBodyRate: 1024,
+			},
+			expectedIdleTimeout:       20 * time.Second,
+			expectedReadTimeout:       22 * time.Second,
+			expectedReadHeaderTimeout: 5 * time.Second,
+			expectedWriteTimeout:      24 * time.Second,
+		},
+		{
+			desc: "partial entry point configuration",
+			globalConfig: configuration.GlobalConfiguration{
+				RespondingTimeouts: &configuration.RespondingTimeouts{
+					IdleTimeout:  parse.Duration(10 * time.Second),
+					ReadTimeout:  parse.Duration(12 * time.Second),
+					WriteTimeout: parse.Duration(14 * time.Second),
+				},
+			},
+			entryPointTimeouts: &configuration.RespondingTimeouts{
+				ReadHeaderTimeout: parse.Duration(5 * time.Second),
+			},
+			expectedIdleTimeout:       10 * time.Second,
+			expectedReadTimeout:       12 * time.Second,
+			expectedReadHeaderTimeout: 5 * time.Second,
+			expectedWriteTimeout:      14 * time.Second,
+		},
+		{
+			desc: "entry point configuration disabling global timeouts",
+			globalConfig: configuration.GlobalConfiguration{
+				RespondingTimeouts: &configuration.RespondingTimeouts{
+					IdleTimeout:        parse.Duration(10 * time.Second),
+					ReadTimeout:        parse.Duration(12 * time.Second),
+					WriteTimeout:       parse.Duration(14 * time.Second),
+					MinRequestBodyRate: 1024,
+				},
+			},
+			entryPointTimeouts: &configuration.RespondingTimeouts{
+				ReadTimeout:        parse.Duration(-time.Second),
+				MinRequestBodyRate: -1,
+			},
+			expectedIdleTimeout:  10 * time.Second,
+			expectedReadTimeout:  0 * time.Second,
+			expectedWriteTimeout: 14 * time.Second,
+		},
+		{
+			desc: "entry point configuration without global configuration",
+			entryPointTimeouts: &configuration.RespondingTimeouts{
+				ReadTimeout: parse.Duration(12 * time.Second),
+			},
+			expectedIdleTimeout: 180 * time.Second,
+			expectedReadTimeout: 12 * time.Second,
+		},
 	}
 
 	for _, test := range testCases {
@@ -56,8 +125,9 @@ func TestPrepareServerTimeouts(t *testing.T) {
 
 			entryPointName := "http"
 			entryPoint := &configuration.EntryPoint{
-				Address:          "localhost:0",
-				ForwardedHeaders: &configuration.ForwardedHeaders{Insecure: true},
+				Address:            "localhost:0",
+				ForwardedHeaders:   &configuration.ForwardedHeaders{Insecure: true},
+				RespondingTimeouts: test.entryPointTimeouts,
 			}
 			router := middlewares.NewHandlerSwitcher(mux.NewRouter())
 
@@ -67,11 +137,49 @@ func TestPrepareServerTimeouts(t *testing.T) {
 
 			assert.Equal(t, test.expectedIdleTimeout, httpServer.IdleTimeout, "IdleTimeout")
 			assert.Equal(t, test.expectedReadTimeout, httpServer.ReadTimeout, "ReadTimeout")
+			assert.Equal(t, test.expectedReadHeaderTimeout, httpServer.ReadHeaderTimeout, "ReadHeaderTimeout")
 			assert.Equal(t, test.expectedWriteTimeout, httpServer.WriteTimeout, "WriteTimeout")
 		})
 	}
 }
 
+func TestNewServerRuntimeToggles(t *testing.T) {
+	testCases := []struct {
+		desc            string
+		api             *api.Handler
+		expectedToggles bool
+	}{
+		{
+			desc: "without API",
+		},
+		{
+			desc: "read-only API",
+			api:  &api.Handler{},
+		},
+		{
+			desc:            "API with runtime toggles",
+			api:             &api.Handler{RuntimeToggles: true},
+			expectedToggles: true,
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			srv := NewServer(configuration.GlobalConfiguration{API: test.api}, nil, nil)
+
+			assert.Equal(t, test.expectedToggles, srv.maintenanceToggles != nil, "maintenance toggles")
+			assert.Equal(t, test.expectedToggles, srv.faultInjectionToggles != nil, "fault injection toggles")
+			if test.api != nil {
+				assert.Equal(t, test.expectedToggles, test.api.MaintenanceToggles != nil, "API maintenance toggles")
+				assert.Equal(t, test.expectedToggles, test.api.FaultInjectionToggles != nil, "API fault injection toggles")
+			}
+		})
+	}
+}
+
 func TestListenProvidersSkipsEmptyConfigs(t *testing.T) {
 	server, stop, invokeStopChan := setupListenProvider(10 * time.Millisecond)
 	defer invokeStopChan()
@@ -349,6 +457,14 @@ func (c mockContext) Value(key interface{}) interface{} {
 	return c.headers
 }
 
+func mustNewHeaderFromStruct(headers *types.Headers) *middlewares.HeaderStruct {
+	header, err := middlewares.NewHeaderFromStruct(headers, nil)
+	if err != nil {
+		panic(err)
+	}
+	return header
+}
+
 func TestNewServerWithResponseModifiers(t *testing.T) {
 	testCases := []struct {
 		desc             string
@@ -369,7 +485,7 @@ func TestNewServerWithResponseModifiers(t *testing.T) {
 		},
 		{
 			desc: "header middleware not nil",
-			headerMiddleware: middlewares.NewHeaderFromStruct(&types.Headers{
+			headerMiddleware: mustNewHeaderFromStruct(&types.Headers{
 				CustomResponseHeaders: map[string]string{
 					"X-Default": "powpow",
 				},
@@ -397,7 +513,7 @@ func TestNewServerWithResponseModifiers(t *testing.T) {
 		},
 		{
 			desc: "header and secure middleware not nil",
-			headerMiddleware: middlewares.NewHeaderFromStruct(&types.Headers{
+			headerMiddleware: mustNewHeaderFromStruct(&types.Headers{
 				CustomResponseHeaders: map[string]string{
 					"Referrer-Policy": "powpow",
 				},
@@ -431,7 +547,7 @@ func TestNewServerWithResponseModifiers(t *testing.T) {
 				Header:  headers,
 			}
 
-			responseModifier := buildModifyResponse(test.secureMiddleware, test.headerMiddleware)
+			responseModifier := buildModifyResponse(test.secureMiddleware, test.headerMiddleware, nil)
 			err := responseModifier(res)
 
 			assert.NoError(t, err)
@@ -0,0 +1,164 @@
+package server
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/containous/traefik/configuration"
+	"github.com/containous/traefik/log"
+	"github.com/containous/traefik/types"
+	"github.com/containous/traefik/udp"
+)
+
+// loadUDPConfig sets the load balancers of the UDP entry points: an entry point forwards its datagrams to a single UDP frontend.
+func (s *Server) loadUDPConfig(configurations types.Configurations, serverEntryPoints map[string]*serverEntryPoint) {
+	var providerNames []string
+	for providerName := range configurations {
+		providerNames = append(providerNames, providerName)
+	}
+	sort.Strings(providerNames)
+
+	for _, providerName := range providerNames {
+		config := configurations[providerName]
+
+		var frontendNames []string
+		for frontendName := range config.UDPFrontends {
+			frontendNames = append(frontendNames, frontendName)
+		}
+		sort.Strings(frontendNames)
+
+		balancers := make(map[string]*udp.LoadBalancer)
+
+		for _, frontendName := range frontendNames {
+			frontend := config.UDPFrontends[frontendName]
+
+			lb, ok := balancers[frontend.Backend]
+			if !ok {
+				backend := config.UDPBackends[frontend.Backend]
+				if backend == nil {
+					log.Errorf("Undefined UDP backend '%s' for UDP frontend %s. Skipping UDP frontend %s...", frontend.Backend, frontendName, frontendName)
+					continue
+				}
+
+				var err error
+				lb, err = buildUDPLoadBalancer(frontend.Backend, backend)
+				if err != nil {
+					log.Errorf("%v. Skipping UDP frontend %s...", err, frontendName)
+					continue
+				}
+
+				balancers[frontend.Backend] = lb
+			}
+
+			if err := s.addUDPLoadBalancer(frontendName, frontend, lb, serverEntryPoints); err != nil {
+				log.Errorf("%v. Skipping UDP frontend %s...", err, frontendName)
+			}
+		}
+	}
+}
+
+func (s *Server) configureUDPFrontends(frontends map[string]*types.UDPFrontend) {
+	for frontendName, frontend := range frontends {
+		frontendEntryPoints, undefinedEntryPoints := s.filterEntryPoints(frontend.EntryPoints)
+		if len(undefinedEntryPoints) > 0 {
+			log.Errorf("Undefined entry point(s) '%s' for UDP frontend %s", strings.Join(undefinedEntryPoints, ","), frontendName)
+		}
+
+		frontend.EntryPoints = frontendEntryPoints
+	}
+}
+
+func (s *Server) addUDPLoadBalancer(frontendName string, frontend *types.UDPFrontend, lb *udp.LoadBalancer, serverEntryPoints map[string]*serverEntryPoint) error {
+	if len(frontend.EntryPoints) == 0 {
+		return fmt.Errorf("no entrypoint defined for UDP frontend %s", frontendName)
+	}
+
+	for _, entryPointName := range frontend.EntryPoints {
+		serverEntryPoint, ok := serverEntryPoints[entryPointName]
+		if !ok {
+			continue
+		}
+
+		if s.entryPoints[entryPointName].Configuration.UDP == nil {
+			log.Errorf("Entry point %s is not a UDP entry point, skipping it for UDP frontend %s", entryPointName, frontendName)
+			continue
+		}
+
+		if serverEntryPoint.udpFrontendName != "" {
+			log.Errorf("UDP entry point %s already forwards to UDP frontend %s, skipping it for UDP frontend %s", entryPointName, serverEntryPoint.udpFrontendName, frontendName)
+			continue
+		}
+
+		log.Debugf("Wiring UDP frontend %s to entryPoint %s", frontendName, entryPointName)
+
+		serverEntryPoint.udpFrontendName = frontendName
+		serverEntryPoint.udpLoadBalancer = lb
+	}
+
+	return nil
+}
+
+func buildUDPLoadBalancer(backendName string, backend *types.UDPBackend) (*udp.LoadBalancer, error) {
+	if len(backend.Servers) == 0 {
+		return nil, fmt.Errorf("no server in UDP backend %s", backendName)
+	}
+
+	var serverNames []string
+	for serverName := range backend.Servers {
+		serverNames = append(serverNames, serverName)
+	}
+	sort.Strings(serverNames)
+
+	lb := udp.NewLoadBalancer(backendName)
+	for _, serverName := range serverNames {
+		srv := backend.Servers[serverName]
+		if len(srv.Address) == 0 {
+			return nil, fmt.Errorf("no address for server %s of UDP backend %s", serverName, backendName)
+		}
+
+		log.Debugf("Creating UDP server %s at %s with weight %d", serverName, srv.Address, srv.Weight)
+		lb.AddServer(srv.Address, srv.Weight)
+	}
+
+	return lb, nil
+}
+
+func (s *Server) setupUDPServerEntryPoint(entryPointName string, serverEntryPoint *serverEntryPoint) *serverEntryPoint {
+	entryPoint := s.entryPoints[entryPointName].Configuration
+
+	conn := s.inheritedSockets.packetConn(entryPointName, "udp", entryPoint.Address)
+	if conn == nil {
+		var err error
+		conn, err = net.ListenPacket("udp", entryPoint.Address)
+		if err != nil {
+			log.Fatal("Error opening UDP listener: ", err)
+		}
+	}
+	s.addSocket(entryPointName, conn.(*net.UDPConn))
+
+	idleTimeout := time.Duration(entryPoint.UDP.IdleTimeout)
+	if idleTimeout <= 0 {
+		idleTimeout = configuration.DefaultUDPIdleTimeout
+	}
+
+	maxSessions := entryPoint.UDP.MaxSessions
+	if maxSessions <= 0 {
+		maxSessions = configuration.DefaultUDPMaxSessions
+	}
+
+	log.Infof("Preparing UDP server %s %+v with idleTimeout=%s maxSessions=%d", entryPointName, entryPoint, idleTimeout, maxSessions)
+
+	serverEntryPoint.udpServer = udp.NewServer(conn, entryPointName, idleTimeout, maxSessions, s.metricsRegistry)
+	return serverEntryPoint
+}
+
+func (s *Server) startUDPServer(serverEntryPoint *serverEntryPoint) {
+	log.Infof("Starting UDP server on %s", serverEntryPoint.udpServer.Addr())
+
+	if err := serverEntryPoint.udpServer.Serve(); err != nil {
+		log.Error("Error running UDP server: ", err)
+	}
+}
@@ -0,0 +1,68 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/containous/traefik/configuration"
+	"github.com/containous/traefik/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServerLoadUDPConfig(t *testing.T) {
+	entryPoints := map[string]EntryPoint{
+		"dns":    {Configuration: &configuration.EntryPoint{UDP: &configuration.UDP{}}},
+		"syslog": {Configuration: &configuration.EntryPoint{UDP: &configuration.UDP{}}},
+		"http":   {Configuration: &configuration.EntryPoint{}},
+	}
+
+	srv := NewServer(configuration.GlobalConfiguration{}, nil, entryPoints)
+
+	dynamicConfigs := types.Configurations{
+		"config": &types.Configuration{
+			UDPFrontends: map[string]*types.UDPFrontend{
+				"dns": {
+					EntryPoints: []string{"dns", "http"},
+					Backend:     "dns",
+				},
+				"dns-duplicate": {
+					EntryPoints: []string{"dns"},
+					Backend:     "syslog",
+				},
+				"syslog": {
+					EntryPoints: []string{"syslog"},
+					Backend:     "syslog",
+				},
+				"undefined": {
+					EntryPoints: []string{"syslog"},
+					Backend:     "undefined",
+				},
+			},
+			UDPBackends: map[string]*types.UDPBackend{
+				"dns": {
+					Servers: map[string]types.UDPServer{
+						"server1": {Address: "10.0.0.1:53", Weight: 2},
+						"server2": {Address: "10.0.0.2:53"},
+					},
+				},
+				"syslog": {
+					Servers: map[string]types.UDPServer{
+						"server1": {Address: "10.0.0.3:514"},
+					},
+				},
+			},
+		},
+	}
+
+	serverEntryPoints := srv.buildServerEntryPoints()
+	srv.loadUDPConfig(dynamicConfigs, serverEntryPoints)
+
+	require.NotNil(t, serverEntryPoints["dns"].udpLoadBalancer)
+	assert.Equal(t, "dns", serverEntryPoints["dns"].udpFrontendName)
+
+	require.NotNil(t, serverEntryPoints["syslog"].udpLoadBalancer)
+	assert.Equal(t, "syslog", serverEntryPoints["syslog"].udpFrontendName)
+
+	// UDP frontends cannot be served by the entry points without UDP
+	assert.Nil(t, serverEntryPoints["http"].udpLoadBalancer)
+}
@@ -0,0 +1,178 @@
+package server
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"os/user"
+	"strconv"
+
+	"github.com/containous/traefik/configuration"
+	"github.com/containous/traefik/log"
+)
+
+const unixSocketScheme = "unix"
+
+// listenUnixSocket creates a listener on the Unix domain socket at path,
+// with the file mode and owner given by the entry point options.
+func listenUnixSocket(path string, options *configuration.UnixSocket) (net.Listener, error) {
+	// a socket left over by a previous process prevents the creation of the new one
+	if fi, err := os.Lstat(path); err == nil && fi.Mode()&os.ModeSocket != 0 {
+		log.Debugf("Removing stale Unix domain socket %s", path)
+		if err := os.Remove(path); err != nil {
+			return nil, fmt.Errorf("unable to remove stale Unix domain socket %s: %v", path, err)
+		}
+	}
+
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := setUnixSocketPermissions(path, options); err != nil {
+		listener.Close()
+		return nil, err
+	}
+
+	return listener, nil
+}
+
+func setUnixSocketPermissions(path string, options *configuration.UnixSocket) error {
+	if options == nil {
+		return nil
+	}
+
+	if len(options.Mode) > 0 {
+		mode, err := strconv.ParseUint(options.Mode, 8, 32)
+		if err != nil {
+			return fmt.Errorf("invalid Unix domain socket mode %q: %v", options.Mode, err)
+		}
+
+		if err := os.Chmod(path, os.FileMode(mode)); err != nil {
+			return fmt.Errorf("unable to change the mode of the Unix domain socket %s: %v", path, err)
+		}
+	}
+
+	if len(options.Owner) == 0 && len(options.Group) == 0 {
+		return nil
+	}
+
+	uid, gid := -1, -1
+
+	if len(options.Owner) > 0 {
+		id, err := lookupUnixSocketID(options.Owner, func(name string) (string, error) {
+			u, err := user.Lookup(name)
+			if err != nil {
+				return "", err
+			}
+			return u.Uid, nil
+		})
+		if err != nil {
+			return fmt.Errorf("invalid Unix domain socket owner %q: %v", options.Owner, err)
+		}
+		uid = id
+	}
+
+	if len(options.Group) > 0 {
+		id, err := lookupUnixSocketID(options.Group, func(name string) (string, error) {
+			g, err := user.LookupGroup(name)
+			if err != nil {
+				return "", err
+			}
+			return g.Gid, nil
+		})
+		if err != nil {
+			return fmt.Errorf("invalid Unix domain socket group %q: %v", options.Group, err)
+		}
+		gid = id
+	}
+
+	if err := os.Lchown(path, uid, gid); err != nil {
+		return fmt.Errorf("unable to change the owner of the Unix domain socket %s: %v", path, err)
+	}
+
+	return nil
+}
+
+// lookupUnixSocketID returns the numeric ID given as is, and looks up the ID of a name otherwise.
+func lookupUnixSocketID(value string, lookup func(name string) (string, error)) (int, error) {
+	if id, err := strconv.Atoi(value); err == nil {
+		return id, nil
+	}
+
+	id, err := lookup(value)
+	if err != nil {
+		return 0, err
+	}
+
+	return strconv.Atoi(id)
+}
+
+// parseServerURL parses the URL of a backend server.
+// The forwarder only keeps the scheme and the host of the server URLs,
+// so the socket path of a unix:// server URL is moved to its host, hex-encoded to remain a valid host.
+func parseServerURL(rawURL string) (*url.URL, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+
+	if u.Scheme != unixSocketScheme {
+		return u, nil
+	}
+
+	if len(u.Host) > 0 || len(u.Path) == 0 {
+		return nil, fmt.Errorf("a Unix domain socket URL must be unix:///path/to/socket, got %s", rawURL)
+	}
+
+	return &url.URL{Scheme: unixSocketScheme, Host: hex.EncodeToString([]byte(u.Path))}, nil
+}
+
+// unixSocketTransport forwards over HTTP/1.1 the requests sent to unix:// server URLs,
+// on the Unix domain socket whose hex-encoded path is the host of the URL.
+type unixSocketTransport struct {
+	*http.Transport
+}
+
+func newUnixSocketTransport(dialer *net.Dialer, transport *http.Transport) *unixSocketTransport {
+	return &unixSocketTransport{
+		Transport: &http.Transport{
+			DialContext: func(ctx context.Context, _, addr string) (net.Conn, error) {
+				host, _, err := net.SplitHostPort(addr)
+				if err != nil {
+					return nil, err
+				}
+
+				path, err := hex.DecodeString(host)
+				if err != nil {
+					return nil, fmt.Errorf("invalid Unix domain socket address %s: %v", addr, err)
+				}
+
+				return dialer.DialContext(ctx, "unix", string(path))
+			},
+			MaxIdleConnsPerHost:   transport.MaxIdleConnsPerHost,
+			IdleConnTimeout:       transport.IdleConnTimeout,
+			ExpectContinueTimeout: transport.ExpectContinueTimeout,
+			ResponseHeaderTimeout: transport.ResponseHeaderTimeout,
+		},
+	}
+}
+
+func (t *unixSocketTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	outReq := new(http.Request)
+	*outReq = *req
+	outReq.URL = new(url.URL)
+	*outReq.URL = *req.URL
+	outReq.URL.Scheme = "http"
+
+	// the encoded socket path is meaningless to the backend
+	if len(req.Host) == 0 || req.Host == req.URL.Host {
+		outReq.Host = "localhost"
+	}
+
+	return t.Transport.RoundTrip(outReq)
+}
@@ -0,0 +1,214 @@
+package server
+
+import (
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/containous/flaeg/parse"
+	"github.com/containous/traefik/configuration"
+	"github.com/containous/traefik/healthcheck"
+	th "github.com/containous/traefik/testhelpers"
+	"github.com/containous/traefik/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListenUnixSocket(t *testing.T) {
+	dir, err := ioutil.TempDir("", "traefik-unix")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "http.sock")
+
+	// a socket left over by a previous process
+	stale, err := net.Listen("unix", path)
+	require.NoError(t, err)
+	stale.(*net.UnixListener).SetUnlinkOnClose(false)
+	require.NoError(t, stale.Close())
+
+	options := &configuration.UnixSocket{Mode: "0600"}
+	// changing the owner of a file needs privileges
+	if os.Getuid() == 0 {
+		options.Owner = "root"
+		options.Group = "0"
+	}
+
+	listener, err := listenUnixSocket(path, options)
+	require.NoError(t, err)
+	defer listener.Close()
+
+	fi, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), fi.Mode().Perm())
+
+	_, err = listenUnixSocket(filepath.Join(dir, "invalid.sock"), &configuration.UnixSocket{Mode: "rw"})
+	assert.Error(t, err)
+}
+
+func TestParseServerURL(t *testing.T) {
+	testCases := []struct {
+		desc        string
+		rawURL      string
+		expected    string
+		expectedErr bool
+	}{
+		{
+			desc:     "HTTP server",
+			rawURL:   "http://10.0.0.1:8080",
+			expected: "http://10.0.0.1:8080",
+		},
+		{
+			desc:     "Unix domain socket",
+			rawURL:   "unix:///run/app.sock",
+			expected: "unix://2f72756e2f6170702e736f636b",
+		},
+		{
+			desc:        "Unix domain socket without path",
+			rawURL:      "unix://",
+			expectedErr: true,
+		},
+		{
+			desc:        "Unix domain socket with host",
+			rawURL:      "unix://localhost/run/app.sock",
+			expectedErr: true,
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			u, err := parseServerURL(test.rawURL)
+			if test.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, u.String())
+		})
+	}
+}
+
+func TestUnixSocketTransport(t *testing.T) {
+	dir, err := ioutil.TempDir("", "traefik-unix")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "app.sock")
+	listener, err := net.Listen("unix", path)
+	require.NoError(t, err)
+
+	backend := httptest.NewUnstartedServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.Header().Set("X-Host", req.Host)
+		rw.Write([]byte(req.URL.RequestURI()))
+	}))
+	backend.Listener = listener
+	backend.Start()
+	defer backend.Close()
+
+	transport, err := createHTTPTransport(configuration.GlobalConfiguration{})
+	require.NoError(t, err)
+
+	serverURL, err := parseServerURL("unix://" + path)
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodGet, "/foo?bar=baz", nil)
+	req.RequestURI = ""
+	req.URL.Scheme = serverURL.Scheme
+	req.URL.Host = serverURL.Host
+	req.Host = serverURL.Host
+
+	resp, err := transport.RoundTrip(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "/foo?bar=baz", string(body))
+	assert.Equal(t, "localhost", resp.Header.Get("X-Host"))
+}
+
+func TestUnixSocketHealthCheck(t *testing.T) {
+	dir, err := ioutil.TempDir("", "traefik-unix")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "app.sock")
+	listener, err := net.Listen("unix", path)
+	require.NoError(t, err)
+
+	var healthChecks int32
+	backend := httptest.NewUnstartedServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.URL.Path == "/health" {
+			atomic.AddInt32(&healthChecks, 1)
+		}
+	}))
+	backend.Listener = listener
+	backend.Start()
+	defer backend.Close()
+
+	globalConfig := configuration.GlobalConfiguration{
+		HealthCheck: &configuration.HealthCheckConfig{
+			Interval: parse.Duration(20 * time.Millisecond),
+			Timeout:  parse.Duration(time.Second),
+		},
+	}
+	entryPoints := map[string]EntryPoint{
+		"http": {
+			Configuration: &configuration.EntryPoint{
+				ForwardedHeaders: &configuration.ForwardedHeaders{Insecure: true},
+			},
+		},
+	}
+
+	dynamicConfigs := types.Configurations{
+		"config": &types.Configuration{
+			Frontends: map[string]*types.Frontend{
+				"frontend": {
+					EntryPoints: []string{"http"},
+					Backend:     "backend",
+				},
+			},
+			Backends: map[string]*types.Backend{
+				"backend": {
+					Servers: map[string]types.Server{
+						"server": {URL: "unix://" + path},
+					},
+					LoadBalancer: &types.LoadBalancer{Method: "Wrr"},
+					// the scheme and port of the health check don't apply to the socket
+					HealthCheck: &types.HealthCheck{Path: "/health", Scheme: "https", Port: 8080},
+				},
+			},
+		},
+	}
+
+	srv := NewServer(globalConfig, nil, entryPoints)
+	defer srv.routinesPool.Cleanup()
+
+	_, err = srv.loadConfig(dynamicConfigs, globalConfig)
+	require.NoError(t, err)
+
+	backends := healthcheck.GetHealthCheck(th.NewCollectingHealthCheckMetrics()).Backends
+	require.Len(t, backends, 1)
+
+	deadline := time.Now().Add(5 * time.Second)
+	for atomic.LoadInt32(&healthChecks) < 3 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	assert.True(t, atomic.LoadInt32(&healthChecks) >= 3, "health checks received by the socket")
+	for _, backendConfig := range backends {
+		assert.Len(t, backendConfig.LB.Servers(), 1, "the healthy server is kept")
+	}
+}
@@ -0,0 +1,290 @@
+package server
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/containous/traefik/log"
+)
+
+const (
+	// upgradeListenersEnv lists the entry points whose sockets are passed to the new process during an upgrade.
+	upgradeListenersEnv = "TRAEFIK_UPGRADE_LISTENERS"
+
+	// upgradeReadyFd is the pipe the new process closes once ready, the sockets of the entry points follow it.
+	upgradeReadyFd = 3
+
+	upgradeReadyTimeout = time.Minute
+)
+
+// socket is a listener or a packet connection which can be passed to another process.
+type socket interface {
+	File() (*os.File, error)
+}
+
+// inheritedSockets holds the sockets passed by the previous process during an upgrade, by entry point name.
+type inheritedSockets struct {
+	lock      sync.Mutex
+	files     map[string]*os.File
+	ready     *os.File
+	readyOnce sync.Once
+}
+
+// loadInheritedSockets returns the sockets passed by the previous process, or nil if the process is not an upgrade.
+func loadInheritedSockets() *inheritedSockets {
+	names := os.Getenv(upgradeListenersEnv)
+	if len(names) == 0 {
+		return nil
+	}
+
+	// the variable must not be passed to the processes started by this one
+	os.Unsetenv(upgradeListenersEnv)
+
+	log.Infof("Taking over the sockets of the entry points %s from the previous process", names)
+
+	inherited := &inheritedSockets{
+		files: make(map[string]*os.File),
+		ready: os.NewFile(upgradeReadyFd, "upgrade-ready"),
+	}
+
+	for i, name := range strings.Split(names, ",") {
+		inherited.files[name] = os.NewFile(uintptr(upgradeReadyFd+1+i), name)
+	}
+
+	return inherited
+}
+
+// take removes the file of the entry point from the inherited ones.
+func (i *inheritedSockets) take(entryPointName string) *os.File {
+	if i == nil {
+		return nil
+	}
+
+	i.lock.Lock()
+	defer i.lock.Unlock()
+
+	file := i.files[entryPointName]
+	delete(i.files, entryPointName)
+	return file
+}
+
+// listener returns the inherited listener of the entry point, or nil when there is none listening on the address.
+func (i *inheritedSockets) listener(entryPointName, network, address string) net.Listener {
+	file := i.take(entryPointName)
+	if file == nil {
+		return nil
+	}
+	defer file.Close()
+
+	listener, err := net.FileListener(file)
+	if err != nil {
+		log.Errorf("Unable to use the listener of the entry point %s passed by the previous process: %v", entryPointName, err)
+		return nil
+	}
+
+	if !matchSocketAddress(listener.Addr(), network, address) {
+		log.Infof("The address of the entry point %s changed from %s to %s, opening a new listener", entryPointName, listener.Addr(), address)
+		listener.Close()
+		return nil
+	}
+
+	return listener
+}
+
+// packetConn returns the inherited packet connection of the entry point, or nil when there is none listening on the address.
+func (i *inheritedSockets) packetConn(entryPointName, network, address string) net.PacketConn {
+	file := i.take(entryPointName)
+	if file == nil {
+		return nil
+	}
+	defer file.Close()
+
+	conn, err := net.FilePacketConn(file)
+	if err != nil {
+		log.Errorf("Unable to use the socket of the entry point %s passed by the previous process: %v", entryPointName, err)
+		return nil
+	}
+
+	if !matchSocketAddress(conn.LocalAddr(), network, address) {
+		log.Infof("The address of the entry point %s changed from %s to %s, opening a new socket", entryPointName, conn.LocalAddr(), address)
+		conn.Close()
+		return nil
+	}
+
+	return conn
+}
+
+// closeUnused closes the inherited sockets of the entry points which no longer exist.
+func (i *inheritedSockets) closeUnused() {
+	if i == nil {
+		return
+	}
+
+	i.lock.Lock()
+	defer i.lock.Unlock()
+
+	for name, file := range i.files {
+		log.Infof("Closing the socket of the removed entry point %s", name)
+		file.Close()
+		delete(i.files, name)
+	}
+}
+
+// notifyReady tells the previous process to hand off its sockets.
+func (i *inheritedSockets) notifyReady() {
+	if i == nil {
+		return
+	}
+
+	i.readyOnce.Do(func() {
+		log.Info("Notifying the previous process to stop accepting connections")
+
+		if _, err := i.ready.Write([]byte{0}); err != nil {
+			log.Errorf("Unable to notify the previous process: %v", err)
+		}
+		i.ready.Close()
+	})
+}
+
+// matchSocketAddress tells if a socket is bound to the address of an entry point.
+func matchSocketAddress(addr net.Addr, network, address string) bool {
+	if network == "unix" {
+		return addr.Network() == "unix" && addr.String() == address
+	}
+
+	if !strings.HasPrefix(addr.Network(), network) {
+		return false
+	}
+
+	host, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return false
+	}
+
+	actualHost, actualPort, err := net.SplitHostPort(addr.String())
+	if err != nil {
+		return false
+	}
+
+	portNum, err := net.LookupPort(network, port)
+	if err != nil {
+		return false
+	}
+
+	actualPortNum, err := net.LookupPort(network, actualPort)
+	if err != nil || portNum != actualPortNum {
+		return false
+	}
+
+	if len(host) == 0 {
+		ip := net.ParseIP(actualHost)
+		return ip != nil && ip.IsUnspecified()
+	}
+
+	if ip, actualIP := net.ParseIP(host), net.ParseIP(actualHost); ip != nil && actualIP != nil {
+		return ip.Equal(actualIP)
+	}
+
+	return host == actualHost
+}
+
+// addSocket records the socket of an entry point, to pass it to the new process on upgrade.
+func (s *Server) addSocket(entryPointName string, sock socket) {
+	if s.sockets == nil {
+		s.sockets = make(map[string]socket)
+	}
+	s.sockets[entryPointName] = sock
+}
+
+// upgrade starts a new process of the executable with the same arguments, passing it the sockets of the entry points.
+// It returns once the new process has applied a configuration, and fails if the new process exits before.
+func (s *Server) upgrade() error {
+	executable, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("unable to find the executable: %v", err)
+	}
+
+	readyReader, readyWriter, err := os.Pipe()
+	if err != nil {
+		return err
+	}
+	defer readyReader.Close()
+
+	files := []*os.File{readyWriter}
+	closeFiles := func() {
+		for _, file := range files {
+			file.Close()
+		}
+	}
+
+	var names []string
+	for name := range s.sockets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		file, err := s.sockets[name].File()
+		if err != nil {
+			closeFiles()
+			return fmt.Errorf("unable to pass the socket of the entry point %s: %v", name, err)
+		}
+		files = append(files, file)
+	}
+
+	cmd := exec.Command(executable, os.Args[1:]...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.Env = append(os.Environ(), upgradeListenersEnv+"="+strings.Join(names, ","))
+	cmd.ExtraFiles = files
+
+	err = cmd.Start()
+	// the new process has its own copies of the files
+	closeFiles()
+	if err != nil {
+		return fmt.Errorf("unable to start %s: %v", executable, err)
+	}
+
+	log.Infof("Started the new process %d with the sockets of the entry points %s", cmd.Process.Pid, strings.Join(names, ","))
+
+	go func() {
+		if err := cmd.Wait(); err != nil {
+			log.Errorf("The new process %d exited: %v", cmd.Process.Pid, err)
+		}
+	}()
+
+	if err := readyReader.SetReadDeadline(time.Now().Add(upgradeReadyTimeout)); err != nil {
+		return err
+	}
+
+	_, err = readyReader.Read(make([]byte, 1))
+	if os.IsTimeout(err) {
+		log.Warnf("The new process did not apply a configuration within %s, handing off the sockets anyway", upgradeReadyTimeout)
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("the new process exited before being ready: %v", err)
+	}
+
+	return nil
+}
+
+// handOff stops the server once the new process accepts the connections, leaving the sockets of the entry points open in it.
+func (s *Server) handOff() {
+	for _, sock := range s.sockets {
+		if listener, ok := sock.(*net.UnixListener); ok {
+			// the socket file is still used by the new process
+			listener.SetUnlinkOnClose(false)
+		}
+	}
+
+	log.Info("The new process accepts the connections, stopping server gracefully")
+	s.Stop()
+}
@@ -0,0 +1,176 @@
+package server
+
+import (
+	"io/ioutil"
+	"net"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatchSocketAddress(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		addr     net.Addr
+		network  string
+		address  string
+		expected bool
+	}{
+		{
+			desc:     "any address",
+			addr:     &net.TCPAddr{IP: net.IPv6unspecified, Port: 80},
+			network:  "tcp",
+			address:  ":80",
+			expected: true,
+		},
+		{
+			desc:     "named port",
+			addr:     &net.TCPAddr{IP: net.IPv6unspecified, Port: 80},
+			network:  "tcp",
+			address:  ":http",
+			expected: true,
+		},
+		{
+			desc:     "other port",
+			addr:     &net.TCPAddr{IP: net.IPv6unspecified, Port: 80},
+			network:  "tcp",
+			address:  ":8080",
+			expected: false,
+		},
+		{
+			desc:     "same IP",
+			addr:     &net.TCPAddr{IP: net.ParseIP("127.0.0.1"), Port: 80},
+			network:  "tcp",
+			address:  "127.0.0.1:80",
+			expected: true,
+		},
+		{
+			desc:     "specific IP instead of any address",
+			addr:     &net.TCPAddr{IP: net.ParseIP("127.0.0.1"), Port: 80},
+			network:  "tcp",
+			address:  ":80",
+			expected: false,
+		},
+		{
+			desc:     "UDP socket",
+			addr:     &net.UDPAddr{IP: net.IPv6unspecified, Port: 53},
+			network:  "udp",
+			address:  ":53",
+			expected: true,
+		},
+		{
+			desc:     "TCP socket for a UDP entry point",
+			addr:     &net.TCPAddr{IP: net.IPv6unspecified, Port: 53},
+			network:  "udp",
+			address:  ":53",
+			expected: false,
+		},
+		{
+			desc:     "same Unix domain socket",
+			addr:     &net.UnixAddr{Name: "/run/traefik.sock", Net: "unix"},
+			network:  "unix",
+			address:  "/run/traefik.sock",
+			expected: true,
+		},
+		{
+			desc:     "other Unix domain socket",
+			addr:     &net.UnixAddr{Name: "/run/traefik.sock", Net: "unix"},
+			network:  "unix",
+			address:  "/run/other.sock",
+			expected: false,
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, test.expected, matchSocketAddress(test.addr, test.network, test.address))
+		})
+	}
+}
+
+func TestInheritedSockets(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer conn.Close()
+
+	listenerFile, err := listener.(*net.TCPListener).File()
+	require.NoError(t, err)
+
+	connFile, err := conn.(*net.UDPConn).File()
+	require.NoError(t, err)
+
+	movedFile, err := listener.(*net.TCPListener).File()
+	require.NoError(t, err)
+
+	removedFile, err := listener.(*net.TCPListener).File()
+	require.NoError(t, err)
+
+	readyReader, readyWriter, err := os.Pipe()
+	require.NoError(t, err)
+	defer readyReader.Close()
+
+	inherited := &inheritedSockets{
+		files: map[string]*os.File{
+			"http":    listenerFile,
+			"udp":     connFile,
+			"moved":   movedFile,
+			"removed": removedFile,
+		},
+		ready: readyWriter,
+	}
+
+	inheritedListener := inherited.listener("http", "tcp", listener.Addr().String())
+	require.NotNil(t, inheritedListener)
+	defer inheritedListener.Close()
+	assert.Equal(t, listener.Addr(), inheritedListener.Addr())
+
+	inheritedConn := inherited.packetConn("udp", "udp", conn.LocalAddr().String())
+	require.NotNil(t, inheritedConn)
+	defer inheritedConn.Close()
+	assert.Equal(t, conn.LocalAddr(), inheritedConn.LocalAddr())
+
+	assert.Nil(t, inherited.listener("moved", "tcp", "127.0.0.1:1"))
+	assert.Nil(t, inherited.listener("unknown", "tcp", listener.Addr().String()))
+
+	inherited.closeUnused()
+	assert.Empty(t, inherited.files)
+
+	// the connections to the listener of the previous process are accepted by the inherited one
+	client, err := net.Dial("tcp", listener.Addr().String())
+	require.NoError(t, err)
+	defer client.Close()
+
+	require.NoError(t, listener.Close())
+
+	server, err := inheritedListener.Accept()
+	require.NoError(t, err)
+	server.Close()
+
+	inherited.notifyReady()
+	inherited.notifyReady()
+
+	notification, err := ioutil.ReadAll(readyReader)
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0}, notification)
+}
+
+func TestInheritedSocketsNotUpgrade(t *testing.T) {
+	os.Unsetenv(upgradeListenersEnv)
+
+	inherited := loadInheritedSockets()
+	assert.Nil(t, inherited)
+
+	assert.Nil(t, inherited.listener("http", "tcp", ":80"))
+	assert.Nil(t, inherited.packetConn("udp", "udp", ":53"))
+	inherited.closeUnused()
+	inherited.notifyReady()
+}
@@ -0,0 +1,97 @@
+package tcp
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/tls"
+	"errors"
+	"io"
+	"net"
+	"time"
+)
+
+const (
+	recordTypeHandshake = 0x16
+	recordHeaderLen     = 5
+	maxPlaintext        = 16384
+)
+
+var errClientHelloRead = errors.New("client hello read")
+
+// clientHelloServerName peeks the TLS ClientHello of the connection, and returns its server name (SNI).
+// It returns false when the connection does not start with a TLS handshake.
+func clientHelloServerName(br *bufio.Reader) (string, bool, error) {
+	header, err := br.Peek(1)
+	if err != nil {
+		return "", false, err
+	}
+
+	if header[0] != recordTypeHandshake {
+		return "", false, nil
+	}
+
+	header, err = br.Peek(recordHeaderLen)
+	if err != nil {
+		return "", true, err
+	}
+
+	recordLen := int(header[3])<<8 | int(header[4])
+	if recordLen > maxPlaintext {
+		return "", true, errors.New("TLS record too large")
+	}
+
+	helloBytes, err := br.Peek(recordHeaderLen + recordLen)
+	if err != nil {
+		return "", true, err
+	}
+
+	var serverName string
+	server := tls.Server(helloConn{reader: bytes.NewReader(helloBytes)}, &tls.Config{
+		GetConfigForClient: func(hello *tls.ClientHelloInfo) (*tls.Config, error) {
+			serverName = hello.ServerName
+			return nil, errClientHelloRead
+		},
+	})
+
+	// the handshake always fails: it stops once the ClientHello is read
+	_ = server.Handshake()
+
+	return serverName, true, nil
+}
+
+// helloConn is a connection only reading the ClientHello, its writes are discarded.
+type helloConn struct {
+	reader io.Reader
+}
+
+func (c helloConn) Read(p []byte) (int, error)         { return c.reader.Read(p) }
+func (c helloConn) Write(p []byte) (int, error)        { return 0, io.EOF }
+func (c helloConn) Close() error                       { return nil }
+func (c helloConn) LocalAddr() net.Addr                { return nil }
+func (c helloConn) RemoteAddr() net.Addr               { return nil }
+func (c helloConn) SetDeadline(t time.Time) error      { return nil }
+func (c helloConn) SetReadDeadline(t time.Time) error  { return nil }
+func (c helloConn) SetWriteDeadline(t time.Time) error { return nil }
+
+// peekedConn is a connection whose first bytes were peeked, and are read again.
+type peekedConn struct {
+	net.Conn
+	reader *bufio.Reader
+}
+
+func (c *peekedConn) Read(p []byte) (int, error) {
+	return c.reader.Read(p)
+}
+
+// CloseWrite closes the writing side of the connection, when supported.
+func (c *peekedConn) CloseWrite() error {
+	if cw, ok := c.Conn.(closeWriter); ok {
+		return cw.CloseWrite()
+	}
+	return c.Conn.Close()
+}
+
+// SetLinger sets the linger option of the connection, when supported.
+func (c *peekedConn) SetLinger(sec int) error {
+	return setLinger(c.Conn, sec)
+}
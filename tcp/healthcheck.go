@@ -0,0 +1,79 @@
+package tcp
+
+import (
+	"context"
+	"net"
+	"time"
+
+	"github.com/containous/traefik/log"
+	"github.com/containous/traefik/safe"
+)
+
+// HealthCheck checks the servers of a TCP backend: they are up when they accept connections.
+type HealthCheck struct {
+	lb       *LoadBalancer
+	interval time.Duration
+	timeout  time.Duration
+}
+
+// NewHealthCheck creates the health check of the servers of a load balancer.
+func NewHealthCheck(lb *LoadBalancer, interval, timeout time.Duration) *HealthCheck {
+	return &HealthCheck{lb: lb, interval: interval, timeout: timeout}
+}
+
+func (hc *HealthCheck) execute(ctx context.Context) {
+	log.Debugf("Initial health check for TCP backend: %q", hc.lb.name)
+	hc.check()
+
+	ticker := time.NewTicker(hc.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Debugf("Stopping current health check goroutines of TCP backend: %s", hc.lb.name)
+			return
+		case <-ticker.C:
+			hc.check()
+		}
+	}
+}
+
+func (hc *HealthCheck) check() {
+	for _, address := range hc.lb.addresses() {
+		conn, err := net.DialTimeout("tcp", address, hc.timeout)
+		if err != nil {
+			if hc.lb.setServerUp(address, false) {
+				log.Warnf("Health check failed: Remove from server list. TCP backend: %q Address: %q Reason: %s", hc.lb.name, address, err)
+			}
+			continue
+		}
+		conn.Close()
+
+		if hc.lb.setServerUp(address, true) {
+			log.Warnf("Health check up: Returning to server list. TCP backend: %q Address: %q", hc.lb.name, address)
+		}
+	}
+}
+
+// HealthChecks runs the health checks of the TCP backends of the current configuration.
+type HealthChecks struct {
+	cancel context.CancelFunc
+}
+
+// SetHealthChecks stops the previous health checks, and starts the new ones.
+func (h *HealthChecks) SetHealthChecks(parentCtx context.Context, healthChecks []*HealthCheck) {
+	if h.cancel != nil {
+		h.cancel()
+	}
+
+	ctx, cancel := context.WithCancel(parentCtx)
+	h.cancel = cancel
+
+	for _, healthCheck := range healthChecks {
+		currentHealthCheck := healthCheck
+		safe.Go(func() {
+			currentHealthCheck.execute(ctx)
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package tcp
+
+import (
+	"net"
+	"sync/atomic"
+	"time"
+)
+
+// idleConn times out its reads once the connection is idle, in both directions, for the idle timeout.
+// The reads are not interrupted while data is written to the connection.
+type idleConn struct {
+	net.Conn
+	timeout time.Duration
+	// lastActivity is the time of the last read or write, in nanoseconds since the epoch.
+	lastActivity int64
+}
+
+func newIdleConn(conn net.Conn, timeout time.Duration) *idleConn {
+	c := &idleConn{Conn: conn, timeout: timeout}
+	c.touch()
+	return c
+}
+
+func (c *idleConn) Read(b []byte) (int, error) {
+	for {
+		if err := c.Conn.SetReadDeadline(time.Now().Add(c.timeout)); err != nil {
+			return 0, err
+		}
+
+		n, err := c.Conn.Read(b)
+		if n > 0 {
+			c.touch()
+		}
+
+		if netErr, ok := err.(net.Error); ok && netErr.Timeout() && n == 0 && !c.idle() {
+			continue
+		}
+		return n, err
+	}
+}
+
+func (c *idleConn) Write(b []byte) (int, error) {
+	n, err := c.Conn.Write(b)
+	if n > 0 {
+		c.touch()
+	}
+	return n, err
+}
+
+// CloseWrite closes the writing side of the connection when supported, and the whole connection otherwise.
+func (c *idleConn) CloseWrite() error {
+	if cw, ok := c.Conn.(closeWriter); ok {
+		return cw.CloseWrite()
+	}
+	return c.Close()
+}
+
+// SetLinger sets the linger option of the connection, when supported.
+func (c *idleConn) SetLinger(sec int) error {
+	return setLinger(c.Conn, sec)
+}
+
+func (c *idleConn) touch() {
+	atomic.StoreInt64(&c.lastActivity, time.Now().UnixNano())
+}
+
+func (c *idleConn) idle() bool {
+	return time.Since(time.Unix(0, atomic.LoadInt64(&c.lastActivity))) >= c.timeout
+}
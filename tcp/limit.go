@@ -0,0 +1,193 @@
+package tcp
+
+import (
+	"errors"
+	"net"
+	"sync"
+
+	"github.com/containous/traefik/log"
+	"github.com/go-kit/kit/metrics"
+)
+
+const (
+	rejectReasonMaxConnections      = "max_connections"
+	rejectReasonMaxConnectionsPerIP = "max_connections_per_ip"
+)
+
+var errTooManyConnectionsPerIP = errors.New("too many connections from the client IP")
+
+// LimitListener caps the number of open connections of a listener, in total and per client IP.
+// The connections over the total limit are closed as soon as they are accepted.
+// The client IP of a connection is only known once its PROXY protocol header has been read,
+// so the connections over the per IP limit are closed on their first read or write, before any TLS handshake.
+type LimitListener struct {
+	net.Listener
+	maxConns        int
+	maxConnsPerIP   int
+	rejectedCounter metrics.Counter
+
+	lock     sync.Mutex
+	conns    int
+	connsPer map[string]int
+}
+
+// NewLimitListener creates a listener allowing at most maxConns open connections, and at most maxConnsPerIP per client IP.
+// A zero limit is no limit, and the rejected connections are counted with a reason label.
+func NewLimitListener(listener net.Listener, maxConns, maxConnsPerIP int, rejectedCounter metrics.Counter) *LimitListener {
+	return &LimitListener{
+		Listener:        listener,
+		maxConns:        maxConns,
+		maxConnsPerIP:   maxConnsPerIP,
+		rejectedCounter: rejectedCounter,
+		connsPer:        make(map[string]int),
+	}
+}
+
+// Accept waits for and returns the next connection under the total limit.
+func (l *LimitListener) Accept() (net.Conn, error) {
+	for {
+		conn, err := l.Listener.Accept()
+		if err != nil {
+			return nil, err
+		}
+
+		if !l.acquire() {
+			log.Debugf("Closing connection on %s: the limit of %d open connections is reached", conn.LocalAddr(), l.maxConns)
+			l.rejectedCounter.With("reason", rejectReasonMaxConnections).Add(1)
+			conn.Close()
+			continue
+		}
+
+		return &limitedConn{Conn: conn, listener: l}, nil
+	}
+}
+
+func (l *LimitListener) acquire() bool {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if l.maxConns > 0 && l.conns >= l.maxConns {
+		return false
+	}
+
+	l.conns++
+	return true
+}
+
+func (l *LimitListener) release() {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	l.conns--
+}
+
+func (l *LimitListener) acquireIP(ip string) bool {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if l.connsPer[ip] >= l.maxConnsPerIP {
+		return false
+	}
+
+	l.connsPer[ip]++
+	return true
+}
+
+func (l *LimitListener) releaseIP(ip string) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	l.connsPer[ip]--
+	if l.connsPer[ip] <= 0 {
+		delete(l.connsPer, ip)
+	}
+}
+
+// limitedConn releases its slots of the listener limits when closed.
+type limitedConn struct {
+	net.Conn
+	listener *LimitListener
+
+	checkOnce sync.Once
+	checkErr  error
+
+	lock   sync.Mutex
+	ip     string
+	closed bool
+}
+
+func (c *limitedConn) check() error {
+	c.checkOnce.Do(func() {
+		if c.listener.maxConnsPerIP <= 0 {
+			return
+		}
+
+		addr, ok := c.Conn.RemoteAddr().(*net.TCPAddr)
+		if !ok {
+			// the clients of a Unix domain socket have no IP
+			return
+		}
+
+		ip := addr.IP.String()
+
+		c.lock.Lock()
+		acquired := !c.closed && c.listener.acquireIP(ip)
+		if acquired {
+			c.ip = ip
+		}
+		closed := c.closed
+		c.lock.Unlock()
+
+		if acquired || closed {
+			return
+		}
+
+		log.Debugf("Closing connection from %s: the limit of %d open connections per IP is reached", ip, c.listener.maxConnsPerIP)
+		c.listener.rejectedCounter.With("reason", rejectReasonMaxConnectionsPerIP).Add(1)
+		c.checkErr = errTooManyConnectionsPerIP
+		c.Close()
+	})
+
+	return c.checkErr
+}
+
+func (c *limitedConn) Read(b []byte) (int, error) {
+	if err := c.check(); err != nil {
+		return 0, err
+	}
+	return c.Conn.Read(b)
+}
+
+func (c *limitedConn) Write(b []byte) (int, error) {
+	if err := c.check(); err != nil {
+		return 0, err
+	}
+	return c.Conn.Write(b)
+}
+
+// CloseWrite closes the writing side of the connection when supported, and the whole connection otherwise.
+func (c *limitedConn) CloseWrite() error {
+	if cw, ok := c.Conn.(closeWriter); ok {
+		return cw.CloseWrite()
+	}
+	return c.Close()
+}
+
+// SetLinger sets the linger option of the connection, when supported.
+func (c *limitedConn) SetLinger(sec int) error {
+	return setLinger(c.Conn, sec)
+}
+
+func (c *limitedConn) Close() error {
+	c.lock.Lock()
+	if !c.closed {
+		c.closed = true
+		c.listener.release()
+		if len(c.ip) > 0 {
+			c.listener.releaseIP(c.ip)
+		}
+	}
+	c.lock.Unlock()
+
+	return c.Conn.Close()
+}
@@ -0,0 +1,123 @@
+package tcp
+
+import (
+	"io"
+	"io/ioutil"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/containous/traefik/testhelpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newLimitListener(t *testing.T, maxConns, maxConnsPerIP int) (*LimitListener, *testhelpers.CollectingCounter, chan net.Conn) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	counter := &testhelpers.CollectingCounter{}
+	limitListener := NewLimitListener(listener, maxConns, maxConnsPerIP, counter)
+
+	conns := make(chan net.Conn)
+	go func() {
+		for {
+			conn, err := limitListener.Accept()
+			if err != nil {
+				close(conns)
+				return
+			}
+			conns <- conn
+		}
+	}()
+
+	return limitListener, counter, conns
+}
+
+func dial(t *testing.T, listener net.Listener) net.Conn {
+	conn, err := net.Dial("tcp", listener.Addr().String())
+	require.NoError(t, err)
+
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	return conn
+}
+
+func acceptConn(t *testing.T, conns chan net.Conn) net.Conn {
+	select {
+	case conn := <-conns:
+		return conn
+	case <-time.After(5 * time.Second):
+		t.Fatal("connection not accepted")
+		return nil
+	}
+}
+
+func assertClosed(t *testing.T, conn net.Conn) {
+	_, err := ioutil.ReadAll(conn)
+	assert.NoError(t, err, "the connection should be closed by the listener")
+}
+
+func TestLimitListenerMaxConnections(t *testing.T) {
+	listener, counter, conns := newLimitListener(t, 1, 0)
+	defer listener.Close()
+
+	client1 := dial(t, listener)
+	defer client1.Close()
+	server1 := acceptConn(t, conns)
+
+	client2 := dial(t, listener)
+	defer client2.Close()
+	assertClosed(t, client2)
+
+	require.NoError(t, server1.Close())
+
+	client3 := dial(t, listener)
+	defer client3.Close()
+	server3 := acceptConn(t, conns)
+	defer server3.Close()
+
+	assert.Equal(t, float64(1), counter.CounterValue)
+	assert.Equal(t, []string{"reason", rejectReasonMaxConnections}, counter.LastLabelValues)
+
+	_, err := io.WriteString(server3, "ok")
+	require.NoError(t, err)
+
+	buf := make([]byte, 2)
+	_, err = io.ReadFull(client3, buf)
+	require.NoError(t, err)
+	assert.Equal(t, "ok", string(buf))
+}
+
+func TestLimitListenerMaxConnectionsPerIP(t *testing.T) {
+	listener, counter, conns := newLimitListener(t, 0, 1)
+	defer listener.Close()
+
+	client1 := dial(t, listener)
+	defer client1.Close()
+	server1 := acceptConn(t, conns)
+
+	_, err := io.WriteString(server1, "ok")
+	require.NoError(t, err)
+
+	client2 := dial(t, listener)
+	defer client2.Close()
+	server2 := acceptConn(t, conns)
+
+	_, err = io.WriteString(server2, "ok")
+	assert.Equal(t, errTooManyConnectionsPerIP, err)
+	assertClosed(t, client2)
+
+	assert.Equal(t, float64(1), counter.CounterValue)
+	assert.Equal(t, []string{"reason", rejectReasonMaxConnectionsPerIP}, counter.LastLabelValues)
+
+	// closing a connection frees a slot for its IP
+	require.NoError(t, server1.Close())
+
+	client3 := dial(t, listener)
+	defer client3.Close()
+	server3 := acceptConn(t, conns)
+	defer server3.Close()
+
+	_, err = io.WriteString(server3, "ok")
+	assert.NoError(t, err)
+}
@@ -0,0 +1,196 @@
+package tcp
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/containous/traefik/log"
+)
+
+// DefaultSniffTimeout is the time given to a client to send the first bytes of its connection, when the entry point has no read timeout.
+const DefaultSniffTimeout = 10 * time.Second
+
+// Listener routes the connections of an entry point to its TCP frontends,
+// the other connections being accepted by the HTTP server of the entry point.
+type Listener struct {
+	net.Listener
+	router       *RouterSwitcher
+	tlsConfig    *tls.Config
+	sniffTimeout time.Duration
+	idleTimeout  time.Duration
+	tracker      *connTracker
+
+	once    sync.Once
+	pending sync.WaitGroup
+	conns   chan net.Conn
+	errs    chan error
+	done    chan struct{}
+	err     error
+}
+
+// NewListener creates a listener routing the connections with the router.
+// The TLS config is used to terminate TLS for the TCP frontends without passthrough, it is nil when the entry point has no TLS.
+// The first bytes of the connections must be received within the sniff timeout (DefaultSniffTimeout when zero),
+// and the connections routed to the TCP frontends are closed once idle for the idle timeout (never when zero).
+func NewListener(listener net.Listener, router *RouterSwitcher, tlsConfig *tls.Config, sniffTimeout, idleTimeout time.Duration) *Listener {
+	if sniffTimeout <= 0 {
+		sniffTimeout = DefaultSniffTimeout
+	}
+
+	return &Listener{
+		Listener:     listener,
+		router:       router,
+		tlsConfig:    tlsConfig,
+		sniffTimeout: sniffTimeout,
+		idleTimeout:  idleTimeout,
+		tracker:      newConnTracker(),
+		conns:        make(chan net.Conn),
+		errs:         make(chan error),
+		done:         make(chan struct{}),
+	}
+}
+
+// Accept waits for and returns the next connection not routed to a TCP frontend.
+func (l *Listener) Accept() (net.Conn, error) {
+	l.once.Do(func() {
+		go l.accept()
+	})
+
+	select {
+	case conn := <-l.conns:
+		return conn, nil
+	case err := <-l.errs:
+		return nil, err
+	case <-l.done:
+		return nil, l.err
+	}
+}
+
+func (l *Listener) accept() {
+	for {
+		conn, err := l.Listener.Accept()
+		if err != nil {
+			if netErr, ok := err.(net.Error); ok && netErr.Temporary() {
+				select {
+				case l.errs <- err:
+					continue
+				case <-l.done:
+					return
+				}
+			}
+
+			// the connections accepted before the listener was closed are still passed to the HTTP server
+			l.pending.Wait()
+
+			l.err = err
+			close(l.done)
+			return
+		}
+
+		l.pending.Add(1)
+		go l.serveConn(conn)
+	}
+}
+
+// Shutdown waits for the connections routed to the TCP frontends to be closed, or for the context to be done.
+// The listener itself is closed by the HTTP server.
+func (l *Listener) Shutdown(ctx context.Context) error {
+	return l.tracker.shutdown(ctx)
+}
+
+// CloseConnections closes the connections routed to the TCP frontends.
+func (l *Listener) CloseConnections() {
+	l.tracker.close()
+}
+
+func (l *Listener) serveConn(conn net.Conn) {
+	// the connections are tracked until they are passed to the HTTP server, or served by a TCP frontend
+	l.tracker.add(conn)
+	defer l.tracker.remove(conn)
+
+	rt, routed, isTLS := l.routeConn(conn)
+	if rt == nil {
+		if routed != nil {
+			l.serveHTTP(routed)
+		}
+		l.pending.Done()
+		return
+	}
+
+	l.pending.Done()
+
+	if l.idleTimeout > 0 {
+		routed = newIdleConn(routed, l.idleTimeout)
+	}
+	l.serveRoute(rt, routed, isTLS)
+}
+
+// routeConn returns the route of the connection, or a nil route for the HTTP server.
+// The returned connection is nil when it has been closed.
+func (l *Listener) routeConn(conn net.Conn) (*route, net.Conn, bool) {
+	router := l.router.GetRouter()
+
+	if router.Empty() {
+		return nil, conn, false
+	}
+
+	if len(router.routes) == 0 {
+		// only a catch-all: the connection is routed without waiting for the client to speak first
+		return router.catchAll, conn, l.tlsConfig != nil
+	}
+
+	// the clients sending nothing must not hold the connection, the read timeouts of the HTTP server being not started yet
+	if err := conn.SetReadDeadline(time.Now().Add(l.sniffTimeout)); err != nil {
+		log.Debugf("Unable to set the read deadline of the connection from %s: %v", conn.RemoteAddr(), err)
+	}
+
+	br := bufio.NewReaderSize(conn, recordHeaderLen+maxPlaintext)
+	serverName, isTLS, err := clientHelloServerName(br)
+	if err != nil {
+		log.Debugf("Error while reading the first bytes of the connection from %s: %v", conn.RemoteAddr(), err)
+		conn.Close()
+		return nil, nil, false
+	}
+
+	if err := conn.SetReadDeadline(time.Time{}); err != nil {
+		log.Debugf("Unable to clear the read deadline of the connection from %s: %v", conn.RemoteAddr(), err)
+	}
+
+	peeked := &peekedConn{Conn: conn, reader: br}
+
+	if !isTLS {
+		// the catch-all route is nil when there is none
+		return router.catchAll, peeked, false
+	}
+
+	return router.match(serverName), peeked, true
+}
+
+func (l *Listener) serveRoute(rt *route, conn net.Conn, isTLS bool) {
+	if !isTLS || rt.passthrough {
+		rt.handler.ServeTCP(conn)
+		return
+	}
+
+	if l.tlsConfig == nil {
+		log.Errorf("Unable to terminate TLS for TCP frontend %s: TLS is not enabled on the entry point", rt.frontendName)
+		conn.Close()
+		return
+	}
+
+	rt.handler.ServeTCP(tls.Server(conn, l.tlsConfig))
+}
+
+func (l *Listener) serveHTTP(conn net.Conn) {
+	if l.tlsConfig != nil {
+		// the connection is wrapped by TLS in the HTTP server, see UnderlyingConn
+		conn = &registeredConn{Conn: conn, registry: tlsConns}
+	}
+
+	// the listener is done once all the accepted connections are passed
+	l.conns <- conn
+}
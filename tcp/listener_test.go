@@ -0,0 +1,367 @@
+package tcp
+
+import (
+	"context"
+	"crypto/tls"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/containous/traefik/tls/generate"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// echoServer replies to the connections with the name of the server, followed by the received data.
+func echoServer(t *testing.T, name string, tlsConfig *tls.Config) net.Listener {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	if tlsConfig != nil {
+		listener = tls.NewListener(listener, tlsConfig)
+	}
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+
+			go func() {
+				defer conn.Close()
+				io.WriteString(conn, name+":")
+				io.Copy(conn, conn)
+			}()
+		}
+	}()
+
+	return listener
+}
+
+func newTLSConfig(t *testing.T) *tls.Config {
+	cert, err := generate.DefaultCertificate()
+	require.NoError(t, err)
+
+	return &tls.Config{Certificates: []tls.Certificate{*cert}}
+}
+
+func newListener(t *testing.T, router *Router, tlsConfig *tls.Config) *Listener {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	return NewListener(listener, NewRouterSwitcher(router), tlsConfig, 0, 0)
+}
+
+func readEcho(t *testing.T, conn net.Conn, expected string) {
+	_, err := io.WriteString(conn, "ping")
+	require.NoError(t, err)
+
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	buf := make([]byte, len(expected))
+	_, err = io.ReadFull(conn, buf)
+	require.NoError(t, err)
+
+	assert.Equal(t, expected, string(buf))
+}
+
+func TestListenerSNIRouting(t *testing.T) {
+	passthroughServer := echoServer(t, "passthrough", newTLSConfig(t))
+	defer passthroughServer.Close()
+
+	terminatedServer := echoServer(t, "terminated", nil)
+	defer terminatedServer.Close()
+
+	router := NewRouter()
+	require.NoError(t, router.AddRoute("passthrough", []string{"pass.example.com"}, true, NewProxy(passthroughServer.Addr().String(), time.Second)))
+	require.NoError(t, router.AddRoute("terminated", []string{"*.example.com"}, false, NewProxy(terminatedServer.Addr().String(), time.Second)))
+
+	listener := newListener(t, router, newTLSConfig(t))
+	defer listener.Close()
+
+	go func() {
+		// the other connections are served by HTTPS
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+
+			go func() {
+				tlsConn := tls.Server(conn, listener.tlsConfig)
+				defer tlsConn.Close()
+				io.WriteString(tlsConn, "https:")
+				io.Copy(tlsConn, tlsConn)
+			}()
+		}
+	}()
+
+	testCases := []struct {
+		serverName string
+		expected   string
+	}{
+		{serverName: "pass.example.com", expected: "passthrough:ping"},
+		{serverName: "db.example.com", expected: "terminated:ping"},
+		{serverName: "www.foo.bar", expected: "https:ping"},
+	}
+
+	for _, test := range testCases {
+		conn, err := tls.Dial("tcp", listener.Addr().String(), &tls.Config{ServerName: test.serverName, InsecureSkipVerify: true})
+		require.NoError(t, err)
+
+		readEcho(t, conn, test.expected)
+		conn.Close()
+	}
+}
+
+func TestListenerPlainConnections(t *testing.T) {
+	router := NewRouter()
+	require.NoError(t, router.AddRoute("tls", []string{"db.example.com"}, true, HandlerFunc(func(conn net.Conn) {
+		t.Error("unexpected TLS route")
+		conn.Close()
+	})))
+
+	listener := newListener(t, router, nil)
+	defer listener.Close()
+
+	conn, err := net.Dial("tcp", listener.Addr().String())
+	require.NoError(t, err)
+	defer conn.Close()
+
+	_, err = io.WriteString(conn, "GET / HTTP/1.1\r\n")
+	require.NoError(t, err)
+
+	// the connection is accepted by the HTTP server, with its first bytes
+	accepted, err := listener.Accept()
+	require.NoError(t, err)
+	defer accepted.Close()
+
+	buf := make([]byte, 3)
+	_, err = io.ReadFull(accepted, buf)
+	require.NoError(t, err)
+	assert.Equal(t, "GET", string(buf))
+}
+
+func TestListenerCatchAll(t *testing.T) {
+	backend := echoServer(t, "tcp", nil)
+	defer backend.Close()
+
+	router := NewRouter()
+	require.NoError(t, router.AddRoute("catch-all", []string{"*"}, false, NewProxy(backend.Addr().String(), time.Second)))
+
+	listener := newListener(t, router, nil)
+	defer listener.Close()
+
+	go listener.Accept()
+
+	conn, err := net.Dial("tcp", listener.Addr().String())
+	require.NoError(t, err)
+	defer conn.Close()
+
+	// the server speaks first
+	buf := make([]byte, 4)
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	_, err = io.ReadFull(conn, buf)
+	require.NoError(t, err)
+	assert.Equal(t, "tcp:", string(buf))
+
+	readEcho(t, conn, "ping")
+}
+
+func TestListenerClose(t *testing.T) {
+	listener := newListener(t, NewRouter(), nil)
+
+	errChan := make(chan error)
+	go func() {
+		_, err := listener.Accept()
+		errChan <- err
+	}()
+
+	require.NoError(t, listener.Close())
+
+	select {
+	case err := <-errChan:
+		assert.Error(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("Accept is not stopped by Close")
+	}
+}
+
+// acceptNotifier signals the connections accepted by the listener.
+type acceptNotifier struct {
+	net.Listener
+	accepted chan struct{}
+}
+
+func (l acceptNotifier) Accept() (net.Conn, error) {
+	conn, err := l.Listener.Accept()
+	if err == nil {
+		l.accepted <- struct{}{}
+	}
+	return conn, err
+}
+
+func TestListenerCloseServesAcceptedConnections(t *testing.T) {
+	router := NewRouter()
+	require.NoError(t, router.AddRoute("tls", []string{"db.example.com"}, true, HandlerFunc(func(conn net.Conn) {
+		t.Error("unexpected TLS route")
+		conn.Close()
+	})))
+
+	netListener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	accepted := make(chan struct{}, 1)
+	listener := NewListener(acceptNotifier{Listener: netListener, accepted: accepted}, NewRouterSwitcher(router), nil, 0, 0)
+
+	type acceptResult struct {
+		conn net.Conn
+		err  error
+	}
+	results := make(chan acceptResult)
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			results <- acceptResult{conn: conn, err: err}
+			if err != nil {
+				return
+			}
+		}
+	}()
+
+	conn, err := net.Dial("tcp", listener.Addr().String())
+	require.NoError(t, err)
+	defer conn.Close()
+
+	select {
+	case <-accepted:
+	case <-time.After(5 * time.Second):
+		t.Fatal("connection not accepted")
+	}
+
+	// the listener is closed while the router waits for the first bytes of the connection
+	require.NoError(t, listener.Close())
+
+	_, err = io.WriteString(conn, "GET / HTTP/1.1\r\n")
+	require.NoError(t, err)
+
+	result := <-results
+	require.NoError(t, result.err)
+	result.conn.Close()
+
+	result = <-results
+	assert.Error(t, result.err)
+}
+
+func TestListenerSniffTimeout(t *testing.T) {
+	router := NewRouter()
+	require.NoError(t, router.AddRoute("tls", []string{"db.example.com"}, true, HandlerFunc(func(conn net.Conn) {
+		t.Error("unexpected TLS route")
+		conn.Close()
+	})))
+
+	netListener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	listener := NewListener(netListener, NewRouterSwitcher(router), nil, 100*time.Millisecond, 0)
+
+	errChan := make(chan error)
+	go func() {
+		_, err := listener.Accept()
+		errChan <- err
+	}()
+
+	conn, err := net.Dial("tcp", listener.Addr().String())
+	require.NoError(t, err)
+	defer conn.Close()
+
+	// the client sends nothing: the connection is closed once the sniff timeout is over
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	_, err = conn.Read(make([]byte, 1))
+	assert.Equal(t, io.EOF, err)
+
+	// the listener is not held by the idle connection
+	require.NoError(t, listener.Close())
+
+	select {
+	case err := <-errChan:
+		assert.Error(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("Accept is not stopped by Close")
+	}
+}
+
+func TestListenerIdleTimeout(t *testing.T) {
+	backend := echoServer(t, "tcp", nil)
+	defer backend.Close()
+
+	router := NewRouter()
+	require.NoError(t, router.AddRoute("catch-all", []string{"*"}, false, NewProxy(backend.Addr().String(), time.Second)))
+
+	netListener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	listener := NewListener(netListener, NewRouterSwitcher(router), nil, 0, 300*time.Millisecond)
+	defer listener.Close()
+
+	go listener.Accept()
+
+	conn, err := net.Dial("tcp", listener.Addr().String())
+	require.NoError(t, err)
+	defer conn.Close()
+
+	buf := make([]byte, 4)
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	_, err = io.ReadFull(conn, buf)
+	require.NoError(t, err)
+
+	// the connection stays open while active
+	for i := 0; i < 3; i++ {
+		time.Sleep(200 * time.Millisecond)
+		readEcho(t, conn, "ping")
+	}
+
+	start := time.Now()
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	_, err = conn.Read(buf)
+	assert.Equal(t, io.EOF, err)
+	assert.True(t, time.Since(start) < 2*time.Second, "the idle connection is not closed")
+}
+
+func TestListenerShutdown(t *testing.T) {
+	backend := echoServer(t, "tcp", nil)
+	defer backend.Close()
+
+	router := NewRouter()
+	require.NoError(t, router.AddRoute("catch-all", []string{"*"}, false, NewProxy(backend.Addr().String(), time.Second)))
+
+	listener := newListener(t, router, nil)
+	defer listener.Close()
+
+	go listener.Accept()
+
+	conn, err := net.Dial("tcp", listener.Addr().String())
+	require.NoError(t, err)
+	defer conn.Close()
+
+	buf := make([]byte, 4)
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	_, err = io.ReadFull(conn, buf)
+	require.NoError(t, err)
+
+	// the shutdown waits for the proxied connection
+	ctx, cancel := context.WithTimeout(context.Background(), 600*time.Millisecond)
+	defer cancel()
+	assert.Equal(t, context.DeadlineExceeded, listener.Shutdown(ctx))
+
+	readEcho(t, conn, "ping")
+
+	listener.CloseConnections()
+
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	_, err = conn.Read(buf)
+	assert.Equal(t, io.EOF, err)
+
+	assert.NoError(t, listener.Shutdown(context.Background()))
+}
@@ -0,0 +1,106 @@
+package tcp
+
+import (
+	"net"
+	"sync"
+
+	"github.com/containous/traefik/log"
+)
+
+type server struct {
+	Handler
+	address       string
+	weight        int
+	currentWeight int
+	up            bool
+}
+
+// LoadBalancer balances the TCP connections between the servers of a backend, with a smooth weighted round robin.
+type LoadBalancer struct {
+	name    string
+	mu      sync.Mutex
+	servers []*server
+}
+
+// NewLoadBalancer creates a load balancer without server.
+func NewLoadBalancer(backendName string) *LoadBalancer {
+	return &LoadBalancer{name: backendName}
+}
+
+// AddServer adds a server to the load balancer, the servers without weight having a weight of 1.
+func (lb *LoadBalancer) AddServer(address string, weight int, handler Handler) {
+	lb.mu.Lock()
+	defer lb.mu.Unlock()
+
+	if weight <= 0 {
+		weight = 1
+	}
+
+	lb.servers = append(lb.servers, &server{Handler: handler, address: address, weight: weight, up: true})
+}
+
+// ServeTCP forwards the connection to the next available server.
+func (lb *LoadBalancer) ServeTCP(conn net.Conn) {
+	srv := lb.next()
+	if srv == nil {
+		log.Errorf("No TCP server available for backend %s", lb.name)
+		conn.Close()
+		return
+	}
+
+	srv.ServeTCP(conn)
+}
+
+func (lb *LoadBalancer) next() *server {
+	lb.mu.Lock()
+	defer lb.mu.Unlock()
+
+	var total int
+	var best *server
+	for _, srv := range lb.servers {
+		if !srv.up {
+			continue
+		}
+
+		srv.currentWeight += srv.weight
+		total += srv.weight
+
+		if best == nil || srv.currentWeight > best.currentWeight {
+			best = srv
+		}
+	}
+
+	if best != nil {
+		best.currentWeight -= total
+	}
+
+	return best
+}
+
+// addresses returns the addresses of the servers.
+func (lb *LoadBalancer) addresses() []string {
+	lb.mu.Lock()
+	defer lb.mu.Unlock()
+
+	var addresses []string
+	for _, srv := range lb.servers {
+		addresses = append(addresses, srv.address)
+	}
+	return addresses
+}
+
+// setServerUp sets whether the server receives connections, and returns whether its status changed.
+func (lb *LoadBalancer) setServerUp(address string, up bool) bool {
+	lb.mu.Lock()
+	defer lb.mu.Unlock()
+
+	var changed bool
+	for _, srv := range lb.servers {
+		if srv.address == address && srv.up != up {
+			srv.up = up
+			srv.currentWeight = 0
+			changed = true
+		}
+	}
+	return changed
+}
@@ -0,0 +1,78 @@
+package tcp
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadBalancerWeights(t *testing.T) {
+	lb := NewLoadBalancer("backend")
+
+	served := make(map[string]int)
+	for _, address := range []string{"a:1", "b:1", "c:1"} {
+		currentAddress := address
+		weight := 1
+		if address == "a:1" {
+			weight = 3
+		}
+		lb.AddServer(address, weight, HandlerFunc(func(conn net.Conn) {
+			served[currentAddress]++
+		}))
+	}
+
+	for i := 0; i < 10; i++ {
+		lb.ServeTCP(nil)
+	}
+	assert.Equal(t, map[string]int{"a:1": 6, "b:1": 2, "c:1": 2}, served)
+
+	assert.True(t, lb.setServerUp("a:1", false))
+	assert.False(t, lb.setServerUp("a:1", false))
+
+	served = make(map[string]int)
+	for i := 0; i < 10; i++ {
+		lb.ServeTCP(nil)
+	}
+	assert.Equal(t, map[string]int{"b:1": 5, "c:1": 5}, served)
+}
+
+func TestLoadBalancerNoServer(t *testing.T) {
+	lb := NewLoadBalancer("backend")
+	lb.AddServer("a:1", 1, HandlerFunc(func(conn net.Conn) {
+		t.Error("the server is down")
+	}))
+	lb.setServerUp("a:1", false)
+
+	client, server := net.Pipe()
+	defer client.Close()
+
+	lb.ServeTCP(server)
+
+	_, err := client.Read(make([]byte, 1))
+	assert.Error(t, err)
+}
+
+func TestHealthCheck(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+
+	closed, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	closedAddress := closed.Addr().String()
+	closed.Close()
+
+	handler := HandlerFunc(func(conn net.Conn) {})
+	lb := NewLoadBalancer("backend")
+	lb.AddServer(listener.Addr().String(), 1, handler)
+	lb.AddServer(closedAddress, 1, handler)
+
+	NewHealthCheck(lb, time.Minute, time.Second).check()
+
+	for i := 0; i < 4; i++ {
+		assert.Equal(t, listener.Addr().String(), lb.next().address)
+	}
+}
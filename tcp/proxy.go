@@ -0,0 +1,76 @@
+package tcp
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"time"
+
+	"github.com/containous/traefik/log"
+)
+
+type closeWriter interface {
+	CloseWrite() error
+}
+
+type lingerer interface {
+	SetLinger(sec int) error
+}
+
+// setLinger sets the linger option of the wrapped connection, when supported.
+func setLinger(conn net.Conn, sec int) error {
+	if l, ok := conn.(lingerer); ok {
+		return l.SetLinger(sec)
+	}
+	return fmt.Errorf("the linger option is not supported by %T", conn)
+}
+
+// Proxy forwards the TCP connections to a server.
+type Proxy struct {
+	address     string
+	dialTimeout time.Duration
+}
+
+// NewProxy creates a proxy to the server address.
+func NewProxy(address string, dialTimeout time.Duration) *Proxy {
+	return &Proxy{address: address, dialTimeout: dialTimeout}
+}
+
+// ServeTCP forwards the connection to the server, until both sides are closed.
+func (p *Proxy) ServeTCP(conn net.Conn) {
+	defer conn.Close()
+
+	serverConn, err := net.DialTimeout("tcp", p.address, p.dialTimeout)
+	if err != nil {
+		log.Errorf("Error while connecting to TCP server %s: %v", p.address, err)
+		return
+	}
+	defer serverConn.Close()
+
+	errChan := make(chan error, 2)
+	go copyConn(serverConn, conn, errChan)
+	go copyConn(conn, serverConn, errChan)
+
+	for i := 0; i < 2; i++ {
+		if err := <-errChan; err != nil {
+			log.Debugf("Error while forwarding TCP connection to %s: %v", p.address, err)
+		}
+	}
+}
+
+// copyConn copies the data of src to dst, then closes the writing side of dst to forward the end of the stream.
+// Both connections are closed on error, e.g. when the connection is idle, for the copy in the other direction to stop too.
+func copyConn(dst, src net.Conn, errChan chan<- error) {
+	_, err := io.Copy(dst, src)
+
+	if err != nil {
+		dst.Close()
+		src.Close()
+	} else if cw, ok := dst.(closeWriter); ok {
+		cw.CloseWrite()
+	} else {
+		dst.Close()
+	}
+
+	errChan <- err
+}
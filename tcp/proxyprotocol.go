@@ -0,0 +1,114 @@
+package tcp
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+)
+
+// proxyProtocolV2Signature starts the headers of the version 2 of the PROXY protocol.
+var proxyProtocolV2Signature = []byte("\r\n\r\n\x00\r\nQUIT\n")
+
+const (
+	proxyProtocolV2Local = 0x20
+	proxyProtocolV2Proxy = 0x21
+
+	proxyProtocolV2Unspec = 0x00
+	proxyProtocolV2TCP4   = 0x11
+	proxyProtocolV2TCP6   = 0x21
+)
+
+// WriteProxyProtocolHeader writes the PROXY protocol header announcing a connection from src to dst,
+// with the version 1 (human-readable) or 2 (binary) of the protocol.
+// When src or dst is not a TCP address, the header announces an unknown connection,
+// which the server handles as a connection from the proxy itself.
+func WriteProxyProtocolHeader(w io.Writer, version int, src, dst net.Addr) error {
+	var header []byte
+
+	switch version {
+	case 1:
+		header = proxyProtocolV1Header(src, dst)
+	case 2:
+		header = proxyProtocolV2Header(src, dst)
+	default:
+		return fmt.Errorf("unsupported PROXY protocol version %d", version)
+	}
+
+	_, err := w.Write(header)
+	return err
+}
+
+func proxyProtocolV1Header(src, dst net.Addr) []byte {
+	srcAddr, dstAddr, ok := proxyProtocolAddrs(src, dst)
+	if !ok {
+		return []byte("PROXY UNKNOWN\r\n")
+	}
+
+	if len(srcAddr.IP) == net.IPv4len {
+		return []byte(fmt.Sprintf("PROXY TCP4 %s %s %d %d\r\n", srcAddr.IP, dstAddr.IP, srcAddr.Port, dstAddr.Port))
+	}
+
+	return []byte(fmt.Sprintf("PROXY TCP6 %s %s %d %d\r\n", formatIPv6(srcAddr.IP), formatIPv6(dstAddr.IP), srcAddr.Port, dstAddr.Port))
+}
+
+func proxyProtocolV2Header(src, dst net.Addr) []byte {
+	header := bytes.NewBuffer(append([]byte{}, proxyProtocolV2Signature...))
+
+	srcAddr, dstAddr, ok := proxyProtocolAddrs(src, dst)
+	if !ok {
+		header.Write([]byte{proxyProtocolV2Local, proxyProtocolV2Unspec, 0, 0})
+		return header.Bytes()
+	}
+
+	family := byte(proxyProtocolV2TCP6)
+	if len(srcAddr.IP) == net.IPv4len {
+		family = proxyProtocolV2TCP4
+	}
+
+	addresses := new(bytes.Buffer)
+	addresses.Write(srcAddr.IP)
+	addresses.Write(dstAddr.IP)
+	binary.Write(addresses, binary.BigEndian, uint16(srcAddr.Port))
+	binary.Write(addresses, binary.BigEndian, uint16(dstAddr.Port))
+
+	header.Write([]byte{proxyProtocolV2Proxy, family})
+	binary.Write(header, binary.BigEndian, uint16(addresses.Len()))
+	header.Write(addresses.Bytes())
+
+	return header.Bytes()
+}
+
+// formatIPv6 formats an IP in the IPv6 notation, including the IPv4-mapped addresses.
+func formatIPv6(ip net.IP) string {
+	if ip4 := ip.To4(); ip4 != nil {
+		return "::ffff:" + ip4.String()
+	}
+	return ip.String()
+}
+
+// proxyProtocolAddrs returns the TCP addresses of src and dst, both in the same IP family:
+// the IPs are 4 bytes long when both are IPv4, and 16 bytes long otherwise.
+func proxyProtocolAddrs(src, dst net.Addr) (*net.TCPAddr, *net.TCPAddr, bool) {
+	srcAddr, ok := src.(*net.TCPAddr)
+	if !ok || srcAddr == nil {
+		return nil, nil, false
+	}
+
+	dstAddr, ok := dst.(*net.TCPAddr)
+	if !ok || dstAddr == nil {
+		return nil, nil, false
+	}
+
+	if srcIP, dstIP := srcAddr.IP.To4(), dstAddr.IP.To4(); srcIP != nil && dstIP != nil {
+		return &net.TCPAddr{IP: srcIP, Port: srcAddr.Port}, &net.TCPAddr{IP: dstIP, Port: dstAddr.Port}, true
+	}
+
+	srcIP, dstIP := srcAddr.IP.To16(), dstAddr.IP.To16()
+	if srcIP == nil || dstIP == nil {
+		return nil, nil, false
+	}
+
+	return &net.TCPAddr{IP: srcIP, Port: srcAddr.Port}, &net.TCPAddr{IP: dstIP, Port: dstAddr.Port}, true
+}
@@ -0,0 +1,103 @@
+package tcp
+
+import (
+	"bytes"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteProxyProtocolHeader(t *testing.T) {
+	tcp4Src := &net.TCPAddr{IP: net.ParseIP("192.168.0.1"), Port: 56324}
+	tcp4Dst := &net.TCPAddr{IP: net.ParseIP("10.0.0.1"), Port: 443}
+	tcp6Src := &net.TCPAddr{IP: net.ParseIP("2001:db8::1"), Port: 56324}
+	tcp6Dst := &net.TCPAddr{IP: net.ParseIP("2001:db8::2"), Port: 443}
+
+	testCases := []struct {
+		desc     string
+		version  int
+		src      net.Addr
+		dst      net.Addr
+		expected []byte
+	}{
+		{
+			desc:     "v1 TCP4",
+			version:  1,
+			src:      tcp4Src,
+			dst:      tcp4Dst,
+			expected: []byte("PROXY TCP4 192.168.0.1 10.0.0.1 56324 443\r\n"),
+		},
+		{
+			desc:     "v1 TCP6",
+			version:  1,
+			src:      tcp6Src,
+			dst:      tcp6Dst,
+			expected: []byte("PROXY TCP6 2001:db8::1 2001:db8::2 56324 443\r\n"),
+		},
+		{
+			desc:     "v1 IPv4 client on an IPv6 address",
+			version:  1,
+			src:      tcp4Src,
+			dst:      tcp6Dst,
+			expected: []byte("PROXY TCP6 ::ffff:192.168.0.1 2001:db8::2 56324 443\r\n"),
+		},
+		{
+			desc:     "v1 unknown client",
+			version:  1,
+			src:      &net.UnixAddr{Name: "@", Net: "unix"},
+			dst:      tcp4Dst,
+			expected: []byte("PROXY UNKNOWN\r\n"),
+		},
+		{
+			desc:    "v2 TCP4",
+			version: 2,
+			src:     tcp4Src,
+			dst:     tcp4Dst,
+			expected: append([]byte("\r\n\r\n\x00\r\nQUIT\n"),
+				0x21, 0x11, 0x00, 0x0c,
+				192, 168, 0, 1,
+				10, 0, 0, 1,
+				0xdc, 0x04,
+				0x01, 0xbb,
+			),
+		},
+		{
+			desc:    "v2 TCP6",
+			version: 2,
+			src:     tcp6Src,
+			dst:     tcp6Dst,
+			expected: append([]byte("\r\n\r\n\x00\r\nQUIT\n"),
+				0x21, 0x21, 0x00, 0x24,
+				0x20, 0x01, 0x0d, 0xb8, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0x01,
+				0x20, 0x01, 0x0d, 0xb8, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0x02,
+				0xdc, 0x04,
+				0x01, 0xbb,
+			),
+		},
+		{
+			desc:     "v2 unknown client",
+			version:  2,
+			expected: append([]byte("\r\n\r\n\x00\r\nQUIT\n"), 0x20, 0x00, 0x00, 0x00),
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			buf := new(bytes.Buffer)
+			err := WriteProxyProtocolHeader(buf, test.version, test.src, test.dst)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expected, buf.Bytes())
+		})
+	}
+}
+
+func TestWriteProxyProtocolHeaderInvalidVersion(t *testing.T) {
+	err := WriteProxyProtocolHeader(new(bytes.Buffer), 3, nil, nil)
+	assert.Error(t, err)
+}
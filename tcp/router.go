@@ -0,0 +1,174 @@
+package tcp
+
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/containous/traefik/safe"
+)
+
+const (
+	hostSNIRule = "HostSNI:"
+	// catchAll is the host of the rules matching all the connections, with or without TLS.
+	catchAll = "*"
+)
+
+// Handler handles the TCP connections.
+type Handler interface {
+	ServeTCP(conn net.Conn)
+}
+
+// HandlerFunc is an adapter allowing the use of ordinary functions as TCP handlers.
+type HandlerFunc func(conn net.Conn)
+
+// ServeTCP calls f(conn).
+func (f HandlerFunc) ServeTCP(conn net.Conn) {
+	f(conn)
+}
+
+// ParseRule parses the rule of a TCP frontend, e.g. "HostSNI:example.com,*.example.com" or "HostSNI:*",
+// and returns its hosts.
+func ParseRule(rule string) ([]string, error) {
+	if !strings.HasPrefix(rule, hostSNIRule) {
+		return nil, fmt.Errorf("unsupported TCP rule %q, expected %s<hosts>", rule, hostSNIRule)
+	}
+
+	var hosts []string
+	for _, host := range strings.Split(strings.TrimPrefix(rule, hostSNIRule), ",") {
+		host = strings.ToLower(strings.TrimSpace(host))
+		if len(host) == 0 {
+			continue
+		}
+
+		if host == catchAll {
+			return []string{catchAll}, nil
+		}
+		hosts = append(hosts, host)
+	}
+
+	if len(hosts) == 0 {
+		return nil, fmt.Errorf("no host in the TCP rule %q", rule)
+	}
+
+	return hosts, nil
+}
+
+// IsCatchAll returns whether the hosts match all the connections.
+func IsCatchAll(hosts []string) bool {
+	return len(hosts) == 1 && hosts[0] == catchAll
+}
+
+type route struct {
+	frontendName string
+	hosts        []string
+	passthrough  bool
+	handler      Handler
+}
+
+// Router routes the connections of an entry point to the TCP frontends, by TLS server name (SNI).
+type Router struct {
+	routes   []*route
+	catchAll *route
+}
+
+// NewRouter creates a router without route.
+func NewRouter() *Router {
+	return &Router{}
+}
+
+// AddRoute adds the route of a TCP frontend.
+// With passthrough, the TLS connections are forwarded as is, otherwise TLS is terminated by the entry point.
+func (r *Router) AddRoute(frontendName string, hosts []string, passthrough bool, handler Handler) error {
+	newRoute := &route{
+		frontendName: frontendName,
+		hosts:        hosts,
+		passthrough:  passthrough,
+		handler:      handler,
+	}
+
+	if IsCatchAll(hosts) {
+		if r.catchAll != nil {
+			return fmt.Errorf("catch-all TCP route already defined by frontend %s", r.catchAll.frontendName)
+		}
+		r.catchAll = newRoute
+		return nil
+	}
+
+	for _, rt := range r.routes {
+		for _, host := range hosts {
+			if rt.hasHost(host) {
+				return fmt.Errorf("host %s already routed to frontend %s", host, rt.frontendName)
+			}
+		}
+	}
+
+	r.routes = append(r.routes, newRoute)
+	return nil
+}
+
+// Empty returns whether the router has no route.
+func (r *Router) Empty() bool {
+	return len(r.routes) == 0 && r.catchAll == nil
+}
+
+// Match returns the name of the TCP frontend routing the connections of the server name, or an empty string.
+func (r *Router) Match(serverName string) string {
+	if rt := r.match(serverName); rt != nil {
+		return rt.frontendName
+	}
+	return ""
+}
+
+// match returns the route of the server name: the exact hosts are preferred to the wildcard ones, and to the catch-all.
+func (r *Router) match(serverName string) *route {
+	serverName = strings.ToLower(serverName)
+
+	for _, rt := range r.routes {
+		if rt.hasHost(serverName) {
+			return rt
+		}
+	}
+
+	if i := strings.Index(serverName, "."); i > 0 {
+		wildcard := "*" + serverName[i:]
+		for _, rt := range r.routes {
+			if rt.hasHost(wildcard) {
+				return rt
+			}
+		}
+	}
+
+	return r.catchAll
+}
+
+func (rt *route) hasHost(host string) bool {
+	for _, h := range rt.hosts {
+		if h == host {
+			return true
+		}
+	}
+	return false
+}
+
+// RouterSwitcher allows hot switching of the TCP router of an entry point.
+type RouterSwitcher struct {
+	router *safe.Safe
+}
+
+// NewRouterSwitcher builds a new instance of RouterSwitcher.
+func NewRouterSwitcher(router *Router) *RouterSwitcher {
+	return &RouterSwitcher{
+		router: safe.New(router),
+	}
+}
+
+// GetRouter returns the current router.
+func (s *RouterSwitcher) GetRouter() *Router {
+	return s.router.Get().(*Router)
+}
+
+// UpdateRouter safely updates the current router with a new one.
+func (s *RouterSwitcher) UpdateRouter(router *Router) {
+	s.router.Set(router)
+}
@@ -0,0 +1,93 @@
+package tcp
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRule(t *testing.T) {
+	testCases := []struct {
+		desc          string
+		rule          string
+		expected      []string
+		expectedError bool
+	}{
+		{
+			desc:     "one host",
+			rule:     "HostSNI:db.example.com",
+			expected: []string{"db.example.com"},
+		},
+		{
+			desc:     "several hosts",
+			rule:     "HostSNI:DB.example.com, *.mqtt.example.com",
+			expected: []string{"db.example.com", "*.mqtt.example.com"},
+		},
+		{
+			desc:     "catch-all",
+			rule:     "HostSNI:*",
+			expected: []string{"*"},
+		},
+		{
+			desc:          "no host",
+			rule:          "HostSNI:",
+			expectedError: true,
+		},
+		{
+			desc:          "unsupported rule",
+			rule:          "Host:example.com",
+			expectedError: true,
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			hosts, err := ParseRule(test.rule)
+			if test.expectedError {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, hosts)
+		})
+	}
+}
+
+func TestRouterMatch(t *testing.T) {
+	router := NewRouter()
+	assert.True(t, router.Empty())
+
+	handler := HandlerFunc(func(conn net.Conn) {})
+
+	require.NoError(t, router.AddRoute("exact", []string{"db.example.com"}, false, handler))
+	require.NoError(t, router.AddRoute("wildcard", []string{"*.example.com"}, true, handler))
+	require.NoError(t, router.AddRoute("catch-all", []string{"*"}, false, handler))
+	assert.False(t, router.Empty())
+
+	assert.Error(t, router.AddRoute("duplicate", []string{"foo.bar", "db.example.com"}, false, handler))
+	assert.Error(t, router.AddRoute("other catch-all", []string{"*"}, false, handler))
+
+	testCases := []struct {
+		serverName string
+		expected   string
+	}{
+		{serverName: "db.example.com", expected: "exact"},
+		{serverName: "DB.Example.com", expected: "exact"},
+		{serverName: "mqtt.example.com", expected: "wildcard"},
+		{serverName: "a.mqtt.example.com", expected: "catch-all"},
+		{serverName: "example.com", expected: "catch-all"},
+		{serverName: "", expected: "catch-all"},
+	}
+
+	for _, test := range testCases {
+		rt := router.match(test.serverName)
+		require.NotNil(t, rt, test.serverName)
+		assert.Equal(t, test.expected, rt.frontendName, test.serverName)
+	}
+}
@@ -0,0 +1,124 @@
+package tcp
+
+import (
+	"crypto/tls"
+	"net"
+	"sync"
+)
+
+// tlsConns keeps the connections passed to the HTTP servers of the TLS entry points, as accepted before TLS wraps them.
+var tlsConns = &connRegistry{conns: make(map[string][]net.Conn)}
+
+// UnderlyingConn returns the connection a TLS connection of an entry point was accepted with,
+// to close it without the close notify alert of TLS.
+// It returns false when the connection is unknown, or cannot be told apart from another one (as the clients of a Unix domain socket).
+func UnderlyingConn(conn *tls.Conn) (net.Conn, bool) {
+	return tlsConns.get(connKey(conn))
+}
+
+// connKey identifies a connection by its local and remote addresses.
+func connKey(conn net.Conn) string {
+	return conn.LocalAddr().String() + " " + conn.RemoteAddr().String()
+}
+
+// connRegistry holds connections by key.
+type connRegistry struct {
+	lock  sync.Mutex
+	conns map[string][]net.Conn
+}
+
+func (r *connRegistry) add(key string, conn net.Conn) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.conns[key] = append(r.conns[key], conn)
+}
+
+func (r *connRegistry) remove(key string, conn net.Conn) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	conns := r.conns[key]
+	for i, c := range conns {
+		if c == conn {
+			conns = append(conns[:i], conns[i+1:]...)
+			break
+		}
+	}
+
+	if len(conns) == 0 {
+		delete(r.conns, key)
+	} else {
+		r.conns[key] = conns
+	}
+}
+
+// get returns the connection of the key, unless several connections share it.
+func (r *connRegistry) get(key string) (net.Conn, bool) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	conns := r.conns[key]
+	if len(conns) != 1 {
+		return nil, false
+	}
+	return conns[0], true
+}
+
+// registeredConn adds itself to the registry on its first read, and removes itself when closed.
+// The remote address of a PROXY protocol connection is only known once its header is read.
+type registeredConn struct {
+	net.Conn
+	registry *connRegistry
+
+	once       sync.Once
+	lock       sync.Mutex
+	key        string
+	registered bool
+	closed     bool
+}
+
+func (c *registeredConn) Read(b []byte) (int, error) {
+	n, err := c.Conn.Read(b)
+
+	c.once.Do(func() {
+		c.lock.Lock()
+		defer c.lock.Unlock()
+
+		if c.closed {
+			return
+		}
+
+		c.key = connKey(c.Conn)
+		c.registry.add(c.key, c)
+		c.registered = true
+	})
+
+	return n, err
+}
+
+// CloseWrite closes the writing side of the connection when supported, and the whole connection otherwise.
+func (c *registeredConn) CloseWrite() error {
+	if cw, ok := c.Conn.(closeWriter); ok {
+		return cw.CloseWrite()
+	}
+	return c.Close()
+}
+
+// SetLinger sets the linger option of the connection, when supported.
+func (c *registeredConn) SetLinger(sec int) error {
+	return setLinger(c.Conn, sec)
+}
+
+func (c *registeredConn) Close() error {
+	c.lock.Lock()
+	if !c.closed {
+		c.closed = true
+		if c.registered {
+			c.registry.remove(c.key, c)
+		}
+	}
+	c.lock.Unlock()
+
+	return c.Conn.Close()
+}
@@ -0,0 +1,56 @@
+package tcp
+
+import (
+	"crypto/tls"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegisteredConn(t *testing.T) {
+	registry := &connRegistry{conns: make(map[string][]net.Conn)}
+
+	server, client := net.Pipe()
+	defer client.Close()
+
+	conn := &registeredConn{Conn: server, registry: registry}
+	key := connKey(conn)
+
+	// the connection is only registered once read
+	_, ok := registry.get(key)
+	assert.False(t, ok)
+
+	go client.Write([]byte("a"))
+
+	_, err := conn.Read(make([]byte, 1))
+	require.NoError(t, err)
+
+	registered, ok := registry.get(key)
+	assert.True(t, ok)
+	assert.Equal(t, conn, registered)
+
+	// the connections sharing the same addresses cannot be told apart
+	other := &registeredConn{Conn: server, registry: registry}
+	registry.add(key, other)
+
+	_, ok = registry.get(key)
+	assert.False(t, ok)
+
+	registry.remove(key, other)
+
+	require.NoError(t, conn.Close())
+	_, ok = registry.get(key)
+	assert.False(t, ok)
+	assert.Empty(t, registry.conns)
+}
+
+func TestUnderlyingConnUnknown(t *testing.T) {
+	server, client := net.Pipe()
+	defer server.Close()
+	defer client.Close()
+
+	_, ok := UnderlyingConn(tls.Server(server, &tls.Config{}))
+	assert.False(t, ok)
+}
@@ -0,0 +1,72 @@
+package tcp
+
+import (
+	"context"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/containous/traefik/log"
+)
+
+// connTracker tracks the open connections, for a graceful shutdown.
+type connTracker struct {
+	lock  sync.Mutex
+	conns map[net.Conn]struct{}
+}
+
+func newConnTracker() *connTracker {
+	return &connTracker{conns: make(map[net.Conn]struct{})}
+}
+
+func (t *connTracker) add(conn net.Conn) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	t.conns[conn] = struct{}{}
+}
+
+func (t *connTracker) remove(conn net.Conn) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	delete(t.conns, conn)
+}
+
+func (t *connTracker) len() int {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	return len(t.conns)
+}
+
+// shutdown waits for the connections to be closed, or for the context to be done.
+func (t *connTracker) shutdown(ctx context.Context) error {
+	ticker := time.NewTicker(500 * time.Millisecond)
+	defer ticker.Stop()
+
+	for {
+		if t.len() == 0 {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// close closes the connections.
+func (t *connTracker) close() {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	for conn := range t.conns {
+		if err := conn.Close(); err != nil {
+			log.Debugf("Error while closing TCP connection: %v", err)
+		}
+		delete(t.conns, conn)
+	}
+}
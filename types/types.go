@@ -29,6 +29,8 @@ type Backend struct {
 	MaxConn        *MaxConn          `json:"maxConn,omitempty"`
 	HealthCheck    *HealthCheck      `json:"healthCheck,omitempty"`
 	Buffering      *Buffering        `json:"buffering,omitempty"`
+	Static         *Static           `json:"static,omitempty"`
+	ProxyProtocol  *ProxyProtocol    `json:"proxyProtocol,omitempty"`
 }
 
 // MaxConn holds maximum connection configuration
@@ -62,12 +64,46 @@ type Buffering struct {
 	RetryExpression      string `json:"retryExpression,omitempty"`
 }
 
+// Static holds the configuration of the backend servers serving static files (file:// URLs).
+type Static struct {
+	IndexFiles []string `json:"indexFiles,omitempty"`
+	SPA        bool     `json:"spa,omitempty"`
+	Browse     bool     `json:"browse,omitempty"`
+}
+
+// ProxyProtocol holds the configuration of the PROXY protocol header sent on the connections to the backend servers.
+type ProxyProtocol struct {
+	Version int `json:"version,omitempty"`
+}
+
 // WhiteList contains white list configuration.
 type WhiteList struct {
 	SourceRange []string    `json:"sourceRange,omitempty"`
+	SourceSets  []string    `json:"sourceSets,omitempty"`
+	DenyRange   []string    `json:"denyRange,omitempty"`
+	DenySets    []string    `json:"denySets,omitempty"`
 	IPStrategy  *IPStrategy `json:"ipStrategy,omitempty"`
 }
 
+// IPSet holds the source of a named list of IPs and CIDRs, referenced by the white lists.
+type IPSet struct {
+	File            string         `description:"File containing one IP or CIDR per line" export:"true"`
+	URL             string         `description:"URL returning one IP or CIDR per line" export:"true"`
+	RefreshInterval parse.Duration `description:"Interval between two reloads of the set" export:"true"`
+}
+
+// GeoIP holds the filtering and enrichment rules based on the location of the client IP.
+type GeoIP struct {
+	CountryDatabase  string      `json:"countryDatabase,omitempty" description:"Path to a GeoLite2/GeoIP2 Country or City database"`
+	ASNDatabase      string      `json:"asnDatabase,omitempty" description:"Path to a GeoLite2/GeoIP2 ASN database"`
+	AllowedCountries []string    `json:"allowedCountries,omitempty" description:"ISO codes of the allowed countries"`
+	DeniedCountries  []string    `json:"deniedCountries,omitempty" description:"ISO codes of the denied countries"`
+	AllowedASNs      []string    `json:"allowedASNs,omitempty" description:"Allowed autonomous system numbers"`
+	DeniedASNs       []string    `json:"deniedASNs,omitempty" description:"Denied autonomous system numbers"`
+	AddHeaders       bool        `json:"addHeaders,omitempty" description:"Add the X-Geo-Country and X-Geo-ASN headers to the request"`
+	IPStrategy       *IPStrategy `json:"ipStrategy,omitempty"`
+}
+
 // HealthCheck holds HealthCheck configuration
 type HealthCheck struct {
 	Scheme   string            `json:"scheme,omitempty"`
@@ -102,9 +138,17 @@ type ServerRoute struct {
 
 // ErrorPage holds custom error page configuration
 type ErrorPage struct {
-	Status  []string `json:"status,omitempty"`
-	Backend string   `json:"backend,omitempty"`
-	Query   string   `json:"query,omitempty"`
+	Status      []string `json:"status,omitempty"`
+	Backend     string   `json:"backend,omitempty"`
+	Query       string   `json:"query,omitempty"`
+	File        string   `json:"file,omitempty"`
+	Body        string   `json:"body,omitempty"`
+	ContentType string   `json:"contentType,omitempty"`
+}
+
+// HasTemplate returns whether the error page is rendered from a template, instead of being fetched from a backend.
+func (e *ErrorPage) HasTemplate() bool {
+	return len(e.File) > 0 || len(e.Body) > 0
 }
 
 // Rate holds a rate limiting configuration for a specific time period
@@ -116,8 +160,13 @@ type Rate struct {
 
 // RateLimit holds a rate limiting configuration for a given frontend
 type RateLimit struct {
-	RateSet       map[string]*Rate `json:"rateset,omitempty"`
-	ExtractorFunc string           `json:"extractorFunc,omitempty"`
+	RateSet           map[string]*Rate `json:"rateset,omitempty"`
+	ExtractorFunc     string           `json:"extractorFunc,omitempty"`
+	Distributed       bool             `json:"distributed,omitempty"`
+	StatusCode        int              `json:"statusCode,omitempty"`
+	Body              string           `json:"body,omitempty"`
+	ContentType       string           `json:"contentType,omitempty"`
+	ExemptSourceRange []string         `json:"exemptSourceRange,omitempty"`
 }
 
 // Headers holds the custom header configuration
@@ -125,6 +174,9 @@ type Headers struct {
 	CustomRequestHeaders  map[string]string `json:"customRequestHeaders,omitempty"`
 	CustomResponseHeaders map[string]string `json:"customResponseHeaders,omitempty"`
 
+	RequestOperations  []HeaderOperation `json:"requestOperations,omitempty"`
+	ResponseOperations []HeaderOperation `json:"responseOperations,omitempty"`
+
 	AllowedHosts            []string          `json:"allowedHosts,omitempty"`
 	HostsProxyHeaders       []string          `json:"hostsProxyHeaders,omitempty"`
 	SSLRedirect             bool              `json:"sslRedirect,omitempty"`
@@ -150,7 +202,9 @@ type Headers struct {
 // HasCustomHeadersDefined checks to see if any of the custom header elements have been set
 func (h *Headers) HasCustomHeadersDefined() bool {
 	return h != nil && (len(h.CustomResponseHeaders) != 0 ||
-		len(h.CustomRequestHeaders) != 0)
+		len(h.CustomRequestHeaders) != 0 ||
+		len(h.RequestOperations) != 0 ||
+		len(h.ResponseOperations) != 0)
 }
 
 // HasSecureHeadersDefined checks to see if any of the secure header elements have been set
@@ -177,6 +231,87 @@ func (h *Headers) HasSecureHeadersDefined() bool {
 		h.IsDevelopment)
 }
 
+// Header operation actions
+const (
+	HeaderOperationSet     = "set"
+	HeaderOperationAppend  = "append"
+	HeaderOperationRemove  = "remove"
+	HeaderOperationReplace = "replace"
+)
+
+// HeaderOperation holds a header modification, applied in order with the other operations of the same list.
+// Value can be a Go template using the request data (e.g. {{ .ClientIP }}, {{ .Vars.subdomain }}).
+type HeaderOperation struct {
+	Action      string   `json:"action,omitempty" description:"set, append, remove or replace"`
+	Name        string   `json:"name,omitempty" description:"Header name"`
+	Value       string   `json:"value,omitempty" description:"Header value, or replacement when the action is replace"`
+	Regex       string   `json:"regex,omitempty" description:"Regex matched against the header values when the action is replace"`
+	StatusCodes []string `json:"statusCodes,omitempty" description:"Only applies the response operation for the given status codes (e.g. 200, 500-599)"`
+}
+
+// Maintenance holds the maintenance mode configuration.
+// In maintenance, the requests are answered with a static page instead of being forwarded to the backend,
+// unless they come from one of the bypass IPs, or have the bypass header.
+type Maintenance struct {
+	Enabled           bool           `json:"enabled,omitempty" description:"Enable the maintenance mode"`
+	StatusCode        int            `json:"statusCode,omitempty" description:"Status code of the maintenance page (default: 503)"`
+	RetryAfter        parse.Duration `json:"retryAfter,omitempty" description:"Value of the Retry-After header (default: 5m)"`
+	Page              string         `json:"page,omitempty" description:"Path to the file of the maintenance page"`
+	SourceRange       []string       `json:"sourceRange,omitempty" description:"IPs or CIDRs allowed to bypass the maintenance"`
+	BypassHeaderName  string         `json:"bypassHeaderName,omitempty" description:"Name of the header allowing to bypass the maintenance"`
+	BypassHeaderValue string         `json:"bypassHeaderValue,omitempty" description:"Value of the header allowing to bypass the maintenance"`
+	IPStrategy        *IPStrategy    `json:"ipStrategy,omitempty"`
+}
+
+// FaultInjection holds the fault injection configuration.
+// When enabled, a percentage of the requests are delayed, and a percentage of them are aborted,
+// optionally only the requests having the header.
+type FaultInjection struct {
+	Enabled           bool           `json:"enabled,omitempty" description:"Enable the fault injection"`
+	HeaderName        string         `json:"headerName,omitempty" description:"Only inject the faults in the requests having this header"`
+	HeaderValue       string         `json:"headerValue,omitempty" description:"Only inject the faults in the requests having the header with this value"`
+	DelayPercentage   float64        `json:"delayPercentage,omitempty" description:"Percentage of the requests to delay"`
+	Delay             parse.Duration `json:"delay,omitempty" description:"Delay of the requests (the mean delay, or the minimum delay for the uniform distribution)"`
+	DelayMax          parse.Duration `json:"delayMax,omitempty" description:"Maximum delay of the requests, required with the uniform distribution"`
+	DelayDistribution string         `json:"delayDistribution,omitempty" description:"Distribution of the delays: fixed, uniform or exponential (default: fixed)"`
+	AbortPercentage   float64        `json:"abortPercentage,omitempty" description:"Percentage of the requests to abort"`
+	AbortStatusCode   int            `json:"abortStatusCode,omitempty" description:"Status code of the aborted requests (default: 503)"`
+	AbortReset        bool           `json:"abortReset,omitempty" description:"Abort the requests by resetting the connection, instead of answering with the status code"`
+}
+
+// SizeLimits holds the size limits of the requests.
+// Unlike the buffering, the limits are enforced while the requests are streamed to the backend.
+type SizeLimits struct {
+	MaxRequestBodyBytes int64 `json:"maxRequestBodyBytes,omitempty" description:"Maximum size of the request bodies, in bytes"`
+	MaxHeaderCount      int   `json:"maxHeaderCount,omitempty" description:"Maximum number of request header fields"`
+	MaxHeaderBytes      int64 `json:"maxHeaderBytes,omitempty" description:"Maximum size of the request header fields (names and values), in bytes"`
+}
+
+// RequestID makes sure every request carries an ID header.
+// The incoming IDs are only kept when trusted, and are replaced by a generated one otherwise.
+type RequestID struct {
+	HeaderName string   `json:"headerName,omitempty" description:"Header carrying the request ID (default: X-Request-Id)" export:"true"`
+	Format     string   `json:"format,omitempty" description:"Format of the generated IDs: uuid or ulid (default: uuid)" export:"true"`
+	Insecure   bool     `json:"insecure,omitempty" description:"Trust all incoming request IDs" export:"true"`
+	TrustedIPs []string `json:"trustedIPs,omitempty" description:"Trust the incoming request IDs only from these IPs"`
+}
+
+// BodyRewrite holds the response body rewriting configuration.
+// The replacements are applied in order, on the responses having one of the content types.
+type BodyRewrite struct {
+	ContentTypes []string          `json:"contentTypes,omitempty" description:"Content types of the rewritten responses (default: HTML, JSON and JavaScript)"`
+	MaxBodySize  int64             `json:"maxBodySize,omitempty" description:"Maximum size of the rewritten bodies, larger bodies are forwarded as is"`
+	Replacements []BodyReplacement `json:"replacements,omitempty"`
+}
+
+// BodyReplacement holds a substitution of a string, or of a regex, in the response body.
+// Replacement can be a Go template using the stripped path prefix (e.g. {{ .Prefix }}).
+type BodyReplacement struct {
+	Search      string `json:"search,omitempty" description:"String to replace"`
+	Regex       string `json:"regex,omitempty" description:"Regex to replace, instead of a string"`
+	Replacement string `json:"replacement,omitempty" description:"Replacement, can refer to the regex groups ($1)"`
+}
+
 // Frontend holds frontend configuration.
 type Frontend struct {
 	EntryPoints       []string              `json:"entryPoints,omitempty" hash:"ignore"`
@@ -192,6 +327,13 @@ type Frontend struct {
 	RateLimit         *RateLimit            `json:"ratelimit,omitempty"`
 	Redirect          *Redirect             `json:"redirect,omitempty"`
 	Auth              *Auth                 `json:"auth,omitempty"`
+	TLSClientCertAuth *TLSClientCertAuth    `json:"tlsClientCertAuth,omitempty"`
+	GeoIP             *GeoIP                `json:"geoIP,omitempty"`
+	BodyRewrite       *BodyRewrite          `json:"bodyRewrite,omitempty"`
+	Maintenance       *Maintenance          `json:"maintenance,omitempty"`
+	FaultInjection    *FaultInjection       `json:"faultInjection,omitempty"`
+	SizeLimits        *SizeLimits           `json:"sizeLimits,omitempty"`
+	RequestID         *RequestID            `json:"requestID,omitempty"`
 }
 
 // Hash returns the hash value of a Frontend struct.
@@ -211,6 +353,14 @@ type Redirect struct {
 	Regex       string `json:"regex,omitempty"`
 	Replacement string `json:"replacement,omitempty"`
 	Permanent   bool   `json:"permanent,omitempty"`
+	StatusCode  int    `json:"statusCode,omitempty"`
+	Scheme      string `json:"scheme,omitempty"`
+	Host        string `json:"host,omitempty"`
+	Port        string `json:"port,omitempty"`
+	Path        string `json:"path,omitempty"`
+	PathPrefix  string `json:"pathPrefix,omitempty"`
+	DropQuery   bool   `json:"dropQuery,omitempty"`
+	AppRoot     string `json:"appRoot,omitempty"`
 }
 
 // LoadBalancerMethod holds the method of load balancing to use.
@@ -247,14 +397,61 @@ func NewLoadBalancerMethod(loadBalancer *LoadBalancer) (LoadBalancerMethod, erro
 	return Wrr, fmt.Errorf("invalid load-balancing method %q, fallback to 'wrr' method", loadBalancer.Method)
 }
 
+// TCPFrontend holds the configuration of a TCP frontend.
+type TCPFrontend struct {
+	EntryPoints []string `json:"entryPoints,omitempty"`
+	Backend     string   `json:"backend,omitempty"`
+	Rule        string   `json:"rule,omitempty"`
+	Passthrough bool     `json:"passthrough,omitempty"`
+}
+
+// TCPBackend holds the configuration of a TCP backend.
+type TCPBackend struct {
+	Servers     map[string]TCPServer `json:"servers,omitempty"`
+	HealthCheck *TCPHealthCheck      `json:"healthCheck,omitempty"`
+}
+
+// TCPServer holds the configuration of a server of a TCP backend.
+type TCPServer struct {
+	Address string `json:"address,omitempty"`
+	Weight  int    `json:"weight"`
+}
+
+// TCPHealthCheck holds the health check configuration of a TCP backend: the servers are up when they accept connections.
+type TCPHealthCheck struct {
+	Interval string `json:"interval,omitempty"`
+	Timeout  string `json:"timeout,omitempty"`
+}
+
+// UDPFrontend holds the configuration of a UDP frontend: it forwards all the datagrams of its UDP entry points.
+type UDPFrontend struct {
+	EntryPoints []string `json:"entryPoints,omitempty"`
+	Backend     string   `json:"backend,omitempty"`
+}
+
+// UDPBackend holds the configuration of a UDP backend.
+type UDPBackend struct {
+	Servers map[string]UDPServer `json:"servers,omitempty"`
+}
+
+// UDPServer holds the configuration of a server of a UDP backend.
+type UDPServer struct {
+	Address string `json:"address,omitempty"`
+	Weight  int    `json:"weight"`
+}
+
 // Configurations is for currentConfigurations Map
 type Configurations map[string]*Configuration
 
 // Configuration of a provider.
 type Configuration struct {
-	Backends  map[string]*Backend         `json:"backends,omitempty"`
-	Frontends map[string]*Frontend        `json:"frontends,omitempty"`
-	TLS       []*traefiktls.Configuration `json:"-"`
+	Backends     map[string]*Backend         `json:"backends,omitempty"`
+	Frontends    map[string]*Frontend        `json:"frontends,omitempty"`
+	TCPBackends  map[string]*TCPBackend      `json:"tcpBackends,omitempty"`
+	TCPFrontends map[string]*TCPFrontend     `json:"tcpFrontends,omitempty"`
+	UDPBackends  map[string]*UDPBackend      `json:"udpBackends,omitempty"`
+	UDPFrontends map[string]*UDPFrontend     `json:"udpFrontends,omitempty"`
+	TLS          []*traefiktls.Configuration `json:"-"`
 }
 
 // ConfigMessage hold configuration information exchanged between parts of traefik.
@@ -416,10 +613,22 @@ type Digest struct {
 
 // Forward authentication
 type Forward struct {
-	Address             string     `description:"Authentication server address" json:"address,omitempty"`
-	TLS                 *ClientTLS `description:"Enable TLS support" json:"tls,omitempty" export:"true"`
-	TrustForwardHeader  bool       `description:"Trust X-Forwarded-* headers" json:"trustForwardHeader,omitempty" export:"true"`
-	AuthResponseHeaders []string   `description:"Headers to be forwarded from auth response" json:"authResponseHeaders,omitempty"`
+	Address             string        `description:"Authentication server address" json:"address,omitempty"`
+	TLS                 *ClientTLS    `description:"Enable TLS support" json:"tls,omitempty" export:"true"`
+	TrustForwardHeader  bool          `description:"Trust X-Forwarded-* headers" json:"trustForwardHeader,omitempty" export:"true"`
+	AuthResponseHeaders []string      `description:"Headers to be forwarded from auth response" json:"authResponseHeaders,omitempty"`
+	AuthRequestHeaders  []string      `description:"Headers to be sent to the auth server (default: all)" json:"authRequestHeaders,omitempty"`
+	Method              string        `description:"HTTP method used to call the auth server (default: GET)" json:"method,omitempty" export:"true"`
+	ForwardBody         bool          `description:"Send the request body to the auth server" json:"forwardBody,omitempty" export:"true"`
+	MaxBodySize         int64         `description:"Maximum size of the request body sent to the auth server" json:"maxBodySize,omitempty" export:"true"`
+	Cache               *ForwardCache `description:"Cache the auth server decisions" json:"cache,omitempty" export:"true"`
+}
+
+// ForwardCache holds the forward authentication cache configuration
+type ForwardCache struct {
+	KeyHeaders  []string       `description:"Request headers used to build the cache key" json:"keyHeaders,omitempty"`
+	TTL         parse.Duration `description:"Duration during which a granted access is cached" json:"ttl,omitempty" export:"true"`
+	NegativeTTL parse.Duration `description:"Duration during which a denied access is cached" json:"negativeTTL,omitempty" export:"true"`
 }
 
 // CanonicalDomain returns a lower case domain with trim space
@@ -657,6 +866,17 @@ type TLSClientHeaders struct {
 	Infos *TLSClientCertificateInfos `description:"Enable header with configured client cert infos" json:"infos,omitempty"`
 }
 
+// TLSClientCertAuth holds the rules a TLS client certificate must match to reach a frontend.
+// A certificate is authorized as soon as one of the values matches.
+type TLSClientCertAuth struct {
+	CommonNames         []string `description:"Allowed subject common names (glob)" json:"commonNames,omitempty"`
+	OrganizationalUnits []string `description:"Allowed subject organizational units (glob)" json:"organizationalUnits,omitempty"`
+	DNSNames            []string `description:"Allowed SAN DNS names (glob)" json:"dnsNames,omitempty"`
+	URIs                []string `description:"Allowed SAN URIs, e.g. SPIFFE IDs (glob)" json:"uris,omitempty"`
+	Issuers             []string `description:"Allowed issuer common names or distinguished names (glob)" json:"issuers,omitempty"`
+	Fingerprints        []string `description:"Allowed SHA-256 certificate fingerprints" json:"fingerprints,omitempty"`
+}
+
 // TLSClientCertificateInfos holds the client TLS certificate infos configuration
 type TLSClientCertificateInfos struct {
 	NotAfter  bool                              `description:"Add NotAfter info in header" json:"notAfter"`
@@ -0,0 +1,59 @@
+package udp
+
+import (
+	"sync"
+)
+
+type server struct {
+	address       string
+	weight        int
+	currentWeight int
+}
+
+// LoadBalancer balances the UDP sessions between the servers of a backend, with a smooth weighted round robin.
+type LoadBalancer struct {
+	name    string
+	mu      sync.Mutex
+	servers []*server
+}
+
+// NewLoadBalancer creates a load balancer without server.
+func NewLoadBalancer(backendName string) *LoadBalancer {
+	return &LoadBalancer{name: backendName}
+}
+
+// AddServer adds a server to the load balancer, the servers without weight having a weight of 1.
+func (lb *LoadBalancer) AddServer(address string, weight int) {
+	lb.mu.Lock()
+	defer lb.mu.Unlock()
+
+	if weight <= 0 {
+		weight = 1
+	}
+
+	lb.servers = append(lb.servers, &server{address: address, weight: weight})
+}
+
+// next returns the address of the server receiving the next session, or an empty string if there is no server.
+func (lb *LoadBalancer) next() string {
+	lb.mu.Lock()
+	defer lb.mu.Unlock()
+
+	var total int
+	var best *server
+	for _, srv := range lb.servers {
+		srv.currentWeight += srv.weight
+		total += srv.weight
+
+		if best == nil || srv.currentWeight > best.currentWeight {
+			best = srv
+		}
+	}
+
+	if best == nil {
+		return ""
+	}
+
+	best.currentWeight -= total
+	return best.address
+}
@@ -0,0 +1,25 @@
+package udp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadBalancerWeights(t *testing.T) {
+	lb := NewLoadBalancer("backend")
+	lb.AddServer("a:1", 3)
+	lb.AddServer("b:1", 1)
+	lb.AddServer("c:1", 0)
+
+	served := make(map[string]int)
+	for i := 0; i < 10; i++ {
+		served[lb.next()]++
+	}
+	assert.Equal(t, map[string]int{"a:1": 6, "b:1": 2, "c:1": 2}, served)
+}
+
+func TestLoadBalancerNoServer(t *testing.T) {
+	lb := NewLoadBalancer("backend")
+	assert.Empty(t, lb.next())
+}
@@ -0,0 +1,229 @@
+package udp
+
+import (
+	"net"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/containous/traefik/log"
+	"github.com/containous/traefik/metrics"
+	"github.com/containous/traefik/safe"
+	gokitmetrics "github.com/go-kit/kit/metrics"
+)
+
+// maxDatagramSize is the size of the largest UDP payload.
+const maxDatagramSize = 65535
+
+// session forwards the datagrams of a client to the server chosen by the load balancer for its first datagram.
+type session struct {
+	clientAddr net.Addr
+	serverConn net.Conn
+	// lastActivity is the time of the last datagram of the session, in Unix nanoseconds.
+	lastActivity int64
+}
+
+func (s *session) touch() {
+	atomic.StoreInt64(&s.lastActivity, time.Now().UnixNano())
+}
+
+func (s *session) idleSince() time.Duration {
+	return time.Since(time.Unix(0, atomic.LoadInt64(&s.lastActivity)))
+}
+
+// Server forwards the datagrams received by a UDP entry point to the servers of its backend.
+// The datagrams are tracked in sessions by client address, so the replies of the servers reach the right clients.
+type Server struct {
+	conn           net.PacketConn
+	entryPointName string
+	idleTimeout    time.Duration
+	maxSessions    int
+	loadBalancer   *safe.Safe
+
+	datagramsCounter gokitmetrics.Counter
+	bytesCounter     gokitmetrics.Counter
+
+	mu       sync.Mutex
+	sessions map[string]*session
+	closed   bool
+}
+
+// NewServer creates a server forwarding the datagrams received on the connection.
+// The sessions are closed when no datagram has been exchanged during the idle timeout,
+// and the datagrams of the new clients are dropped while maxSessions sessions are open (no limit when zero).
+func NewServer(conn net.PacketConn, entryPointName string, idleTimeout time.Duration, maxSessions int, registry metrics.Registry) *Server {
+	return &Server{
+		conn:             conn,
+		entryPointName:   entryPointName,
+		idleTimeout:      idleTimeout,
+		maxSessions:      maxSessions,
+		loadBalancer:     safe.New((*LoadBalancer)(nil)),
+		datagramsCounter: registry.EntrypointUDPDatagramsCounter().With("entrypoint", entryPointName),
+		bytesCounter:     registry.EntrypointUDPBytesCounter().With("entrypoint", entryPointName),
+		sessions:         make(map[string]*session),
+	}
+}
+
+// Addr returns the address of the entry point.
+func (s *Server) Addr() net.Addr {
+	return s.conn.LocalAddr()
+}
+
+// UpdateLoadBalancer sets the load balancer choosing the servers of the new sessions, nil dropping the new sessions.
+// The existing sessions keep their server until they expire.
+func (s *Server) UpdateLoadBalancer(lb *LoadBalancer) {
+	s.loadBalancer.Set(lb)
+}
+
+// Serve forwards the datagrams until the server is closed.
+func (s *Server) Serve() error {
+	buf := make([]byte, maxDatagramSize)
+	for {
+		n, clientAddr, err := s.conn.ReadFrom(buf)
+		if err != nil {
+			if s.isClosed() {
+				return nil
+			}
+			if netErr, ok := err.(net.Error); ok && netErr.Temporary() {
+				log.Debugf("Error while reading UDP datagram on entrypoint %s: %v", s.entryPointName, err)
+				continue
+			}
+			return err
+		}
+
+		s.countDatagram("in", n)
+
+		sess, err := s.getSession(clientAddr)
+		if err != nil {
+			log.Errorf("Error while creating UDP session for %s on entrypoint %s: %v", clientAddr, s.entryPointName, err)
+			continue
+		}
+		if sess == nil {
+			s.countDatagram("dropped", n)
+			continue
+		}
+
+		sess.touch()
+		if _, err := sess.serverConn.Write(buf[:n]); err != nil {
+			log.Debugf("Error while forwarding UDP datagram of %s to %s: %v", clientAddr, sess.serverConn.RemoteAddr(), err)
+		}
+	}
+}
+
+// Close stops the server and closes its sessions.
+func (s *Server) Close() error {
+	s.mu.Lock()
+	s.closed = true
+	for key, sess := range s.sessions {
+		sess.serverConn.Close()
+		delete(s.sessions, key)
+	}
+	s.mu.Unlock()
+
+	return s.conn.Close()
+}
+
+func (s *Server) isClosed() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.closed
+}
+
+// getSession returns the session of the client, creating it if needed.
+// It returns a nil session when the datagram cannot be forwarded to any server.
+func (s *Server) getSession(clientAddr net.Addr) (*session, error) {
+	key := clientAddr.String()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if sess, ok := s.sessions[key]; ok {
+		return sess, nil
+	}
+
+	if s.closed {
+		return nil, nil
+	}
+
+	// every client address opens a socket to the server: spoofed addresses must not exhaust the file descriptors
+	if s.maxSessions > 0 && len(s.sessions) >= s.maxSessions {
+		log.Debugf("Dropping the datagram of %s on entrypoint %s: the limit of %d UDP sessions is reached", clientAddr, s.entryPointName, s.maxSessions)
+		return nil, nil
+	}
+
+	lb, _ := s.loadBalancer.Get().(*LoadBalancer)
+	if lb == nil {
+		log.Debugf("No UDP backend for entrypoint %s, dropping the datagram of %s", s.entryPointName, clientAddr)
+		return nil, nil
+	}
+
+	address := lb.next()
+	if len(address) == 0 {
+		log.Errorf("No UDP server available for backend %s", lb.name)
+		return nil, nil
+	}
+
+	serverConn, err := net.Dial("udp", address)
+	if err != nil {
+		return nil, err
+	}
+
+	sess := &session{clientAddr: clientAddr, serverConn: serverConn}
+	sess.touch()
+	s.sessions[key] = sess
+
+	go s.reply(key, sess)
+
+	return sess, nil
+}
+
+// reply forwards the datagrams of the server to the client, until the session expires.
+func (s *Server) reply(key string, sess *session) {
+	defer s.closeSession(key, sess)
+
+	buf := make([]byte, maxDatagramSize)
+	for {
+		sess.serverConn.SetReadDeadline(time.Now().Add(s.idleTimeout))
+
+		n, err := sess.serverConn.Read(buf)
+		if err != nil {
+			if netErr, ok := err.(net.Error); ok && netErr.Timeout() {
+				if sess.idleSince() < s.idleTimeout {
+					continue
+				}
+				log.Debugf("UDP session of %s on entrypoint %s expired", sess.clientAddr, s.entryPointName)
+				return
+			}
+			if !s.isClosed() {
+				log.Debugf("Error while reading UDP datagram from %s: %v", sess.serverConn.RemoteAddr(), err)
+			}
+			return
+		}
+
+		sess.touch()
+		if _, err := s.conn.WriteTo(buf[:n], sess.clientAddr); err != nil {
+			log.Debugf("Error while forwarding UDP datagram of %s to %s: %v", sess.serverConn.RemoteAddr(), sess.clientAddr, err)
+			continue
+		}
+
+		s.countDatagram("out", n)
+	}
+}
+
+func (s *Server) closeSession(key string, sess *session) {
+	s.mu.Lock()
+	if s.sessions[key] == sess {
+		delete(s.sessions, key)
+	}
+	s.mu.Unlock()
+
+	sess.serverConn.Close()
+}
+
+// countDatagram counts a datagram received from a client ("in"), sent to a client ("out"),
+// or received from a client but not forwarded ("dropped").
+func (s *Server) countDatagram(direction string, size int) {
+	s.datagramsCounter.With("direction", direction).Add(1)
+	s.bytesCounter.With("direction", direction).Add(float64(size))
+}
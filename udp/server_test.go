@@ -0,0 +1,235 @@
+package udp
+
+import (
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/containous/traefik/metrics"
+	gokitmetrics "github.com/go-kit/kit/metrics"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// echoServer replies to the datagrams with the name of the server, followed by the received data.
+func echoServer(t *testing.T, name string) net.PacketConn {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	go func() {
+		buf := make([]byte, maxDatagramSize)
+		for {
+			n, addr, err := conn.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+			conn.WriteTo(append([]byte(name+":"), buf[:n]...), addr)
+		}
+	}()
+
+	return conn
+}
+
+func newServer(t *testing.T, idleTimeout time.Duration, lb *LoadBalancer) *Server {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	server := NewServer(conn, "udp", idleTimeout, 0, metrics.NewVoidRegistry())
+	server.UpdateLoadBalancer(lb)
+	go server.Serve()
+
+	return server
+}
+
+func newClient(t *testing.T, server *Server) net.Conn {
+	conn, err := net.Dial("udp", server.Addr().String())
+	require.NoError(t, err)
+	return conn
+}
+
+func readEcho(t *testing.T, conn net.Conn, expected string) {
+	_, err := conn.Write([]byte("ping"))
+	require.NoError(t, err)
+
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	buf := make([]byte, maxDatagramSize)
+	n, err := conn.Read(buf)
+	require.NoError(t, err)
+
+	assert.Equal(t, expected, string(buf[:n]))
+}
+
+func sessionCount(server *Server) int {
+	server.mu.Lock()
+	defer server.mu.Unlock()
+
+	return len(server.sessions)
+}
+
+func TestServerSessions(t *testing.T) {
+	backend1 := echoServer(t, "backend1")
+	defer backend1.Close()
+
+	backend2 := echoServer(t, "backend2")
+	defer backend2.Close()
+
+	lb := NewLoadBalancer("backend")
+	lb.AddServer(backend1.LocalAddr().String(), 1)
+	lb.AddServer(backend2.LocalAddr().String(), 1)
+
+	server := newServer(t, time.Minute, lb)
+	defer server.Close()
+
+	client1 := newClient(t, server)
+	defer client1.Close()
+
+	client2 := newClient(t, server)
+	defer client2.Close()
+
+	// the datagrams of a client are forwarded to the same server
+	readEcho(t, client1, "backend1:ping")
+	readEcho(t, client2, "backend2:ping")
+	readEcho(t, client1, "backend1:ping")
+	readEcho(t, client2, "backend2:ping")
+
+	assert.Equal(t, 2, sessionCount(server))
+}
+
+func TestServerIdleTimeout(t *testing.T) {
+	backend := echoServer(t, "backend")
+	defer backend.Close()
+
+	lb := NewLoadBalancer("backend")
+	lb.AddServer(backend.LocalAddr().String(), 1)
+
+	server := newServer(t, 100*time.Millisecond, lb)
+	defer server.Close()
+
+	client := newClient(t, server)
+	defer client.Close()
+
+	readEcho(t, client, "backend:ping")
+	assert.Equal(t, 1, sessionCount(server))
+
+	deadline := time.Now().Add(5 * time.Second)
+	for sessionCount(server) > 0 && time.Now().Before(deadline) {
+		time.Sleep(50 * time.Millisecond)
+	}
+	assert.Equal(t, 0, sessionCount(server))
+}
+
+// directionCounter counts the datagrams by direction, safely for the concurrent sessions.
+type directionCounter struct {
+	lock      *sync.Mutex
+	counts    map[string]float64
+	direction string
+}
+
+func newDirectionCounter() *directionCounter {
+	return &directionCounter{lock: &sync.Mutex{}, counts: make(map[string]float64)}
+}
+
+func (c *directionCounter) With(labelValues ...string) gokitmetrics.Counter {
+	counter := *c
+	for i := 0; i+1 < len(labelValues); i += 2 {
+		if labelValues[i] == "direction" {
+			counter.direction = labelValues[i+1]
+		}
+	}
+	return &counter
+}
+
+func (c *directionCounter) Add(delta float64) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.counts[c.direction] += delta
+}
+
+func (c *directionCounter) count(direction string) float64 {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.counts[direction]
+}
+
+func TestServerMaxSessions(t *testing.T) {
+	backend := echoServer(t, "backend")
+	defer backend.Close()
+
+	lb := NewLoadBalancer("backend")
+	lb.AddServer(backend.LocalAddr().String(), 1)
+
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	counter := newDirectionCounter()
+	server := NewServer(conn, "udp", 300*time.Millisecond, 1, metrics.NewVoidRegistry())
+	server.datagramsCounter = counter
+	server.UpdateLoadBalancer(lb)
+	go server.Serve()
+	defer server.Close()
+
+	client1 := newClient(t, server)
+	defer client1.Close()
+
+	client2 := newClient(t, server)
+	defer client2.Close()
+
+	readEcho(t, client1, "backend:ping")
+
+	// the datagrams of a new client are dropped while the limit is reached
+	_, err = client2.Write([]byte("ping"))
+	require.NoError(t, err)
+
+	client2.SetReadDeadline(time.Now().Add(200 * time.Millisecond))
+	_, err = client2.Read(make([]byte, 1))
+	assert.Error(t, err)
+	assert.Equal(t, 1, sessionCount(server))
+	assert.Equal(t, float64(1), counter.count("dropped"))
+
+	// the new clients are accepted once the sessions expire
+	deadline := time.Now().Add(5 * time.Second)
+	for sessionCount(server) > 0 && time.Now().Before(deadline) {
+		time.Sleep(50 * time.Millisecond)
+	}
+	readEcho(t, client2, "backend:ping")
+}
+
+func TestServerWithoutBackend(t *testing.T) {
+	server := newServer(t, time.Minute, nil)
+	defer server.Close()
+
+	client := newClient(t, server)
+	defer client.Close()
+
+	_, err := client.Write([]byte("ping"))
+	require.NoError(t, err)
+
+	client.SetReadDeadline(time.Now().Add(200 * time.Millisecond))
+	_, err = client.Read(make([]byte, 1))
+	assert.Error(t, err)
+	assert.Equal(t, 0, sessionCount(server))
+}
+
+func TestServerClose(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	server := NewServer(conn, "udp", time.Minute, 0, metrics.NewVoidRegistry())
+
+	errChan := make(chan error)
+	go func() {
+		errChan <- server.Serve()
+	}()
+
+	require.NoError(t, server.Close())
+
+	select {
+	case err := <-errChan:
+		assert.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("Serve is not stopped by Close")
+	}
+}